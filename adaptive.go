@@ -0,0 +1,261 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// A cuckoo filter that can be told about false positives and stop returning them. See
+// https://arxiv.org/abs/1704.06818.
+//
+// Each entry keeps a few selector bits along with its fingerprint, choosing which of several hash
+// functions made the fingerprint. When Contains(x) returns Maybe for an x that isn't in the set,
+// Adapt(x) moves each entry that matched x to its next selector, recomputing its fingerprint from
+// the item it stands for, so that the same query returns No from then on (unless x is a false
+// positive against something else too). That needs the items themselves, so an AdaptiveFilter keeps
+// a copy of every item alongside its entries, the way an adaptive filter sits in front of the table
+// of items it summarizes: the entries are small enough to stay in cache, and the items are only read
+// to adapt, to delete, and to move entries while adding.
+//
+// Because the items are kept, Delete removes exactly the item given, and deleting an item that
+// wasn't added is reported rather than removing some other item.
+type AdaptiveFilter struct {
+	// The extra bits of each entry are its selector.
+	entryTable
+	// The item in each slot of entries, or nil for an empty slot.
+	items [][]byte
+}
+
+const (
+	// The number of selector bits in each entry.
+	adaptiveSelectorBits = 2
+	adaptiveSelectors    = 1 << adaptiveSelectorBits
+)
+
+// Returns a new AdaptiveFilter capable of holding n items with an estimated false-positive rate of
+// fp, before any adapting.
+func NewAdaptive(n int, fp float64) *AdaptiveFilter {
+	return NewAdaptiveRaw(params(n, fp))
+}
+
+// Returns a new AdaptiveFilter constructed using raw parameters. See NewRaw.
+func NewAdaptiveRaw(f, b, n int) *AdaptiveFilter {
+	nBuckets := rawBuckets(f, b, n)
+	return &AdaptiveFilter{
+		entryTable: newEntryTable(f, b, adaptiveSelectorBits, nBuckets),
+		items:      make([][]byte, nBuckets*b),
+	}
+}
+
+// Adds a copy of x to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (af *AdaptiveFilter) Add(x []byte) {
+	af.h.count++
+	if !af.h.overflowed && !af.add(x, false) {
+		af.h.overflowed = true
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns ErrOverflowed
+// and leaves the filter as it was, rather than overflowing it.
+func (af *AdaptiveFilter) Insert(x []byte) error {
+	if af.h.overflowed || !af.add(x, true) {
+		return ErrOverflowed
+	}
+	af.h.count++
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (af *AdaptiveFilter) TryAdd(x []byte) bool {
+	return af.Insert(x) == nil
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (af *AdaptiveFilter) Contains(x []byte) Result {
+	hash := af.h.hashItem(x)
+	_, i1, i2 := af.h.hashToIdxs(hash)
+	fs := af.fingerprints(hash)
+	for _, i := range [2]uint64{i1, i2} {
+		for s := af.slot(i, 0); s < af.slot(i+1, 0); s++ {
+			if e := af.entry(s); e != 0 && af.fingerprint(e) == fs[af.extraBits(e)] {
+				return Maybe
+			}
+		}
+	}
+	if af.h.overflowed {
+		return Maybe
+	}
+	return No
+}
+
+// Reports that x isn't in the set, though Contains(x) may have returned Maybe, and changes the
+// fingerprints that x matches so that it doesn't anymore. Returns the number of entries changed,
+// which is 0 if x doesn't match any, or if x was added.
+func (af *AdaptiveFilter) Adapt(x []byte) int {
+	hash := af.h.hashItem(x)
+	_, i1, i2 := af.h.hashToIdxs(hash)
+	if _, ok := af.find(x, i1, i2); ok {
+		// x was added, so it's a true positive.
+		return 0
+	}
+	fs := af.fingerprints(hash)
+	changed := 0
+	for _, i := range [2]uint64{i1, i2} {
+		for s := af.slot(i, 0); s < af.slot(i+1, 0); s++ {
+			e := af.entry(s)
+			if e == 0 || af.fingerprint(e) != fs[af.extraBits(e)] {
+				continue
+			}
+			// Move on to the next selector whose fingerprint for the item differs from x's. All of
+			// them being the same is vanishingly unlikely, but then there's nothing to be done.
+			itemFs := af.fingerprints(af.h.hashItem(af.items[s]))
+			sel := af.extraBits(e)
+			for j := 1; j < adaptiveSelectors; j++ {
+				next := (sel + uint64(j)) % adaptiveSelectors
+				if itemFs[next] != fs[next] {
+					af.setEntry(s, af.makeEntry(itemFs[next], next))
+					changed++
+					break
+				}
+			}
+		}
+		if i1 == i2 {
+			break
+		}
+	}
+	return changed
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (af *AdaptiveFilter) Delete(x []byte) {
+	if !af.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x isn't in the filter, returns false instead of
+// panicking. Unlike Filter's, this never removes some other item that x collides with.
+func (af *AdaptiveFilter) TryDelete(x []byte) bool {
+	_, i1, i2 := af.h.hashToIdxs(af.h.hashItem(x))
+	if s, ok := af.find(x, i1, i2); ok {
+		af.setEntry(s, 0)
+		af.items[s] = nil
+		af.h.count--
+		return true
+	}
+	// Once the filter has overflowed, x may have been the item there was no room for.
+	if af.h.overflowed {
+		af.h.count--
+		return true
+	}
+	return false
+}
+
+// Returns the number of items in the filter.
+func (af *AdaptiveFilter) Count() int {
+	return af.h.count
+}
+
+// Removes every item from the filter, reusing its memory.
+func (af *AdaptiveFilter) Reset() {
+	af.entryTable.Reset()
+	for i := range af.items {
+		af.items[i] = nil
+	}
+}
+
+// Returns an independent copy of the filter.
+func (af *AdaptiveFilter) Clone() *AdaptiveFilter {
+	return &AdaptiveFilter{
+		entryTable: af.clone(),
+		items:      append([][]byte(nil), af.items...),
+	}
+}
+
+// Returns the slot holding x in bucket i1 or i2. Shadows entryTable.find, since items are compared
+// directly rather than by fingerprint.
+func (af *AdaptiveFilter) find(x []byte, i1, i2 uint64) (uint64, bool) {
+	for _, i := range [2]uint64{i1, i2} {
+		for s := af.slot(i, 0); s < af.slot(i+1, 0); s++ {
+			if af.items[s] != nil && bytes.Equal(af.items[s], x) {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Returns the fingerprint under each selector of an item whose hash is hash. Selector 0 gives the
+// same fingerprint as a Filter would, and the others remix the hash with SplitMix64's finalizer,
+// offset differently for each.
+func (af *AdaptiveFilter) fingerprints(hash uint64) [adaptiveSelectors]fingerprint {
+	var fs [adaptiveSelectors]fingerprint
+	fs[0] = af.h.hashToFingerprint(hash)
+	for s := uint64(1); s < adaptiveSelectors; s++ {
+		z := hash + s*0x9E3779B97F4A7C15
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		fs[s] = af.h.hashToFingerprint(z ^ (z >> 31))
+	}
+	return fs
+}
+
+// Returns the bucket other than i that item's entry can be in. Fingerprints change when entries
+// adapt, so this goes by the item rather than the fingerprint.
+func (af *AdaptiveFilter) otherIdx(item []byte, i uint64) uint64 {
+	_, i1, i2 := af.h.hashToIdxs(af.h.hashItem(item))
+	if i == i1 {
+		return i2
+	}
+	return i1
+}
+
+// A write made while kicking: e and item replaced the entry and item in slot s.
+type adaptiveKick struct {
+	s    uint64
+	e    uint64
+	item []byte
+}
+
+// Places a copy of x in one of its candidate buckets, kicking other entries to their other buckets
+// to make room if necessary. Returns false if no room could be made, first undoing the kicks if
+// undo is true.
+func (af *AdaptiveFilter) add(x []byte, undo bool) bool {
+	f, i1, i2 := af.h.hashToIdxs(af.h.hashItem(x))
+	e, item := af.makeEntry(f, 0), append(make([]byte, 0, len(x)), x...)
+	for _, i := range [2]uint64{i1, i2} {
+		if s, ok := af.empty(i); ok {
+			af.setEntry(s, e)
+			af.items[s] = item
+			return true
+		}
+	}
+
+	var pathBuf [16]adaptiveKick
+	path := pathBuf[:0]
+	is := [2]uint64{i1, i2}
+	i := is[af.h.randInt()%len(is)]
+	for n := 0; n < maxNumKicks; n++ {
+		s := af.slot(i, uint64(af.h.randInt()%af.h.b))
+		victim, victimItem := af.entry(s), af.items[s]
+		if undo {
+			path = append(path, adaptiveKick{s: s, e: victim, item: victimItem})
+		}
+		af.setEntry(s, e)
+		af.items[s] = item
+		e, item = victim, victimItem
+		i = af.otherIdx(item, i)
+		if s, ok := af.empty(i); ok {
+			af.setEntry(s, e)
+			af.items[s] = item
+			return true
+		}
+	}
+
+	for j := len(path) - 1; j >= 0; j-- {
+		af.setEntry(path[j].s, path[j].e)
+		af.items[path[j].s] = path[j].item
+	}
+	return false
+}
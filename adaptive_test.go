@@ -0,0 +1,93 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptive(t *testing.T) {
+	af := NewAdaptiveRaw(6, 4, 256)
+	keys := make([][]byte, 900)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		af.Add(keys[i])
+	}
+	require.False(t, af.Overflowed())
+	require.Equal(t, len(keys), af.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, af.Contains(x))
+		require.Zero(t, af.Adapt(x))
+	}
+
+	// With 6-bit fingerprints, plenty of absent items are false positives. Once adapted to, each
+	// should stop being one, without disturbing the items that were added.
+	var fps [][]byte
+	for i := len(keys); len(fps) < 100; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		if af.Contains(x) == Maybe {
+			fps = append(fps, x)
+		}
+	}
+	for _, x := range fps {
+		// Adapting to an earlier one may have already changed the entry this one matched.
+		if af.Contains(x) == Maybe {
+			require.Positive(t, af.Adapt(x))
+		}
+		require.Equal(t, No, af.Contains(x))
+	}
+	for _, x := range keys {
+		require.Equal(t, Maybe, af.Contains(x))
+	}
+	// Adapting to later false positives can bring back earlier ones, but most should stay gone.
+	still := 0
+	for _, x := range fps {
+		if af.Contains(x) == Maybe {
+			still++
+		}
+	}
+	require.Less(t, still, 30)
+
+	for _, x := range keys[:450] {
+		af.Delete(x)
+	}
+	require.Equal(t, 450, af.Count())
+	for _, x := range keys[450:] {
+		require.Equal(t, Maybe, af.Contains(x))
+	}
+	require.False(t, af.TryDelete(keys[0]))
+	require.False(t, af.TryDelete(fps[0]))
+	require.Panics(t, func() { af.Delete(keys[0]) })
+
+	c := af.Clone()
+	af.Reset()
+	require.Zero(t, af.Count())
+	require.Equal(t, No, af.Contains(keys[500]))
+	require.Equal(t, Maybe, c.Contains(keys[500]))
+}
+
+func TestAdaptiveFull(t *testing.T) {
+	af := NewAdaptiveRaw(12, 4, 64)
+	var added [][]byte
+	for i := 0; ; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		before := append([]uint64(nil), af.entries...)
+		beforeItems := append([][]byte(nil), af.items...)
+		if !af.TryAdd(x) {
+			require.Equal(t, before, af.entries)
+			require.Equal(t, beforeItems, af.items)
+			break
+		}
+		added = append(added, x)
+	}
+	require.False(t, af.Overflowed())
+	for _, x := range added {
+		require.Equal(t, Maybe, af.Contains(x))
+	}
+	for i := 0; i < 100; i++ {
+		af.Add(binary.LittleEndian.AppendUint64(nil, uint64(len(added)+i)))
+	}
+	require.True(t, af.Overflowed())
+	require.Equal(t, Maybe, af.Contains([]byte("never added")))
+}
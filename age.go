@@ -0,0 +1,84 @@
+package cuckoo
+
+import "fmt"
+
+// The resolution of the fraction passed to Age.
+const ageBits = 30
+
+// Forgets about the given fraction of the items in the filter, chosen at random, and returns how
+// many it forgot. Calling Age periodically lets a long-running filter, such as one for dedup, make
+// room for new items by gradually forgetting old ones rather than being Reset all at once: an item
+// survives k calls to Age(p) with probability (1-p)^k, so older items are the most likely to be
+// gone.
+//
+// Forgotten items are removed just as by Delete, and recorded that way in a log set with SetLog.
+// An overflowed filter has already lost an item that forgetting others won't bring back, so Age
+// leaves it as it is and returns 0; Reset it instead.
+func (fl *Filter) Age(fraction float64) int {
+	threshold := ageThreshold(fraction)
+	if fl.overflowed {
+		return 0
+	}
+	n := 0
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		if fl.loadBits(i) == 0 {
+			continue
+		}
+		b := fl.getBucket(i)
+		changed := false
+		for j := 0; j < b.l; j++ {
+			f := b.entries[j]
+			if f == 0 || fl.randInt()&(1<<ageBits-1) >= threshold {
+				continue
+			}
+			b.entries[j] = 0
+			changed = true
+			n++
+			if fl.log != nil {
+				fl.log.record(walOpDelete, f, i)
+			}
+		}
+		if changed {
+			fl.setBucket(i, b)
+		}
+	}
+	fl.count -= n
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+	return n
+}
+
+// Like Filter.Age, but rather than forgetting items outright, takes one away from the count of
+// each of the given fraction of the filter's entries, chosen at random, so that an item added many
+// times takes many calls to Age to forget. Returns the number of counts taken away, by which Count
+// goes down.
+func (cf *CountingFilter) Age(fraction float64) int {
+	threshold := ageThreshold(fraction)
+	if cf.h.overflowed {
+		return 0
+	}
+	n := 0
+	for s := uint64(0); s < cf.h.nBuckets()*uint64(cf.h.b); s++ {
+		e := cf.entry(s)
+		if e == 0 || cf.h.randInt()&(1<<ageBits-1) >= threshold {
+			continue
+		}
+		if cf.extraBits(e) == 0 {
+			cf.setEntry(s, 0)
+		} else {
+			cf.setEntry(s, e-1<<uint(cf.h.f))
+		}
+		n++
+	}
+	cf.h.count -= n
+	return n
+}
+
+// Returns fraction scaled to ageBits, to compare against that many random bits.
+func ageThreshold(fraction float64) int {
+	if !(fraction >= 0 && fraction <= 1) {
+		panic(fmt.Errorf("%w: fraction=%v must be in [0, 1]", ErrInvalidParams, fraction))
+	}
+	return int(fraction * (1 << ageBits))
+}
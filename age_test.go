@@ -0,0 +1,94 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAge(t *testing.T) {
+	const n = 10000
+	fl := New(n, 0.001)
+	for i := 0; i < n; i++ {
+		fl.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	require.Zero(t, fl.Age(0))
+	require.Equal(t, n, fl.Count())
+
+	forgot := fl.Age(0.25)
+	require.InDelta(t, n/4, forgot, n/20)
+	require.Equal(t, n-forgot, fl.Count())
+	require.NoError(t, fl.CheckInvariants())
+	present := 0
+	for i := 0; i < n; i++ {
+		if fl.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			present++
+		}
+	}
+	require.InDelta(t, fl.Count(), present, n/100)
+
+	// The room made is used by new items.
+	for i := n; i < n+forgot; i++ {
+		require.NoError(t, fl.Insert(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	fl.Age(1)
+	require.Zero(t, fl.Count())
+	require.Equal(t, No, fl.Contains(binary.LittleEndian.AppendUint64(nil, uint64(n))))
+
+	require.Panics(t, func() { fl.Age(1.5) })
+}
+
+func TestAgeOverflowed(t *testing.T) {
+	fl := NewRaw(8, 2, 4)
+	for i := 0; !fl.Overflowed(); i++ {
+		fl.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	count := fl.Count()
+	require.Zero(t, fl.Age(1))
+	require.Equal(t, count, fl.Count())
+	require.True(t, fl.Overflowed())
+}
+
+func TestAgeRecover(t *testing.T) {
+	fl := New(1000, 0.01)
+	var snapshot, log bytes.Buffer
+	_, err := fl.WriteTo(&snapshot)
+	require.NoError(t, err)
+	fl.SetLog(&log)
+
+	for i := 0; i < 1000; i++ {
+		fl.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	fl.Age(0.5)
+	require.NoError(t, fl.LogErr())
+
+	recovered, err := Recover(&snapshot, &log)
+	require.NoError(t, err)
+	require.True(t, fl.Equal(recovered))
+}
+
+func TestCountingAge(t *testing.T) {
+	cf := NewCounting(1000, 0.01)
+	x, y := []byte("x"), []byte("y")
+	for i := 0; i < 10; i++ {
+		cf.Add(x)
+	}
+	cf.Add(y)
+
+	// An item added many times takes many calls to forget.
+	require.Equal(t, 2, cf.Age(1))
+	require.Equal(t, 9, cf.Count())
+	require.Equal(t, 9, cf.Occurrences(x))
+	require.Equal(t, No, cf.Contains(y))
+	for i := 0; i < 8; i++ {
+		cf.Age(1)
+	}
+	require.Equal(t, 1, cf.Occurrences(x))
+	cf.Age(1)
+	require.Zero(t, cf.Count())
+	require.Equal(t, No, cf.Contains(x))
+	require.Zero(t, cf.Age(1))
+}
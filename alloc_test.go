@@ -0,0 +1,147 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Filters covering each hash scheme and storage layout that Add, Contains, and Delete can take.
+var allocFilters = []struct {
+	name string
+	new  func() *Filter
+}{
+	{"XXH", func() *Filter { return NewRaw(12, 4, 1<<10) }},
+	{"XXH128", func() *Filter {
+		fl := NewRaw(12, 4, 1<<10)
+		fl.Use128BitHash()
+		return fl
+	}},
+	{"Seeded", func() *Filter {
+		fl := NewRaw(12, 4, 1<<10)
+		fl.SetSeed(12345)
+		return fl
+	}},
+	{"Direct", func() *Filter { return NewRaw(16, 2, 1<<10) }},
+	{"Aligned", func() *Filter { return NewRawAligned(12, 4, 1<<10) }},
+	{"Exact", func() *Filter { return NewRawExact(12, 4, 1000) }},
+	{"BreadthFirst", func() *Filter {
+		fl := NewRaw(12, 4, 1<<10)
+		fl.SetInsertStrategy(BreadthFirst)
+		return fl
+	}},
+}
+
+func allocItems(n int) [][]byte {
+	items := make([][]byte, n)
+	for i := range items {
+		items[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+	return items
+}
+
+func TestZeroAllocs(t *testing.T) {
+	for _, c := range allocFilters {
+		t.Run(c.name, func(t *testing.T) {
+			fl := c.new()
+			// Fill the filter most of the way, so that adds have to kick fingerprints around.
+			items := allocItems(int(fl.nBuckets()) * fl.b * 9 / 10)
+			for _, item := range items[:len(items)-100] {
+				fl.Add(item)
+			}
+			extra := items[len(items)-100:]
+			allocs := testing.AllocsPerRun(10, func() {
+				for _, item := range extra {
+					fl.Add(item)
+				}
+				for _, item := range extra {
+					fl.Contains(item)
+				}
+				for _, item := range extra {
+					fl.Delete(item)
+				}
+			})
+			require.Equal(t, 0.0, allocs)
+		})
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	for _, c := range allocFilters {
+		b.Run(c.name, func(b *testing.B) {
+			fl := c.new()
+			items := allocItems(int(fl.nBuckets()) * fl.b * 9 / 10)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if i%len(items) == 0 {
+					b.StopTimer()
+					fl.Reset()
+					b.StartTimer()
+				}
+				fl.Add(items[i%len(items)])
+			}
+		})
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	for _, c := range allocFilters {
+		b.Run(c.name, func(b *testing.B) {
+			fl := c.new()
+			items := allocItems(int(fl.nBuckets()) * fl.b * 9 / 10)
+			for _, item := range items[:len(items)/2] {
+				fl.Add(item)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fl.Contains(items[i%len(items)])
+			}
+		})
+	}
+}
+
+// Like BenchmarkContains, but looking items up a chunk at a time with ContainsBatch. Reports the
+// time per item, so the two can be compared directly.
+func BenchmarkContainsBatch(b *testing.B) {
+	for _, c := range allocFilters {
+		b.Run(c.name, func(b *testing.B) {
+			fl := c.new()
+			items := allocItems(int(fl.nBuckets()) * fl.b * 9 / 10)
+			for _, item := range items[:len(items)/2] {
+				fl.Add(item)
+			}
+			out := make([]Result, batchChunk)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i += batchChunk {
+				start := i % (len(items) - batchChunk)
+				fl.containsBatch(items[start:start+batchChunk], out)
+			}
+		})
+	}
+}
+
+func BenchmarkDelete(b *testing.B) {
+	for _, c := range allocFilters {
+		b.Run(c.name, func(b *testing.B) {
+			fl := c.new()
+			items := allocItems(int(fl.nBuckets()) * fl.b * 9 / 10)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if i%len(items) == 0 {
+					b.StopTimer()
+					fl.Reset()
+					for _, item := range items {
+						fl.Add(item)
+					}
+					b.StartTimer()
+				}
+				fl.Delete(items[i%len(items)])
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package cuckoo
+
+import "encoding"
+
+// The operations shared by the approximate set-membership filters in this package, so that callers
+// can swap one for another, for example to compare a BloomFilter and a Filter on a workload,
+// without changing call sites.
+type ApproxSet interface {
+	// Adds an item to the set. After Add(x) returns, Contains(x) returns Maybe.
+	Add(x []byte)
+	// Returns No if x is definitely not in the set, and Maybe if x might be in the set.
+	Contains(x []byte) Result
+	// Returns the number of bytes used by the set's table.
+	SizeBytes() uint64
+	encoding.BinaryMarshaler
+}
+
+var (
+	_ ApproxSet = (*Filter)(nil)
+	_ ApproxSet = (*BloomFilter)(nil)
+	_ ApproxSet = (*CountingFilter)(nil)
+	_ ApproxSet = (*DAryFilter)(nil)
+	_ ApproxSet = (*DynamicFilter)(nil)
+	_ ApproxSet = (*MortonFilter)(nil)
+	_ ApproxSet = (*PrefixFilter)(nil)
+	_ ApproxSet = (*QuotientFilter)(nil)
+	_ ApproxSet = (*TaffyFilter)(nil)
+	_ ApproxSet = (*TieredFilter)(nil)
+	_ ApproxSet = (*TTLFilter)(nil)
+	_ ApproxSet = (*WindowedFilter)(nil)
+)
@@ -0,0 +1,319 @@
+package cuckoo
+
+import (
+	"context"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// The number of keys a ContainsParallel worker claims at a time. Large enough that claiming is
+// cheap next to the lookups, small enough that the workers finish at about the same time.
+const parallelChunk = 1024
+
+// Returns Contains(keys[i]) for each i in order, spreading the lookups across up to workers
+// goroutines.
+//
+// Lookups only read the filter, so this is safe to call concurrently with other lookups but not
+// with Add or Delete.
+func (fl *Filter) ContainsParallel(keys [][]byte, workers int) []Result {
+	out, _ := fl.ContainsParallelContext(context.Background(), keys, workers)
+	return out
+}
+
+// Like ContainsParallel, but stops early once ctx is cancelled. The returned results are for a
+// prefix of keys, which is all of them unless the error is ctx.Err().
+func (fl *Filter) ContainsParallelContext(
+	ctx context.Context,
+	keys [][]byte,
+	workers int,
+) ([]Result, error) {
+	out := make([]Result, len(keys))
+	if workers > (len(keys)+parallelChunk-1)/parallelChunk {
+		workers = (len(keys) + parallelChunk - 1) / parallelChunk
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Workers claim contiguous chunks so that each writes its own stretch of out. A worker always
+	// finishes the chunk it claimed, so after a cancellation every chunk below next is complete.
+	var next atomic.Int64
+	var cancelled atomic.Bool
+	work := func() {
+		for {
+			if ctx.Err() != nil {
+				cancelled.Store(true)
+				return
+			}
+			end := int(next.Add(parallelChunk))
+			start := end - parallelChunk
+			if start >= len(keys) {
+				return
+			}
+			if end > len(keys) {
+				end = len(keys)
+			}
+			fl.containsBatch(keys[start:end], out[start:end])
+		}
+	}
+	if workers == 1 {
+		work()
+	} else {
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				work()
+			}()
+		}
+		wg.Wait()
+	}
+	if cancelled.Load() {
+		if done := int(next.Load()); done < len(out) {
+			return out[:done], ctx.Err()
+		}
+	}
+	return out, nil
+}
+
+// The number of keys AddBatch and ContainsBatch hash ahead of placing or looking them up. Must be
+// 64, since matchBuckets returns one bit per key in a uint64.
+const batchChunk = 64
+
+// Adds each of keys to the filter in order, like Insert. If the filter fills up, stops and returns
+// the index of the key that didn't fit along with an error: keys before it were added, and it and
+// the keys after it weren't. Otherwise, returns len(keys) and nil.
+//
+// For large filters, keys are hashed and then added in order of bucket rather than in the order
+// given, so that writes move through the table in one direction instead of jumping all over it. The
+// fingerprints may end up in different slots than adding the keys one at a time would put them, but
+// the set of items reported Maybe, and Count, are the same whatever the order. Filters with a
+// write-ahead log or load thresholds always add keys in the order given, so that the log and the
+// callbacks see them in that order.
+func (fl *Filter) AddBatch(keys [][]byte) (int, error) {
+	if len(keys) > batchChunk && fl.SizeBytes() >= largeFilterBytes && fl.log == nil &&
+		fl.thresholds == nil {
+		return fl.addBatchSorted(keys)
+	}
+	var fs [batchChunk]fingerprint
+	var i1s, i2s [batchChunk]uint64
+	for start := 0; start < len(keys); start += batchChunk {
+		chunk := keys[start:]
+		if len(chunk) > batchChunk {
+			chunk = chunk[:batchChunk]
+		}
+		// Hashing a run of keys up front keeps the hash function's code and state hot, and lets the
+		// bucket accesses below overlap with each other instead of with hashing.
+		for j, x := range chunk {
+			fs[j], i1s[j], i2s[j] = fl.itemToIdxs(x)
+		}
+		for j := range chunk {
+			if err := fl.insert(fs[j], i1s[j], i2s[j]); err != nil {
+				return start + j, err
+			}
+		}
+	}
+	return len(keys), nil
+}
+
+// The number of keys addBatchSorted sorts by bucket at a time. The more keys, the closer together
+// consecutive writes are, but the more memory it takes to hold them: two sortedKeys per key, so 16MB
+// at this size.
+const addSortChunk = 1 << 18
+
+// addBatchSorted only orders keys by which of this many equal ranges of buckets their primary bucket
+// is in, which is enough to make writes mostly sequential and is much cheaper than a full sort.
+const addSortBits = 12
+
+// A key waiting to be placed by addBatchSorted.
+type sortedKey struct {
+	i1, i2 uint64
+	f      fingerprint
+	// The key's index within its chunk of keys.
+	j int
+}
+
+// Does AddBatch's work, adding each chunk of keys in order of primary bucket.
+func (fl *Filter) addBatchSorted(keys [][]byte) (int, error) {
+	n := len(keys)
+	if n > addSortChunk {
+		n = addSortChunk
+	}
+	hashed := make([]sortedKey, n)
+	sorted := make([]sortedKey, n)
+	shift := bits.Len64(fl.nBuckets()-1) - addSortBits
+	if shift < 0 {
+		shift = 0
+	}
+	for start := 0; start < len(keys); start += addSortChunk {
+		chunk := keys[start:]
+		if len(chunk) > addSortChunk {
+			chunk = chunk[:addSortChunk]
+		}
+		// A counting sort by range, which keeps keys in the same range in their original order.
+		var offsets [1<<addSortBits + 1]int
+		for j, x := range chunk {
+			f, i1, i2 := fl.itemToIdxs(x)
+			hashed[j] = sortedKey{i1: i1, i2: i2, f: f, j: j}
+			offsets[i1>>shift+1]++
+		}
+		for g := 1; g < len(offsets); g++ {
+			offsets[g] += offsets[g-1]
+		}
+		s := sorted[:len(chunk)]
+		for _, sk := range hashed[:len(chunk)] {
+			g := sk.i1 >> shift
+			s[offsets[g]] = sk
+			offsets[g]++
+		}
+		for k, sk := range s {
+			if fl.insert(sk.f, sk.i1, sk.i2) != nil {
+				if j, err := fl.addChunkInOrder(chunk, s[:k], sk.j); err != nil {
+					return start + j, err
+				}
+				break
+			}
+		}
+	}
+	return len(keys), nil
+}
+
+// Called when the key at index failed in a chunk being added in bucket order, after the keys in
+// added were. Keys after failed in the chunk weren't supposed to be added before it, and may have
+// taken the room it needed, so this takes them back out and then adds everything not yet added in
+// the chunk in the order given, like AddBatch does for small filters. Returns the index of the key
+// that didn't fit and an error, or 0 and nil if they all did.
+func (fl *Filter) addChunkInOrder(chunk [][]byte, added []sortedKey, failed int) (int, error) {
+	// Takes out the keys in added with indexes in (lo, hi).
+	undo := func(lo, hi int) {
+		for _, sk := range added {
+			if sk.j > lo && sk.j < hi {
+				fl.delete(sk.f, sk.i1, sk.i2)
+			}
+		}
+	}
+	undo(failed, len(chunk))
+	done := make([]bool, len(chunk))
+	for _, sk := range added {
+		done[sk.j] = sk.j < failed
+	}
+	for j, x := range chunk {
+		if done[j] {
+			continue
+		}
+		f, i1, i2 := fl.itemToIdxs(x)
+		if err := fl.insert(f, i1, i2); err != nil {
+			// Keys before failed that were added out of order may come after this one.
+			undo(j, failed)
+			return j, err
+		}
+	}
+	return 0, nil
+}
+
+// Returns Contains(keys[i]) for each i, in order.
+//
+// Keys are hashed a chunk at a time, and their buckets are then matched against the whole chunk
+// at once, with AVX2 on amd64 and NEON on arm64. Directly encoded buckets are matched exactly that
+// way. Packed buckets, which are the default for buckets of 4 or 8 entries with fingerprints of at
+// least 4 bits, only have the bits of each entry above its low 4 matched that way, and the keys
+// that match are confirmed one at a time. The fewer of those there are, the more this saves over
+// Contains: with buckets of 4 entries, about 4 keys in 10 need confirming with 8-bit fingerprints,
+// and about 3 in 100 with 12-bit ones. Packed buckets of 4-bit fingerprints have nothing to match
+// in bulk, so those filters, and overflowed ones, just look up each key in turn.
+func (fl *Filter) ContainsBatch(keys [][]byte) []Result {
+	out := make([]Result, len(keys))
+	fl.containsBatch(keys, out)
+	return out
+}
+
+// Filters at least this big are unlikely to be mostly in cache, so batch operations go out of their
+// way to help the memory system: ContainsBatch prefetches buckets and AddBatch sorts keys by bucket.
+// For smaller filters that would only add work.
+const largeFilterBytes = 4 << 20
+
+// Sets out[i] to Contains(keys[i]) for each i. out must be at least as long as keys.
+func (fl *Filter) containsBatch(keys [][]byte, out []Result) {
+	// The fields matched in bulk, and how to get each key's pattern for them from its fingerprint.
+	// For packed buckets these are the fields holding the bits of each entry above its low 4, at
+	// the bottom of the encoding.
+	e, exact, shift := fl.bucketEncoding.direct, true, uint(0)
+	if p := fl.bucketEncoding.packed; fl.bucketEncoding.isPacked {
+		e, exact, shift = newDirectBucketEncoding(p.f-4, p.b), false, 4
+	}
+	bulk := e.f > 0 && !fl.overflowed
+	pf := fl.SizeBytes() >= largeFilterBytes
+	var fs [batchChunk]fingerprint
+	var i1s, i2s [batchChunk]uint64
+	var w1s, w2s, pats [batchChunk]uint64
+	for start := 0; start < len(keys); start += batchChunk {
+		chunk := keys[start:]
+		if len(chunk) > batchChunk {
+			chunk = chunk[:batchChunk]
+		}
+		// Starting the loads of a key's buckets as soon as it's hashed overlaps the memory latency
+		// with hashing the rest of the chunk, instead of paying it for each key in turn below.
+		for j, x := range chunk {
+			fs[j], i1s[j], i2s[j] = fl.itemToIdxs(x)
+			if pf {
+				fl.prefetchBucket(i1s[j])
+				fl.prefetchBucket(i2s[j])
+			}
+		}
+		if !bulk {
+			for j := range chunk {
+				out[start+j] = fl.contains(fs[j], i1s[j], i2s[j])
+			}
+			continue
+		}
+		// Gather both buckets of every key, then match the whole chunk at once. Entries past the
+		// end of chunk hold leftovers from the previous chunk and their results are ignored.
+		for j := range chunk {
+			w1s[j] = fl.loadBits(i1s[j])
+			w2s[j] = fl.loadBits(i2s[j])
+			pats[j] = uint64(fs[j]>>shift) * e.lows
+		}
+		hits := matchBuckets(&w1s, &w2s, &pats, e.highs-e.lows, e.highs)
+		for j := range chunk {
+			out[start+j] = No
+			if hits&(1<<uint(j)) == 0 {
+				continue
+			}
+			if exact {
+				out[start+j] = Maybe
+			} else {
+				out[start+j] = fl.contains(fs[j], i1s[j], i2s[j])
+			}
+		}
+	}
+}
+
+// Asks the CPU to start loading bucket i into cache without waiting for it.
+func (fl *Filter) prefetchBucket(i uint64) {
+	if fl.aligned != nil {
+		prefetch(&fl.aligned[i/fl.perWord])
+	} else if fl.paged == nil {
+		prefetch(&fl.words[i*fl.bucketEncoding.size()/64])
+	}
+}
+
+// Returns a mask with bit j set if either w1s[j] or w2s[j] has a field equal to the corresponding
+// field of pats[j], which is a fingerprint copied into every field of a directly encoded bucket. low
+// and highs are all-but-the-high-bit and the high bit of every field. See
+// directBucketEncoding.contains, which this does for batchChunk buckets at once.
+//
+// matchBuckets is the fastest implementation available on the platform; this is the portable one.
+func matchBucketsGeneric(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64 {
+	zero := func(v uint64) uint64 {
+		return ^(((v & low) + low) | v | low) & highs
+	}
+	var hits uint64
+	for j := range pats {
+		if zero(w1s[j]^pats[j])|zero(w2s[j]^pats[j]) != 0 {
+			hits |= 1 << uint(j)
+		}
+	}
+	return hits
+}
@@ -0,0 +1,45 @@
+//go:build amd64 && !purego
+
+package cuckoo
+
+// True if the CPU supports AVX2 and the OS saves the YMM registers across context switches.
+var hasAVX2 = detectAVX2()
+
+func detectAVX2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const osxsave, avx = 1 << 27, 1 << 28
+	if ecx1&osxsave == 0 || ecx1&avx == 0 {
+		return false
+	}
+	// XMM and YMM state both enabled in XCR0.
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
+
+// Issues PREFETCHT0 for the cache line holding *addr.
+//
+//go:noescape
+func prefetch(addr *uint64)
+
+// Does matchBucketsGeneric's work four buckets at a time. Must only be called if hasAVX2.
+//
+//go:noescape
+func matchBucketsAVX2(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64
+
+func matchBuckets(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64 {
+	if hasAVX2 {
+		return matchBucketsAVX2(w1s, w2s, pats, low, highs)
+	}
+	return matchBucketsGeneric(w1s, w2s, pats, low, highs)
+}
@@ -0,0 +1,80 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func prefetch(addr *uint64)
+TEXT ·prefetch(SB), NOSPLIT, $0-8
+	MOVQ       addr+0(FP), AX
+	PREFETCHT0 (AX)
+	RET
+
+// func matchBucketsAVX2(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64
+//
+// For each group of four buckets, computes directBucketEncoding.hasZeroField of w^pat for both
+// buckets in each of the four lanes, then collects one bit per lane into the result.
+TEXT ·matchBucketsAVX2(SB), NOSPLIT, $0-48
+	MOVQ         w1s+0(FP), SI
+	MOVQ         w2s+8(FP), DI
+	MOVQ         pats+16(FP), DX
+	VPBROADCASTQ low+24(FP), Y14
+	VPBROADCASTQ highs+32(FP), Y15
+	VPXOR        Y13, Y13, Y13
+	XORQ         AX, AX
+
+	// CX is both the index of the first bucket in the group and the position of its bit in the
+	// result.
+	XORQ CX, CX
+
+loop:
+	VMOVDQU (DX)(CX*8), Y0
+	VPXOR   (SI)(CX*8), Y0, Y1
+	VPXOR   (DI)(CX*8), Y0, Y2
+
+	// Y3 = ^(((v1 & low) + low) | v1 | low) & highs
+	VPAND  Y14, Y1, Y3
+	VPADDQ Y14, Y3, Y3
+	VPOR   Y1, Y3, Y3
+	VPOR   Y14, Y3, Y3
+	VPANDN Y15, Y3, Y3
+
+	// Y4 = the same for v2
+	VPAND  Y14, Y2, Y4
+	VPADDQ Y14, Y4, Y4
+	VPOR   Y2, Y4, Y4
+	VPOR   Y14, Y4, Y4
+	VPANDN Y15, Y4, Y4
+
+	// A lane is a hit if either is non-zero.
+	VPOR      Y3, Y4, Y4
+	VPCMPEQQ  Y13, Y4, Y4
+	VMOVMSKPD Y4, BX
+	XORQ      $0xF, BX
+	SHLQ      CX, BX
+	ORQ       BX, AX
+
+	ADDQ $4, CX
+	CMPQ CX, $64
+	JLT  loop
+
+	VZEROUPPER
+	MOVQ AX, ret+40(FP)
+	RET
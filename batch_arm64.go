@@ -0,0 +1,18 @@
+//go:build arm64 && !purego
+
+package cuckoo
+
+// Issues PRFM PLDL1KEEP for the cache line holding *addr.
+//
+//go:noescape
+func prefetch(addr *uint64)
+
+// Does matchBucketsGeneric's work two buckets at a time. NEON is part of every arm64 CPU, so
+// unlike AVX2 there's nothing to detect.
+//
+//go:noescape
+func matchBucketsNEON(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64
+
+func matchBuckets(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64 {
+	return matchBucketsNEON(w1s, w2s, pats, low, highs)
+}
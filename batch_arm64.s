@@ -0,0 +1,71 @@
+//go:build arm64 && !purego
+
+#include "textflag.h"
+
+// func prefetch(addr *uint64)
+TEXT ·prefetch(SB), NOSPLIT, $0-8
+	MOVD addr+0(FP), R0
+	PRFM (R0), PLDL1KEEP
+	RET
+
+// func matchBucketsNEON(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64
+//
+// For each pair of buckets, computes directBucketEncoding.hasZeroField of w^pat for both buckets
+// in each of the two lanes, then collects one bit per lane into the result. NEON has no movemask,
+// so each lane is compared against highs to make it all ones or all zeros, and then moved out.
+TEXT ·matchBucketsNEON(SB), NOSPLIT, $0-48
+	MOVD w1s+0(FP), R0
+	MOVD w2s+8(FP), R1
+	MOVD pats+16(FP), R2
+	MOVD low+24(FP), R3
+	MOVD highs+32(FP), R4
+	VDUP R3, V14.D2
+	VDUP R4, V15.D2
+	MOVD ZR, R5
+
+	// R6 is the position of the first bucket's bit in the result.
+	MOVD ZR, R6
+
+loop:
+	VLD1.P 16(R2), [V0.D2]
+	VLD1.P 16(R0), [V1.D2]
+	VLD1.P 16(R1), [V2.D2]
+	VEOR   V0.B16, V1.B16, V1.B16
+	VEOR   V0.B16, V2.B16, V2.B16
+
+	// V3 = (((v1 & low) + low) | v1 | low) & highs, which is highs exactly when v1 has no zero
+	// field, and then all ones in the lanes where it is.
+	VAND  V14.B16, V1.B16, V3.B16
+	VADD  V14.D2, V3.D2, V3.D2
+	VORR  V1.B16, V3.B16, V3.B16
+	VORR  V14.B16, V3.B16, V3.B16
+	VAND  V15.B16, V3.B16, V3.B16
+	VCMEQ V15.D2, V3.D2, V3.D2
+
+	// V4 = the same for v2
+	VAND  V14.B16, V2.B16, V4.B16
+	VADD  V14.D2, V4.D2, V4.D2
+	VORR  V2.B16, V4.B16, V4.B16
+	VORR  V14.B16, V4.B16, V4.B16
+	VAND  V15.B16, V4.B16, V4.B16
+	VCMEQ V15.D2, V4.D2, V4.D2
+
+	// A lane is a hit unless neither bucket has a zero field, that is, if it's zero here. Adding
+	// one turns all ones into 0 and 0 into 1.
+	VAND V3.B16, V4.B16, V4.B16
+	VMOV V4.D[0], R7
+	ADD  $1, R7, R7
+	LSL  R6, R7, R7
+	ORR  R7, R5, R5
+	ADD  $1, R6, R6
+	VMOV V4.D[1], R7
+	ADD  $1, R7, R7
+	LSL  R6, R7, R7
+	ORR  R7, R5, R5
+	ADD  $1, R6, R6
+
+	CMP $64, R6
+	BLT loop
+
+	MOVD R5, ret+40(FP)
+	RET
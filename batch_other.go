@@ -0,0 +1,11 @@
+//go:build (!amd64 && !arm64) || purego
+
+package cuckoo
+
+// No prefetch instruction is reachable without assembly here, so ContainsBatch relies on its
+// separate hashing and probing passes to keep several loads in flight.
+func prefetch(addr *uint64) {}
+
+func matchBuckets(w1s, w2s, pats *[batchChunk]uint64, low, highs uint64) uint64 {
+	return matchBucketsGeneric(w1s, w2s, pats, low, highs)
+}
@@ -0,0 +1,199 @@
+package cuckoo
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainsParallel(t *testing.T) {
+	const n = 20000
+	fl := New(n, 0.001)
+	keys := make([][]byte, 2*n+17)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		if i%2 == 0 {
+			fl.Add(keys[i])
+		}
+	}
+
+	for _, workers := range []int{0, 1, 3, 8, 1000} {
+		results := fl.ContainsParallel(keys, workers)
+		require.Len(t, results, len(keys))
+		for i, r := range results {
+			require.Equal(t, fl.Contains(keys[i]), r)
+		}
+	}
+	require.Empty(t, fl.ContainsParallel(nil, 4))
+}
+
+func TestContainsParallelContextCancelled(t *testing.T) {
+	fl := New(100, 0.001)
+	keys := make([][]byte, 10*parallelChunk)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := fl.ContainsParallelContext(ctx, keys, 4)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, results)
+
+	results, err = fl.ContainsParallelContext(context.Background(), keys, 4)
+	require.NoError(t, err)
+	require.Len(t, results, len(keys))
+}
+
+func TestAddBatch(t *testing.T) {
+	fl := New(1000, 0.001)
+	keys := make([][]byte, 500)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+	n, err := fl.AddBatch(keys)
+	require.NoError(t, err)
+	require.Equal(t, len(keys), n)
+	require.Equal(t, len(keys), fl.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, fl.Contains(x))
+	}
+}
+
+func TestAddBatchFull(t *testing.T) {
+	fl := NewRaw(8, 2, 16)
+	keys := make([][]byte, 200)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+	n, err := fl.AddBatch(keys)
+	require.Error(t, err)
+	require.Less(t, n, len(keys))
+	require.False(t, fl.Overflowed())
+	require.Equal(t, n, fl.Count())
+	for _, x := range keys[:n] {
+		require.Equal(t, Maybe, fl.Contains(x))
+	}
+	require.NoError(t, fl.CheckInvariants())
+}
+
+func TestAddBatchSorted(t *testing.T) {
+	keys := make([][]byte, 5000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+
+	fl := NewRaw(16, 4, 1<<13)
+	n, err := fl.addBatchSorted(keys)
+	require.NoError(t, err)
+	require.Equal(t, len(keys), n)
+	require.Equal(t, len(keys), fl.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, fl.Contains(x))
+	}
+	require.NoError(t, fl.CheckInvariants())
+
+	// Sorting changes which key the filter fills up at, but the keys before the one returned must
+	// still be exactly the ones added.
+	for _, size := range []int{16, 256, 1024} {
+		fl := NewRaw(8, 2, size)
+		n, err := fl.addBatchSorted(keys)
+		require.Error(t, err)
+		require.Less(t, n, len(keys))
+		require.False(t, fl.Overflowed())
+		require.Equal(t, n, fl.Count())
+		for _, x := range keys[:n] {
+			require.Equal(t, Maybe, fl.Contains(x))
+		}
+		require.NoError(t, fl.CheckInvariants())
+	}
+}
+
+func TestContainsBatch(t *testing.T) {
+	for name, fl := range map[string]*Filter{
+		"Packed":      NewRaw(8, 4, 256),
+		"Packed5":     NewRaw(5, 4, 256),
+		"Packed4":     NewRaw(4, 4, 256),
+		"Packed8":     NewRaw(6, 8, 128),
+		"Direct":      NewRaw(16, 2, 512),
+		"ByteAligned": NewRawByteAligned(12, 4, 256),
+	} {
+		t.Run(name, func(t *testing.T) {
+			keys := make([][]byte, 1000)
+			for i := range keys {
+				keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+				if i%3 == 0 {
+					fl.Add(keys[i])
+				}
+			}
+			require.False(t, fl.Overflowed())
+			results := fl.ContainsBatch(keys)
+			require.Len(t, results, len(keys))
+			for i, r := range results {
+				require.Equal(t, fl.Contains(keys[i]), r)
+			}
+			require.Empty(t, fl.ContainsBatch(nil))
+		})
+	}
+}
+
+func TestMatchBuckets(t *testing.T) {
+	trand.RandomN(t, 200, func(t *testing.T, r *rand.Rand) {
+		f := r.Intn(15) + 2
+		b := r.Intn(8) + 1
+		if f*b > 64 {
+			b = 64 / f
+		}
+		e := newDirectBucketEncoding(f, b)
+		randBucket := func() uint64 {
+			bkt := bucket{l: b}
+			for i := 0; i < b; i++ {
+				if r.Intn(3) > 0 {
+					bkt.entries[i] = fingerprint(r.Intn(1<<f-1) + 1)
+				}
+			}
+			return e.encode(bkt)
+		}
+		var w1s, w2s, pats [batchChunk]uint64
+		var want uint64
+		for j := range pats {
+			w1s[j], w2s[j] = randBucket(), randBucket()
+			fp := fingerprint(r.Intn(1<<f-1) + 1)
+			if r.Intn(2) == 0 {
+				// Make hits common enough to matter.
+				fp = e.decode(w1s[j]).entries[r.Intn(b)] | 1
+			}
+			pats[j] = uint64(fp) * e.lows
+			if e.contains(w1s[j], fp) || e.contains(w2s[j], fp) {
+				want |= 1 << uint(j)
+			}
+		}
+		require.Equal(t, want, matchBucketsGeneric(&w1s, &w2s, &pats, e.highs-e.lows, e.highs))
+		require.Equal(t, want, matchBuckets(&w1s, &w2s, &pats, e.highs-e.lows, e.highs))
+	})
+}
+
+func TestContainsBatchPrefetch(t *testing.T) {
+	fl := NewRaw(16, 4, largeFilterBytes/8)
+	require.GreaterOrEqual(t, fl.SizeBytes(), uint64(largeFilterBytes))
+	aligned := NewRawAligned(12, 4, largeFilterBytes/4)
+	require.GreaterOrEqual(t, aligned.SizeBytes(), uint64(largeFilterBytes))
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		if i%3 == 0 {
+			fl.Add(keys[i])
+			aligned.Add(keys[i])
+		}
+	}
+	for _, fl := range []*Filter{fl, aligned} {
+		results := fl.ContainsBatch(keys)
+		for i, r := range results {
+			require.Equal(t, fl.Contains(keys[i]), r)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cuckoo
+
+// Helpers for arrays of k-bit values packed back to back into []uint64, lowest bits first. Values
+// may straddle two words.
+
+// Returns the number of words needed to hold n k-bit values.
+func packedWords(k, n uint64) uint64 {
+	return (k*n + 63) / 64
+}
+
+// Returns the k-bit value at index i of a.
+func getPacked(a []uint64, k, i uint64) uint64 {
+	return getBits(a, k, i*k)
+}
+
+// Returns the k-bit value that starts at bit of a.
+func getBits(a []uint64, k, bit uint64) uint64 {
+	word, shift := bit/64, bit%64
+	mask := ^uint64(0) >> (64 - k)
+	v := a[word] >> shift
+	if shift+k > 64 {
+		v |= a[word+1] << (64 - shift)
+	}
+	return v & mask
+}
+
+// Like getPacked, but k must be a power of two so that no value straddles two words.
+func getPackedPow2(a []uint64, k, i uint64) uint64 {
+	bit := i * k
+	return (a[bit/64] >> (bit % 64)) & (^uint64(0) >> (64 - k))
+}
+
+// Like setPacked, but k must be a power of two so that no value straddles two words.
+func setPackedPow2(a []uint64, k, i, v uint64) {
+	bit := i * k
+	word, shift := bit/64, bit%64
+	mask := ^uint64(0) >> (64 - k)
+	a[word] = (a[word] &^ (mask << shift)) | ((v & mask) << shift)
+}
+
+// Sets the k-bit value at index i of a to v.
+func setPacked(a []uint64, k, i, v uint64) {
+	setBits(a, k, i*k, v)
+}
+
+// Sets the k-bit value that starts at bit of a to v.
+func setBits(a []uint64, k, bit, v uint64) {
+	word, shift := bit/64, bit%64
+	mask := ^uint64(0) >> (64 - k)
+	v &= mask
+	a[word] = (a[word] &^ (mask << shift)) | (v << shift)
+	if shift+k > 64 {
+		rshift := 64 - shift
+		a[word+1] = (a[word+1] &^ (mask >> rshift)) | (v >> rshift)
+	}
+}
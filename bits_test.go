@@ -0,0 +1,47 @@
+package cuckoo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacked(t *testing.T) {
+	trand.RandomN(t, 100, func(t *testing.T, r *rand.Rand) {
+		k := uint64(r.Intn(64) + 1)
+		n := uint64(r.Intn(300) + 1)
+		a := make([]uint64, packedWords(k, n))
+		expected := make([]uint64, n)
+		mask := ^uint64(0) >> (64 - k)
+		for j := 0; j < 1000; j++ {
+			i := uint64(r.Intn(int(n)))
+			v := r.Uint64()
+			setPacked(a, k, i, v)
+			expected[i] = v & mask
+		}
+		for i := uint64(0); i < n; i++ {
+			require.Equal(t, expected[i], getPacked(a, k, i), "k=%d i=%d", k, i)
+		}
+	})
+}
+
+func TestPackedPow2(t *testing.T) {
+	trand.RandomN(t, 100, func(t *testing.T, r *rand.Rand) {
+		k := uint64(1) << uint(r.Intn(7))
+		n := uint64(r.Intn(300) + 1)
+		a := make([]uint64, packedWords(k, n))
+		b := make([]uint64, len(a))
+		for j := 0; j < 1000; j++ {
+			i := uint64(r.Intn(int(n)))
+			v := r.Uint64()
+			setPackedPow2(a, k, i, v)
+			setPacked(b, k, i, v)
+		}
+		require.Equal(t, b, a)
+		for i := uint64(0); i < n; i++ {
+			require.Equal(t, getPacked(b, k, i), getPackedPow2(a, k, i), "k=%d i=%d", k, i)
+		}
+	})
+}
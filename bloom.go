@@ -0,0 +1,182 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// A Bloom filter, for comparison with Filter on the same workload through ApproxSet.
+//
+// A Bloom filter sets k bits of a bit array for each item, and reports Maybe for an item whose k
+// bits are all set. It uses less space than a Filter for false-positive rates above about 3%, and
+// never overflows, but its false-positive rate climbs past fp as more than n items are added, and it
+// doesn't support Delete.
+type BloomFilter struct {
+	bits []uint64
+	// The number of bits in bits that are used.
+	m uint64
+	// The number of bits set for each item.
+	k     int
+	count int
+	seed  uint64
+}
+
+// Returns a new BloomFilter sized to hold n items with an estimated false-positive rate of fp.
+func NewBloom(n int, fp float64) *BloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	m := math.Ceil(-float64(n) * math.Log(fp) / (math.Ln2 * math.Ln2))
+	k := int(math.Max(math.Round(m/float64(n)*math.Ln2), 1))
+	return NewBloomRaw(uint64(m), k)
+}
+
+// Returns a new BloomFilter with m bits, setting k of them for each item.
+func NewBloomRaw(m uint64, k int) *BloomFilter {
+	if m < 1 {
+		m = 1
+	}
+	if k < 1 {
+		k = 1
+	}
+	return &BloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (bf *BloomFilter) SetSeed(seed uint64) {
+	if bf.count != 0 {
+		panic("cuckoo: SetSeed must be called before adding any items")
+	}
+	bf.seed = seed
+}
+
+// Returns the seed set with SetSeed.
+func (bf *BloomFilter) Seed() uint64 {
+	return bf.seed
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (bf *BloomFilter) Add(x []byte) {
+	h1, h2 := bf.hashes(x)
+	for j := 0; j < bf.k; j++ {
+		i := bf.idx(h1, h2, j)
+		bf.bits[i/64] |= 1 << (i % 64)
+	}
+	bf.count++
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (bf *BloomFilter) Contains(x []byte) Result {
+	h1, h2 := bf.hashes(x)
+	for j := 0; j < bf.k; j++ {
+		i := bf.idx(h1, h2, j)
+		if bf.bits[i/64]&(1<<(i%64)) == 0 {
+			return No
+		}
+	}
+	return Maybe
+}
+
+// Returns the number of items added to the filter, counting each Add of the same item.
+func (bf *BloomFilter) Count() int {
+	return bf.count
+}
+
+// Returns the number of bytes used by the filter's bits.
+func (bf *BloomFilter) SizeBytes() uint64 {
+	return uint64(len(bf.bits)) * 8
+}
+
+// Returns an estimate of the filter's current false-positive rate, from the fraction of its bits
+// that are set.
+func (bf *BloomFilter) EstimatedFalsePositiveRate() float64 {
+	set := 0
+	for _, w := range bf.bits {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(bf.m), float64(bf.k))
+}
+
+// Removes every item from the filter, reusing its memory.
+func (bf *BloomFilter) Reset() {
+	for i := range bf.bits {
+		bf.bits[i] = 0
+	}
+	bf.count = 0
+}
+
+// Returns an independent copy of the filter.
+func (bf *BloomFilter) Clone() *BloomFilter {
+	c := *bf
+	c.bits = append([]uint64(nil), bf.bits...)
+	return &c
+}
+
+// Returns the two hashes that each of x's bits are derived from. The second is odd, so that the k
+// bits are distinct when m is a power of two.
+func (bf *BloomFilter) hashes(x []byte) (uint64, uint64) {
+	h := xxhash64(x, bf.seed)
+	z := (h ^ (h >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return h, (z ^ (z >> 31)) | 1
+}
+
+// Returns the index of the jth bit of the item whose hashes are h1 and h2, using double hashing.
+// See https://www.eecs.harvard.edu/~michaelm/postscripts/rsa2008.pdf.
+func (bf *BloomFilter) idx(h1, h2 uint64, j int) uint64 {
+	hi, _ := bits.Mul64(h1+uint64(j)*h2, bf.m)
+	return hi
+}
+
+// Serialized format, little-endian:
+//
+//	magic  [4]byte  "CKBL"
+//	k      uint8    the number of bits set for each item
+//	m      uint64   the number of bits
+//	count  uint64   the number of items added
+//	seed   uint64
+//	bits   [(m+63)/64]uint64
+var bloomMagic = [4]byte{'C', 'K', 'B', 'L'}
+
+const bloomHeaderSize = 4 + 1 + 8 + 8 + 8
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (bf *BloomFilter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, bloomHeaderSize+len(bf.bits)*8)
+	out = append(out, bloomMagic[:]...)
+	out = append(out, byte(bf.k))
+	out = binary.LittleEndian.AppendUint64(out, bf.m)
+	out = binary.LittleEndian.AppendUint64(out, uint64(bf.count))
+	out = binary.LittleEndian.AppendUint64(out, bf.seed)
+	for _, w := range bf.bits {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of bf with the filter encoded in
+// data.
+func (bf *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeaderSize || !bytes.Equal(data[:4], bloomMagic[:]) {
+		return errCorrupt
+	}
+	k := int(data[4])
+	m := binary.LittleEndian.Uint64(data[5:])
+	count := binary.LittleEndian.Uint64(data[13:])
+	seed := binary.LittleEndian.Uint64(data[21:])
+	data = data[bloomHeaderSize:]
+	if k == 0 || m == 0 || count > uint64(maxInt) || uint64(len(data))/8 != (m+63)/64 ||
+		len(data)%8 != 0 {
+		return errCorrupt
+	}
+	result := NewBloomRaw(m, k)
+	for i := range result.bits {
+		result.bits[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	result.count = int(count)
+	result.seed = seed
+	*bf = *result
+	return nil
+}
@@ -0,0 +1,95 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloom(t *testing.T) {
+	const fp = 0.01
+	bf := NewBloom(10000, fp)
+	keys := make([][]byte, 10000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		bf.Add(keys[i])
+	}
+	require.Equal(t, len(keys), bf.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, bf.Contains(x))
+	}
+
+	fps := 0
+	const tries = 100000
+	for i := len(keys); i < len(keys)+tries; i++ {
+		if bf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.InDelta(t, fp, float64(fps)/tries, fp/2)
+	require.InDelta(t, fp, bf.EstimatedFalsePositiveRate(), fp/4)
+
+	c := bf.Clone()
+	bf.Reset()
+	require.Zero(t, bf.Count())
+	require.Equal(t, No, bf.Contains(keys[0]))
+	require.Equal(t, Maybe, c.Contains(keys[0]))
+}
+
+func TestBloomSerialize(t *testing.T) {
+	bf := NewBloom(1000, 0.01)
+	bf.SetSeed(42)
+	for i := 0; i < 1000; i++ {
+		bf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := bf.MarshalBinary()
+	require.NoError(t, err)
+
+	var bf2 BloomFilter
+	require.NoError(t, bf2.UnmarshalBinary(data))
+	require.Equal(t, bf, &bf2)
+
+	require.Error(t, bf2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, bf2.UnmarshalBinary(append(data, 0)))
+}
+
+func TestApproxSet(t *testing.T) {
+	const n, fp = 1000, 0.01
+	sets := map[string]ApproxSet{
+		"Filter":         New(n, fp),
+		"BloomFilter":    NewBloom(n, fp),
+		"CountingFilter": NewCounting(n, fp),
+		"DAryFilter":     NewDAry(n, fp, 3),
+		"DynamicFilter":  NewDynamic(n, fp),
+		"MortonFilter":   NewMorton(n, fp),
+		"QuotientFilter": NewQuotient(n, fp),
+		"TaffyFilter":    NewTaffy(n, fp),
+		"TieredFilter":   NewTiered(n, fp, n/10),
+		"TTLFilter":      NewTTL(n, fp, time.Hour),
+		"WindowedFilter": NewWindowed(n, fp, 4),
+	}
+	for name, s := range sets {
+		t.Run(name, func(t *testing.T) {
+			empty := s.SizeBytes()
+			require.NotZero(t, empty)
+			for i := 0; i < n; i++ {
+				s.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+			}
+			for i := 0; i < n; i++ {
+				require.Equal(t, Maybe, s.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+			}
+			fps := 0
+			for i := n; i < 11*n; i++ {
+				if s.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+					fps++
+				}
+			}
+			require.Less(t, float64(fps)/(10*n), 2*fp)
+			data, err := s.MarshalBinary()
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, uint64(len(data)), empty)
+		})
+	}
+}
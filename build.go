@@ -0,0 +1,301 @@
+package cuckoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// A hashed key waiting to be placed by BuildParallel.
+type staged struct {
+	i1 uint64
+	f  fingerprint
+}
+
+// A batch of keys copied out of the caller's iterator, so that the iterator is free to reuse its
+// buffers.
+type keyBatch struct {
+	arena []byte
+	ends  []int
+}
+
+const buildBatchSize = 4096
+
+// Returns a filter sized as New(n, fp) containing every key produced by keys, built using up to
+// workers goroutines.
+//
+// keys is called once, and should call yield for each key until it runs out or yield returns
+// false. The key passed to yield may be reused once yield returns.
+//
+// Keys are hashed concurrently and then placed concurrently, with each goroutine owning a
+// contiguous region of buckets. Only the few keys whose candidate buckets are both already full
+// have to be placed one at a time. While building, every key's fingerprint and bucket index is held
+// in memory, about 16 bytes per key.
+func BuildParallel(n int, fp float64, keys func(yield func(key []byte) bool), workers int) *Filter {
+	fl, _ := BuildParallelContext(context.Background(), n, fp, keys, workers)
+	return fl
+}
+
+// Like BuildParallel, but stops pulling keys once ctx is cancelled. The returned filter holds the
+// first fl.Count() keys produced by keys, which is all of them unless the error is ctx.Err().
+func BuildParallelContext(
+	ctx context.Context,
+	n int,
+	fp float64,
+	keys func(yield func(key []byte) bool),
+	workers int,
+) (*Filter, error) {
+	fl := New(n, fp)
+	err := fl.AddParallelContext(ctx, keys, workers)
+	return fl, err
+}
+
+// Adds every key produced by keys to the filter, as if by Add, using up to workers goroutines. See
+// BuildParallel, which does this for a new filter, for how keys is called and how the work is
+// split. AddParallel is for filters that need to be set up first, such as with NewExact, SetSeed,
+// or NewAligned, and for adding to a filter that already has items in it.
+//
+// Nothing else may use the filter until AddParallel returns. If the filter has a log (see SetLog),
+// keys are added one at a time so that each is logged in order. Otherwise keys are hashed on
+// several goroutines at once, so a Hasher set with SetHasher must be safe for concurrent use.
+func (fl *Filter) AddParallel(keys func(yield func(key []byte) bool), workers int) {
+	_ = fl.AddParallelContext(context.Background(), keys, workers)
+}
+
+// Like AddParallel, but stops pulling keys once ctx is cancelled. The keys consumed before then are
+// all added, and the error is ctx.Err().
+func (fl *Filter) AddParallelContext(
+	ctx context.Context,
+	keys func(yield func(key []byte) bool),
+	workers int,
+) error {
+	if fl.log != nil {
+		var err error
+		n := 0
+		keys(func(key []byte) bool {
+			fl.Add(key)
+			n++
+			if n%buildBatchSize == 0 {
+				err = ctx.Err()
+				return err == nil
+			}
+			return true
+		})
+		return err
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	// Regions are multiples of 64 buckets so that no two share a word of the backing array or of the
+	// dirty bitmap. Aligned storage packs perWord buckets to a word, so regions are also multiples
+	// of that.
+	align := uint64(64)
+	if fl.aligned != nil {
+		align *= fl.perWord
+	}
+	regionSize := (fl.nBuckets() + uint64(workers) - 1) / uint64(workers)
+	regionSize = (regionSize + align - 1) / align * align
+	nRegions := int((fl.nBuckets() + regionSize - 1) / regionSize)
+	region := func(i uint64) int { return int(i / regionSize) }
+
+	// Phase 1: hash every key, sorting them by the region of their primary bucket. Each worker has
+	// its own staging area so they don't contend.
+	byWorker := make([][][]staged, workers)
+	batches := make(chan keyBatch, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		byWorker[w] = make([][]staged, nRegions)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for batch := range batches {
+				start := 0
+				for _, end := range batch.ends {
+					f, i1, _ := fl.itemToIdxs(batch.arena[start:end])
+					r := region(i1)
+					byWorker[w][r] = append(byWorker[w][r], staged{i1: i1, f: f})
+					start = end
+				}
+			}
+		}(w)
+	}
+
+	total := 0
+	batch := keyBatch{}
+	var err error
+	keys(func(key []byte) bool {
+		batch.arena = append(batch.arena, key...)
+		batch.ends = append(batch.ends, len(batch.arena))
+		total++
+		if len(batch.ends) == buildBatchSize {
+			batches <- batch
+			batch = keyBatch{}
+			// Checking once a batch keeps ctx off the per-key path.
+			err = ctx.Err()
+			return err == nil
+		}
+		return true
+	})
+	if len(batch.ends) > 0 {
+		batches <- batch
+	}
+	close(batches)
+	wg.Wait()
+
+	fl.count += total
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+	if fl.overflowed {
+		return err
+	}
+
+	// Phase 2: each region's owner places fingerprints whose primary bucket it owns, then (after
+	// regrouping) those whose alternate bucket it owns. Neither pass kicks, so no goroutine ever
+	// touches another's region.
+	leftover := make([][]staged, nRegions)
+	placeInRegion := func(in func(r int) [][]staged, idx func(s staged) uint64) {
+		var wg sync.WaitGroup
+		for r := 0; r < nRegions; r++ {
+			wg.Add(1)
+			go func(r int) {
+				defer wg.Done()
+				var remaining []staged
+				for _, list := range in(r) {
+					for _, s := range list {
+						i := idx(s)
+						b := fl.getBucket(i)
+						if b.hasEmpty() {
+							b.add(s.f)
+							fl.setBucket(i, b)
+						} else {
+							remaining = append(remaining, s)
+						}
+					}
+				}
+				leftover[r] = remaining
+			}(r)
+		}
+		wg.Wait()
+	}
+
+	placeInRegion(
+		func(r int) [][]staged {
+			lists := make([][]staged, workers)
+			for w := range byWorker {
+				lists[w] = byWorker[w][r]
+			}
+			return lists
+		},
+		func(s staged) uint64 { return s.i1 },
+	)
+	byWorker = nil
+
+	byAlt := make([][]staged, nRegions)
+	for _, list := range leftover {
+		for _, s := range list {
+			r := region(fl.otherIdx(s.f, s.i1))
+			byAlt[r] = append(byAlt[r], s)
+		}
+	}
+	placeInRegion(
+		func(r int) [][]staged { return [][]staged{byAlt[r]} },
+		func(s staged) uint64 { return fl.otherIdx(s.f, s.i1) },
+	)
+
+	// Phase 3: whatever is left needs kicks, which can reach anywhere in the table.
+	for _, list := range leftover {
+		for _, s := range list {
+			if fl.overflowed {
+				return err
+			}
+			fl.place(s.f, s.i1, fl.otherIdx(s.f, s.i1))
+		}
+	}
+	return err
+}
+
+// Where a key goes in a particular filter. See Filter.Locate.
+type Location struct {
+	// The key's primary bucket.
+	Bucket uint64
+	// The key's fingerprint, which is never zero.
+	Fingerprint uint16
+}
+
+// Returned by AddSorted if the locations aren't sorted by bucket.
+var ErrNotSorted = errors.New("cuckoo: locations are not sorted by bucket")
+
+// Returns where x goes in the filter. Locations depend on the filter's size, fingerprint size, and
+// hashing, so they're only meaningful to this filter and others created the same way.
+func (fl *Filter) Locate(x []byte) Location {
+	f, i1, _ := fl.itemToIdxs(x)
+	return Location{Bucket: i1, Fingerprint: uint16(f)}
+}
+
+// Adds each location produced by locs to the filter, as if by Add on the key it came from.
+//
+// locs is called once, and should call yield for each location until it runs out or yield returns
+// false. The locations must come in order of Bucket, which lets AddSorted fill buckets one after
+// another instead of jumping around the table. Only the few fingerprints whose primary bucket is
+// already full have to be placed by kicking once the rest are in. This suits building very large
+// filters from keys that have been located and then sorted externally.
+//
+// If a location is out of order or doesn't belong to the filter, stops and returns an error. The
+// locations before it were added and the rest weren't.
+func (fl *Filter) AddSorted(locs func(yield func(loc Location) bool)) error {
+	var err error
+	var deferred []staged
+	var cur uint64
+	var b bucket
+	started, changed := false, false
+	flush := func() {
+		if changed {
+			fl.setBucket(cur, b)
+		}
+	}
+	locs(func(loc Location) bool {
+		if loc.Bucket >= fl.nBuckets() || loc.Fingerprint == 0 ||
+			uint64(loc.Fingerprint) >= uint64(1)<<uint(fl.f) {
+			err = fmt.Errorf("cuckoo: location %+v doesn't belong to this filter", loc)
+			return false
+		}
+		if started && loc.Bucket < cur {
+			err = ErrNotSorted
+			return false
+		}
+		if !started || loc.Bucket != cur {
+			flush()
+			cur, b = loc.Bucket, fl.getBucket(loc.Bucket)
+			started, changed = true, false
+		}
+		f := fingerprint(loc.Fingerprint)
+		fl.count++
+		if fl.log != nil {
+			fl.log.record(walOpAdd, f, cur)
+		}
+		if fl.overflowed {
+			return true
+		}
+		if b.hasEmpty() {
+			b.add(f)
+			changed = true
+		} else {
+			deferred = append(deferred, staged{i1: cur, f: f})
+		}
+		return true
+	})
+	flush()
+
+	for _, s := range deferred {
+		if fl.overflowed {
+			break
+		}
+		fl.place(s.f, s.i1, fl.otherIdx(s.f, s.i1))
+	}
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+package cuckoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildParallel(t *testing.T) {
+	for _, workers := range []int{1, 3, 8} {
+		const n = 100000
+		keys := func(yield func([]byte) bool) {
+			// Reuse the buffer, which BuildParallel has to tolerate.
+			var buf [8]byte
+			for i := 0; i < n; i++ {
+				binary.LittleEndian.PutUint64(buf[:], uint64(i))
+				if !yield(buf[:]) {
+					return
+				}
+			}
+		}
+
+		fl := BuildParallel(n, 0.01, keys, workers)
+		require.False(t, fl.Overflowed())
+		require.Equal(t, n, fl.Count())
+		for i := 0; i < n; i++ {
+			require.Equal(t, Maybe, fl.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+		}
+		require.NoError(t, fl.CheckInvariants())
+	}
+}
+
+func TestBuildParallelContextCancelled(t *testing.T) {
+	const n = 100000
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := func(yield func([]byte) bool) {
+		for i := 0; i < n; i++ {
+			if i == n/2 {
+				cancel()
+			}
+			if !yield(binary.LittleEndian.AppendUint64(nil, uint64(i))) {
+				return
+			}
+		}
+	}
+
+	fl, err := BuildParallelContext(ctx, n, 0.01, keys, 4)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, fl.Count(), n)
+	require.GreaterOrEqual(t, fl.Count(), n/2)
+	// Everything consumed before the cancellation made it in.
+	for i := 0; i < fl.Count(); i++ {
+		require.Equal(t, Maybe, fl.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+	require.NoError(t, fl.CheckInvariants())
+}
+
+func TestAddParallel(t *testing.T) {
+	const n = 50000
+	keys := func(start, end int) func(yield func([]byte) bool) {
+		return func(yield func([]byte) bool) {
+			for i := start; i < end; i++ {
+				if !yield(binary.LittleEndian.AppendUint64(nil, uint64(i))) {
+					return
+				}
+			}
+		}
+	}
+	seeded := New(n, 0.01)
+	seeded.SetSeed(42)
+	var log bytes.Buffer
+	logged := New(n, 0.01)
+	logged.SetLog(&log)
+	for _, fl := range []*Filter{NewExact(n, 0.01), NewRawAligned(10, 2, 1<<16), seeded, logged} {
+		// Half up front, so that AddParallel starts from a filter that already has items.
+		fl.AddParallel(keys(0, n/2), 4)
+		fl.AddParallel(keys(n/2, n), 3)
+		require.False(t, fl.Overflowed())
+		require.Equal(t, n, fl.Count())
+		keys(0, n)(func(x []byte) bool {
+			require.Equal(t, Maybe, fl.Contains(x))
+			return true
+		})
+		require.NoError(t, fl.CheckInvariants())
+	}
+	require.NoError(t, logged.LogErr())
+	require.NotZero(t, log.Len())
+}
+
+func TestAddSorted(t *testing.T) {
+	const n = 100000
+	fl := New(n, 0.01)
+	keys := make([][]byte, n)
+	locs := make([]Location, n)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		locs[i] = fl.Locate(keys[i])
+	}
+	sort.Slice(locs, func(i, j int) bool { return locs[i].Bucket < locs[j].Bucket })
+
+	err := fl.AddSorted(func(yield func(Location) bool) {
+		for _, loc := range locs {
+			if !yield(loc) {
+				return
+			}
+		}
+	})
+	require.NoError(t, err)
+	require.False(t, fl.Overflowed())
+	require.Equal(t, n, fl.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, fl.Contains(x))
+	}
+	require.NoError(t, fl.CheckInvariants())
+}
+
+func TestAddSortedInvalid(t *testing.T) {
+	fl := New(1000, 0.01)
+	a, b := fl.Locate([]byte("a")), fl.Locate([]byte("b"))
+	if a.Bucket > b.Bucket {
+		a, b = b, a
+	}
+	if a.Bucket == b.Bucket {
+		t.Skip("a and b share a bucket")
+	}
+	err := fl.AddSorted(func(yield func(Location) bool) {
+		_ = yield(b) && yield(a)
+	})
+	require.ErrorIs(t, err, ErrNotSorted)
+	require.Equal(t, 1, fl.Count())
+
+	err = fl.AddSorted(func(yield func(Location) bool) {
+		yield(Location{Bucket: fl.nBuckets(), Fingerprint: 1})
+	})
+	require.Error(t, err)
+	err = fl.AddSorted(func(yield func(Location) bool) {
+		yield(Location{Bucket: b.Bucket})
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, fl.Count())
+	require.NoError(t, fl.CheckInvariants())
+}
@@ -0,0 +1,213 @@
+package cuckoo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// Collects items for a filter that is built once and then only queried, such as one written
+// alongside an immutable file. Nothing is placed until Freeze, which knows exactly how many items
+// there are and so can size the table for them rather than for a guessed capacity.
+type Builder struct {
+	fp     float64
+	seed   uint64
+	hashes []uint64
+}
+
+// Returns a new Builder for a filter with an estimated false-positive rate of fp.
+func NewBuilder(fp float64) *Builder {
+	if !(fp > 0 && fp < 1) {
+		panic(fmt.Errorf("%w: false-positive rate fp=%v must be in (0, 1)", ErrInvalidParams, fp))
+	}
+	return &Builder{fp: fp}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (bd *Builder) SetSeed(seed uint64) {
+	if len(bd.hashes) != 0 {
+		panic("cuckoo: SetSeed must be called before adding any items")
+	}
+	bd.seed = seed
+}
+
+// Adds an item to the filter that Freeze will build. Only x's hash is kept, 8 bytes per item.
+func (bd *Builder) Add(x []byte) {
+	bd.hashes = append(bd.hashes, xxhash64(x, bd.seed))
+}
+
+// Returns the number of items added so far, counting repeats.
+func (bd *Builder) Len() int {
+	return len(bd.hashes)
+}
+
+// The load Freeze aims for at first. Packing is rarely possible much beyond this with 4-entry
+// buckets, and every failed attempt costs a full rebuild.
+const frozenLoad = 0.97
+
+// Builds a read-only filter containing every item added so far, as small as Freeze can make it.
+// The Builder can keep being added to and frozen again afterward.
+func (bd *Builder) Freeze() *FrozenFilter {
+	hs := append([]uint64(nil), bd.hashes...)
+	sort.Slice(hs, func(a, b int) bool { return hs[a] < hs[b] })
+	// Repeats have the same fingerprint and buckets, so they only need one entry.
+	unique := hs[:0]
+	for j, h := range hs {
+		if j == 0 || h != hs[j-1] {
+			unique = append(unique, h)
+		}
+	}
+	f, b, _ := params(len(unique), bd.fp)
+	nBuckets := int(math.Ceil(float64(len(unique)) / float64(b) / frozenLoad))
+	for {
+		if nBuckets < 1 {
+			nBuckets = 1
+		}
+		// Any number of buckets works, so grow a little at a time rather than doubling.
+		if fl, ok := packFrozen(unique, f, b, nBuckets, bd.seed); ok {
+			return &FrozenFilter{
+				words:    fl.words,
+				encoding: fl.bucketEncoding,
+				n:        fl.n,
+				f:        f,
+				b:        b,
+				count:    len(unique),
+				seed:     bd.seed,
+			}
+		}
+		nBuckets += nBuckets/64 + 1
+	}
+}
+
+// Places every hash in hs into a new filter with nBuckets buckets, or returns false if they don't
+// all fit.
+func packFrozen(hs []uint64, f, b, nBuckets int, seed uint64) (*Filter, bool) {
+	fl := newFilter(f, b, nBuckets)
+	fl.seed = seed
+	// Nothing needs undoing, since the filter is thrown away on failure, and the breadth-first
+	// search finds room in more crowded tables than a random walk does.
+	fl.insertStrategy = BreadthFirst
+	for _, h := range hs {
+		fp, i1, i2 := fl.hashToIdxs(h)
+		if !fl.kick(fp, i1, i2, false) {
+			return nil, false
+		}
+	}
+	fl.count = len(hs)
+	return fl, true
+}
+
+// A filter that can't be modified, from Builder.Freeze. It answers Contains exactly as a Filter
+// holding the same items would, but its table is sized for exactly the items in it, and it carries
+// none of a Filter's bookkeeping for adds and deletes.
+//
+// A FrozenFilter is safe for concurrent use.
+type FrozenFilter struct {
+	words    []uint64
+	encoding bucketEncoding
+	// The number of buckets.
+	n     uint64
+	f, b  int
+	count int
+	seed  uint64
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (ff *FrozenFilter) Contains(x []byte) Result {
+	// The same as Filter's hashToIdxs and otherIdx for the default hash.
+	h := xxhash64(x, ff.seed)
+	hi, _ := bits.Mul64(h, (uint64(1)<<uint(ff.f))-1)
+	f := fingerprint(hi + 1)
+	i1 := ff.reduce(bits.RotateLeft64(h, 32))
+	if ff.encoding.contains(ff.loadBits(i1), f) {
+		return Maybe
+	}
+	i2 := pairedIdx(i1, ff.reduce(mixFingerprint(f)), ff.n)
+	if ff.encoding.contains(ff.loadBits(i2), f) {
+		return Maybe
+	}
+	return No
+}
+
+func (ff *FrozenFilter) reduce(x uint64) uint64 {
+	hi, _ := bits.Mul64(x, ff.n)
+	return hi
+}
+
+func (ff *FrozenFilter) loadBits(i uint64) uint64 {
+	k := ff.encoding.size()
+	if k&(k-1) == 0 {
+		return getPackedPow2(ff.words, k, i)
+	}
+	return getPacked(ff.words, k, i)
+}
+
+// Returns the number of distinct items in the filter.
+func (ff *FrozenFilter) Count() int {
+	return ff.count
+}
+
+// Returns the seed the filter's items were hashed with.
+func (ff *FrozenFilter) Seed() uint64 {
+	return ff.seed
+}
+
+// Returns the number of bytes used by the filter's buckets.
+func (ff *FrozenFilter) SizeBytes() uint64 {
+	return uint64(len(ff.words)) * 8
+}
+
+// Returns an estimate of the filter's false-positive rate.
+func (ff *FrozenFilter) EstimatedFalsePositiveRate() float64 {
+	return ff.filter().EstimatedFalsePositiveRate()
+}
+
+// Returns a Filter that shares ff's buckets, for reusing Filter's methods that only read. It must
+// not be modified.
+func (ff *FrozenFilter) filter() *Filter {
+	fl := newFilterWords(ff.f, ff.b, int(ff.n), ff.encoding, ff.words)
+	fl.seed = ff.seed
+	fl.count = ff.count
+	return fl
+}
+
+// Returns a Filter holding the same items as ff, which can be modified. Its table is as full as
+// ff's, so items added to it are likely to overflow it.
+func (ff *FrozenFilter) Thaw() *Filter {
+	return ff.filter().Clone()
+}
+
+// Returned by FrozenFilter.UnmarshalBinary for filters that can't be frozen.
+var errNotFreezable = errors.New(
+	"cuckoo: FrozenFilter can only hold a filter with the default hash and in-memory buckets")
+
+// Implements encoding.BinaryMarshaler. The encoding is the same as a Filter's, so it can also be
+// read with Filter.UnmarshalBinary.
+func (ff *FrozenFilter) MarshalBinary() ([]byte, error) {
+	return ff.filter().MarshalBinary()
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of ff with the filter encoded in
+// data. data can come from Filter.MarshalBinary as well, as long as the filter uses the default hash
+// and hasn't overflowed.
+func (ff *FrozenFilter) UnmarshalBinary(data []byte) error {
+	fl := &Filter{}
+	if err := fl.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	if fl.hashing != hashXXH || fl.overflowed || fl.aligned != nil || fl.paged != nil {
+		return errNotFreezable
+	}
+	*ff = FrozenFilter{
+		words:    fl.words,
+		encoding: fl.bucketEncoding,
+		n:        fl.n,
+		f:        fl.f,
+		b:        fl.b,
+		count:    fl.count,
+		seed:     fl.seed,
+	}
+	return nil
+}
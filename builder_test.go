@@ -0,0 +1,87 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	const n = 10000
+	bd := NewBuilder(0.01)
+	bd.SetSeed(7)
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for i := 0; i < n; i++ {
+		bd.Add(key(i))
+	}
+	// Repeats only take one entry.
+	bd.Add(key(0))
+	require.Equal(t, n+1, bd.Len())
+	require.Panics(t, func() { bd.SetSeed(8) })
+
+	ff := bd.Freeze()
+	require.Equal(t, n, ff.Count())
+	require.Equal(t, uint64(7), ff.Seed())
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, ff.Contains(key(i)))
+	}
+	fps := 0
+	for i := n; i < 11*n; i++ {
+		if ff.Contains(key(i)) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/(10*n), 0.01)
+	require.Less(t, ff.EstimatedFalsePositiveRate(), 0.01)
+
+	// Sized for what it holds rather than rounded up to a power of two.
+	fl := New(n, 0.01)
+	require.Less(t, ff.SizeBytes(), fl.SizeBytes()*3/4)
+
+	// A thawed copy answers the same and can be modified.
+	thawed := ff.Thaw()
+	for i := 0; i < 2*n; i++ {
+		require.Equal(t, ff.Contains(key(i)), thawed.Contains(key(i)))
+	}
+	thawed.Delete(key(0))
+	require.Equal(t, Maybe, ff.Contains(key(0)))
+}
+
+func TestBuilderEmpty(t *testing.T) {
+	ff := NewBuilder(0.01).Freeze()
+	require.Zero(t, ff.Count())
+	require.Equal(t, No, ff.Contains([]byte("x")))
+	require.Panics(t, func() { NewBuilder(0) })
+}
+
+func TestFrozenSerialize(t *testing.T) {
+	bd := NewBuilder(0.001)
+	for i := 0; i < 1000; i++ {
+		bd.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	ff := bd.Freeze()
+	data, err := ff.MarshalBinary()
+	require.NoError(t, err)
+
+	var ff2 FrozenFilter
+	require.NoError(t, ff2.UnmarshalBinary(data))
+	require.Equal(t, ff.words, ff2.words)
+	require.Equal(t, ff.Count(), ff2.Count())
+
+	// The encoding is a Filter's.
+	fl := &Filter{}
+	require.NoError(t, fl.UnmarshalBinary(data))
+	for i := 0; i < 2000; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		require.Equal(t, ff.Contains(x), fl.Contains(x))
+		require.Equal(t, ff.Contains(x), ff2.Contains(x))
+	}
+
+	require.Error(t, ff2.UnmarshalBinary(data[:len(data)-1]))
+	wide := New(100, 0.01)
+	wide.Use128BitHash()
+	data, err = wide.MarshalBinary()
+	require.NoError(t, err)
+	require.ErrorIs(t, ff2.UnmarshalBinary(data), errNotFreezable)
+}
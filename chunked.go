@@ -0,0 +1,236 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Chunked format, for moving very large filters over unreliable transports. Each chunk is
+// self-describing and separately checksummed, so chunks can be written and read in any order and a
+// chunk that fails to arrive intact can be requested again on its own. All multi-byte fields are
+// little-endian.
+//
+//	magic            [4]byte  "CKOC"
+//	filter header    20 bytes, or 28 with a seed: the full format's header after the magic
+//	chunk            uint32   index of this chunk
+//	nChunks          uint32
+//	bucketsPerChunk  uint64
+//	buckets          bucketsPerChunk buckets (fewer for the last chunk), as in the full format
+//	checksum         uint32   CRC-32C of everything above
+//
+// chunk, nChunks, and bucketsPerChunk take up chunkFieldsSize bytes.
+const chunkFieldsSize = 4 + 4 + 8
+
+var chunkMagic = [4]byte{'C', 'K', 'O', 'C'}
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// Returned by ChunkLoader.ReadChunk when a chunk's checksum doesn't match its contents.
+var errChunkChecksum = errors.New("cuckoo: chunk checksum mismatch")
+
+// Returns the number of buckets to put in each chunk so that chunks hold about chunkSize bytes.
+func (fl *Filter) bucketsPerChunk(chunkSize int) uint64 {
+	n := uint64(chunkSize / fl.bucketBytes())
+	if n == 0 {
+		n = 1
+	}
+	return n
+}
+
+// Returns the number of chunks that WriteChunk splits the filter into when writing chunks of about
+// chunkSize bytes each.
+func (fl *Filter) NumChunks(chunkSize int) int {
+	bpc := fl.bucketsPerChunk(chunkSize)
+	return int((fl.nBuckets() + bpc - 1) / bpc)
+}
+
+// Writes chunk i of the filter split into chunks of about chunkSize bytes of bucket data each. A
+// ChunkLoader that has read all NumChunks(chunkSize) chunks, in any order, reconstructs the filter.
+//
+// The filter must not be modified between writing the first and last chunk.
+func (fl *Filter) WriteChunk(w io.Writer, chunkSize int, i int) (int64, error) {
+	if fl.hashing == hashCMU {
+		return 0, errCMUSerialize
+	}
+	nChunks := fl.NumChunks(chunkSize)
+	if i < 0 || i >= nChunks {
+		return 0, fmt.Errorf("cuckoo: chunk %d out of range [0, %d)", i, nChunks)
+	}
+	bpc := fl.bucketsPerChunk(chunkSize)
+	start := uint64(i) * bpc
+	end := start + bpc
+	if end > fl.nBuckets() {
+		end = fl.nBuckets()
+	}
+
+	bw := fl.bucketBytes()
+	hdr := fl.encodeHeader()
+	out := make([]byte, 0, len(hdr)+chunkFieldsSize+int(end-start)*bw+4)
+	out = append(out, hdr...)
+	copy(out[0:4], chunkMagic[:])
+	out = binary.LittleEndian.AppendUint32(out, uint32(i))
+	out = binary.LittleEndian.AppendUint32(out, uint32(nChunks))
+	out = binary.LittleEndian.AppendUint64(out, bpc)
+	var word [8]byte
+	for j := start; j < end; j++ {
+		binary.LittleEndian.PutUint64(word[:], fl.loadBits(j))
+		out = append(out, word[:bw]...)
+	}
+	out = binary.LittleEndian.AppendUint32(out, crc32.Checksum(out, crc32c))
+
+	n, err := w.Write(out)
+	return int64(n), err
+}
+
+// Reassembles a filter from the chunks written by Filter.WriteChunk.
+//
+// Nothing is allocated up front for the filter that the first chunk's header describes, since the
+// header hasn't been checked against anything but itself. Instead, chunks are stored in order, with
+// the filter's buckets growing as each is stored, and a chunk that arrives before the ones ahead of
+// it is held until they do. Either way, memory grows only with the data actually read.
+type ChunkLoader struct {
+	// The header of the first chunk read. Every other chunk must match it.
+	header  []byte
+	fl      *Filter
+	bpc     uint64
+	nChunks uint32
+	// Chunks [0, stored) have been read intact and stored in fl.
+	stored uint32
+	// The bucket data of chunks read intact that are waiting on an earlier chunk to be stored.
+	pending map[uint32][]byte
+}
+
+// Returns a ChunkLoader that hasn't read any chunks yet.
+func NewChunkLoader() *ChunkLoader {
+	return &ChunkLoader{}
+}
+
+// Reads one chunk written by Filter.WriteChunk from r. If the chunk is truncated or fails its
+// checksum an error is returned and the chunk must be read again, but chunks already read are
+// kept.
+func (l *ChunkLoader) ReadChunk(r io.Reader) (int64, error) {
+	var hBuf [headerSize + seedSize + chunkFieldsSize]byte
+	hdrBytes, read, err := readHeader(r, hBuf[:])
+	if err != nil {
+		return read, err
+	}
+	if !bytes.Equal(hdrBytes[0:4], chunkMagic[:]) {
+		return read, errCorrupt
+	}
+	h := hBuf[:len(hdrBytes)+chunkFieldsSize]
+	n, err := io.ReadFull(r, h[len(hdrBytes):])
+	read += int64(n)
+	if err != nil {
+		return read, noEOF(err)
+	}
+	fields := h[len(hdrBytes):]
+	chunk := binary.LittleEndian.Uint32(fields[0:])
+	nChunks := binary.LittleEndian.Uint32(fields[4:])
+	bpc := binary.LittleEndian.Uint64(fields[8:])
+
+	fullHeader := append(append([]byte{}, serializeMagic[:]...), hdrBytes[4:]...)
+	hdr, err := decodeHeader(fullHeader)
+	if err != nil {
+		return read, err
+	}
+	if hdr.hashing == hashCMU {
+		return read, errCMUSerialize
+	}
+	if bpc == 0 || uint64(nChunks) != (hdr.nBuckets+bpc-1)/bpc || chunk >= nChunks {
+		return read, errCorrupt
+	}
+	start := uint64(chunk) * bpc
+	end := start + bpc
+	if end > hdr.nBuckets {
+		end = hdr.nBuckets
+	}
+
+	// The buffer grows as the data arrives rather than being sized from bpc up front.
+	bw := uint64((hdr.encoding().size() + 7) / 8)
+	var rest bytes.Buffer
+	n64, err := io.CopyN(&rest, r, int64((end-start)*bw+4))
+	read += n64
+	if err != nil {
+		return read, noEOF(err)
+	}
+	data := rest.Bytes()
+	crc := crc32.Update(crc32.Checksum(h, crc32c), crc32c, data[:len(data)-4])
+	if crc != binary.LittleEndian.Uint32(data[len(data)-4:]) {
+		return read, errChunkChecksum
+	}
+	data = data[:len(data)-4]
+
+	if l.fl == nil {
+		l.header = append([]byte{}, hdrBytes...)
+		l.fl = newFilterWords(hdr.f, hdr.b, int(hdr.nBuckets), hdr.encoding(), nil)
+		hdr.restore(l.fl)
+		l.bpc = bpc
+		l.nChunks = nChunks
+		l.pending = make(map[uint32][]byte)
+	} else if !bytes.Equal(l.header, hdrBytes) || l.bpc != bpc {
+		return read, fmt.Errorf("cuckoo: chunk %d is from a different filter or chunk size", chunk)
+	}
+
+	if chunk > l.stored {
+		l.pending[chunk] = data
+		return read, nil
+	}
+	l.storeChunk(chunk, data)
+	for chunk == l.stored {
+		l.stored++
+		next, ok := l.pending[l.stored]
+		if !ok {
+			break
+		}
+		delete(l.pending, l.stored)
+		chunk = l.stored
+		l.storeChunk(chunk, next)
+	}
+	return read, nil
+}
+
+// Stores the bucket data of chunk i in l.fl, which must already hold every chunk before i.
+func (l *ChunkLoader) storeChunk(i uint32, data []byte) {
+	k := l.fl.bucketEncoding.size()
+	bw := int((k + 7) / 8)
+	start := uint64(i) * l.bpc
+	end := start + uint64(len(data)/bw)
+	l.fl.growWords(packedWords(k, end), packedWords(k, l.fl.nBuckets()))
+	var word [8]byte
+	for j := start; j < end; j++ {
+		off := int(j-start) * bw
+		copy(word[:bw], data[off:off+bw])
+		l.fl.storeBits(j, binary.LittleEndian.Uint64(word[:]))
+	}
+}
+
+// Returns the indexes of the chunks that haven't been read intact yet, which is what a sender needs
+// to resume an interrupted transfer. Before any chunk has been read the total isn't known, so nil
+// is returned.
+func (l *ChunkLoader) Missing() []int {
+	var missing []int
+	for i := l.stored; i < l.nChunks; i++ {
+		if _, ok := l.pending[i]; !ok {
+			missing = append(missing, int(i))
+		}
+	}
+	return missing
+}
+
+// True once every chunk has been read.
+func (l *ChunkLoader) Done() bool {
+	return l.fl != nil && l.stored == l.nChunks
+}
+
+// Returns the reassembled filter. Returns an error if any chunks are still missing.
+func (l *ChunkLoader) Filter() (*Filter, error) {
+	if !l.Done() {
+		return nil, fmt.Errorf("cuckoo: %d chunks still missing",
+			int(l.nChunks-l.stored)-len(l.pending))
+	}
+	return l.fl, nil
+}
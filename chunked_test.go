@@ -0,0 +1,135 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedRoundTrip(t *testing.T) {
+	trand.RandomN(t, 20, func(t *testing.T, r *rand.Rand) {
+		n := r.Int()%5000 + 10
+		fl := New(n, 0.01)
+		if r.Intn(2) == 0 {
+			fl.SetSeed(r.Uint64())
+		}
+		items := make([][]byte, n)
+		for i := range items {
+			var key [8]byte
+			_, _ = r.Read(key[:])
+			items[i] = key[:]
+			fl.Add(items[i])
+		}
+
+		chunkSize := r.Int()%1000 + 1
+		nChunks := fl.NumChunks(chunkSize)
+		chunks := make([][]byte, nChunks)
+		for i := range chunks {
+			var buf bytes.Buffer
+			_, err := fl.WriteChunk(&buf, chunkSize, i)
+			require.NoError(t, err)
+			chunks[i] = buf.Bytes()
+		}
+
+		l := NewChunkLoader()
+		require.Nil(t, l.Missing())
+		for _, i := range r.Perm(nChunks) {
+			// Corrupt some chunks in transit; they have to be sent again.
+			if r.Intn(4) == 0 {
+				bad := append([]byte{}, chunks[i]...)
+				bad[r.Intn(len(bad))] ^= 0x80
+				_, err := l.ReadChunk(bytes.NewReader(bad))
+				require.Error(t, err)
+			} else if r.Intn(4) == 0 {
+				_, err := l.ReadChunk(bytes.NewReader(chunks[i][:r.Intn(len(chunks[i]))]))
+				require.Error(t, err)
+			}
+			read, err := l.ReadChunk(bytes.NewReader(chunks[i]))
+			require.NoError(t, err)
+			require.Equal(t, int64(len(chunks[i])), read)
+		}
+		require.Empty(t, l.Missing())
+		require.True(t, l.Done())
+
+		fl2, err := l.Filter()
+		require.NoError(t, err)
+		require.Equal(t, fl.Count(), fl2.Count())
+		for _, item := range items {
+			require.Equal(t, Maybe, fl2.Contains(item))
+		}
+	})
+}
+
+func TestChunkedResume(t *testing.T) {
+	fl := NewRaw(8, 4, 1000)
+	for i := 0; i < 1000; i++ {
+		fl.Add([]byte{byte(i), byte(i >> 8)})
+	}
+	const chunkSize = 256
+	require.Equal(t, 16, fl.NumChunks(chunkSize))
+
+	l := NewChunkLoader()
+	for i := 0; i < 16; i += 2 {
+		var buf bytes.Buffer
+		_, err := fl.WriteChunk(&buf, chunkSize, i)
+		require.NoError(t, err)
+		_, err = l.ReadChunk(&buf)
+		require.NoError(t, err)
+	}
+	_, err := l.Filter()
+	require.Error(t, err)
+	require.Equal(t, []int{1, 3, 5, 7, 9, 11, 13, 15}, l.Missing())
+
+	for _, i := range l.Missing() {
+		var buf bytes.Buffer
+		_, err := fl.WriteChunk(&buf, chunkSize, i)
+		require.NoError(t, err)
+		_, err = l.ReadChunk(&buf)
+		require.NoError(t, err)
+	}
+	fl2, err := l.Filter()
+	require.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, Maybe, fl2.Contains([]byte{byte(i), byte(i >> 8)}))
+	}
+
+	// Chunks from a different filter are rejected.
+	var buf bytes.Buffer
+	_, err = NewRaw(8, 4, 2048).WriteChunk(&buf, chunkSize, 0)
+	require.NoError(t, err)
+	_, err = l.ReadChunk(&buf)
+	require.Error(t, err)
+}
+
+func TestChunkLoaderHugeHeader(t *testing.T) {
+	// A chunk whose header claims a filter of 2^27 buckets, about the most a 32-bit platform
+	// accepts, in 2^23 chunks of 16, with a valid checksum. Reading it mustn't allocate the whole
+	// filter.
+	hdr := NewRaw(8, 4, 16).encodeHeader()
+	copy(hdr[0:4], chunkMagic[:])
+	binary.LittleEndian.PutUint64(hdr[8:16], 1<<27)
+	const nChunks = 1 << 23
+	chunk := binary.LittleEndian.AppendUint32(hdr, nChunks-1)
+	chunk = binary.LittleEndian.AppendUint32(chunk, nChunks)
+	chunk = binary.LittleEndian.AppendUint64(chunk, 16)
+	chunk = append(chunk, make([]byte, 16*4)...)
+	chunk = binary.LittleEndian.AppendUint32(chunk, crc32.Checksum(chunk, crc32c))
+
+	l := NewChunkLoader()
+	_, err := l.ReadChunk(bytes.NewReader(chunk))
+	require.NoError(t, err)
+	require.False(t, l.Done())
+	require.Empty(t, l.fl.words)
+
+	// The same header claiming it all in one chunk, with the data cut short.
+	binary.LittleEndian.PutUint32(chunk[len(hdr):], 0)
+	binary.LittleEndian.PutUint32(chunk[len(hdr)+4:], 1)
+	binary.LittleEndian.PutUint64(chunk[len(hdr)+8:], 1<<27)
+	_, err = NewChunkLoader().ReadChunk(bytes.NewReader(chunk))
+	require.ErrorIs(t, err, errCorrupt)
+}
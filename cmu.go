@@ -0,0 +1,293 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Interop with the reference C++ implementation from https://github.com/efficient/cuckoofilter.
+//
+// That implementation has no serialization of its own, so interop is in terms of its in-memory
+// state: the table's buckets_ array and the TwoIndependentMultiplyShift hasher's multiply_ and
+// add_, which it picks randomly at construction. Its items are uint64 keys.
+//
+// Both of its tables are supported: the plain SingleTable with DecodeCMU and EncodeCMU, and the
+// semi-sorted PackedTable (CuckooFilter<..., PackedTable>) with DecodeCMUPacked and
+// EncodeCMUPacked.
+
+// The state of the reference implementation's TwoIndependentMultiplyShift hasher. Each 128-bit
+// value is split into its high and low 64 bits.
+type CMUHasher struct {
+	MultiplyHi, MultiplyLo uint64
+	AddHi, AddLo           uint64
+}
+
+// Returns the hash of key, (add_ + multiply_ * key) >> 64 in 128-bit arithmetic.
+func (h CMUHasher) hash(key uint64) uint64 {
+	hi, lo := bits.Mul64(h.MultiplyLo, key)
+	hi += h.MultiplyHi * key
+	_, carry := bits.Add64(lo, h.AddLo, 0)
+	return hi + h.AddHi + carry
+}
+
+// The reference implementation's victim cache, which holds the one item that didn't fit when the
+// table filled.
+type CMUVictim struct {
+	Used  bool
+	Index uint64
+	Tag   uint32
+}
+
+// Returns the number of bytes per bucket in the reference implementation's SingleTable.
+func cmuBytesPerBucket(bitsPerTag int) int {
+	return (bitsPerTag*4 + 7) / 8
+}
+
+// Returns the number of zeroed buckets that the reference implementation's SingleTable allocates
+// after the real ones, so that reading a bucket as 64-bit words never runs off the end.
+func cmuPaddingBuckets(bitsPerTag int) int {
+	bpb := cmuBytesPerBucket(bitsPerTag)
+	return (((bpb+7)/8)*8 - 1) / bpb
+}
+
+// Builds a filter from the state of a CuckooFilter<uint64_t, bitsPerTag> from the reference C++
+// implementation, so that filters built there can be queried here.
+//
+// table is the SingleTable's buckets_ array, of at least numBuckets buckets (any trailing padding
+// buckets are ignored), hasher is its hasher's state, and victim is its victim cache. bitsPerTag
+// must be one of 2, 4, 8, 12, or 16.
+//
+// Items of the resulting filter are the uint64 keys given to the reference implementation, encoded
+// as 8 little-endian bytes. Passing an item of any other length panics.
+func DecodeCMU(
+	table []byte,
+	bitsPerTag int,
+	numBuckets int,
+	hasher CMUHasher,
+	victim CMUVictim,
+) (*Filter, error) {
+	switch bitsPerTag {
+	case 2, 4, 8, 12, 16:
+	default:
+		return nil, fmt.Errorf("cuckoo: unsupported bits per tag %d for CMU filter", bitsPerTag)
+	}
+	if err := checkCMUBuckets(numBuckets); err != nil {
+		return nil, err
+	}
+	bpb := cmuBytesPerBucket(bitsPerTag)
+	if len(table) < numBuckets*bpb {
+		return nil, fmt.Errorf(
+			"cuckoo: CMU table of %d buckets needs at least %d bytes, got %d",
+			numBuckets, numBuckets*bpb, len(table),
+		)
+	}
+
+	fl := newCMUFilter(bitsPerTag, numBuckets, hasher)
+	// Tag j of a bucket occupies bits [j*bitsPerTag, (j+1)*bitsPerTag) of the bucket read as a
+	// little-endian integer, which is exactly directBucketEncoding.
+	enc := newDirectBucketEncoding(bitsPerTag, 4)
+	var word [8]byte
+	for i := 0; i < numBuckets; i++ {
+		copy(word[:], table[i*bpb:(i+1)*bpb])
+		b := enc.decode(binary.LittleEndian.Uint64(word[:]))
+		for j := 0; j < b.l; j++ {
+			if b.entries[j] != 0 {
+				fl.count++
+			}
+		}
+		fl.setBucket(uint64(i), b)
+	}
+	if err := fl.addCMUVictim(victim); err != nil {
+		return nil, err
+	}
+	return fl, nil
+}
+
+func checkCMUBuckets(numBuckets int) error {
+	if numBuckets <= 0 || numBuckets&(numBuckets-1) != 0 || uint64(numBuckets) > 1<<32 {
+		return fmt.Errorf("cuckoo: invalid number of buckets %d for CMU filter", numBuckets)
+	}
+	return nil
+}
+
+func newCMUFilter(bitsPerTag, numBuckets int, hasher CMUHasher) *Filter {
+	fl := newFilter(bitsPerTag, 4, numBuckets)
+	fl.hashing = hashCMU
+	fl.cmu = hasher
+	return fl
+}
+
+// Adds the item held in the reference implementation's victim cache, if any.
+func (fl *Filter) addCMUVictim(victim CMUVictim) error {
+	if !victim.Used {
+		return nil
+	}
+	f := fingerprint(victim.Tag)
+	if f == 0 || uint64(f) >= uint64(1)<<uint(fl.f) || victim.Index >= fl.nBuckets() {
+		return fmt.Errorf("cuckoo: invalid victim for CMU filter")
+	}
+	fl.add(f, victim.Index, fl.otherIdx(f, victim.Index))
+	return nil
+}
+
+// Encodes the filter as a SingleTable buckets_ array for the reference C++ implementation,
+// including its trailing padding buckets. Only filters created by DecodeCMU can be encoded this
+// way. The hasher state and number of buckets are unchanged from DecodeCMU; the victim cache is
+// always empty.
+func (fl *Filter) EncodeCMU() ([]byte, error) {
+	if err := fl.checkEncodeCMU(); err != nil {
+		return nil, err
+	}
+	bpb := cmuBytesPerBucket(fl.f)
+	enc := newDirectBucketEncoding(fl.f, 4)
+	out := make([]byte, (int(fl.nBuckets())+cmuPaddingBuckets(fl.f))*bpb)
+	var word [8]byte
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		binary.LittleEndian.PutUint64(word[:], enc.encode(fl.getBucket(i)))
+		copy(out[int(i)*bpb:], word[:bpb])
+	}
+	return out, nil
+}
+
+func (fl *Filter) checkEncodeCMU() error {
+	if fl.hashing != hashCMU {
+		return fmt.Errorf("cuckoo: only filters from DecodeCMU can be encoded for the CMU " +
+			"reference implementation")
+	}
+	if fl.overflowed {
+		return fmt.Errorf("cuckoo: cannot encode an overflowed filter for the CMU reference " +
+			"implementation")
+	}
+	return nil
+}
+
+// Returns the number of bits per bucket in the reference implementation's PackedTable. Each bucket
+// starts with a 12-bit codeword for the sorted low 4 bits of its 4 tags, which is their rank among
+// all such sorted sequences, the same as rankNibbles. The rest of each tag follows, for tags 0 to 3
+// in the order the codeword lists their low bits. Buckets are packed back to back with no padding,
+// as a little-endian bit stream, which is followed by 7 zero bytes so that reading a bucket as a
+// 64-bit word never runs off the end.
+//
+// This is the same as packedBucketEncoding with 4 entries, except that the codeword comes first
+// rather than last.
+func cmuPackedBucketBits(bitsPerTag int) int {
+	return 12 + 4*(bitsPerTag-4)
+}
+
+// Converts a bucket from packedBucketEncoding to the reference implementation's PackedTable, by
+// moving the codeword from the top to the bottom.
+func cmuPackedFromBits(x uint64, bitsPerTag int) uint64 {
+	high := uint(4 * (bitsPerTag - 4))
+	return x>>high | (x&(1<<high-1))<<12
+}
+
+// The inverse of cmuPackedFromBits.
+func cmuPackedToBits(x uint64, bitsPerTag int) uint64 {
+	high := uint(4 * (bitsPerTag - 4))
+	return x>>12 | (x&0xFFF)<<high
+}
+
+// Like DecodeCMU, but for a CuckooFilter<uint64_t, bitsPerTag, PackedTable>, whose buckets_ array
+// is semi-sorted to save a bit per tag. See cmuPackedBucketBits for the layout.
+//
+// table must hold at least numBuckets buckets; the trailing padding is ignored. bitsPerTag must be
+// one of 5, 6, 7, 8, 9, or 13, the sizes PackedTable supports that fit in a 16-bit fingerprint.
+func DecodeCMUPacked(
+	table []byte,
+	bitsPerTag int,
+	numBuckets int,
+	hasher CMUHasher,
+	victim CMUVictim,
+) (*Filter, error) {
+	switch bitsPerTag {
+	case 5, 6, 7, 8, 9, 13:
+	default:
+		return nil, fmt.Errorf("cuckoo: unsupported bits per tag %d for CMU packed filter",
+			bitsPerTag)
+	}
+	if err := checkCMUBuckets(numBuckets); err != nil {
+		return nil, err
+	}
+	k := cmuPackedBucketBits(bitsPerTag)
+	if need := (numBuckets*k + 7) / 8; len(table) < need {
+		return nil, fmt.Errorf(
+			"cuckoo: CMU packed table of %d buckets needs at least %d bytes, got %d",
+			numBuckets, need, len(table),
+		)
+	}
+
+	fl := newCMUFilter(bitsPerTag, numBuckets, hasher)
+	enc := fl.bucketEncoding.packed
+	var word [8]byte
+	for i := 0; i < numBuckets; i++ {
+		off := i * k
+		word = [8]byte{}
+		copy(word[:], table[off/8:])
+		x := cmuPackedToBits(
+			binary.LittleEndian.Uint64(word[:])>>uint(off%8)&(1<<uint(k)-1), bitsPerTag)
+		if !enc.valid(x) {
+			return nil, fmt.Errorf("cuckoo: invalid codeword in bucket %d of CMU packed table", i)
+		}
+		b := enc.decode(x)
+		for j := 0; j < b.l; j++ {
+			if b.entries[j] != 0 {
+				fl.count++
+			}
+		}
+		fl.storeBits(uint64(i), x)
+	}
+	if err := fl.addCMUVictim(victim); err != nil {
+		return nil, err
+	}
+	return fl, nil
+}
+
+// Like EncodeCMU, but encodes the filter as a PackedTable buckets_ array, including its 7 trailing
+// padding bytes. Only filters created by DecodeCMU or DecodeCMUPacked with a bitsPerTag that
+// DecodeCMUPacked accepts can be encoded this way.
+func (fl *Filter) EncodeCMUPacked() ([]byte, error) {
+	if err := fl.checkEncodeCMU(); err != nil {
+		return nil, err
+	}
+	switch fl.f {
+	case 5, 6, 7, 8, 9, 13:
+	default:
+		return nil, fmt.Errorf("cuckoo: %d-bit tags can't be encoded in a CMU packed table", fl.f)
+	}
+	k := cmuPackedBucketBits(fl.f)
+	enc := packedEncoding(fl.f, 4)
+	out := make([]byte, (int(fl.nBuckets())*k+7)/8+7)
+	var word [8]byte
+	for i := 0; i < int(fl.nBuckets()); i++ {
+		off := i * k
+		x := cmuPackedFromBits(enc.encode(fl.getBucket(uint64(i))), fl.f) << uint(off%8)
+		binary.LittleEndian.PutUint64(word[:], x)
+		// Buckets share bytes at their edges, so OR rather than copy.
+		for j := 0; j < (off%8+k+7)/8; j++ {
+			out[off/8+j] |= word[j]
+		}
+	}
+	return out, nil
+}
+
+func (fl *Filter) cmuHashItem(x []byte) uint64 {
+	if len(x) != 8 {
+		panic(fmt.Sprintf("items of a CMU filter must be 8 bytes, got %d", len(x)))
+	}
+	return fl.cmu.hash(binary.LittleEndian.Uint64(x))
+}
+
+func (fl *Filter) cmuHashToIdxs(h uint64) (fingerprint, uint64, uint64) {
+	i1 := (h >> 32) & (fl.nBuckets() - 1)
+	f := fingerprint(h & ((uint64(1) << uint(fl.f)) - 1))
+	if f == 0 {
+		f = 1
+	}
+	return f, i1, fl.cmuOtherIdx(f, i1)
+}
+
+func (fl *Filter) cmuOtherIdx(f fingerprint, i1 uint64) uint64 {
+	// 0x5bd1e995 is the MurmurHash2 constant, multiplied in 32 bits as in the original.
+	return uint64(uint32(i1)^(uint32(f)*0x5bd1e995)) & (fl.nBuckets() - 1)
+}
@@ -0,0 +1,289 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCMUHash(t *testing.T) {
+	trand.RandomN(t, 100, func(t *testing.T, r *rand.Rand) {
+		h := CMUHasher{r.Uint64(), r.Uint64(), r.Uint64(), r.Uint64()}
+		key := r.Uint64()
+
+		u128 := func(hi, lo uint64) *big.Int {
+			v := new(big.Int).Lsh(new(big.Int).SetUint64(hi), 64)
+			return v.Or(v, new(big.Int).SetUint64(lo))
+		}
+		mod := new(big.Int).Lsh(big.NewInt(1), 128)
+		expected := new(big.Int).Mul(u128(h.MultiplyHi, h.MultiplyLo), new(big.Int).SetUint64(key))
+		expected.Add(expected, u128(h.AddHi, h.AddLo))
+		expected.Mod(expected, mod)
+		expected.Rsh(expected, 64)
+
+		require.Equal(t, expected.Uint64(), h.hash(key))
+	})
+}
+
+func TestDecodeCMU(t *testing.T) {
+	for _, bitsPerTag := range []int{2, 4, 8, 12, 16} {
+		hasher := CMUHasher{0x1234, 0x9e3779b97f4a7c15, 0x5678, 0xbf58476d1ce4e5b9}
+		numBuckets := 64
+		bpb := cmuBytesPerBucket(bitsPerTag)
+		table := make([]byte, (numBuckets+cmuPaddingBuckets(bitsPerTag))*bpb)
+
+		// Place keys the way the reference implementation would: tag in the first free slot of the
+		// primary bucket, packed little-endian.
+		var keys [][]byte
+		for n := uint64(0); n < 10; n++ {
+			k := n * 0xd6e8feb86659fd93
+			h := hasher.hash(k)
+			tag := h & ((1 << uint(bitsPerTag)) - 1)
+			if tag == 0 {
+				tag = 1
+			}
+			i := int(uint32(h>>32)) & (numBuckets - 1)
+			var word [8]byte
+			copy(word[:], table[i*bpb:(i+1)*bpb])
+			bucketBits := binary.LittleEndian.Uint64(word[:])
+			placed := false
+			for j := 0; j < 4 && !placed; j++ {
+				if (bucketBits>>uint(j*bitsPerTag))&((1<<uint(bitsPerTag))-1) == 0 {
+					bucketBits |= tag << uint(j*bitsPerTag)
+					placed = true
+				}
+			}
+			require.True(t, placed)
+			binary.LittleEndian.PutUint64(word[:], bucketBits)
+			copy(table[i*bpb:(i+1)*bpb], word[:bpb])
+			keys = append(keys, binary.LittleEndian.AppendUint64(nil, k))
+		}
+
+		fl, err := DecodeCMU(table, bitsPerTag, numBuckets, hasher, CMUVictim{})
+		require.NoError(t, err)
+		for _, key := range keys {
+			require.Equal(t, Maybe, fl.Contains(key))
+		}
+
+		encoded, err := fl.EncodeCMU()
+		require.NoError(t, err)
+		fl2, err := DecodeCMU(encoded, bitsPerTag, numBuckets, hasher, CMUVictim{})
+		require.NoError(t, err)
+		for i := uint64(0); i < fl.nBuckets(); i++ {
+			b1, b2 := fl.getBucket(i), fl2.getBucket(i)
+			b1.sort()
+			b2.sort()
+			require.Equal(t, b1, b2)
+		}
+
+		// Keys added here can be found by the reference implementation's AltIndex.
+		fl.Add(binary.LittleEndian.AppendUint64(nil, 1000))
+		require.Equal(t, Maybe, fl.Contains(binary.LittleEndian.AppendUint64(nil, 1000)))
+	}
+}
+
+func TestDecodeCMUVictim(t *testing.T) {
+	hasher := CMUHasher{0, 0x9e3779b97f4a7c15, 0, 0xbf58476d1ce4e5b9}
+	h := hasher.hash(7)
+	victim := CMUVictim{Used: true, Index: (h >> 32) & 15, Tag: uint32(h & 0xFF)}
+	if victim.Tag == 0 {
+		victim.Tag = 1
+	}
+	fl, err := DecodeCMU(make([]byte, 16*4), 8, 16, hasher, victim)
+	require.NoError(t, err)
+	require.Equal(t, 1, fl.Count())
+	require.Equal(t, Maybe, fl.Contains(binary.LittleEndian.AppendUint64(nil, 7)))
+
+	_, err = fl.MarshalBinary()
+	require.Error(t, err)
+	_, err = New(10, 0.01).EncodeCMU()
+	require.Error(t, err)
+	_, err = DecodeCMU(make([]byte, 16*4), 7, 16, hasher, CMUVictim{})
+	require.Error(t, err)
+}
+
+// The reference implementation's PermEncoding and PackedTable, transcribed as directly as possible
+// so that they check the layout independently of packedBucketEncoding.
+type cmuRefPackedTable struct {
+	bitsPerTag int
+	encTable   map[uint16]uint16
+	decTable   []uint16
+	buckets    []byte
+}
+
+func newCMURefPackedTable(bitsPerTag, numBuckets int) *cmuRefPackedTable {
+	t := &cmuRefPackedTable{bitsPerTag: bitsPerTag, encTable: make(map[uint16]uint16)}
+	var dst [4]uint8
+	var genTables func(base, k int)
+	genTables = func(base, k int) {
+		for i := base; i < 16; i++ {
+			dst[k] = uint8(i)
+			if k+1 < 4 {
+				genTables(i, k+1)
+			} else {
+				t.encTable[cmuRefPack(dst)] = uint16(len(t.decTable))
+				t.decTable = append(t.decTable, cmuRefPack(dst))
+			}
+		}
+	}
+	genTables(0, 0)
+	t.buckets = make([]byte, (numBuckets*cmuPackedBucketBits(bitsPerTag)+7)/8+7)
+	return t
+}
+
+func cmuRefPack(in [4]uint8) uint16 {
+	return uint16(in[0]) | uint16(in[2])<<4 | uint16(in[1])<<8 | uint16(in[3])<<12
+}
+
+func cmuRefUnpack(in uint16) [4]uint8 {
+	return [4]uint8{uint8(in & 0xf), uint8(in >> 8 & 0xf), uint8(in >> 4 & 0xf), uint8(in >> 12 & 0xf)}
+}
+
+func (t *cmuRefPackedTable) readBucket(i int) [4]uint32 {
+	k := cmuPackedBucketBits(t.bitsPerTag)
+	var word [8]byte
+	copy(word[:], t.buckets[i*k/8:])
+	bucketBits := binary.LittleEndian.Uint64(word[:]) >> uint(i*k%8)
+	lowBits := cmuRefUnpack(t.decTable[bucketBits&0x0fff])
+	dirBits := uint(t.bitsPerTag - 4)
+	dirMask := uint64(1<<dirBits-1) << 4
+	var tags [4]uint32
+	for j := range tags {
+		tags[j] = uint32(lowBits[j]) | uint32(bucketBits>>(8+uint(j)*dirBits)&dirMask)
+	}
+	return tags
+}
+
+func (t *cmuRefPackedTable) writeBucket(i int, tags [4]uint32) {
+	sortPair := func(a, b int) {
+		if tags[a]&0x0f > tags[b]&0x0f {
+			tags[a], tags[b] = tags[b], tags[a]
+		}
+	}
+	sortPair(0, 2)
+	sortPair(1, 3)
+	sortPair(0, 1)
+	sortPair(2, 3)
+	sortPair(1, 2)
+
+	var lowBits [4]uint8
+	for j := range tags {
+		lowBits[j] = uint8(tags[j] & 0x0f)
+	}
+	bucketBits := uint64(t.encTable[cmuRefPack(lowBits)])
+	dirBits := uint(t.bitsPerTag - 4)
+	for j := range tags {
+		bucketBits |= uint64(tags[j]&0xfffffff0) << (8 + uint(j)*dirBits)
+	}
+
+	k := cmuPackedBucketBits(t.bitsPerTag)
+	var word [8]byte
+	copy(word[:], t.buckets[i*k/8:])
+	x := binary.LittleEndian.Uint64(word[:])
+	mask := uint64(1<<uint(k)-1) << uint(i*k%8)
+	x = x&^mask | bucketBits<<uint(i*k%8)
+	binary.LittleEndian.PutUint64(word[:], x)
+	copy(t.buckets[i*k/8:], word[:])
+}
+
+func TestDecodeCMUPacked(t *testing.T) {
+	for _, bitsPerTag := range []int{5, 6, 7, 8, 9, 13} {
+		hasher := CMUHasher{0x1234, 0x9e3779b97f4a7c15, 0x5678, 0xbf58476d1ce4e5b9}
+		numBuckets := 64
+		ref := newCMURefPackedTable(bitsPerTag, numBuckets)
+
+		// Place keys the way the reference implementation would: tag in the first free slot of the
+		// primary bucket, then the bucket rewritten sorted.
+		var keys [][]byte
+		for n := uint64(0); n < 100; n++ {
+			k := n * 0xd6e8feb86659fd93
+			h := hasher.hash(k)
+			tag := uint32(h & ((1 << uint(bitsPerTag)) - 1))
+			if tag == 0 {
+				tag = 1
+			}
+			i := int(uint32(h>>32)) & (numBuckets - 1)
+			tags := ref.readBucket(i)
+			placed := false
+			for j := range tags {
+				if tags[j] == 0 {
+					tags[j] = tag
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				continue
+			}
+			ref.writeBucket(i, tags)
+			keys = append(keys, binary.LittleEndian.AppendUint64(nil, k))
+		}
+		require.Greater(t, len(keys), 50)
+
+		fl, err := DecodeCMUPacked(ref.buckets, bitsPerTag, numBuckets, hasher, CMUVictim{})
+		require.NoError(t, err)
+		require.Equal(t, len(keys), fl.Count())
+		for _, key := range keys {
+			require.Equal(t, Maybe, fl.Contains(key))
+		}
+
+		// The reference implementation reads back the same tags from EncodeCMUPacked, though ties in
+		// the low bits may be ordered differently.
+		encoded, err := fl.EncodeCMUPacked()
+		require.NoError(t, err)
+		require.Len(t, encoded, len(ref.buckets))
+		got := &cmuRefPackedTable{bitsPerTag: bitsPerTag, decTable: ref.decTable, buckets: encoded}
+		for i := 0; i < numBuckets; i++ {
+			b1, b2 := ref.readBucket(i), got.readBucket(i)
+			sort.Slice(b1[:], func(x, y int) bool { return b1[x] < b1[y] })
+			sort.Slice(b2[:], func(x, y int) bool { return b2[x] < b2[y] })
+			require.Equal(t, b1, b2)
+		}
+		fl2, err := DecodeCMUPacked(encoded, bitsPerTag, numBuckets, hasher, CMUVictim{})
+		require.NoError(t, err)
+		require.True(t, fl.Equal(fl2))
+
+		// A filter decoded from a SingleTable can move to a PackedTable too.
+		single, err := fl.EncodeCMU()
+		require.NoError(t, err)
+		fromSingle, err := DecodeCMU(single, bitsPerTag, numBuckets, hasher, CMUVictim{})
+		if bitsPerTag == 8 {
+			require.NoError(t, err)
+			packed, err := fromSingle.EncodeCMUPacked()
+			require.NoError(t, err)
+			require.Equal(t, encoded, packed)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestEncodeCMUPackedLayout(t *testing.T) {
+	// One bucket of 8-bit tags holding 0x21 and 0x13. Sorted by their low 4 bits the tags are 0, 0,
+	// 0x21, 0x13, whose low bits (0, 0, 1, 3) are the 18th sorted sequence counting from 0. Their
+	// high 4 bits follow at bits 12, 16, 20, and 24.
+	hasher := CMUHasher{0, 1, 0, 0}
+	fl, err := DecodeCMU([]byte{0x21, 0x13, 0, 0, 0, 0, 0, 0}, 8, 1, hasher, CMUVictim{})
+	require.NoError(t, err)
+	encoded, err := fl.EncodeCMUPacked()
+	require.NoError(t, err)
+	require.Equal(t, "1200200100000000000000", hex.EncodeToString(encoded))
+
+	ref := newCMURefPackedTable(8, 1)
+	ref.writeBucket(0, [4]uint32{0x21, 0x13, 0, 0})
+	require.Equal(t, ref.buckets, encoded)
+
+	// Codewords past the last sorted sequence don't describe any bucket.
+	_, err = DecodeCMUPacked([]byte{0xFF, 0x0F, 0, 0}, 8, 1, hasher, CMUVictim{})
+	require.Error(t, err)
+	_, err = DecodeCMUPacked(encoded, 4, 1, hasher, CMUVictim{})
+	require.Error(t, err)
+	_, err = DecodeCMUPacked(encoded[:3], 8, 1, hasher, CMUVictim{})
+	require.Error(t, err)
+}
@@ -0,0 +1,405 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// The number of consecutive buckets covered by one lock stripe. Buckets are bit-packed, so this is
+// 64 to guarantee that no two stripes ever share a word of the backing array. Aligned storage packs
+// perWord buckets to a word, so its stripes cover stripeBuckets*perWord.
+const stripeBuckets = 64
+
+// A Filter that can be used from multiple goroutines at once.
+//
+// The buckets are partitioned into lock stripes, so that operations on items whose candidate
+// buckets are in different stripes proceed in parallel. Adds that have to kick fingerprints around
+// the table to make room, which is rare until the filter is nearly full, briefly take exclusive
+// access to the whole filter.
+type ConcurrentFilter struct {
+	// Held for reading by operations that only touch an item's two candidate buckets, and for
+	// writing by Adds that have to kick.
+	kickMu  sync.RWMutex
+	stripes []paddedRWMutex
+	// The number of consecutive buckets covered by each stripe.
+	stripeSize uint64
+	fl         *Filter
+	count      atomic.Int64
+
+	// Held while bringing fl.count up to date and calling the functions registered with OnLoad, so
+	// that they're called one at a time.
+	loadMu sync.Mutex
+
+	// Held for the duration of Snapshot, so that only one runs at a time.
+	snapMu sync.Mutex
+	// The snapshot being taken, if any. Only changed while holding kickMu for writing.
+	snap *cowSnapshot
+}
+
+type paddedRWMutex struct {
+	sync.RWMutex
+	// Keep each stripe's lock on its own cache line so uncontended stripes don't slow each other
+	// down.
+	_ [64 - 24]byte
+}
+
+// Returns a ConcurrentFilter backed by fl, which it takes ownership of: fl must not be used
+// directly afterwards. stripes is the number of lock stripes to use; more stripes allow more
+// parallelism at the cost of a little memory. fl must not have a log set with SetLog.
+//
+// Functions registered on fl with OnLoad and OnOverflow are called as they would be for fl itself,
+// with the count as of the operation that crossed the threshold or overflowed the filter. They're
+// called while the filter is locked, so they must not use it.
+func NewConcurrent(fl *Filter, stripes int) *ConcurrentFilter {
+	if fl.log != nil {
+		panic("cannot make a ConcurrentFilter from a filter with a log")
+	}
+	stripeSize := uint64(stripeBuckets)
+	if fl.aligned != nil {
+		stripeSize *= fl.perWord
+	}
+	maxStripes := int((fl.nBuckets() + stripeSize - 1) / stripeSize)
+	if stripes > maxStripes {
+		stripes = maxStripes
+	}
+	if stripes < 1 {
+		stripes = 1
+	}
+	c := &ConcurrentFilter{
+		stripes:    make([]paddedRWMutex, stripes),
+		stripeSize: stripeSize,
+		fl:         fl,
+	}
+	c.count.Store(int64(fl.count))
+	return c
+}
+
+// Adds n to the count, and calls the functions registered with OnLoad on the wrapped filter for any
+// thresholds that takes it past. The caller must hold kickMu.
+func (c *ConcurrentFilter) addCount(n int64) {
+	c.count.Add(n)
+	if c.fl.thresholds == nil {
+		return
+	}
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+	c.fl.count = int(c.count.Load())
+	c.fl.checkLoad()
+}
+
+// Places f in bucket i1 or i2 like Filter.place, kicking if needed, with the wrapped filter's count
+// brought up to date first so that OnOverflow sees it. The caller must hold kickMu for writing.
+func (c *ConcurrentFilter) place(f fingerprint, i1, i2 uint64) {
+	c.fl.count = int(c.count.Load())
+	c.fl.place(f, i1, i2)
+}
+
+func (c *ConcurrentFilter) stripe(i uint64) int {
+	return int((i / c.stripeSize) % uint64(len(c.stripes)))
+}
+
+// Locks the stripes covering buckets i1 and i2, always in the same order to avoid deadlock.
+// Returns the function that unlocks them.
+func (c *ConcurrentFilter) lock(i1, i2 uint64) func() {
+	s1, s2 := c.stripe(i1), c.stripe(i2)
+	if s1 > s2 {
+		s1, s2 = s2, s1
+	}
+	c.stripes[s1].Lock()
+	if s1 == s2 {
+		return c.stripes[s1].Unlock
+	}
+	c.stripes[s2].Lock()
+	return func() {
+		c.stripes[s2].Unlock()
+		c.stripes[s1].Unlock()
+	}
+}
+
+// Like lock, but for reading.
+func (c *ConcurrentFilter) rlock(i1, i2 uint64) func() {
+	s1, s2 := c.stripe(i1), c.stripe(i2)
+	if s1 > s2 {
+		s1, s2 = s2, s1
+	}
+	c.stripes[s1].RLock()
+	if s1 == s2 {
+		return c.stripes[s1].RUnlock
+	}
+	c.stripes[s2].RLock()
+	return func() {
+		c.stripes[s2].RUnlock()
+		c.stripes[s1].RUnlock()
+	}
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (c *ConcurrentFilter) Add(x []byte) {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	c.addCount(1)
+	if c.fl.overflowed {
+		c.kickMu.RUnlock()
+		return
+	}
+	unlock := c.lock(i1, i2)
+	ok := c.fl.placeNoKick(f, i1, i2)
+	unlock()
+	c.kickMu.RUnlock()
+	if ok {
+		return
+	}
+
+	c.kickMu.Lock()
+	defer c.kickMu.Unlock()
+	if !c.fl.overflowed {
+		c.place(f, i1, i2)
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns false and
+// leaves the filter as it was, rather than overflowing it.
+func (c *ConcurrentFilter) TryAdd(x []byte) bool {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	if c.fl.overflowed {
+		c.kickMu.RUnlock()
+		return false
+	}
+	unlock := c.lock(i1, i2)
+	ok := c.fl.placeNoKick(f, i1, i2)
+	if ok {
+		c.addCount(1)
+	}
+	unlock()
+	c.kickMu.RUnlock()
+	if ok {
+		return true
+	}
+
+	c.kickMu.Lock()
+	defer c.kickMu.Unlock()
+	if c.fl.overflowed || !c.fl.kick(f, i1, i2, true) {
+		return false
+	}
+	c.addCount(1)
+	return true
+}
+
+// Adds x to the filter, returning what Contains(x) would have returned just before, as one atomic
+// operation: of several goroutines calling TestAndAdd with the same x at once, exactly one sees No
+// (unless x was already present or is a false positive).
+func (c *ConcurrentFilter) TestAndAdd(x []byte) Result {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	if c.fl.overflowed {
+		c.addCount(1)
+		c.kickMu.RUnlock()
+		return Maybe
+	}
+	unlock := c.lock(i1, i2)
+	r := c.fl.contains(f, i1, i2)
+	ok := c.fl.placeNoKick(f, i1, i2)
+	if ok {
+		c.addCount(1)
+	}
+	unlock()
+	c.kickMu.RUnlock()
+	if ok {
+		return r
+	}
+
+	// Another goroutine may have added x between dropping the stripe locks and getting exclusive
+	// access, so test again.
+	c.kickMu.Lock()
+	defer c.kickMu.Unlock()
+	c.addCount(1)
+	r = c.fl.contains(f, i1, i2)
+	if !c.fl.overflowed {
+		c.place(f, i1, i2)
+	}
+	return r
+}
+
+// Adds x to the filter only if Contains(x) would return No, and returns true if it did so, as one
+// atomic operation: of several goroutines calling AddIfNotContains with the same x at once, at most
+// one adds it.
+func (c *ConcurrentFilter) AddIfNotContains(x []byte) bool {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	if c.fl.overflowed {
+		c.kickMu.RUnlock()
+		return false
+	}
+	unlock := c.lock(i1, i2)
+	if c.fl.lookup(f, i1, i2) {
+		unlock()
+		c.kickMu.RUnlock()
+		return false
+	}
+	ok := c.fl.placeNoKick(f, i1, i2)
+	if ok {
+		c.addCount(1)
+	}
+	unlock()
+	c.kickMu.RUnlock()
+	if ok {
+		return true
+	}
+
+	// Another goroutine may have added x between dropping the stripe locks and getting exclusive
+	// access, so test again.
+	c.kickMu.Lock()
+	defer c.kickMu.Unlock()
+	if c.fl.contains(f, i1, i2) == Maybe {
+		return false
+	}
+	c.addCount(1)
+	c.place(f, i1, i2)
+	return true
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (c *ConcurrentFilter) Delete(x []byte) {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	defer c.kickMu.RUnlock()
+	c.addCount(-1)
+	if c.fl.overflowed {
+		return
+	}
+	unlock := c.lock(i1, i2)
+	ok := c.fl.remove(f, i1, i2)
+	unlock()
+	if !ok {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking. The test and the delete are one atomic operation: of several goroutines
+// calling TryDelete with the same x at once, only as many succeed as there were copies of x.
+func (c *ConcurrentFilter) TryDelete(x []byte) bool {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	defer c.kickMu.RUnlock()
+	if c.fl.overflowed {
+		c.addCount(-1)
+		return true
+	}
+	unlock := c.lock(i1, i2)
+	ok := c.fl.remove(f, i1, i2)
+	unlock()
+	if ok {
+		c.addCount(-1)
+	}
+	return ok
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (c *ConcurrentFilter) Contains(x []byte) Result {
+	f, i1, i2 := c.fl.itemToIdxs(x)
+
+	c.kickMu.RLock()
+	defer c.kickMu.RUnlock()
+	unlock := c.rlock(i1, i2)
+	defer unlock()
+	return c.fl.contains(f, i1, i2)
+}
+
+// Returns false if x is definitely not in the filter, and true if x might be in the filter. The
+// same as Contains(x).IsMaybe().
+func (c *ConcurrentFilter) MayContain(x []byte) bool {
+	return c.Contains(x) == Maybe
+}
+
+// True if the filter has overflowed, and now blindly returns Maybe for every query.
+func (c *ConcurrentFilter) Overflowed() bool {
+	c.kickMu.RLock()
+	defer c.kickMu.RUnlock()
+	return c.fl.overflowed
+}
+
+// Returns the number of items in the filter.
+func (c *ConcurrentFilter) Count() int {
+	return int(c.count.Load())
+}
+
+// Returns the number of bytes used by the filter.
+func (c *ConcurrentFilter) SizeBytes() uint64 {
+	return c.fl.SizeBytes()
+}
+
+// A snapshot being copied out of a ConcurrentFilter.
+type cowSnapshot struct {
+	fl *Filter
+	// preserved[block] is true once the contents of the block of stripeSize buckets as of the start
+	// of the snapshot have been copied into fl. Only accessed while holding the block's stripe lock,
+	// or kickMu for writing.
+	preserved []bool
+}
+
+// Returns a consistent copy of the filter as of the moment Snapshot was called, for example to
+// persist with WriteTo, without stopping writers for the time it takes to copy the whole table.
+//
+// The copy is made copy-on-write in blocks of buckets: Snapshot copies blocks in the background,
+// and a writer about to modify a block that hasn't been copied yet copies it first. Writers are
+// only excluded for two brief moments, at the start and end of the snapshot.
+func (c *ConcurrentFilter) Snapshot() *Filter {
+	c.snapMu.Lock()
+	defer c.snapMu.Unlock()
+
+	nBlocks := int((c.fl.nBuckets() + c.stripeSize - 1) / c.stripeSize)
+	s := &cowSnapshot{
+		fl:        c.fl.newLike(),
+		preserved: make([]bool, nBlocks),
+	}
+	s.fl.hashingFrom(c.fl)
+
+	c.kickMu.Lock()
+	s.fl.count = int(c.count.Load())
+	s.fl.overflowed = c.fl.overflowed
+	c.snap = s
+	c.fl.onWrite = func(i uint64) { c.preserve(i / c.stripeSize) }
+	c.kickMu.Unlock()
+
+	for block := 0; block < nBlocks; block++ {
+		c.kickMu.RLock()
+		stripe := &c.stripes[block%len(c.stripes)]
+		stripe.Lock()
+		c.preserve(uint64(block))
+		stripe.Unlock()
+		c.kickMu.RUnlock()
+	}
+
+	c.kickMu.Lock()
+	c.fl.onWrite = nil
+	c.snap = nil
+	c.kickMu.Unlock()
+	return s.fl
+}
+
+// Copies block into the snapshot being taken, if it hasn't been already. The caller must hold the
+// block's stripe lock or kickMu for writing.
+func (c *ConcurrentFilter) preserve(block uint64) {
+	s := c.snap
+	if s.preserved[block] {
+		return
+	}
+	end := (block + 1) * c.stripeSize
+	if end > c.fl.nBuckets() {
+		end = c.fl.nBuckets()
+	}
+	for i := block * c.stripeSize; i < end; i++ {
+		s.fl.storeBits(i, c.fl.loadBits(i))
+	}
+	s.preserved[block] = true
+}
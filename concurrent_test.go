@@ -0,0 +1,352 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		perRoutine = 20000
+	)
+	c := NewConcurrent(New(goroutines*perRoutine, 0.01), 16)
+
+	key := func(g, i int) []byte {
+		var b [8]byte
+		binary.LittleEndian.PutUint32(b[:4], uint32(g))
+		binary.LittleEndian.PutUint32(b[4:], uint32(i))
+		return b[:]
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perRoutine; i++ {
+				c.Add(key(g, i))
+				// Read back something written by this goroutine and something that's likely being
+				// written by another one.
+				if c.Contains(key(g, i)) != Maybe {
+					panic("missing item")
+				}
+				_ = c.Contains(key((g+1)%goroutines, i))
+			}
+			for i := 0; i < perRoutine; i += 2 {
+				c.Delete(key(g, i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.False(t, c.Overflowed())
+	require.Equal(t, goroutines*perRoutine/2, c.Count())
+	for g := 0; g < goroutines; g++ {
+		for i := 1; i < perRoutine; i += 2 {
+			require.Equal(t, Maybe, c.Contains(key(g, i)))
+		}
+	}
+	stored, err := c.fl.checkBuckets()
+	require.NoError(t, err)
+	require.Equal(t, c.Count(), stored)
+}
+
+func TestConcurrentSnapshot(t *testing.T) {
+	const n = 100000
+	c := NewConcurrent(New(2*n, 0.01), 16)
+	key := func(i int) []byte {
+		return binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+	for i := 0; i < n; i++ {
+		c.Add(key(i))
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := n + g; i < 2*n; i += 4 {
+				c.Add(key(i))
+			}
+		}()
+	}
+	snap := c.Snapshot()
+	wg.Wait()
+
+	require.NoError(t, snap.CheckInvariants())
+	require.True(t, snap.Count() >= n && snap.Count() <= 2*n)
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, snap.Contains(key(i)))
+	}
+	// Every item counted by the snapshot is in it.
+	found := 0
+	for i := n; i < 2*n; i++ {
+		if snap.Contains(key(i)) == Maybe {
+			found++
+		}
+	}
+	require.True(t, found >= snap.Count()-n)
+
+	// The snapshot is independent of the live filter.
+	before, err := snap.MarshalBinary()
+	require.NoError(t, err)
+	for i := 2 * n; i < 2*n+1000; i++ {
+		c.Add(key(i))
+	}
+	after, err := snap.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+}
+
+func TestConcurrentTestAndAdd(t *testing.T) {
+	const (
+		goroutines = 4
+		n          = 20000
+	)
+	// Every copy of a key lands in the same two buckets, so leave plenty of room to keep from
+	// overflowing.
+	filters := map[string]interface {
+		TestAndAdd([]byte) Result
+		Overflowed() bool
+	}{
+		"Concurrent": NewConcurrent(New(4*goroutines*n, 0.0001), 16),
+		"LockFree":   NewLockFree(4*goroutines*n, 0.0001),
+		"Sharded":    NewSharded(4*goroutines*n, 0.0001, 16),
+	}
+	for name, fl := range filters {
+		t.Run(name, func(t *testing.T) {
+			// Every goroutine adds the same keys, and each key should be new to exactly one of them
+			// (give or take false positives).
+			var wg sync.WaitGroup
+			var firsts [goroutines]int
+			for g := 0; g < goroutines; g++ {
+				g := g
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < n; i++ {
+						if fl.TestAndAdd(binary.LittleEndian.AppendUint64(nil, uint64(i))) == No {
+							firsts[g]++
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			require.False(t, fl.Overflowed())
+			total := 0
+			for _, c := range firsts {
+				total += c
+			}
+			require.True(t, total <= n)
+			require.True(t, total > n*99/100, "%d", total)
+		})
+	}
+}
+
+func TestConcurrentTryDelete(t *testing.T) {
+	filters := map[string]interface {
+		Add([]byte)
+		TryDelete([]byte) bool
+		Count() int
+	}{
+		"Concurrent": NewConcurrent(New(100, 0.0001), 4),
+		"LockFree":   NewLockFree(100, 0.0001),
+		"Epoch":      NewEpoch(100, 0.0001),
+		"Sharded":    NewSharded(100, 0.0001, 4),
+	}
+	for name, fl := range filters {
+		t.Run(name, func(t *testing.T) {
+			require.False(t, fl.TryDelete([]byte("a")))
+			fl.Add([]byte("a"))
+			require.True(t, fl.TryDelete([]byte("a")))
+			require.False(t, fl.TryDelete([]byte("a")))
+			require.Equal(t, 0, fl.Count())
+		})
+	}
+}
+
+func TestConcurrentTryAdd(t *testing.T) {
+	filters := map[string]interface {
+		TryAdd([]byte) bool
+		Contains([]byte) Result
+		Overflowed() bool
+		Count() int
+	}{
+		"Concurrent": NewConcurrent(NewRaw(8, 2, 16), 4),
+		"LockFree":   NewLockFreeRaw(8, 2, 16),
+		"Sharded":    NewSharded(50, 0.01, 2),
+	}
+	for name, fl := range filters {
+		t.Run(name, func(t *testing.T) {
+			var added [][]byte
+			for i := 0; ; i++ {
+				x := []byte(fmt.Sprintf("item-%d", i))
+				if !fl.TryAdd(x) {
+					break
+				}
+				added = append(added, x)
+			}
+			require.False(t, fl.Overflowed())
+			require.Equal(t, len(added), fl.Count())
+			for _, x := range added {
+				require.Equal(t, Maybe, fl.Contains(x))
+			}
+		})
+	}
+}
+
+func TestConcurrentAddIfNotContains(t *testing.T) {
+	const (
+		goroutines = 8
+		n          = 20000
+	)
+	filters := map[string]interface {
+		AddIfNotContains([]byte) bool
+		Count() int
+	}{
+		"Concurrent": NewConcurrent(New(n, 0.0001), 16),
+		"LockFree":   NewLockFree(n, 0.0001),
+		"Sharded":    NewSharded(n, 0.0001, 16),
+	}
+	for name, fl := range filters {
+		t.Run(name, func(t *testing.T) {
+			// Every goroutine offers the same keys, and each key should be added by exactly one of
+			// them (give or take false positives).
+			var wg sync.WaitGroup
+			var added atomic.Int64
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < n; i++ {
+						if fl.AddIfNotContains(binary.LittleEndian.AppendUint64(nil, uint64(i))) {
+							added.Add(1)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			require.Equal(t, int(added.Load()), fl.Count())
+			require.True(t, added.Load() <= n)
+			require.True(t, added.Load() > n*99/100, "%d", added.Load())
+		})
+	}
+}
+
+func TestConcurrentTryDeleteClaimsOnce(t *testing.T) {
+	const (
+		goroutines = 8
+		n          = 20000
+	)
+	filters := map[string]interface {
+		Add([]byte)
+		TryDelete([]byte) bool
+		Count() int
+	}{
+		"Concurrent": NewConcurrent(New(n, 0.0001), 16),
+		"LockFree":   NewLockFree(n, 0.0001),
+		"Sharded":    NewSharded(n, 0.0001, 16),
+	}
+	for name, fl := range filters {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < n; i++ {
+				fl.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+			}
+			// Every goroutine tries to claim every key, and each key should be claimed exactly once.
+			var wg sync.WaitGroup
+			var claimed atomic.Int64
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < n; i++ {
+						if fl.TryDelete(binary.LittleEndian.AppendUint64(nil, uint64(i))) {
+							claimed.Add(1)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			require.Equal(t, int64(n), claimed.Load())
+			require.Equal(t, 0, fl.Count())
+		})
+	}
+}
+
+func TestConcurrentAligned(t *testing.T) {
+	const (
+		goroutines = 8
+		perRoutine = 5000
+	)
+	// Three buckets to a word, which doesn't divide 64, so stripes of 64 buckets would share words.
+	fl := NewRawAligned(6, 4, goroutines*perRoutine/2)
+	require.Equal(t, uint64(3), fl.perWord)
+	c := NewConcurrent(fl, 64)
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		require.Equal(t, c.stripe(i/3*3), c.stripe(i))
+	}
+
+	key := func(g, i int) []byte {
+		var b [8]byte
+		binary.LittleEndian.PutUint32(b[:4], uint32(g))
+		binary.LittleEndian.PutUint32(b[4:], uint32(i))
+		return b[:]
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perRoutine; i++ {
+				c.Add(key(g, i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.False(t, c.Overflowed())
+	for g := 0; g < goroutines; g++ {
+		for i := 0; i < perRoutine; i++ {
+			require.Equal(t, Maybe, c.Contains(key(g, i)))
+		}
+	}
+	stored, err := c.fl.checkBuckets()
+	require.NoError(t, err)
+	require.Equal(t, c.Count(), stored)
+}
+
+func TestConcurrentCallbacks(t *testing.T) {
+	fl := NewRaw(8, 4, 256)
+	var loads []float64
+	fl.OnLoad(0.5, func(load float64) { loads = append(loads, load) })
+	var overflow *OverflowEvent
+	fl.OnOverflow(func(e OverflowEvent) { overflow = &e })
+	c := NewConcurrent(fl, 4)
+	half := int(fl.nBuckets()) * 4 / 2
+
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for i := 0; i < half; i++ {
+		c.Add(key(i))
+	}
+	require.Equal(t, []float64{0.5}, loads)
+	for i := 0; i < half; i++ {
+		c.Delete(key(i))
+	}
+	i := 0
+	for ; overflow == nil; i++ {
+		c.Add(key(i))
+	}
+	require.Len(t, loads, 2)
+	require.Equal(t, i, overflow.Count)
+	require.Equal(t, i, c.Count())
+}
@@ -0,0 +1,169 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// A cuckoo filter that keeps a small counter with each fingerprint, so that an item can be added
+// many times and then deleted the same number of times.
+//
+// A Filter stores a separate copy of a fingerprint for every Add, all in the same two buckets, so
+// adding an item more than 2*b times overflows the filter no matter how empty it is. A
+// CountingFilter instead bumps the counter on an existing copy, and only takes another slot once the
+// counter is full. Delete decrements it, so the item stays in the filter until it has been deleted
+// as many times as it was added.
+//
+// Items whose fingerprints and buckets are the same share counters, just as they would share
+// fingerprints in a Filter, so deleting one of them leaves the filter reporting Maybe for the rest.
+// As with Filter, deleting an item that was never added can remove one that was.
+type CountingFilter struct {
+	// The extra bits of each entry are one less than its count.
+	entryTable
+}
+
+// Returns a new CountingFilter capable of holding n distinct items with an estimated false-positive
+// rate of fp, with 4-bit counters, so that each entry counts up to 16 adds of an item.
+func NewCounting(n int, fp float64) *CountingFilter {
+	f, b, nBuckets := params(n, fp)
+	return NewCountingRaw(f, b, 4, nBuckets)
+}
+
+// Returns a new CountingFilter constructed using raw parameters. f, b, and n are as for NewRaw, and
+// c is the number of bits in each counter, in [1, 16], so that each entry counts up to 2^c adds.
+func NewCountingRaw(f, b, c, n int) *CountingFilter {
+	nBuckets := rawBuckets(f, b, n)
+	if c < 1 || c > 16 {
+		panic(fmt.Errorf("%w: counter bits c=%d must be in [1, 16]", ErrInvalidParams, c))
+	}
+	return newCountingFilter(f, b, c, nBuckets)
+}
+
+func newCountingFilter(f, b, c, nBuckets int) *CountingFilter {
+	return &CountingFilter{newEntryTable(f, b, c, nBuckets)}
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe until x has been
+// deleted as many times as it has been added.
+func (cf *CountingFilter) Add(x []byte) {
+	f, i1, i2 := cf.h.itemToIdxs(x)
+	cf.h.count++
+	if !cf.h.overflowed && !cf.add(f, i1, i2, false) {
+		cf.h.overflowed = true
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns ErrOverflowed
+// and leaves the filter as it was, rather than overflowing it.
+func (cf *CountingFilter) Insert(x []byte) error {
+	f, i1, i2 := cf.h.itemToIdxs(x)
+	if cf.h.overflowed || !cf.add(f, i1, i2, true) {
+		return ErrOverflowed
+	}
+	cf.h.count++
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (cf *CountingFilter) TryAdd(x []byte) bool {
+	return cf.Insert(x) == nil
+}
+
+// Counts another add of fingerprint f, whose candidate buckets are i1 and i2, either in an entry
+// that already holds f or in a new one.
+func (cf *CountingFilter) add(f fingerprint, i1, i2 uint64, undo bool) bool {
+	full := uint64(1)<<uint(cf.extra) - 1
+	for _, i := range [2]uint64{i1, i2} {
+		for s := cf.slot(i, 0); s < cf.slot(i+1, 0); s++ {
+			if e := cf.entry(s); cf.fingerprint(e) == f && cf.extraBits(e) < full {
+				cf.setEntry(s, e+1<<uint(cf.h.f))
+				return true
+			}
+		}
+	}
+	return cf.kick(uint64(f), i1, i2, undo)
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (cf *CountingFilter) Delete(x []byte) {
+	if !cf.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (cf *CountingFilter) TryDelete(x []byte) bool {
+	f, i1, i2 := cf.h.itemToIdxs(x)
+	if !cf.h.overflowed {
+		s, ok := cf.find(f, i1, i2)
+		if !ok {
+			return false
+		}
+		e := cf.entry(s)
+		if cf.extraBits(e) == 0 {
+			cf.setEntry(s, 0)
+		} else {
+			cf.setEntry(s, e-1<<uint(cf.h.f))
+		}
+	}
+	cf.h.count--
+	return true
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (cf *CountingFilter) Contains(x []byte) Result {
+	f, i1, i2 := cf.h.itemToIdxs(x)
+	if _, ok := cf.find(f, i1, i2); ok || cf.h.overflowed {
+		return Maybe
+	}
+	return No
+}
+
+// Returns the number of times x has been added, less the number of times it has been deleted. Like
+// Contains, this can be too high, since it includes items that share x's fingerprint and buckets,
+// but it's never too low. Once the filter has overflowed, it's meaningless.
+func (cf *CountingFilter) Occurrences(x []byte) int {
+	f, i1, i2 := cf.h.itemToIdxs(x)
+	n := 0
+	for _, i := range [2]uint64{i1, i2} {
+		for s := cf.slot(i, 0); s < cf.slot(i+1, 0); s++ {
+			if e := cf.entry(s); cf.fingerprint(e) == f {
+				n += int(cf.extraBits(e)) + 1
+			}
+		}
+		if i1 == i2 {
+			break
+		}
+	}
+	return n
+}
+
+// Returns the number of items in the filter, counting each add of the same item.
+func (cf *CountingFilter) Count() int {
+	return cf.h.count
+}
+
+// Returns an independent copy of the filter.
+func (cf *CountingFilter) Clone() *CountingFilter {
+	return &CountingFilter{cf.clone()}
+}
+
+// The magic that starts a CountingFilter's encoding. See entryTable.marshal.
+var countingMagic = [4]byte{'C', 'K', 'C', 'T'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (cf *CountingFilter) MarshalBinary() ([]byte, error) {
+	return cf.marshal(countingMagic), nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of cf with the filter encoded in
+// data.
+func (cf *CountingFilter) UnmarshalBinary(data []byte) error {
+	t, err := unmarshalEntryTable(countingMagic, data)
+	if err != nil {
+		return err
+	}
+	cf.entryTable = t
+	return nil
+}
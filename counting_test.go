@@ -0,0 +1,113 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounting(t *testing.T) {
+	cf := NewCounting(1000, 0.01)
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		cf.Add(keys[i])
+	}
+	require.False(t, cf.Overflowed())
+	require.Equal(t, len(keys), cf.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, cf.Contains(x))
+		require.GreaterOrEqual(t, cf.Occurrences(x), 1)
+	}
+	for _, x := range keys[:500] {
+		cf.Delete(x)
+	}
+	for _, x := range keys[500:] {
+		require.Equal(t, Maybe, cf.Contains(x))
+	}
+	require.Equal(t, 500, cf.Count())
+	require.False(t, cf.TryDelete([]byte("never added")))
+	require.Panics(t, func() { cf.Delete([]byte("never added")) })
+
+	cf.Reset()
+	require.Zero(t, cf.Count())
+	require.Equal(t, No, cf.Contains(keys[0]))
+}
+
+func TestCountingRepeats(t *testing.T) {
+	// A Filter overflows once an item is added more times than its two buckets have slots.
+	fl := NewRaw(8, 4, 1024)
+	for i := 0; i < 9; i++ {
+		fl.Add([]byte("x"))
+	}
+	require.True(t, fl.Overflowed())
+
+	// A CountingFilter with 2-bit counters has room for 4 adds per slot.
+	cf := NewCountingRaw(8, 4, 2, 1024)
+	x := []byte("x")
+	for i := 0; i < 32; i++ {
+		require.True(t, cf.TryAdd(x))
+		require.Equal(t, i+1, cf.Occurrences(x))
+	}
+	require.False(t, cf.TryAdd(x))
+	require.False(t, cf.Overflowed())
+	require.Equal(t, 32, cf.Count())
+	for i := 32; i > 0; i-- {
+		require.Equal(t, Maybe, cf.Contains(x))
+		cf.Delete(x)
+		require.Equal(t, i-1, cf.Occurrences(x))
+	}
+	require.Equal(t, No, cf.Contains(x))
+	for _, w := range cf.entries {
+		require.Zero(t, w)
+	}
+}
+
+func TestCountingFull(t *testing.T) {
+	cf := NewCountingRaw(12, 4, 4, 64)
+	var added [][]byte
+	for i := 0; ; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		before := append([]uint64(nil), cf.entries...)
+		if !cf.TryAdd(x) {
+			require.Equal(t, before, cf.entries)
+			break
+		}
+		added = append(added, x)
+	}
+	require.False(t, cf.Overflowed())
+	require.Greater(t, len(added), 64*4*9/10)
+	for _, x := range added {
+		require.Equal(t, Maybe, cf.Contains(x))
+	}
+	cf.Add([]byte("one too many"))
+	for i := 0; i < 100; i++ {
+		cf.Add(binary.LittleEndian.AppendUint64(nil, uint64(len(added)+i)))
+	}
+	require.True(t, cf.Overflowed())
+	require.Equal(t, Maybe, cf.Contains([]byte("never added")))
+}
+
+func TestCountingSerialize(t *testing.T) {
+	cf := NewCountingRaw(10, 4, 3, 256)
+	cf.SetSeed(99)
+	for i := 0; i < 500; i++ {
+		cf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i%100)))
+	}
+	data, err := cf.MarshalBinary()
+	require.NoError(t, err)
+
+	var cf2 CountingFilter
+	require.NoError(t, cf2.UnmarshalBinary(data))
+	require.Equal(t, cf.entries, cf2.entries)
+	require.Equal(t, 500, cf2.Count())
+	require.Equal(t, uint64(99), cf2.Seed())
+	for i := 0; i < 100; i++ {
+		require.GreaterOrEqual(t, cf2.Occurrences(binary.LittleEndian.AppendUint64(nil, uint64(i))), 5)
+	}
+
+	require.Error(t, cf2.UnmarshalBinary(data[:len(data)-1]))
+	var fl Filter
+	require.Error(t, fl.UnmarshalBinary(data))
+}
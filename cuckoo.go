@@ -24,26 +24,194 @@ package cuckoo
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"hash/fnv"
 	"math"
 	"math/bits"
 	"math/rand"
 	"strings"
-
-	"github.com/bradenaw/bitarray"
 )
 
 type Filter struct {
-	// The bitarray used to store buckets encoded with bucketEncoding.
-	inner          bitarray.Array
+	// Buckets encoded with bucketEncoding, packed back to back. See getPacked.
+	words []uint64
+	// If non-nil, used instead of words to store buckets, with perWord buckets packed into each word
+	// so that no bucket straddles two words. This lets every bucket be read and written atomically,
+	// and with a single load or store. See LockFreeFilter and NewRawAligned.
+	aligned []uint64
+	perWord uint64
+	// If non-nil, used instead of words to store buckets. See EpochFilter.
+	paged          *pagedStorage
 	bucketEncoding bucketEncoding
+	// The number of buckets.
+	n uint64
 	// The number of items in the filter.
 	count int
 	// True if the filter is overflowed, and now just returns Maybe for all queries.
 	overflowed bool
 	// The number of bits per fingerprint.
 	f int
+	// The number of entries per bucket.
+	b int
+	// One bit per bucket, set when the bucket is modified. nil if changes aren't being tracked. See
+	// SaveDelta.
+	dirty []uint64
+	// If non-nil, called with a bucket's index just before the bucket is modified. See
+	// ConcurrentFilter.Snapshot.
+	onWrite func(i uint64)
+	// If non-nil, Add and Delete are recorded here. See SetLog.
+	log *walWriter
+	// How items are mapped to fingerprints and buckets.
+	hashing hashScheme
+	// Only used when hashing is hashCMU.
+	cmu CMUHasher
+	// Only used when hashing is hashCustom. See SetHasher.
+	hasher Hasher
+	// Mixed into the hash of every item when hashing is hashXXH. See SetSeed.
+	seed uint64
+	// If non-nil, called when the filter overflows. See OnOverflow.
+	onOverflow func(e OverflowEvent)
+	// Registered with OnLoad.
+	thresholds []loadThreshold
+	// If non-zero, Delete folds the filter when its load falls below shrinkBelow, as long as it keeps
+	// at least shrinkFloor buckets. See SetAutoShrink.
+	shrinkBelow float64
+	shrinkFloor uint64
+	// Chooses which fingerprint to kick. If nil, rngState is used instead. See SetRand.
+	rng *rand.Rand
+	// The state of the filter's own generator for choosing which fingerprint to kick. Starts from
+	// the seed set with SetSeed, so that a seeded filter's layout depends only on the items added to
+	// it and their order, and otherwise from a random state. See randInt.
+	rngState uint64
+	// How to make room when both of an item's buckets are full. See SetInsertStrategy.
+	insertStrategy InsertStrategy
+	// If non-nil, frees words, which were allocated outside the Go heap. See NewRawOffHeap.
+	release func() error
+}
+
+// Identifies how a filter maps items to fingerprints and buckets. Filters built with different
+// schemes are not interchangeable even if they have the same parameters.
+type hashScheme byte
+
+const (
+	// xxHash64 of the item, fingerprint from the high bits and primary bucket from the low bits,
+	// with the alternate bucket from a cheap mix of the fingerprint. The default.
+	hashXXH hashScheme = iota
+	// Like hashXXH, but with two independently seeded xxHash64s of the item, one for the primary
+	// bucket and one for the fingerprint. See Use128BitHash.
+	hashXXH128
+	// Like hashXXH, but with a caller-supplied hash of the item. See SetHasher.
+	hashCustom
+	// The scheme used by github.com/seiflotfy/cuckoofilter. See DecodeSeiflotfy.
+	hashSeiflotfy
+	// The scheme used by the CMU reference implementation. See DecodeCMU.
+	hashCMU
+)
+
+// Mixed into the seed of the second half of a hashXXH128 hash.
+const xxh128Lane = 0x9E3779B97F4A7C15
+
+// Maps items to 64-bit hashes. See SetHasher.
+//
+// AddParallel, AddParallelContext, and ConcurrentFilter call Hash64 from several goroutines at
+// once, so a Hasher used with them must be safe for concurrent use. A pure function of x, like
+// xxhash.Sum64, is.
+type Hasher interface {
+	Hash64(x []byte) uint64
+}
+
+// Adapts an ordinary function, such as xxhash.Sum64, to a Hasher.
+type HasherFunc func(x []byte) uint64
+
+func (h HasherFunc) Hash64(x []byte) uint64 {
+	return h(x)
+}
+
+// Sets the hash function used to map items to fingerprints and buckets in place of the default,
+// xxHash64. h must spread its output evenly across all 64 bits, since the fingerprint is taken from
+// the high bits and the bucket from the low bits. Passing nil goes back to the default.
+//
+// Must be called before any items are added. The filter can still be serialized, but the Hasher
+// isn't part of the encoding: a filter decoded from one that used a Hasher needs SetHasher called
+// with an equivalent Hasher before it can be used.
+func (fl *Filter) SetHasher(h Hasher) {
+	if fl.hashing != hashXXH && fl.hashing != hashXXH128 && fl.hashing != hashCustom {
+		panic("cuckoo: SetHasher can't be used with a filter decoded from another implementation")
+	}
+	decoded := fl.hashing == hashCustom && fl.hasher == nil
+	if fl.count != 0 && !decoded {
+		panic("cuckoo: SetHasher must be called before adding any items")
+	}
+	if h != nil && fl.seed != 0 {
+		panic("cuckoo: SetSeed only applies to the default hash, seed the Hasher itself instead")
+	}
+	if h == nil {
+		if decoded {
+			panic("cuckoo: a filter decoded from one that used a Hasher needs a Hasher")
+		}
+		fl.hashing = hashXXH
+		fl.hasher = nil
+		return
+	}
+	fl.hashing = hashCustom
+	fl.hasher = h
+}
+
+// Sets the seed mixed into the hash of every item. Filters with different seeds map the same items
+// to different fingerprints and buckets, so replicas of a filter built from the same data with
+// different seeds, such as SetSeed(rand.Uint64()), don't share false positives: an item that's a
+// false positive in one is very unlikely to be in another. The seed is part of every encoding of
+// the filter. The default is 0.
+//
+// Must be called before any items are added, and only with the default hash or Use128BitHash: a
+// Hasher passed to SetHasher should be seeded itself. Hashes passed to AddHash and ContainsHash are
+// used as-is.
+func (fl *Filter) SetSeed(seed uint64) {
+	if fl.hashing != hashXXH && fl.hashing != hashXXH128 {
+		panic("cuckoo: SetSeed can only be used with the default hash")
+	}
+	if fl.count != 0 {
+		panic("cuckoo: SetSeed must be called before adding any items")
+	}
+	fl.seed = seed
+	if seed != 0 {
+		fl.rngState = seed
+	}
+}
+
+// Returns the seed set with SetSeed.
+func (fl *Filter) Seed() uint64 {
+	return fl.seed
+}
+
+// Makes the filter hash items to 128 bits instead of 64, taking the bucket index and the
+// fingerprint from separate halves.
+//
+// With 64 bits, the index and fingerprint share the hash: a filter with 2^48 buckets and 16-bit
+// fingerprints uses every bit, and fingerprints start to depend on the index once the two need more
+// than 64 bits between them. This doesn't matter for filters that fit in memory on most machines,
+// but for filters with billions of buckets it keeps the false-positive rate where Params says it
+// should be. Hashing costs about twice as much.
+//
+// Must be called before any items are added, and can't be combined with SetHasher. The choice is
+// part of every encoding of the filter.
+func (fl *Filter) Use128BitHash() {
+	if fl.hashing != hashXXH && fl.hashing != hashXXH128 {
+		panic("cuckoo: Use128BitHash can't be used with a Hasher or a filter from another " +
+			"implementation")
+	}
+	if fl.count != 0 {
+		panic("cuckoo: Use128BitHash must be called before adding any items")
+	}
+	fl.hashing = hashXXH128
+}
+
+// Makes fl map items to fingerprints and buckets the same way as other.
+func (fl *Filter) hashingFrom(other *Filter) {
+	fl.hashing = other.hashing
+	fl.cmu = other.cmu
+	fl.hasher = other.hasher
+	fl.seed = other.seed
 }
 
 type Result byte
@@ -55,6 +223,11 @@ const (
 	Maybe
 )
 
+// Returns true if r is Maybe.
+func (r Result) IsMaybe() bool {
+	return r == Maybe
+}
+
 func (r Result) String() string {
 	switch r {
 	case No:
@@ -66,13 +239,65 @@ func (r Result) String() string {
 	}
 }
 
+var (
+	// Returned by Insert and AddBatch when there's no room for an item, either because its candidate
+	// buckets are full and no room can be made or because the filter has already overflowed.
+	ErrOverflowed = errors.New("cuckoo: filter is full")
+	// Reported when deleting an item that isn't in the filter. Delete panics with an error that wraps
+	// it.
+	ErrNotInserted = errors.New("cuckoo: item not previously inserted")
+	// Wrapped by the errors from TryNew and TryNewRaw, and the panics from New and NewRaw, when the
+	// parameters are invalid.
+	ErrInvalidParams = errors.New("cuckoo: invalid params")
+)
+
 // Returns a new filter capable of holding n items with an estimated false-positive rate of fp.
 // If more than n items are added, the false-positive rate approaches 1.
 func New(n int, fp float64) *Filter {
-	b := 4
-	f := int(math.Min(math.Max(math.Ceil(math.Log2(2*float64(b)/float64(fp))), 4), 16))
+	return NewRaw(params(n, fp))
+}
+
+// The parameters New chooses for a filter. See Params.
+type Parameters struct {
+	// Fingerprint length in bits.
+	F int
+	// Bucket size in number of entries.
+	B int
+	// The number of buckets in the table.
+	Buckets int
+	// The number of bytes used by the buckets, as reported by SizeBytes.
+	SizeBytes uint64
+	// The expected false-positive rate once the filter holds the number of items it was sized for.
+	// Usually lower than requested, because f is rounded up to a whole number of bits and the number
+	// of buckets to a power of two.
+	FalsePositiveRate float64
+}
+
+// Returns the parameters New(n, fp) would use, without allocating the filter.
+func Params(n int, fp float64) Parameters {
+	f, b, nBuckets := params(n, fp)
+	nBuckets = rawBuckets(f, b, nBuckets)
+	return Parameters{
+		F:                 f,
+		B:                 b,
+		Buckets:           nBuckets,
+		SizeBytes:         (uint64(nBuckets)*bucketEncodingFor(f, b).size() + 7) / 8,
+		FalsePositiveRate: falsePositiveRate(f, b, float64(n)/(float64(nBuckets)*float64(b))),
+	}
+}
+
+// Returns the number of bytes of buckets New(n, fp) would allocate, without allocating them. The
+// same as Params(n, fp).SizeBytes.
+func EstimateSizeBytes(n int, fp float64) uint64 {
+	return Params(n, fp).SizeBytes
+}
+
+// Returns the parameters for NewRaw that New(n, fp) uses.
+func params(n int, fp float64) (f, b, nBuckets int) {
+	b = 4
+	f = int(math.Min(math.Max(math.Ceil(math.Log2(2*float64(b)/float64(fp))), 4), 16))
 	loadFactor := 0.95
-	return NewRaw(f, b, int(float64(n)/float64(b)/float64(loadFactor)))
+	return f, b, int(float64(n) / float64(b) / float64(loadFactor))
 }
 
 // Returns a new filter constructed using raw parameters.
@@ -90,61 +315,329 @@ func New(n int, fp float64) *Filter {
 // See https://www.cs.cmu.edu/~dga/papers/cuckoo-conext2014.pdf for more information on how to
 // select these parameters.
 func NewRaw(f, b, n int) *Filter {
-	if f < 2 || f > 16 || b < 1 || b > 8 || f*b > 64 {
-		panic("invalid params")
+	return newFilter(f, b, rawBuckets(f, b, n))
+}
+
+// Panics if f, b, and n are invalid parameters for NewRaw, and otherwise returns the number of
+// buckets NewRaw uses for n.
+func rawBuckets(f, b, n int) int {
+	nBuckets, err := checkRaw(f, b, n)
+	if err != nil {
+		panic(err)
 	}
+	return nBuckets
+}
 
+// Returns an error if f, b, and n are invalid parameters for NewRaw, and otherwise returns the
+// number of buckets NewRaw uses for n.
+func checkRaw(f, b, n int) (int, error) {
+	if f < 2 || f > 16 {
+		return 0, fmt.Errorf("%w: fingerprint length f=%d must be in [2, 16]", ErrInvalidParams, f)
+	}
+	if b < 1 || b > 8 {
+		return 0, fmt.Errorf("%w: bucket size b=%d must be in [1, 8]", ErrInvalidParams, b)
+	}
+	if f*b > 64 {
+		return 0, fmt.Errorf("%w: f*b=%d must be at most 64", ErrInvalidParams, f*b)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%w: number of buckets n=%d must not be negative", ErrInvalidParams, n)
+	}
 	// Round n to an even power of two, so that the xor operations work.
-	n = 1 << uint(bits.Len64(uint64(n)))
-	var enc bucketEncoding
-	if f >= 4 && b == 4 {
-		enc = packedBucketEncoding{f}
+	l := bits.Len64(uint64(n))
+	// Leave room for up to 64 bits per bucket without overflowing the size in bits.
+	if l > bits.UintSize-8 {
+		return 0, fmt.Errorf("%w: number of buckets n=%d is too large", ErrInvalidParams, n)
+	}
+	return 1 << uint(l), nil
+}
+
+// Like New, but lays buckets out for speed rather than size. See NewRawAligned.
+func NewAligned(n int, fp float64) *Filter {
+	return NewRawAligned(params(n, fp))
+}
+
+// Like NewRaw, but pads buckets so that each one lies within a single 64-bit word, packing as many
+// whole buckets into each word as fit. NewRaw packs buckets end to end, so a bucket can straddle two
+// words, or two cache lines, and reading it costs two loads and sometimes two cache misses. Aligned
+// buckets never cross a cache line, and reading one is a single load and shift.
+//
+// The padding costs nothing when the bucket size divides 64 bits, such as 8-bit fingerprints in
+// buckets of 4 or 8, and up to nearly half the space for buckets just over 32 bits. MemStats and
+// SizeBytes include it.
+//
+// The layout isn't part of the filter's encoding: the encoding is the same as NewRaw's, and decoding
+// always produces NewRaw's layout.
+func NewRawAligned(f, b, n int) *Filter {
+	return newAlignedFilter(f, b, rawBuckets(f, b, n))
+}
+
+// Like New, but stores fingerprints at byte-aligned positions for speed. See NewRawByteAligned.
+func NewByteAligned(n int, fp float64) *Filter {
+	return NewRawByteAligned(params(n, fp))
+}
+
+// Like NewRaw, but rounds f up to 8 or 16 and stores each bucket's fingerprints directly, one to a
+// byte or a pair of bytes, rather than packed. Buckets of 2 or 4 entries (or 8 with f <= 8) then fill
+// a power-of-two number of bits, so none straddles two words and reading or writing one is a single
+// load, shift, and mask. Matching a fingerprint against a directly encoded bucket doesn't need to
+// decode it either.
+//
+// This trades memory for speed: for example, with New's default of 4 entries per bucket, 8-bit
+// fingerprints take 32 bits per bucket rather than 28. The extra fingerprint bits aren't wasted,
+// since they lower the false-positive rate.
+//
+// Unlike NewRawAligned, this changes the filter's encoding, which it keeps when serialized.
+func NewRawByteAligned(f, b, n int) *Filter {
+	if f <= 8 {
+		f = 8
 	} else {
-		enc = directBucketEncoding{f, b}
+		f = 16
 	}
+	return newFilterEncoded(f, b, rawBuckets(f, b, n), directEncoding(f, b))
+}
+
+// Returns a new, empty filter with the same parameters and storage layout as fl.
+func (fl *Filter) newLike() *Filter {
+	if fl.aligned != nil {
+		return newAlignedFilter(fl.f, fl.b, int(fl.nBuckets()))
+	}
+	return newFilterEncoded(fl.f, fl.b, int(fl.nBuckets()), fl.bucketEncoding)
+}
+
+// Like New, but uses exactly as many buckets as needed for n items. See NewRawExact.
+func NewExact(n int, fp float64) *Filter {
+	return NewRawExact(params(n, fp))
+}
+
+// Like NewRaw, but uses exactly n buckets instead of rounding up to a power of two, so that memory
+// tracks the requested capacity: rounding can nearly double it, for example from 1.1 million buckets
+// to 2 million. n is at least 1.
+//
+// The default hash scheme works with any number of buckets, so these filters support everything a
+// filter from NewRaw does except Fold and SetAutoShrink, which need a power of two.
+func NewRawExact(f, b, n int) *Filter {
+	if _, err := checkRaw(f, b, n); err != nil {
+		panic(err)
+	}
+	if n < 1 {
+		n = 1
+	}
+	return newFilter(f, b, n)
+}
+
+// Like NewRaw, but returns an error instead of panicking if the parameters are invalid.
+func TryNewRaw(f, b, n int) (*Filter, error) {
+	nBuckets, err := checkRaw(f, b, n)
+	if err != nil {
+		return nil, err
+	}
+	return newFilter(f, b, nBuckets), nil
+}
+
+// Like New, but returns an error instead of panicking if the parameters are invalid.
+func TryNew(n int, fp float64) (*Filter, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: capacity n=%d must not be negative", ErrInvalidParams, n)
+	}
+	if !(fp > 0 && fp < 1) {
+		return nil, fmt.Errorf("%w: false-positive rate fp=%v must be in (0, 1)", ErrInvalidParams, fp)
+	}
+	return TryNewRaw(params(n, fp))
+}
+
+// Returns a new, empty filter with exactly n buckets. n must be a power of two unless the filter
+// will only use hash schemes that allow any number of buckets. See NewRawExact.
+func newFilter(f, b, n int) *Filter {
+	return newFilterEncoded(f, b, n, bucketEncodingFor(f, b))
+}
+
+// Like newFilter, but with buckets encoded by enc rather than the best encoding available.
+func newFilterEncoded(f, b, n int, enc bucketEncoding) *Filter {
+	return newFilterWords(f, b, n, enc, make([]uint64, packedWords(enc.size(), uint64(n))))
+}
+
+// Like newFilterEncoded, but stores buckets in words, which must be zeroed and exactly
+// packedWords(enc.size(), n) long.
+func newFilterWords(f, b, n int, enc bucketEncoding, words []uint64) *Filter {
 	return &Filter{
-		inner:          bitarray.New(n, uint(enc.size())),
+		words:          words,
+		n:              uint64(n),
 		f:              f,
+		b:              b,
 		bucketEncoding: enc,
+		hashing:        hashXXH,
+		rngState:       rand.Uint64(),
 	}
 }
 
-// Returns the number of bytes used by the filter.
+// Returns the number of bytes used by the filter's buckets. See MemStats for a complete accounting.
 func (fl *Filter) SizeBytes() uint64 {
-	return uint64(fl.inner.Len()) * uint64(fl.inner.K())
+	if fl.aligned != nil {
+		return uint64(len(fl.aligned)) * 8
+	} else if fl.paged != nil {
+		return uint64(len(fl.paged.pages)) * uint64(len(fl.paged.pages[0])) * 8
+	}
+	return (fl.nBuckets()*fl.bucketEncoding.size() + 7) / 8
 }
 
 func (fl *Filter) nBuckets() uint64 {
-	return uint64(fl.inner.Len())
+	return fl.n
 }
 
 // Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
 func (fl *Filter) Add(x []byte) {
+	f, i1, i2 := fl.itemToIdxs(x)
+	fl.add(f, i1, i2)
+}
+
+// Adds fingerprint f, whose candidate buckets are i1 and i2, to the filter.
+func (fl *Filter) add(f fingerprint, i1, i2 uint64) {
 	fl.count++
-	if fl.overflowed {
-		return
+	if fl.log != nil {
+		fl.log.record(walOpAdd, f, i1)
 	}
-	f, i1, i2 := fl.itemToIdxs(x)
+	if !fl.overflowed {
+		fl.place(f, i1, i2)
+	}
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+}
 
-	// First, attempt to add x's fingerprint to either of its candidate buckets, as long as there's
-	// room.
-	is := [2]uint64{i1, i2}
-	for _, i := range is {
-		b := fl.getBucket(i)
-		if b.hasEmpty() {
+// Attempts to place fingerprint f in either of its candidate buckets i1 and i2, without moving
+// anything else. Returns false if both are full.
+func (fl *Filter) placeNoKick(f fingerprint, i1, i2 uint64) bool {
+	for _, i := range [2]uint64{i1, i2} {
+		x := fl.loadBits(i)
+		if fl.bitsHaveEmpty(x) {
+			b := fl.bucketEncoding.decode(x)
 			b.add(f)
 			fl.setBucket(i, b)
-			return
+			return true
 		}
 	}
+	return false
+}
+
+// Places fingerprint f in one of its candidate buckets i1 and i2, kicking other fingerprints to
+// their other candidate buckets to make room if necessary. If no room can be made, marks the
+// filter overflowed.
+func (fl *Filter) place(f fingerprint, i1, i2 uint64) {
+	if !fl.kick(f, i1, i2, false) {
+		// We did maxNumKicks successive kicks without finding a bucket with empty space, so we
+		// should just consider the filter 'overflowed' and return Maybe for everything from now on.
+		fl.overflowed = true
+		if fl.onOverflow != nil {
+			fl.onOverflow(OverflowEvent{
+				Fingerprint: uint16(f),
+				Count:       fl.count,
+				Load:        fl.load(),
+			})
+		}
+	}
+}
+
+// Describes the insert that overflowed a filter. See OnOverflow.
+type OverflowEvent struct {
+	// The fingerprint of the item being added.
+	Fingerprint uint16
+	// The number of items in the filter, including the one being added.
+	Count int
+	// Count as a fraction of the filter's capacity in entries.
+	Load float64
+}
+
+// Registers fn to be called when an insert can't make room for its item and the filter overflows,
+// after which it returns Maybe for every query. fn is called synchronously from the Add that
+// overflowed the filter, and must not use the filter. Passing nil removes the callback.
+func (fl *Filter) OnOverflow(fn func(e OverflowEvent)) {
+	fl.onOverflow = fn
+}
+
+// Sets the source of randomness used to choose which fingerprints to move when an item's candidate
+// buckets are both full. With a seeded r, the layout of the filter depends only on the items added
+// and the order they were added in, which makes tests reproducible. r must not be used by anything
+// else concurrently with the filter. Passing nil goes back to the filter's own generator.
+//
+// Without SetRand, the filter uses a small generator of its own, which starts from the seed set with
+// SetSeed if there is one and from a random state otherwise. A seeded filter is therefore already
+// reproducible, and SetRand is only needed to replay the choices from a particular source.
+func (fl *Filter) SetRand(r *rand.Rand) {
+	fl.rng = r
+}
+
+// How a filter makes room for an item when both of its candidate buckets are full.
+type InsertStrategy byte
+
+const (
+	// Repeatedly moves a randomly chosen fingerprint to its other bucket, displacing another from
+	// there if that one is full too, for up to 500 moves. Cheap when the filter isn't very full. The
+	// default.
+	RandomWalk InsertStrategy = iota
+	// Searches breadth-first through the fingerprints that could be moved, and the ones that could
+	// be moved out of the way of those, for the shortest chain of moves that ends in an empty slot.
+	// Makes far fewer writes, fills the filter a little further before it overflows, and leaves
+	// the filter untouched when it fails, at the cost of more bucket reads per insertion.
+	BreadthFirst
+)
+
+// Sets how the filter makes room for an item when both of its candidate buckets are full. Can be
+// changed at any time.
+func (fl *Filter) SetInsertStrategy(s InsertStrategy) {
+	fl.insertStrategy = s
+}
+
+// Returns a non-negative pseudo-random int, from the source set with SetRand if there is one and
+// otherwise from the filter's own SplitMix64 generator. That's a single add and a few multiplies
+// and shifts, with no locking, and its quality is more than enough for choosing what to kick.
+func (fl *Filter) randInt() int {
+	if fl.rng != nil {
+		return fl.rng.Int()
+	}
+	fl.rngState += 0x9E3779B97F4A7C15
+	z := fl.rngState
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	// 31 bits, so that the result is non-negative even where int is 32 bits.
+	return int((z ^ (z >> 31)) >> 33)
+}
+
+// A fingerprint written into a bucket while kicking.
+type kickStep struct {
+	i uint64
+	f fingerprint
+}
+
+const maxNumKicks = 500
+
+// Places fingerprint f in one of its candidate buckets i1 and i2, kicking other fingerprints to
+// their other candidate buckets to make room if necessary. Returns false if no room can be made, in
+// which case some fingerprint has been dropped, unless undo is set, in which case the kicks are
+// reversed so that the filter is left as it was.
+func (fl *Filter) kick(f fingerprint, i1, i2 uint64, undo bool) bool {
+	// First, attempt to add x's fingerprint to either of its candidate buckets, as long as there's
+	// room.
+	if fl.placeNoKick(f, i1, i2) {
+		return true
+	}
+	if fl.insertStrategy == BreadthFirst {
+		// Never changes the filter unless it succeeds, so there's nothing to undo.
+		return fl.kickBFS(f, i1, i2)
+	}
 
 	// If there isn't any room, then we have to kick something out of one of the buckets (placing it
 	// in its other candidate bucket) in order to make room.
-	i := is[rand.Int()%len(is)]
-	maxNumKicks := 500
+	var pathBuf [16]kickStep
+	path := pathBuf[:0]
+	is := [2]uint64{i1, i2}
+	i := is[fl.randInt()%len(is)]
 	b := fl.getBucket(i)
 	for n := 0; n < maxNumKicks; n++ {
-		entry := rand.Int() % b.l
+		entry := fl.randInt() % b.l
+		if undo {
+			path = append(path, kickStep{i: i, f: f})
+		}
 		f, b.entries[entry] = b.entries[entry], f
 		fl.setBucket(i, b)
 		i = fl.otherIdx(f, i)
@@ -152,51 +645,269 @@ func (fl *Filter) Add(x []byte) {
 		if b.hasEmpty() {
 			b.add(f)
 			fl.setBucket(i, b)
-			return
+			return true
 		}
 		// But if there's no room in the bucket we're kicking to, then we have to kick something out
 		// of _that_ bucket, so loop around again.
 	}
-	// If we made it here, then we did maxNumKicks successive kicks without finding a bucket with
-	// empty space, so we should just consider the filter 'overflowed' and return Maybe for
-	// everything from now on.
-	fl.overflowed = true
+
+	// Walk the kicks backwards, returning each evicted fingerprint to the bucket it came from. The
+	// bucket encoding may reorder entries, so this goes by value rather than by slot.
+	for j := len(path) - 1; j >= 0; j-- {
+		b := fl.getBucket(path[j].i)
+		b.delete(path[j].f)
+		b.add(f)
+		fl.setBucket(path[j].i, b)
+		f = path[j].f
+	}
+	return false
+}
+
+// A bucket reached while searching for room with kickBFS.
+type bfsNode struct {
+	i uint64
+	// The fingerprint that would move into this bucket from the parent's bucket. Unset for the
+	// item's own candidate buckets.
+	f fingerprint
+	// Index of the parent node, or -1 for the item's own candidate buckets.
+	parent int
+}
+
+// The most buckets kickBFS looks at before giving up. Several times the random walk's limit, since
+// the search only reads buckets where the walk reads and writes them.
+const maxBFSNodes = 2048
+
+// Places fingerprint f in bucket i1 or i2 by moving the fewest other fingerprints possible, found
+// by breadth-first search. Both buckets must be full. Returns false without changing the filter if
+// no chain of moves within maxBFSNodes buckets makes room.
+func (fl *Filter) kickBFS(f fingerprint, i1, i2 uint64) bool {
+	var nodes [maxBFSNodes]bfsNode
+	nodes[0] = bfsNode{i: i1, parent: -1}
+	nodes[1] = bfsNode{i: i2, parent: -1}
+	n := 2
+	for next := 0; next < n; next++ {
+		b := fl.getBucket(nodes[next].i)
+		for j := 0; j < b.l && n < len(nodes); j++ {
+			moved := b.entries[j]
+			alt := fl.otherIdx(moved, nodes[next].i)
+			if fl.onPath(nodes[:n], next, alt) {
+				// Moving through the same bucket twice would undo part of the chain.
+				continue
+			}
+			nodes[n] = bfsNode{i: alt, f: moved, parent: next}
+			if fl.bitsHaveEmpty(fl.loadBits(alt)) {
+				fl.applyBFSPath(nodes[:n+1], f)
+				return true
+			}
+			n++
+		}
+	}
+	return false
+}
+
+// Returns true if bucket i is the bucket of node or one of its ancestors.
+func (fl *Filter) onPath(nodes []bfsNode, node int, i uint64) bool {
+	for ; node >= 0; node = nodes[node].parent {
+		if nodes[node].i == i {
+			return true
+		}
+	}
+	return false
+}
+
+// Makes the moves along the path from the last of nodes, whose bucket has room, back to one of the
+// item's candidate buckets, then places f there. Each fingerprint is added to its new bucket before
+// being removed from its old one, so it's never missing from the filter.
+func (fl *Filter) applyBFSPath(nodes []bfsNode, f fingerprint) {
+	node := len(nodes) - 1
+	for nodes[node].parent >= 0 {
+		to, from := nodes[node].i, nodes[nodes[node].parent].i
+		b := fl.getBucket(to)
+		b.add(nodes[node].f)
+		fl.setBucket(to, b)
+		b = fl.getBucket(from)
+		b.delete(nodes[node].f)
+		fl.setBucket(from, b)
+		node = nodes[node].parent
+	}
+	b := fl.getBucket(nodes[node].i)
+	b.add(f)
+	fl.setBucket(nodes[node].i, b)
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns an error and
+// leaves the filter as it was, rather than overflowing it.
+func (fl *Filter) Insert(x []byte) error {
+	f, i1, i2 := fl.itemToIdxs(x)
+	return fl.insert(f, i1, i2)
+}
+
+// Adds fingerprint f, whose candidate buckets are i1 and i2, to the filter, or returns
+// ErrOverflowed and leaves the filter unchanged if there's no room for it.
+func (fl *Filter) insert(f fingerprint, i1, i2 uint64) error {
+	if fl.overflowed || !fl.kick(f, i1, i2, true) {
+		return ErrOverflowed
+	}
+	fl.count++
+	if fl.log != nil {
+		fl.log.record(walOpAdd, f, i1)
+	}
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (fl *Filter) TryAdd(x []byte) bool {
+	return fl.Insert(x) == nil
+}
+
+// Adds x to the filter, returning what Contains(x) would have returned just before. Hashes x only
+// once.
+func (fl *Filter) TestAndAdd(x []byte) Result {
+	f, i1, i2 := fl.itemToIdxs(x)
+	r := fl.contains(f, i1, i2)
+	fl.add(f, i1, i2)
+	return r
+}
+
+// Adds x to the filter only if Contains(x) would return No, and returns true if it did so. This is
+// the usual way to deduplicate a stream of items, since each item is hashed only once.
+func (fl *Filter) AddIfNotContains(x []byte) bool {
+	f, i1, i2 := fl.itemToIdxs(x)
+	if fl.contains(f, i1, i2) == Maybe {
+		return false
+	}
+	fl.add(f, i1, i2)
+	return true
 }
 
 // Deletes x from the filter. x must have been previously added.
 func (fl *Filter) Delete(x []byte) {
-	fl.count--
-	if fl.overflowed {
-		return
+	f, i1, i2 := fl.itemToIdxs(x)
+	if !fl.delete(f, i1, i2) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
 	}
+	fl.checkShrink()
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+//
+// If x was never added but is a false positive, this removes a fingerprint belonging to some other
+// item, so it's still only safe to delete items that were added.
+func (fl *Filter) TryDelete(x []byte) bool {
 	f, i1, i2 := fl.itemToIdxs(x)
+	if !fl.overflowed && !fl.lookup(f, i1, i2) {
+		return false
+	}
+	fl.delete(f, i1, i2)
+	fl.checkShrink()
+	return true
+}
+
+// Deletes fingerprint f, whose candidate buckets are i1 and i2, from the filter. Returns false,
+// leaving the filter unchanged, if neither bucket contains f. An overflowed filter has lost track
+// of which fingerprints it holds, so for one this only decrements the count and returns true.
+func (fl *Filter) delete(f fingerprint, i1, i2 uint64) bool {
+	if !fl.overflowed && !fl.remove(f, i1, i2) {
+		return false
+	}
+	fl.count--
+	if fl.log != nil {
+		fl.log.record(walOpDelete, f, i1)
+	}
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+	return true
+}
 
+// Removes one instance of fingerprint f from either of its candidate buckets i1 and i2. Returns
+// false if neither contains f.
+func (fl *Filter) remove(f fingerprint, i1, i2 uint64) bool {
 	is := [2]uint64{i1, i2}
 	for _, i := range is {
 		b := fl.getBucket(i)
 		if b.contains(f) {
 			b.delete(f)
 			fl.setBucket(i, b)
-			return
+			return true
 		}
 	}
-	panic(fmt.Sprintf("item %s not previously inserted", hex.EncodeToString(x)))
+	return false
 }
 
 // Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
 func (fl *Filter) Contains(x []byte) Result {
-	if fl.overflowed {
+	f, i1, i2 := fl.itemToIdxs(x)
+	return fl.contains(f, i1, i2)
+}
+
+// Returns false if x is definitely not in the filter, and true if x might be in the filter. The
+// same as Contains(x).IsMaybe().
+func (fl *Filter) MayContain(x []byte) bool {
+	return fl.Contains(x) == Maybe
+}
+
+// Like Add, but takes a hash of the item instead of the item itself, for callers that have already
+// hashed it for some other purpose. h should be a well-distributed 64-bit hash, such as one from
+// hash/maphash.
+//
+// The filter uses h in place of its own hash of the item, so an item added with AddHash can only be
+// found with ContainsHash and the same hash, and not with Contains.
+func (fl *Filter) AddHash(h uint64) {
+	f, i1, i2 := fl.hashToIdxs(h)
+	fl.add(f, i1, i2)
+}
+
+// Like Contains, but for an item added with AddHash(h).
+func (fl *Filter) ContainsHash(h uint64) Result {
+	f, i1, i2 := fl.hashToIdxs(h)
+	return fl.contains(f, i1, i2)
+}
+
+// Like Delete, but for an item added with AddHash(h).
+func (fl *Filter) DeleteHash(h uint64) {
+	f, i1, i2 := fl.hashToIdxs(h)
+	if !fl.delete(f, i1, i2) {
+		panic(fmt.Errorf("%w: hash %x", ErrNotInserted, h))
+	}
+	fl.checkShrink()
+}
+
+// Returns Maybe if either of buckets i1 and i2 contains fingerprint f.
+func (fl *Filter) contains(f fingerprint, i1, i2 uint64) Result {
+	if fl.overflowed || fl.lookup(f, i1, i2) {
 		return Maybe
 	}
-	f, i1, i2 := fl.itemToIdxs(x)
+	return No
+}
+
+// Returns true if either of buckets i1 and i2 contains fingerprint f, ignoring overflow.
+func (fl *Filter) lookup(f fingerprint, i1, i2 uint64) bool {
 	is := [2]uint64{i1, i2}
 	for _, i := range is {
-		b := fl.getBucket(i)
-		if b.contains(f) {
-			return Maybe
+		if fl.bitsContain(fl.loadBits(i), f) {
+			return true
 		}
 	}
-	return No
+	return false
+}
+
+// Returns true if the bucket whose encoded bits are x has an empty entry.
+func (fl *Filter) bitsHaveEmpty(x uint64) bool {
+	if fl.bucketEncoding.isPacked {
+		return fl.bucketEncoding.packed.contains(x, 0)
+	}
+	return fl.bucketEncoding.direct.hasEmpty(x)
+}
+
+// Returns true if the bucket whose encoded bits are x contains fingerprint f. Avoids decoding the
+// bucket where the encoding allows it, since this is on the path of every Contains.
+func (fl *Filter) bitsContain(x uint64, f fingerprint) bool {
+	return fl.bucketEncoding.contains(x, f)
 }
 
 // True if the filter has overflowed, and now blindly returns Maybe for every query. This happens
@@ -210,74 +921,430 @@ func (fl *Filter) Count() int {
 	return fl.count
 }
 
-func (fl *Filter) dump() {
+// Returns an independent copy of the filter. The copy doesn't inherit a log set with SetLog or
+// change tracking started by SaveDelta.
+func (fl *Filter) Clone() *Filter {
+	c := fl.newLike()
 	for i := uint64(0); i < fl.nBuckets(); i++ {
-		b := fl.getBucket(i)
-		fmt.Printf("%d: %08x %s\n", i, fl.inner.Get(int(i)), b)
+		if bits := fl.loadBits(i); bits != 0 {
+			c.storeBits(i, bits)
+		}
+	}
+	c.count = fl.count
+	c.overflowed = fl.overflowed
+	c.hashingFrom(fl)
+	c.insertStrategy = fl.insertStrategy
+	c.rngState = fl.rngState
+	return c
+}
+
+// Returns true if fl and other have the same parameters, count, and overflowed state, and each of
+// their buckets holds the same fingerprints, though not necessarily in the same order within the
+// bucket. Filters that were given the same items can still differ if they placed some of them in
+// different candidate buckets, which depends on the order the items were added in.
+func (fl *Filter) Equal(other *Filter) bool {
+	if fl.f != other.f || fl.b != other.b || fl.nBuckets() != other.nBuckets() ||
+		fl.hashing != other.hashing || fl.cmu != other.cmu || fl.seed != other.seed ||
+		fl.count != other.count ||
+		fl.overflowed != other.overflowed {
+		return false
+	}
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		x, y := fl.loadBits(i), other.loadBits(i)
+		if x == y {
+			continue
+		}
+		bx, by := fl.bucketEncoding.decode(x), other.bucketEncoding.decode(y)
+		bx.sort()
+		by.sort()
+		if bx != by {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes every item from the filter, leaving it as if it had just been constructed, but reusing
+// its memory.
+func (fl *Filter) Reset() {
+	if fl.log != nil {
+		fl.log.record(walOpReset, 0, 0)
+	}
+	fl.reset()
+}
+
+func (fl *Filter) reset() {
+	// A freshly constructed filter's buckets are all zero bits. Skipping buckets that already are
+	// keeps the writes, and the pages they dirty, to what the filter actually used.
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		if fl.loadBits(i) != 0 {
+			fl.writeBits(i, 0)
+		}
+	}
+	fl.count = 0
+	fl.overflowed = false
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+}
+
+// Returns Count as a fraction of the number of entries in the filter.
+func (fl *Filter) load() float64 {
+	return float64(fl.count) / (float64(fl.nBuckets()) * float64(fl.b))
+}
+
+// A load factor registered with OnLoad.
+type loadThreshold struct {
+	// The count at which the filter reaches the load factor.
+	at    int
+	fn    func(load float64)
+	above bool
+}
+
+// Registers fn to be called when adding items brings the filter's load factor, Count as a fraction
+// of the number of entries in the filter, to load or above. This gives a chance to start draining
+// or resizing well before the filter overflows, which typically happens somewhere above 0.95 with
+// the default bucket size.
+//
+// fn is called once each time the load factor rises past load, with the load factor at that point.
+// Once Delete or Reset brings the load factor back under load, fn can be called again. fn is
+// called synchronously from the Add that crossed the threshold, and must not use the filter.
+func (fl *Filter) OnLoad(load float64, fn func(load float64)) {
+	at := int(math.Ceil(load * float64(fl.nBuckets()) * float64(fl.b)))
+	fl.thresholds = append(fl.thresholds, loadThreshold{at: at, fn: fn, above: fl.count >= at})
+}
+
+// Calls the functions registered with OnLoad for the thresholds the count has just risen past.
+func (fl *Filter) checkLoad() {
+	for i := range fl.thresholds {
+		t := &fl.thresholds[i]
+		if fl.count < t.at {
+			t.above = false
+		} else if !t.above {
+			t.above = true
+			t.fn(fl.load())
+		}
+	}
+}
+
+// Returns the expected false-positive rate of the filter as it is now, that is, the chance that
+// Contains returns Maybe for an item that was never added. This grows as the filter fills, up to
+// about the rate New was asked for once it holds the number of items it was sized for.
+func (fl *Filter) EstimatedFalsePositiveRate() float64 {
+	if fl.overflowed {
+		return 1
+	}
+	return falsePositiveRate(fl.f, fl.b, fl.load())
+}
+
+// Returns the expected false-positive rate of a filter with f-bit fingerprints and b-entry buckets
+// when the given fraction of its entries are occupied.
+func falsePositiveRate(f, b int, load float64) float64 {
+	load = math.Min(load, 1)
+	// A query compares against the 2b entries of its two candidate buckets, each of which is
+	// occupied with probability load. An occupied entry matches with probability 1/(2^f-1), since
+	// fingerprints are never zero.
+	pMatch := 1 / (math.Exp2(float64(f)) - 1)
+	return 1 - math.Pow(1-pMatch, 2*float64(b)*load)
+}
+
+// Returns a one-line summary of the filter's parameters and occupancy, for logging.
+func (fl *Filter) String() string {
+	return fmt.Sprintf(
+		"cuckoo.Filter{f=%d, b=%d, buckets=%d, count=%d, load=%.1f%%, overflowed=%t}",
+		fl.f, fl.b, fl.nBuckets(), fl.count, 100*fl.load(), fl.overflowed,
+	)
+}
+
+// The contents of one bucket, as produced by Buckets.
+type BucketInfo struct {
+	// The bucket's position in the table.
+	Index uint64
+	// The bucket's entries, where 0 is an empty slot. Fingerprints in a bucket may be reordered by
+	// the bucket encoding, so the slot a fingerprint appears in isn't meaningful.
+	Slots []uint16
+	// The number of non-empty entries in Slots.
+	Occupied int
+}
+
+// Returns an iterator over the filter's buckets in order, for debugging and offline analysis.
+//
+// The Slots of the BucketInfo passed to yield are reused, so they must be copied to be kept after
+// yield returns. The filter must not be modified during iteration.
+func (fl *Filter) Buckets() func(yield func(BucketInfo) bool) {
+	return func(yield func(BucketInfo) bool) {
+		var slots [8]uint16
+		for i := uint64(0); i < fl.nBuckets(); i++ {
+			b := fl.getBucket(i)
+			info := BucketInfo{Index: i, Slots: slots[:b.l]}
+			for j := 0; j < b.l; j++ {
+				slots[j] = uint16(b.entries[j])
+				if b.entries[j] != 0 {
+					info.Occupied++
+				}
+			}
+			if !yield(info) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an error describing the first inconsistency found in the filter's internal state, or nil
+// if there are none. Useful after decoding a filter from an untrusted source, or in tests of code
+// that embeds a filter.
+//
+// Checks that every bucket is a valid encoding of fingerprints of the right size, and that the
+// number of fingerprints stored matches Count unless the filter has overflowed.
+func (fl *Filter) CheckInvariants() error {
+	stored, err := fl.checkBuckets()
+	if err != nil {
+		return err
 	}
+	if fl.count < 0 {
+		return fmt.Errorf("cuckoo: negative count %d", fl.count)
+	}
+	if !fl.overflowed && stored != fl.count {
+		return fmt.Errorf("cuckoo: count is %d but %d fingerprints are stored", fl.count, stored)
+	}
+	return nil
 }
 
-func (fl *Filter) check() {
+// Checks the buckets for CheckInvariants, returning the number of fingerprints stored. The count
+// isn't checked, since the concurrent filters keep their own.
+func (fl *Filter) checkBuckets() (int, error) {
+	stored := 0
 	for i := uint64(0); i < fl.nBuckets(); i++ {
+		bits := fl.loadBits(i)
+		if fl.bucketEncoding.isPacked && !fl.bucketEncoding.packed.valid(bits) {
+			return 0, fmt.Errorf("cuckoo: bucket %d has invalid encoding %x", i, bits)
+		}
 		b := fl.getBucket(i)
-		bits := fl.inner.Get(int(i))
 		if bits != fl.bucketEncoding.encode(b) {
-			panic("bucket broken")
+			return 0, fmt.Errorf("cuckoo: bucket %d has non-canonical encoding %x", i, bits)
+		}
+		for j := 0; j < b.l; j++ {
+			if uint64(b.entries[j]) >= uint64(1)<<uint(fl.f) {
+				return 0, fmt.Errorf("cuckoo: bucket %d holds fingerprint %x wider than %d bits", i,
+					b.entries[j], fl.f)
+			}
+			if b.entries[j] != 0 {
+				stored++
+			}
 		}
 	}
+	return stored, nil
 }
 
 // Given x, returns x's fingerprint and the indexes of the two buckets that x's fingerprint would be
 // placed in.
 func (fl *Filter) itemToIdxs(x []byte) (fingerprint, uint64, uint64) {
-	h := fl.hashItem(x)
+	if fl.hashing == hashXXH128 {
+		return fl.hash128ToIdxs(xxhash64(x, fl.seed), xxhash64(x, fl.seed^xxh128Lane))
+	}
+	return fl.hashToIdxs(fl.hashItem(x))
+}
+
+// Returns the fingerprint and candidate buckets for an item whose hashXXH128 hash is (lo, hi).
+func (fl *Filter) hash128ToIdxs(lo, hi uint64) (fingerprint, uint64, uint64) {
+	f := fl.hashToFingerprint(hi)
+	i1 := fl.reduce(lo)
+	return f, i1, fl.otherIdx(f, i1)
+}
+
+// Returns the fingerprint and candidate buckets for an item whose hash is h.
+func (fl *Filter) hashToIdxs(h uint64) (fingerprint, uint64, uint64) {
+	switch fl.hashing {
+	case hashSeiflotfy:
+		return fl.seiflotfyHashToIdxs(h)
+	case hashCMU:
+		return fl.cmuHashToIdxs(h)
+	}
 	f := fl.hashToFingerprint(h)
-	i1 := h % fl.nBuckets()
+	// The fingerprint comes mostly from the high bits of h, and reduce uses mostly the high bits of
+	// its argument, so rotate the low bits up.
+	i1 := fl.reduce(bits.RotateLeft64(h, 32))
 	return f, i1, fl.otherIdx(f, i1)
 }
 
 // Given either index that fingerprint would be contained in, returns the other one.
 func (fl *Filter) otherIdx(f fingerprint, i1 uint64) uint64 {
-	return (i1 ^ fl.hashFingerprint(f)) % fl.nBuckets()
+	switch fl.hashing {
+	case hashSeiflotfy:
+		return fl.seiflotfyOtherIdx(f, i1)
+	case hashCMU:
+		return fl.cmuOtherIdx(f, i1)
+	}
+	return pairedIdx(i1, fl.reduce(mixFingerprint(f)), fl.nBuckets())
+}
+
+// Returns the bucket paired with bucket i, out of n, for a fingerprint that reduces to m. With a
+// power-of-two number of buckets the two are XORed with m, and otherwise they sum to m modulo n,
+// which works for any number of buckets. XOR keeps the pairs intact when the low bit of every index
+// is dropped, since reduce then drops the low bit of m too, which is what lets Fold halve the table.
+func pairedIdx(i, m, n uint64) uint64 {
+	if n&(n-1) == 0 {
+		return i ^ m
+	}
+	if i <= m {
+		return m - i
+	}
+	return m + n - i
+}
+
+// Maps x onto [0, nBuckets) with a multiplication rather than a division, which works for any number
+// of buckets and depends mostly on the high bits of x. See
+// https://lemire.me/blog/2016/06/27/a-fast-alternative-to-the-modulo-reduction/.
+func (fl *Filter) reduce(x uint64) uint64 {
+	hi, _ := bits.Mul64(x, fl.nBuckets())
+	return hi
 }
 
 func (fl *Filter) getBucket(i uint64) bucket {
-	return fl.bucketEncoding.decode(fl.inner.Get(int(i)))
+	return fl.bucketEncoding.decode(fl.loadBits(i))
 }
 
 func (fl *Filter) setBucket(i uint64, b bucket) {
-	bits := fl.bucketEncoding.encode(b)
-	fl.inner.Set(int(i), bits)
+	fl.writeBits(i, fl.bucketEncoding.encode(b))
+}
+
+// Sets the encoded bits of bucket i, notifying onWrite and tracking the change for SaveDelta.
+func (fl *Filter) writeBits(i uint64, bits uint64) {
+	if fl.onWrite != nil {
+		fl.onWrite(i)
+	}
+	fl.storeBits(i, bits)
+	if fl.dirty != nil {
+		fl.dirty[i/64] |= 1 << (i % 64)
+	}
 }
 
+// Returns the encoded bits of bucket i.
+func (fl *Filter) loadBits(i uint64) uint64 {
+	if fl.aligned != nil {
+		return fl.loadAligned(i)
+	} else if fl.paged != nil {
+		return fl.paged.load(i)
+	}
+	k := fl.bucketEncoding.size()
+	if k&(k-1) == 0 {
+		return getPackedPow2(fl.words, k, i)
+	}
+	return getPacked(fl.words, k, i)
+}
+
+// Sets the encoded bits of bucket i.
+func (fl *Filter) storeBits(i uint64, bits uint64) {
+	if fl.aligned != nil {
+		fl.storeAligned(i, bits)
+		return
+	} else if fl.paged != nil {
+		fl.paged.store(i, bits)
+		return
+	}
+	k := fl.bucketEncoding.size()
+	if k&(k-1) == 0 {
+		setPackedPow2(fl.words, k, i, bits)
+		return
+	}
+	setPacked(fl.words, k, i, bits)
+}
+
+// Maps hash to one of the 2^f-1 non-zero fingerprints, each equally likely.
 func (fl *Filter) hashToFingerprint(hash uint64) fingerprint {
-	// Prefer the high bits of the hash, because the low bits are used for i1.
-	mask := (uint64(1) << uint(fl.f)) - 1
-	for shift := 64 - fl.f; shift > 0; shift -= fl.f {
-		result := fingerprint((hash >> uint(shift)) & mask)
-		if result != 0 {
-			return result
+	// Scales hash down to [0, 2^f-1) by taking the high word of the product, which depends mostly on
+	// the high bits of the hash, because the low bits are used for i1. Each result comes from
+	// either floor or ceil of 2^64/(2^f-1) hashes, so the difference between them is negligible.
+	hi, _ := bits.Mul64(hash, (uint64(1)<<uint(fl.f))-1)
+	return fingerprint(hi + 1)
+}
+
+func (fl *Filter) hashItem(x []byte) uint64 {
+	switch fl.hashing {
+	case hashSeiflotfy:
+		return metroHash64(x, seiflotfySeed)
+	case hashCMU:
+		return fl.cmuHashItem(x)
+	case hashCustom:
+		if fl.hasher == nil {
+			panic("cuckoo: filter was decoded from one that used a Hasher, call SetHasher first")
 		}
+		return fl.hasher.Hash64(x)
 	}
-	return 1
+	return xxhash64(x, fl.seed)
 }
 
-func (fl *Filter) hashItem(x []byte) uint64 {
-	h := fnv.New64a()
-	_, _ = h.Write(x)
-	return h.Sum64()
+// Spreads the bits of f across a 64-bit word, so that every bit of f affects the high bits used to
+// pick the alternate bucket. Much cheaper than hashing f again, and just as good for this purpose
+// since f is already a uniformly random hash.
+func mixFingerprint(f fingerprint) uint64 {
+	x := uint64(f) * 0x9E3779B97F4A7C15
+	return x ^ (x >> 29)
+}
+
+// Returns true if the filter's buckets are directly encoded even though they could be packed, as
+// they are for filters from NewRawByteAligned. See flagDirect.
+func (fl *Filter) directPackable() bool {
+	return !fl.bucketEncoding.isPacked && bucketEncodingFor(fl.f, fl.b).isPacked
+}
+
+// Returns the most compact encoding available for buckets of b f-bit fingerprints.
+func bucketEncodingFor(f, b int) bucketEncoding {
+	if f >= 4 && (b == 4 || b == 8) {
+		return packedEncoding(f, b)
+	}
+	return directEncoding(f, b)
+}
+
+// How a filter's buckets are encoded as bits: either packed (see packedBucketEncoding) or directly
+// (see directBucketEncoding). This is a struct that switches between the two rather than an
+// interface, because every operation encodes or decodes buckets, and a switch lets the compiler
+// inline the dispatch and call the right method directly where an interface can't.
+type bucketEncoding struct {
+	isPacked bool
+	// Only used when isPacked.
+	packed packedBucketEncoding
+	// Only used when !isPacked.
+	direct directBucketEncoding
+	// The size of an encoded bucket in bits, which is more expensive to compute for packed.
+	bits uint64
+}
+
+func packedEncoding(f, b int) bucketEncoding {
+	e := packedBucketEncoding{f: f, b: b}
+	return bucketEncoding{isPacked: true, packed: e, bits: e.size()}
+}
+
+func directEncoding(f, b int) bucketEncoding {
+	e := newDirectBucketEncoding(f, b)
+	return bucketEncoding{direct: e, bits: e.size()}
+}
+
+func (e *bucketEncoding) encode(b bucket) uint64 {
+	if e.isPacked {
+		return e.packed.encode(b)
+	}
+	return e.direct.encode(b)
 }
 
-func (fl *Filter) hashFingerprint(x fingerprint) uint64 {
-	h := fnv.New64a()
-	_, _ = h.Write([]byte{byte(x >> 8), byte(x)})
-	return h.Sum64()
+func (e *bucketEncoding) decode(x uint64) bucket {
+	if e.isPacked {
+		return e.packed.decode(x)
+	}
+	return e.direct.decode(x)
+}
+
+func (e bucketEncoding) size() uint64 {
+	return e.bits
 }
 
-type bucketEncoding interface {
-	encode(b bucket) uint64
-	decode(x uint64) bucket
-	size() uint64
+// Returns true if the bucket encoded as x contains f, without decoding it where possible. f may be
+// 0 to look for an empty entry.
+func (e *bucketEncoding) contains(x uint64, f fingerprint) bool {
+	if e.isPacked {
+		return e.packed.contains(x, f)
+	}
+	return e.direct.contains(x, f)
 }
 
 // Direct encoding of buckets. Just appends each of the fingerprints to each other to make a
@@ -285,6 +1352,19 @@ type bucketEncoding interface {
 type directBucketEncoding struct {
 	f int
 	b int
+	// The lowest and highest bit of each of the b f-bit fields, for matching all of the entries at
+	// once in contains and hasEmpty.
+	lows  uint64
+	highs uint64
+}
+
+func newDirectBucketEncoding(f, b int) directBucketEncoding {
+	e := directBucketEncoding{f: f, b: b}
+	for i := 0; i < b; i++ {
+		e.lows |= 1 << uint(i*f)
+	}
+	e.highs = e.lows << uint(f-1)
+	return e
 }
 
 func (e directBucketEncoding) encode(b bucket) uint64 {
@@ -307,49 +1387,111 @@ func (e directBucketEncoding) size() uint64 {
 	return uint64(e.f * e.b)
 }
 
+// Returns true if the bucket encoded as x contains f, the same as decode(x).contains(f) but without
+// building the bucket. XORing with f copied into every field zeroes exactly the fields equal to f.
+func (e directBucketEncoding) contains(x uint64, f fingerprint) bool {
+	return e.hasZeroField(x ^ (uint64(f) * e.lows))
+}
+
+// Returns true if the bucket encoded as x has an empty entry, the same as decode(x).hasEmpty().
+func (e directBucketEncoding) hasEmpty(x uint64) bool {
+	return e.hasZeroField(x)
+}
+
+// Returns true if any of the b f-bit fields of v are zero, without looping over them. Adding
+// all-but-the-high-bit to each field's low bits carries into its high bit exactly when those low
+// bits are non-zero, and never into the next field. A field is zero when neither that nor its own
+// high bit is set.
+func (e directBucketEncoding) hasZeroField(v uint64) bool {
+	low := e.highs - e.lows
+	y := (v & low) + low
+	return ^(y|v|low)&e.highs != 0
+}
+
 // Packed encoding of buckets.
 //
 // Uses the technique from https://www.cs.cmu.edu/~dga/papers/cuckoo-conext2014.pdf section 5.2 to
-// save one bit per fingerprint in buckets of size 4.
+// save one bit per fingerprint in buckets of size 4, and extends it to buckets of size 8, where it
+// saves 13 bits per bucket.
 //
-// As such, only works with buckets of size 4 and fingerprints of size >=4.
-type packedBucketEncoding struct{ f int }
+// As such, only works with buckets of size 4 or 8 and fingerprints of size >=4. Buckets of size 2
+// can't save anything this way: there are (2^k+1 choose 2) unordered pairs of k-bit values, which is
+// more than 2^(2k-1), so no k makes the sorted pair fit in fewer bits than the pair as it is.
+type packedBucketEncoding struct{ f, b int }
 
 func (e packedBucketEncoding) encode(b bucket) uint64 {
 	// The order of items isn't meaningful. And because the order doesn't matter, there are only
-	// 3,876 possible buckets, which is encodable in 12 bits.
+	// 3,876 possible sets of low nibbles for 4 entries, which is encodable in 12 bits, and 490,314
+	// for 8 entries, encodable in 19.
 	e.sortBucketByLower4(&b)
 
 	result := uint64(0)
-	packed := uint16(0)
-	for i := 0; i < 4; i++ {
-		packed |= uint16(b.entries[i]&0xF) << uint(12-4*i)
+	var nibbles [8]int
+	for i := 0; i < e.b; i++ {
+		nibbles[i] = int(b.entries[i] & 0xF)
 		result |= (uint64(b.entries[i]) >> 4) << uint((e.f-4)*i)
 	}
-	size := e.size()
-	result |= uint64(lookupFingerprintsToBits[packed]) << uint(size-12)
+	result |= rankNibbles(nibbles[:e.b]) << uint(e.size()-e.rankBits())
 	return result
 }
 
 func (e packedBucketEncoding) decode(x uint64) bucket {
-	size := e.size()
 	var b bucket
-	b.l = 4
-	packed := lookupBitsToFingerprints[uint16(x>>uint(size-12))]
+	b.l = e.b
+	var nibbles [8]int
+	unrankNibbles(x>>uint(e.size()-e.rankBits()), nibbles[:e.b])
 	mask := (uint64(1) << uint(e.f-4)) - 1
-	for i := 0; i < 4; i++ {
-		b.entries[i] = fingerprint((uint64(packed) >> uint(12-4*i)) & 0xf)
+	for i := 0; i < e.b; i++ {
+		b.entries[i] = fingerprint(nibbles[i])
 		b.entries[i] |= fingerprint((x >> uint((e.f-4)*i) & mask) << 4)
 	}
 	return b
 }
 
+// Returns true if the bucket encoded as x has an entry equal to f, which may be 0 to look for an
+// empty entry. The high bits of each entry are stored as they are, so those are compared first and
+// the low nibbles are only unranked if one of them matches, which is rare when f isn't there.
+func (e packedBucketEncoding) contains(x uint64, f fingerprint) bool {
+	hb := uint(e.f - 4)
+	mask := (uint64(1) << hb) - 1
+	hi := uint64(f) >> 4
+	var match [8]bool
+	any := false
+	for i := 0; i < e.b; i++ {
+		match[i] = (x>>(hb*uint(i)))&mask == hi
+		any = any || match[i]
+	}
+	if !any {
+		return false
+	}
+	var nibbles [8]int
+	unrankNibbles(x>>uint(e.size()-e.rankBits()), nibbles[:e.b])
+	for i := 0; i < e.b; i++ {
+		if match[i] && nibbles[i] == int(f&0xF) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if x is the encoding of some bucket. Not every value of the rank's bits is used, for
+// example only 3,876 of the 4,096 12-bit values.
+func (e packedBucketEncoding) valid(x uint64) bool {
+	return x>>uint(e.size()-e.rankBits()) < nibbleSeqs[e.b][0]
+}
+
 func (e packedBucketEncoding) size() uint64 {
-	return uint64(12 + (e.f-4)*4)
+	return e.rankBits() + uint64((e.f-4)*e.b)
+}
+
+// Returns the number of bits needed for the rank of the bucket's low nibbles: 12 for 4 entries and
+// 19 for 8.
+func (e packedBucketEncoding) rankBits() uint64 {
+	return uint64(bits.Len64(nibbleSeqs[e.b][0] - 1))
 }
 
 func (e packedBucketEncoding) sortBucketByLower4(b *bucket) {
-	for i := 3; i >= 0; i-- {
+	for i := e.b - 1; i >= 0; i-- {
 		for j := 0; j < i; j++ {
 			if (b.entries[j] & 0xF) > (b.entries[j+1] & 0xF) {
 				b.entries[j], b.entries[j+1] = b.entries[j+1], b.entries[j]
@@ -358,6 +1500,53 @@ func (e packedBucketEncoding) sortBucketByLower4(b *bucket) {
 	}
 }
 
+// nibbleSeqs[k][v] is the number of nondecreasing sequences of k nibbles that are all at least v,
+// which is (15-v+k) choose k.
+var nibbleSeqs = func() (t [9][17]uint64) {
+	for v := range t[0] {
+		t[0][v] = 1
+	}
+	for k := 1; k < len(t); k++ {
+		// Sequences starting with v, plus those starting with something larger.
+		for v := 15; v >= 0; v-- {
+			t[k][v] = t[k-1][v] + t[k][v+1]
+		}
+	}
+	return t
+}()
+
+// Returns the position of nibbles, which must be sorted, among all sorted sequences of as many
+// nibbles in lexicographic order. This is the same order as the 16-bit numbers made by writing the
+// nibbles out from most to least significant. Serialized filters depend on this order, so it must
+// not change.
+func rankNibbles(nibbles []int) uint64 {
+	k := len(nibbles)
+	r := uint64(0)
+	prev := 0
+	for i, v := range nibbles {
+		// Every sequence that agrees up to i but has a smaller nibble at i comes first.
+		r += nibbleSeqs[k-i][prev] - nibbleSeqs[k-i][v]
+		prev = v
+	}
+	return r
+}
+
+// The inverse of rankNibbles: fills nibbles with the sorted sequence at position r.
+func unrankNibbles(r uint64, nibbles []int) {
+	k := len(nibbles)
+	prev := 0
+	for i := range nibbles {
+		base := nibbleSeqs[k-i][prev]
+		v := prev
+		for v < 15 && base-nibbleSeqs[k-i][v+1] <= r {
+			v++
+		}
+		r -= base - nibbleSeqs[k-i][v]
+		nibbles[i] = v
+		prev = v
+	}
+}
+
 // A fingerprint of an element. 0 means 'none'.
 type fingerprint uint16
 type bucket struct {
@@ -391,6 +1580,7 @@ func (b *bucket) delete(f fingerprint) {
 	for i := 0; i < b.l; i++ {
 		if f == b.entries[i] {
 			b.entries[i] = 0
+			return
 		}
 	}
 }
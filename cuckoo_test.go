@@ -1,8 +1,13 @@
 package cuckoo
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/bradenaw/trand"
@@ -19,8 +24,49 @@ func TestBucketSort(t *testing.T) {
 	require.Equal(t, bucket{l: 4, entries: [8]fingerprint{0x0F, 0x1A, 0x2C, 0x35}}, b)
 }
 
+func TestDeleteDuplicate(t *testing.T) {
+	// Both copies land in the same bucket, and each Delete removes only one of them.
+	fl := New(100, 0.01)
+	fl.Add([]byte("a"))
+	fl.Add([]byte("a"))
+	fl.Delete([]byte("a"))
+	require.Equal(t, Maybe, fl.Contains([]byte("a")))
+	fl.Delete([]byte("a"))
+	require.Equal(t, No, fl.Contains([]byte("a")))
+}
+
+func TestBucketDelete(t *testing.T) {
+	b := bucket{l: 4, entries: [8]fingerprint{0x3, 0x5, 0x3, 0x0}}
+	b.delete(0x3)
+	require.True(t, b.contains(0x3))
+	b.delete(0x3)
+	require.False(t, b.contains(0x3))
+	require.True(t, b.contains(0x5))
+}
+
+func TestRankNibbles(t *testing.T) {
+	prev := -1
+	for r := uint64(0); r < nibbleSeqs[4][0]; r++ {
+		var n [4]int
+		unrankNibbles(r, n[:])
+		require.True(t, sort.IntsAreSorted(n[:]), "%d: %v", r, n)
+		require.Equal(t, r, rankNibbles(n[:]))
+		// Ranks are in the order of the nibbles written out as a 16-bit number.
+		packed := n[0]<<12 | n[1]<<8 | n[2]<<4 | n[3]
+		require.Greater(t, packed, prev)
+		prev = packed
+	}
+	require.Equal(t, uint64(3876), nibbleSeqs[4][0])
+	require.Equal(t, uint64(0x800), rankNibbles([]int{0x2, 0xd, 0xd, 0xf}))
+	require.Equal(t, uint64(0xabc), rankNibbles([]int{0x4, 0x7, 0xc, 0xf}))
+	require.Equal(t, uint64(0xf23), rankNibbles([]int{0xf, 0xf, 0xf, 0xf}))
+}
+
 func TestBucketEncode(t *testing.T) {
-	check := func(enc bucketEncoding, b bucket) {
+	check := func(enc interface {
+		encode(b bucket) uint64
+		decode(x uint64) bucket
+	}, b bucket) {
 		bits := enc.encode(b)
 		b2 := enc.decode(bits)
 		b.sort()
@@ -28,12 +74,16 @@ func TestBucketEncode(t *testing.T) {
 		require.Equal(t, b, b2)
 	}
 
-	check(packedBucketEncoding{f: 4}, bucket{l: 4, entries: [8]fingerprint{0x0, 0x0, 0x0, 0x0}})
-	check(packedBucketEncoding{f: 4}, bucket{l: 4, entries: [8]fingerprint{0xA, 0x0, 0x0, 0x0}})
-	check(packedBucketEncoding{f: 4}, bucket{l: 4, entries: [8]fingerprint{0x0, 0xF, 0x1, 0xA}})
-	check(packedBucketEncoding{f: 5}, bucket{l: 4, entries: [8]fingerprint{0x1C, 0x0F, 0x15, 0x1A}})
-	check(packedBucketEncoding{f: 6}, bucket{l: 4, entries: [8]fingerprint{0x2C, 0x0F, 0x35, 0x1A}})
-	check(packedBucketEncoding{f: 8}, bucket{l: 4, entries: [8]fingerprint{0x8C, 0x7D, 0x38, 0x44}})
+	check(packedBucketEncoding{f: 4, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x0, 0x0, 0x0, 0x0}})
+	check(packedBucketEncoding{f: 4, b: 4}, bucket{l: 4, entries: [8]fingerprint{0xA, 0x0, 0x0, 0x0}})
+	check(packedBucketEncoding{f: 4, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x0, 0xF, 0x1, 0xA}})
+	check(packedBucketEncoding{f: 5, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x1C, 0x0F, 0x15, 0x1A}})
+	check(packedBucketEncoding{f: 6, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x2C, 0x0F, 0x35, 0x1A}})
+	check(packedBucketEncoding{f: 8, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x8C, 0x7D, 0x38, 0x44}})
+	check(packedBucketEncoding{f: 4, b: 8}, bucket{l: 8, entries: [8]fingerprint{0xF, 0, 0x3, 0x3, 0, 0xA, 0x1, 0}})
+	check(packedBucketEncoding{f: 8, b: 8},
+		bucket{l: 8, entries: [8]fingerprint{0x8C, 0x7D, 0x38, 0x44, 0xFF, 0x01, 0x7D, 0x10}})
+	require.Equal(t, uint64(19+4*8), packedBucketEncoding{f: 8, b: 8}.size())
 
 	check(directBucketEncoding{f: 2, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x0, 0x0, 0x0, 0x0}})
 	check(directBucketEncoding{f: 2, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x3, 0x0, 0x0, 0x0}})
@@ -46,6 +96,51 @@ func TestBucketEncode(t *testing.T) {
 	check(directBucketEncoding{f: 8, b: 4}, bucket{l: 4, entries: [8]fingerprint{0x8C, 0x7D, 0x38, 0x44}})
 }
 
+func TestDirectBucketMatch(t *testing.T) {
+	trand.RandomN(t, 500, func(t *testing.T, r *rand.Rand) {
+		f := r.Intn(15) + 2
+		b := r.Intn(8) + 1
+		if f*b > 64 {
+			b = 64 / f
+		}
+		enc := newDirectBucketEncoding(f, b)
+		bkt := bucket{l: b}
+		for i := 0; i < b; i++ {
+			// Leave some slots empty and repeat some fingerprints.
+			if r.Intn(3) > 0 {
+				bkt.entries[i] = fingerprint(r.Intn(1<<f-1) + 1)
+			}
+		}
+		x := enc.encode(bkt)
+		for fp := fingerprint(1); fp < 1<<f && fp < 1024; fp++ {
+			require.Equal(t, bkt.contains(fp), enc.contains(x, fp), "f=%d b=%d %x", f, b, fp)
+		}
+		require.Equal(t, bkt.hasEmpty(), enc.hasEmpty(x), "f=%d b=%d", f, b)
+	})
+}
+
+func TestPackedBucketMatch(t *testing.T) {
+	trand.RandomN(t, 500, func(t *testing.T, r *rand.Rand) {
+		f, b := r.Intn(13)+4, 4
+		if r.Intn(2) == 0 {
+			f, b = r.Intn(5)+4, 8
+		}
+		enc := packedBucketEncoding{f: f, b: b}
+		bkt := bucket{l: b}
+		for i := 0; i < b; i++ {
+			if r.Intn(3) > 0 {
+				bkt.entries[i] = fingerprint(r.Intn(1<<f-1) + 1)
+			}
+		}
+		x := enc.encode(bkt)
+		for fp := fingerprint(1); fp < 1<<f && fp < 1024; fp++ {
+			require.Equal(t, bkt.contains(fp), enc.contains(x, fp), "f=%d b=%d %x", f, b, fp)
+		}
+		require.Equal(t, bkt.hasEmpty(), enc.contains(x, 0), "f=%d b=%d", f, b)
+		require.True(t, enc.valid(x))
+	})
+}
+
 func TestBasic(t *testing.T) {
 	f := NewRaw(4, 4, 7)
 	key := []byte{0x51}
@@ -129,9 +224,692 @@ func testRandom(t *testing.T, r *rand.Rand, n int, fl *Filter) {
 		require.Equal(t, Maybe, fl.Contains(items[i]), "item %d broken", i)
 	}
 
-	fl.check()
+	require.NoError(t, fl.CheckInvariants())
 
 	for i := range items {
 		require.Equal(t, Maybe, fl.Contains(items[i]), "item %s missing", hex.EncodeToString(items[i]))
 	}
 }
+
+func TestTestAndAdd(t *testing.T) {
+	fl := New(100, 0.001)
+	require.Equal(t, No, fl.TestAndAdd([]byte("a")))
+	require.Equal(t, Maybe, fl.TestAndAdd([]byte("a")))
+	require.Equal(t, 2, fl.Count())
+	fl.Delete([]byte("a"))
+	require.Equal(t, Maybe, fl.Contains([]byte("a")))
+	fl.Delete([]byte("a"))
+	require.Equal(t, No, fl.Contains([]byte("a")))
+}
+
+func TestInsertFull(t *testing.T) {
+	fl := NewRaw(8, 2, 16)
+	var inserted [][]byte
+	var failed []byte
+	for i := 0; failed == nil; i++ {
+		x := []byte(fmt.Sprintf("item-%d", i))
+		err := fl.Insert(x)
+		if err != nil {
+			failed = x
+		} else {
+			inserted = append(inserted, x)
+		}
+	}
+
+	// The failed insert didn't lose anything or overflow the filter.
+	require.False(t, fl.Overflowed())
+	require.Equal(t, len(inserted), fl.Count())
+	for _, x := range inserted {
+		require.Equal(t, Maybe, fl.Contains(x))
+	}
+	require.NoError(t, fl.CheckInvariants())
+
+	for _, x := range inserted {
+		fl.Delete(x)
+	}
+	require.NoError(t, fl.Insert(failed))
+	require.Equal(t, Maybe, fl.Contains(failed))
+}
+
+func TestTryDelete(t *testing.T) {
+	fl := New(100, 0.0001)
+	require.False(t, fl.TryDelete([]byte("a")))
+	require.Equal(t, 0, fl.Count())
+
+	fl.Add([]byte("a"))
+	require.True(t, fl.TryDelete([]byte("a")))
+	require.Equal(t, No, fl.Contains([]byte("a")))
+	require.False(t, fl.TryDelete([]byte("a")))
+	require.Equal(t, 0, fl.Count())
+}
+
+func TestTryNew(t *testing.T) {
+	fl, err := TryNew(1000, 0.01)
+	require.NoError(t, err)
+	require.Equal(t, New(1000, 0.01).SizeBytes(), fl.SizeBytes())
+
+	for _, c := range []struct {
+		n  int
+		fp float64
+	}{{-1, 0.01}, {100, 0}, {100, 1}, {100, -0.5}, {100, math.NaN()}} {
+		_, err := TryNew(c.n, c.fp)
+		require.Error(t, err, "n=%d fp=%v", c.n, c.fp)
+	}
+
+	_, err = TryNewRaw(4, 4, 100)
+	require.NoError(t, err)
+	for _, c := range [][3]int{{1, 4, 100}, {17, 4, 100}, {8, 0, 100}, {8, 9, 100}, {16, 8, 100}, {8, 4, -1}, {8, 4, math.MaxInt}} {
+		_, err := TryNewRaw(c[0], c[1], c[2])
+		require.Error(t, err, "%v", c)
+		require.Panics(t, func() { NewRaw(c[0], c[1], c[2]) })
+	}
+}
+
+func TestReset(t *testing.T) {
+	fl := NewRaw(8, 4, 16)
+	for i := 0; !fl.Overflowed(); i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	size := fl.SizeBytes()
+
+	fl.Reset()
+	require.False(t, fl.Overflowed())
+	require.Equal(t, 0, fl.Count())
+	require.Equal(t, size, fl.SizeBytes())
+	require.Equal(t, No, fl.Contains([]byte("item-0")))
+	require.NoError(t, fl.CheckInvariants())
+
+	fl.Add([]byte("a"))
+	require.Equal(t, Maybe, fl.Contains([]byte("a")))
+}
+
+func TestClone(t *testing.T) {
+	fl := New(100, 0.001)
+	for i := 0; i < 50; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	c := fl.Clone()
+	require.Equal(t, fl.Count(), c.Count())
+	for i := 0; i < 50; i++ {
+		require.Equal(t, Maybe, c.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+
+	// Changes to one don't show up in the other.
+	c.Add([]byte("a"))
+	fl.Delete([]byte("item-0"))
+	require.Equal(t, No, fl.Contains([]byte("a")))
+	require.Equal(t, Maybe, c.Contains([]byte("item-0")))
+	require.Equal(t, 49, fl.Count())
+	require.Equal(t, 51, c.Count())
+	require.NoError(t, c.CheckInvariants())
+}
+
+func TestEqual(t *testing.T) {
+	a := NewRaw(8, 4, 64)
+	b := NewRaw(8, 4, 64)
+	require.True(t, a.Equal(b))
+
+	// Same items in a different order, few enough that none of them have to go in their alternate
+	// bucket.
+	for i := 0; i < 40; i++ {
+		a.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	for i := 39; i >= 0; i-- {
+		b.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.True(t, a.Equal(b))
+	require.True(t, a.Equal(a.Clone()))
+
+	b.Delete([]byte("item-0"))
+	require.False(t, a.Equal(b))
+	b.Add([]byte("item-0"))
+	require.True(t, a.Equal(b))
+
+	require.False(t, a.Equal(NewRaw(8, 4, 128)))
+	require.False(t, a.Equal(NewRaw(8, 2, 64)))
+}
+
+func TestEstimatedFalsePositiveRate(t *testing.T) {
+	const n = 100000
+	fl := New(n, 0.01)
+	require.Equal(t, 0.0, fl.EstimatedFalsePositiveRate())
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		fl.Add(binary.LittleEndian.AppendUint64(nil, r.Uint64()))
+	}
+	estimate := fl.EstimatedFalsePositiveRate()
+	require.Less(t, estimate, 0.01)
+
+	falsePositives := 0
+	for i := 0; i < 10*n; i++ {
+		if fl.Contains(binary.LittleEndian.AppendUint64(nil, r.Uint64())) == Maybe {
+			falsePositives++
+		}
+	}
+	actual := float64(falsePositives) / (10 * n)
+	require.InDelta(t, estimate, actual, estimate*0.1, "estimate %f, actual %f", estimate, actual)
+}
+
+func TestSetRand(t *testing.T) {
+	build := func() *Filter {
+		fl := NewRaw(8, 4, 64)
+		fl.SetRand(rand.New(rand.NewSource(1)))
+		for i := 0; i < 240; i++ {
+			fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+		}
+		return fl
+	}
+	a := build()
+	b := build()
+	require.False(t, a.Overflowed())
+	for i := uint64(0); i < a.nBuckets(); i++ {
+		require.Equal(t, a.loadBits(i), b.loadBits(i))
+	}
+
+	// The filter's own generator is just as reproducible, given a seed.
+	c, d := NewRaw(8, 4, 64), NewRaw(8, 4, 64)
+	c.SetSeed(5)
+	d.SetSeed(5)
+	for i := 0; i < 240; i++ {
+		c.Add([]byte(fmt.Sprintf("item-%d", i)))
+		d.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.False(t, c.Overflowed())
+	for i := uint64(0); i < c.nBuckets(); i++ {
+		require.Equal(t, c.loadBits(i), d.loadBits(i))
+	}
+	// Unseeded filters start their generators from different states.
+	require.NotEqual(t, NewRaw(8, 4, 64).rngState, NewRaw(8, 4, 64).rngState)
+}
+
+func TestMayContain(t *testing.T) {
+	fl := New(100, 0.0001)
+	fl.Add([]byte("a"))
+	require.True(t, fl.MayContain([]byte("a")))
+	require.False(t, fl.MayContain([]byte("b")))
+	require.True(t, Maybe.IsMaybe())
+	require.False(t, No.IsMaybe())
+}
+
+func TestHashMethods(t *testing.T) {
+	fl := New(1000, 0.0001)
+	r := rand.New(rand.NewSource(0))
+	hashes := make([]uint64, 500)
+	for i := range hashes {
+		hashes[i] = r.Uint64()
+		fl.AddHash(hashes[i])
+	}
+	for _, h := range hashes {
+		require.Equal(t, Maybe, fl.ContainsHash(h))
+	}
+	// Using the filter's own hash of an item is the same as using the item.
+	x := []byte("a")
+	fl.AddHash(fl.hashItem(x))
+	require.Equal(t, Maybe, fl.Contains(x))
+
+	for _, h := range hashes {
+		fl.DeleteHash(h)
+	}
+	fl.Delete(x)
+	require.Equal(t, 0, fl.Count())
+	require.Panics(t, func() { fl.DeleteHash(hashes[0]) })
+}
+
+func TestFailedDeleteLeavesFilter(t *testing.T) {
+	fl := New(100, 0.0001)
+	fl.Add([]byte("a"))
+	var log bytes.Buffer
+	fl.SetLog(&log)
+	logged := log.Len()
+	fired := false
+	fl.OnLoad(0.001, func(float64) { fired = true })
+
+	require.Panics(t, func() { fl.Delete([]byte("b")) })
+	require.False(t, fl.TryDelete([]byte("b")))
+	require.Equal(t, 1, fl.Count())
+	require.NoError(t, fl.CheckInvariants())
+	require.Equal(t, logged, log.Len())
+	require.False(t, fired)
+}
+
+func TestAddIfNotContains(t *testing.T) {
+	fl := New(100, 0.0001)
+	require.True(t, fl.AddIfNotContains([]byte("a")))
+	require.False(t, fl.AddIfNotContains([]byte("a")))
+	require.Equal(t, 1, fl.Count())
+}
+
+func TestParams(t *testing.T) {
+	for _, c := range []struct {
+		n  int
+		fp float64
+	}{{0, 0.01}, {1000, 0.01}, {1000000, 0.001}, {123456, 0.2}} {
+		p := Params(c.n, c.fp)
+		fl := New(c.n, c.fp)
+		require.Equal(t, fl.f, p.F)
+		require.Equal(t, fl.b, p.B)
+		require.Equal(t, int(fl.nBuckets()), p.Buckets)
+		require.Equal(t, fl.SizeBytes(), p.SizeBytes)
+		require.LessOrEqual(t, p.FalsePositiveRate, c.fp)
+	}
+}
+
+func TestEstimateSizeBytes(t *testing.T) {
+	require.Equal(t, New(100000, 0.01).SizeBytes(), EstimateSizeBytes(100000, 0.01))
+	// Far too big to actually build here: 2^31 buckets of four 16-bit fingerprints, packed into 60
+	// bits each. That many items don't fit in an int on 32-bit platforms.
+	if n := int64(5000000000); n <= int64(maxInt) {
+		require.Equal(t, uint64(1<<31)*60/8, EstimateSizeBytes(int(n), 0.0001))
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	_, err := TryNewRaw(1, 4, 100)
+	require.ErrorIs(t, err, ErrInvalidParams)
+	_, err = TryNew(100, 2)
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	// Copies of one item can only go in its two single-entry candidate buckets.
+	fl := NewRaw(8, 1, 16)
+	_ = fl.Insert([]byte("a"))
+	_ = fl.Insert([]byte("a"))
+	require.ErrorIs(t, fl.Insert([]byte("a")), ErrOverflowed)
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			require.ErrorIs(t, err, ErrNotInserted)
+		}()
+		New(100, 0.0001).Delete([]byte("a"))
+	}()
+}
+
+func TestFilterString(t *testing.T) {
+	fl := NewRaw(8, 4, 100)
+	for i := 0; i < 64; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.Equal(
+		t,
+		"cuckoo.Filter{f=8, b=4, buckets=128, count=64, load=12.5%, overflowed=false}",
+		fl.String(),
+	)
+}
+
+func TestCheckInvariants(t *testing.T) {
+	fl := NewRaw(8, 4, 16)
+	fl.Add([]byte("a"))
+	require.NoError(t, fl.CheckInvariants())
+
+	fl.count++
+	require.Error(t, fl.CheckInvariants())
+	fl.count--
+
+	// Lookup table indexes past the end of the table don't decode to anything.
+	fl.storeBits(0, uint64(0xFFF)<<(fl.bucketEncoding.size()-12))
+	require.Error(t, fl.CheckInvariants())
+}
+
+func TestBuckets(t *testing.T) {
+	fl := NewRaw(8, 4, 16)
+	for i := 0; i < 40; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	f, i1, i2 := fl.itemToIdxs([]byte("item-0"))
+	n := uint64(0)
+	occupied := 0
+	found := false
+	fl.Buckets()(func(b BucketInfo) bool {
+		require.Equal(t, n, b.Index)
+		require.Len(t, b.Slots, 4)
+		n++
+		occupied += b.Occupied
+		if b.Index == i1 || b.Index == i2 {
+			for _, slot := range b.Slots {
+				found = found || slot == uint16(f)
+			}
+		}
+		return true
+	})
+	require.Equal(t, fl.nBuckets(), n)
+	require.Equal(t, 40, occupied)
+	require.True(t, found)
+
+	// Stops early.
+	n = 0
+	fl.Buckets()(func(b BucketInfo) bool {
+		n++
+		return n < 3
+	})
+	require.Equal(t, uint64(3), n)
+}
+
+func TestOnOverflow(t *testing.T) {
+	fl := NewRaw(8, 2, 16)
+	var events []OverflowEvent
+	fl.OnOverflow(func(e OverflowEvent) {
+		events = append(events, e)
+	})
+	n := 0
+	for ; !fl.Overflowed(); n++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", n)))
+	}
+	// Adds after overflowing don't report again.
+	fl.Add([]byte("a"))
+
+	require.Len(t, events, 1)
+	f, _, _ := fl.itemToIdxs([]byte(fmt.Sprintf("item-%d", n-1)))
+	require.Equal(t, uint16(f), events[0].Fingerprint)
+	require.Equal(t, n, events[0].Count)
+	require.InDelta(t, float64(n)/float64(fl.nBuckets()*2), events[0].Load, 1e-9)
+}
+
+func TestOnLoad(t *testing.T) {
+	fl := NewRaw(8, 4, 16)
+	var fired []float64
+	fl.OnLoad(0.5, func(load float64) {
+		fired = append(fired, load)
+	})
+
+	// 32 buckets of 4 entries, so half full at 64 items.
+	for i := 0; i < 63; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.Empty(t, fired)
+	fl.Add([]byte("item-63"))
+	require.Equal(t, []float64{0.5}, fired)
+	fl.Add([]byte("item-64"))
+	require.Len(t, fired, 1)
+
+	// Dropping back under rearms it.
+	fl.Delete([]byte("item-64"))
+	fl.Delete([]byte("item-63"))
+	fl.Add([]byte("item-63"))
+	require.Len(t, fired, 2)
+
+	fl.Reset()
+	for i := 0; i < 64; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.Len(t, fired, 3)
+}
+
+func TestSetHasher(t *testing.T) {
+	calls := 0
+	h := HasherFunc(func(x []byte) uint64 {
+		calls++
+		return metroHash64(x, 1)
+	})
+	fl := New(1000, 0.001)
+	fl.SetHasher(h)
+	for i := 0; i < 500; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.Equal(t, 500, calls)
+	for i := 0; i < 500; i++ {
+		require.Equal(t, Maybe, fl.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+	require.NoError(t, fl.CheckInvariants())
+	require.Panics(t, func() { fl.SetHasher(nil) })
+
+	// The Hasher has to be supplied again after decoding.
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Filter
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Panics(t, func() { decoded.Contains([]byte("item-0")) })
+	decoded.SetHasher(h)
+	require.True(t, fl.Equal(&decoded))
+	for i := 0; i < 500; i++ {
+		require.Equal(t, Maybe, decoded.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+}
+
+func TestSetSeed(t *testing.T) {
+	const n = 1000
+	a := New(n, 0.01)
+	b := New(n, 0.01)
+	a.SetSeed(1)
+	b.SetSeed(2)
+	for i := 0; i < n; i++ {
+		a.Add([]byte(fmt.Sprintf("item-%d", i)))
+		b.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.Panics(t, func() { a.SetSeed(3) })
+	require.Panics(t, func() { a.SetHasher(HasherFunc(func(x []byte) uint64 { return 0 })) })
+
+	// Differently seeded filters of the same items rarely agree on false positives.
+	fpA, fpBoth := 0, 0
+	for i := n; i < 100*n; i++ {
+		x := []byte(fmt.Sprintf("item-%d", i))
+		if a.Contains(x) == Maybe {
+			fpA++
+			if b.Contains(x) == Maybe {
+				fpBoth++
+			}
+		}
+	}
+	require.Greater(t, fpA, 100)
+	require.Less(t, fpBoth, fpA/10)
+
+	// The seed survives encoding.
+	data, err := a.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Filter
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, uint64(1), decoded.Seed())
+	require.True(t, a.Equal(&decoded))
+	require.False(t, a.Equal(b))
+	data, err = a.ToProto()
+	require.NoError(t, err)
+	fromProto, err := FromProto(data)
+	require.NoError(t, err)
+	require.True(t, a.Equal(fromProto))
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, decoded.Contains([]byte(fmt.Sprintf("item-%d", i))))
+		require.Equal(t, Maybe, fromProto.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+}
+
+func TestOtherIdx(t *testing.T) {
+	fl := NewRaw(16, 4, 1<<12)
+	offsets := make(map[uint64]struct{})
+	for f := fingerprint(1); f < 1<<12; f++ {
+		i1 := uint64(f) * 7 % fl.nBuckets()
+		i2 := fl.otherIdx(f, i1)
+		require.Equal(t, i1, fl.otherIdx(f, i2))
+		offsets[i1^i2] = struct{}{}
+	}
+	// Fingerprints should send items to alternate buckets all over the filter, not just a few
+	// offsets away.
+	require.Greater(t, len(offsets), 1<<11)
+}
+
+func TestUse128BitHash(t *testing.T) {
+	const n = 10000
+	fl := New(n, 0.01)
+	fl.Use128BitHash()
+	fl.SetSeed(5)
+	for i := 0; i < n; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.Panics(t, func() { fl.Use128BitHash() })
+	require.NoError(t, fl.CheckInvariants())
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, fl.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+	fp := 0
+	for i := n; i < 11*n; i++ {
+		if fl.Contains([]byte(fmt.Sprintf("item-%d", i))) == Maybe {
+			fp++
+		}
+	}
+	require.Less(t, float64(fp)/(10*n), 0.01)
+
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Filter
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.True(t, fl.Equal(&decoded))
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, decoded.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+}
+
+func TestHashToFingerprint(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, f := range []int{2, 3, 8} {
+		fl := NewRaw(f, 4, 16)
+		const perValue = 2000
+		counts := make([]int, 1<<f)
+		for i := 0; i < perValue*(1<<f-1); i++ {
+			counts[fl.hashToFingerprint(r.Uint64())]++
+		}
+		require.Zero(t, counts[0])
+		for v := 1; v < len(counts); v++ {
+			require.InDelta(t, perValue, counts[v], perValue*0.15, "f=%d fingerprint %d", f, v)
+		}
+	}
+
+	// Every fingerprint is reachable, including the largest.
+	fl := NewRaw(4, 4, 16)
+	require.Equal(t, fingerprint(1), fl.hashToFingerprint(0))
+	require.Equal(t, fingerprint(15), fl.hashToFingerprint(^uint64(0)))
+}
+
+func TestNewExact(t *testing.T) {
+	const n = 100000
+	fl := NewExact(n, 0.01)
+	_, _, nBuckets := params(n, 0.01)
+	require.Equal(t, uint64(nBuckets), fl.nBuckets())
+	require.NotZero(t, nBuckets&(nBuckets-1), "test should use a non-power-of-two size")
+	require.Less(t, fl.SizeBytes(), New(n, 0.01).SizeBytes())
+
+	for i := 0; i < n; i++ {
+		fl.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	require.False(t, fl.Overflowed())
+	require.NoError(t, fl.CheckInvariants())
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, fl.Contains([]byte(fmt.Sprintf("item-%d", i))))
+	}
+	fp := 0
+	for i := n; i < 2*n; i++ {
+		if fl.Contains([]byte(fmt.Sprintf("item-%d", i))) == Maybe {
+			fp++
+		}
+	}
+	require.Less(t, float64(fp)/n, 0.01)
+
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Filter
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.True(t, fl.Equal(&decoded))
+
+	// Every item's two buckets are each other's alternates, even in tiny filters.
+	for _, nBuckets := range []int{1, 2, 3, 7, 100} {
+		fl := NewRawExact(8, 4, nBuckets)
+		for i := 0; i < 200; i++ {
+			f, i1, i2 := fl.itemToIdxs([]byte(fmt.Sprintf("item-%d", i)))
+			require.Less(t, i1, fl.nBuckets())
+			require.Less(t, i2, fl.nBuckets())
+			require.Equal(t, i1, fl.otherIdx(f, i2))
+		}
+	}
+}
+
+func TestBreadthFirst(t *testing.T) {
+	for _, b := range []int{2, 4} {
+		fl := NewRaw(12, b, 1<<10)
+		fl.SetInsertStrategy(BreadthFirst)
+		var items [][]byte
+		for i := 0; ; i++ {
+			item := []byte(fmt.Sprintf("item-%d", i))
+			var before *Filter
+			if fl.load() > 0.8 {
+				before = fl.Clone()
+			}
+			if err := fl.Insert(item); err != nil {
+				// A failed search leaves the filter as it was.
+				require.True(t, before.Equal(fl))
+				break
+			}
+			items = append(items, item)
+		}
+		require.NoError(t, fl.CheckInvariants())
+		for _, item := range items {
+			require.Equal(t, Maybe, fl.Contains(item))
+		}
+		// A little past where a random walk typically gives up, about 0.97 and 0.89.
+		if b == 4 {
+			require.Greater(t, fl.load(), 0.975)
+		} else {
+			require.Greater(t, fl.load(), 0.895)
+		}
+	}
+}
+
+func TestNewRawAligned(t *testing.T) {
+	for _, fb := range [][2]int{{8, 4}, {9, 4}, {12, 3}, {16, 4}, {4, 2}} {
+		f, b := fb[0], fb[1]
+		aligned := NewRawAligned(f, b, 500)
+		packed := NewRaw(f, b, 500)
+		aligned.SetRand(rand.New(rand.NewSource(1)))
+		packed.SetRand(rand.New(rand.NewSource(1)))
+		for i := 0; i < 1500; i++ {
+			aligned.Add([]byte(fmt.Sprintf("item-%d", i)))
+			packed.Add([]byte(fmt.Sprintf("item-%d", i)))
+		}
+		require.NoError(t, aligned.CheckInvariants())
+		require.True(t, aligned.Equal(packed))
+		require.GreaterOrEqual(t, aligned.SizeBytes(), packed.SizeBytes())
+		if 64%bucketEncodingFor(f, b).size() == 0 {
+			require.Equal(t, packed.SizeBytes(), aligned.SizeBytes())
+		}
+
+		// Same encoding as NewRaw.
+		a, err := aligned.MarshalBinary()
+		require.NoError(t, err)
+		p, err := packed.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, p, a)
+
+		c := aligned.Clone()
+		require.NotNil(t, c.aligned)
+		require.True(t, c.Equal(aligned))
+	}
+}
+
+func TestNewRawByteAligned(t *testing.T) {
+	for _, c := range []struct{ f, b, wantF int }{{4, 4, 8}, {8, 4, 8}, {12, 4, 16}, {7, 8, 8}, {9, 2, 16}} {
+		fl := NewRawByteAligned(c.f, c.b, 1000)
+		require.Equal(t, c.wantF, fl.f)
+		require.Equal(t, uint64(c.wantF*c.b), fl.bucketEncoding.size())
+		require.Equal(t, c.b == 4 || c.b == 8, fl.directPackable())
+		for i := 0; i < 2*c.b*100; i++ {
+			fl.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		}
+
+		data, err := fl.MarshalBinary()
+		require.NoError(t, err)
+		var fl2 Filter
+		require.NoError(t, fl2.UnmarshalBinary(data))
+		data, err = fl.ToProto()
+		require.NoError(t, err)
+		fl3, err := FromProto(data)
+		require.NoError(t, err)
+		for _, other := range []*Filter{&fl2, fl3, fl.Clone()} {
+			require.Equal(t, fl.bucketEncoding, other.bucketEncoding)
+			require.True(t, fl.Equal(other))
+			require.NoError(t, other.CheckInvariants())
+		}
+		for i := 0; i < 2*c.b*100; i++ {
+			require.Equal(t, Maybe, fl2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+		}
+	}
+	require.Panics(t, func() { NewRawByteAligned(12, 8, 1000) })
+}
@@ -0,0 +1,284 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// A cuckoo filter in which each item can go in any of d buckets rather than two, for d from 2 to 4.
+//
+// With more places to put each item, the table can be filled much further before there's no room
+// left: about 95% for d=2, 98% for d=3, and 99% for d=4 with 4-entry buckets. In exchange, Contains
+// looks through d buckets instead of two, and so compares against more fingerprints, so each needs
+// about log2(d/2) more bits for the same false-positive rate. That's still a saving when memory is
+// what's scarce, and a larger one the more the filter is filled.
+//
+// The table is split into d equal parts, and each item has one candidate bucket in each. An entry's
+// candidates are found from its bucket and fingerprint alone: moving to the next part adds an
+// offset chosen by the fingerprint, and the d offsets sum to zero, so that the d steps lead back
+// around to where they started.
+type DAryFilter struct {
+	entryTable
+	// The number of candidate buckets of each item.
+	d int
+	// The number of buckets in each of the d parts of the table.
+	m uint64
+}
+
+// The most candidate buckets an item can have.
+const maxCandidates = 4
+
+// Returns a new DAryFilter capable of holding n items with an estimated false-positive rate of fp,
+// in which each item has d candidate buckets, in [2, 4].
+func NewDAry(n int, fp float64, d int) *DAryFilter {
+	checkCandidates(d)
+	b := 4
+	f := int(math.Min(math.Max(math.Ceil(math.Log2(float64(d*b)/fp)), 4), 16))
+	loadFactor := [...]float64{2: 0.95, 3: 0.98, 4: 0.99}[d]
+	return NewDAryRaw(f, b, d, int(float64(n)/float64(b)/loadFactor))
+}
+
+// Returns a new DAryFilter constructed using raw parameters. f, b, and n are as for NewRaw, except
+// that it's each of the d parts of the table that has its number of buckets rounded to a power of
+// two. d is the number of candidate buckets of each item, in [2, 4].
+func NewDAryRaw(f, b, d, n int) *DAryFilter {
+	checkCandidates(d)
+	return newDAryFilter(f, b, d, d*rawBuckets(f, b, (n+d-1)/d))
+}
+
+func checkCandidates(d int) {
+	if d < 2 || d > maxCandidates {
+		panic(fmt.Errorf("%w: candidate buckets d=%d must be in [2, %d]", ErrInvalidParams, d,
+			maxCandidates))
+	}
+}
+
+func newDAryFilter(f, b, d, nBuckets int) *DAryFilter {
+	return &DAryFilter{
+		entryTable: newEntryTable(f, b, 0, nBuckets),
+		d:          d,
+		m:          uint64(nBuckets / d),
+	}
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (df *DAryFilter) Add(x []byte) {
+	f, is := df.itemToIdxs(x)
+	df.h.count++
+	if !df.h.overflowed && !df.kick(f, &is, false) {
+		df.h.overflowed = true
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns ErrOverflowed
+// and leaves the filter as it was, rather than overflowing it.
+func (df *DAryFilter) Insert(x []byte) error {
+	f, is := df.itemToIdxs(x)
+	if df.h.overflowed || !df.kick(f, &is, true) {
+		return ErrOverflowed
+	}
+	df.h.count++
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (df *DAryFilter) TryAdd(x []byte) bool {
+	return df.Insert(x) == nil
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (df *DAryFilter) Contains(x []byte) Result {
+	f, is := df.itemToIdxs(x)
+	if _, ok := df.find(f, &is); ok || df.h.overflowed {
+		return Maybe
+	}
+	return No
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (df *DAryFilter) Delete(x []byte) {
+	if !df.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (df *DAryFilter) TryDelete(x []byte) bool {
+	f, is := df.itemToIdxs(x)
+	if !df.h.overflowed {
+		s, ok := df.find(f, &is)
+		if !ok {
+			return false
+		}
+		df.setEntry(s, 0)
+	}
+	df.h.count--
+	return true
+}
+
+// Returns the number of items in the filter.
+func (df *DAryFilter) Count() int {
+	return df.h.count
+}
+
+// Returns the number of candidate buckets of each item.
+func (df *DAryFilter) Candidates() int {
+	return df.d
+}
+
+// Returns the fraction of the filter's slots that are full.
+func (df *DAryFilter) Load() float64 {
+	return float64(df.h.count) / float64(df.h.nBuckets()*uint64(df.h.b))
+}
+
+// Returns an estimate of the filter's current false-positive rate, based on how full it is.
+func (df *DAryFilter) EstimatedFalsePositiveRate() float64 {
+	if df.h.overflowed {
+		return 1
+	}
+	// Like falsePositiveRate, but over d buckets rather than two.
+	pMatch := 1 / (math.Exp2(float64(df.h.f)) - 1)
+	return 1 - math.Pow(1-pMatch, float64(df.d*df.h.b)*math.Min(df.Load(), 1))
+}
+
+// Returns an independent copy of the filter.
+func (df *DAryFilter) Clone() *DAryFilter {
+	return &DAryFilter{entryTable: df.clone(), d: df.d, m: df.m}
+}
+
+// Returns x's fingerprint and candidate buckets, of which the first d are used.
+func (df *DAryFilter) itemToIdxs(x []byte) (fingerprint, [maxCandidates]uint64) {
+	hash := df.h.hashItem(x)
+	f := df.h.hashToFingerprint(hash)
+	// Like Filter, the fingerprint comes from the high bits of the hash, so rotate the low bits up
+	// to choose the first bucket.
+	i, _ := bits.Mul64(bits.RotateLeft64(hash, 32), df.h.nBuckets())
+	var is [maxCandidates]uint64
+	is[0] = i
+	for j := 1; j < df.d; j++ {
+		is[j] = df.next(f, is[j-1])
+	}
+	return f, is
+}
+
+// Returns the candidate bucket after i of an entry with fingerprint f, in the next part of the
+// table. Applying this d times returns to i.
+func (df *DAryFilter) next(f fingerprint, i uint64) uint64 {
+	part, p := i/df.m, i%df.m
+	// Offsets for the first d-1 parts, from independent-enough windows of the fingerprint's mix.
+	// The last part's offset cancels the rest.
+	var offset uint64
+	mix := mixFingerprint(f)
+	if part < uint64(df.d-1) {
+		offset, _ = bits.Mul64(bits.RotateLeft64(mix, 21*int(part)), df.m)
+	} else {
+		for j := 0; j < df.d-1; j++ {
+			o, _ := bits.Mul64(bits.RotateLeft64(mix, 21*j), df.m)
+			offset += df.m - o
+		}
+		offset %= df.m
+	}
+	return (part+1)%uint64(df.d)*df.m + (p+offset)%df.m
+}
+
+// Returns the index of an entry holding fingerprint f in one of the buckets is.
+func (df *DAryFilter) find(f fingerprint, is *[maxCandidates]uint64) (uint64, bool) {
+	for _, i := range is[:df.d] {
+		for s := df.slot(i, 0); s < df.slot(i+1, 0); s++ {
+			if df.entry(s) == uint64(f) {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Places fingerprint f in one of the buckets is, kicking other entries to their other buckets to
+// make room if necessary. Returns false if no room could be made, first undoing the kicks if undo
+// is true.
+func (df *DAryFilter) kick(f fingerprint, is *[maxCandidates]uint64, undo bool) bool {
+	for _, i := range is[:df.d] {
+		if s, ok := df.empty(i); ok {
+			df.setEntry(s, uint64(f))
+			return true
+		}
+	}
+
+	var pathBuf [16]entryKick
+	path := pathBuf[:0]
+	e := uint64(f)
+	i := is[df.h.randInt()%df.d]
+	for n := 0; n < maxNumKicks; n++ {
+		s := df.slot(i, uint64(df.h.randInt()%df.h.b))
+		victim := df.entry(s)
+		if undo {
+			path = append(path, entryKick{s: s, e: victim})
+		}
+		df.setEntry(s, e)
+		e = victim
+		// Look for room in each of the victim's other buckets before kicking again from a random
+		// one of them.
+		var others [maxCandidates - 1]uint64
+		j := i
+		for k := range others[:df.d-1] {
+			j = df.next(fingerprint(e), j)
+			if s, ok := df.empty(j); ok {
+				df.setEntry(s, e)
+				return true
+			}
+			others[k] = j
+		}
+		i = others[df.h.randInt()%(df.d-1)]
+	}
+
+	for j := len(path) - 1; j >= 0; j-- {
+		df.setEntry(path[j].s, path[j].e)
+	}
+	return false
+}
+
+// The magic that starts a DAryFilter's encoding.
+var daryMagic = [4]byte{'C', 'K', 'D', 'A'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform: a Filter header
+// with its own magic, then the number of candidate buckets as a byte, and then the packed entries
+// as little-endian words.
+func (df *DAryFilter) MarshalBinary() ([]byte, error) {
+	h := encodeVariantHeader(daryMagic, df.h)
+	out := make([]byte, 0, len(h)+1+len(df.entries)*8)
+	out = append(out, h...)
+	out = append(out, byte(df.d))
+	for _, w := range df.entries {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of df with the filter encoded in
+// data.
+func (df *DAryFilter) UnmarshalBinary(data []byte) error {
+	hdr, data, err := decodeVariantHeader(daryMagic, data)
+	if err != nil {
+		return err
+	}
+	if len(data) < 1 || data[0] < 2 || data[0] > maxCandidates {
+		return errCorrupt
+	}
+	d := int(data[0])
+	data = data[1:]
+	if hdr.nBuckets%uint64(d) != 0 ||
+		uint64(len(data)) != packedWords(uint64(hdr.f), hdr.nBuckets*uint64(hdr.b))*8 {
+		return errCorrupt
+	}
+	result := newDAryFilter(hdr.f, hdr.b, d, int(hdr.nBuckets))
+	for i := range result.entries {
+		result.entries[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	hdr.restore(result.h)
+	*df = *result
+	return nil
+}
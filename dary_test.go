@@ -0,0 +1,101 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAry(t *testing.T) {
+	for d := 2; d <= 4; d++ {
+		df := NewDAry(1000, 0.01, d)
+		require.Equal(t, d, df.Candidates())
+		keys := make([][]byte, 1000)
+		for i := range keys {
+			keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+			df.Add(keys[i])
+		}
+		require.False(t, df.Overflowed())
+		require.Equal(t, len(keys), df.Count())
+		for _, x := range keys {
+			require.Equal(t, Maybe, df.Contains(x))
+		}
+
+		fps := 0
+		const tries = 100000
+		for i := len(keys); i < len(keys)+tries; i++ {
+			if df.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+				fps++
+			}
+		}
+		require.Less(t, float64(fps)/tries, 0.01)
+
+		for _, x := range keys[:500] {
+			df.Delete(x)
+		}
+		require.Equal(t, 500, df.Count())
+		require.False(t, df.TryDelete([]byte("never added")))
+
+		c := df.Clone()
+		df.Reset()
+		require.Zero(t, df.Count())
+		require.Equal(t, Maybe, c.Contains(keys[999]))
+	}
+}
+
+func TestDAryCycle(t *testing.T) {
+	for d := 2; d <= 4; d++ {
+		df := NewDAryRaw(8, 4, d, 3000)
+		for f := fingerprint(1); f < 256; f++ {
+			for _, i := range []uint64{0, 1, df.m - 1, df.m, uint64(d)*df.m - 1} {
+				j, parts := i, map[uint64]bool{}
+				for k := 0; k < d; k++ {
+					parts[j/df.m] = true
+					j = df.next(f, j)
+				}
+				require.Equal(t, i, j)
+				require.Len(t, parts, d)
+			}
+		}
+	}
+}
+
+func TestDAryLoad(t *testing.T) {
+	// With more candidates, the same table fills further before an item finds no room.
+	var loads [5]float64
+	for d := 2; d <= 4; d++ {
+		df := NewDAryRaw(12, 4, d, 4096*d)
+		for i := 0; df.TryAdd(binary.LittleEndian.AppendUint64(nil, uint64(i))); i++ {
+		}
+		loads[d] = df.Load()
+		t.Logf("d=%d load=%f", d, loads[d])
+	}
+	require.Greater(t, loads[3], loads[2])
+	require.Greater(t, loads[4], loads[3])
+	require.Greater(t, loads[4], 0.98)
+}
+
+func TestDArySerialize(t *testing.T) {
+	df := NewDAry(1000, 0.01, 3)
+	df.SetSeed(42)
+	for i := 0; i < 1000; i++ {
+		df.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := df.MarshalBinary()
+	require.NoError(t, err)
+
+	var df2 DAryFilter
+	require.NoError(t, df2.UnmarshalBinary(data))
+	require.Equal(t, df.entries, df2.entries)
+	require.Equal(t, 3, df2.Candidates())
+	require.Equal(t, df.m, df2.m)
+	require.Equal(t, uint64(42), df2.Seed())
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, Maybe, df2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, df2.UnmarshalBinary(data[:len(data)-1]))
+	data[len(encodeVariantHeader(daryMagic, df.h))] = 5
+	require.Error(t, df2.UnmarshalBinary(data))
+}
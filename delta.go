@@ -0,0 +1,156 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Delta format. Like the full serialized format, all multi-byte fields are little-endian.
+//
+//	magic      [4]byte  "CKOD"
+//	version    uint8
+//	f          uint8
+//	b          uint8
+//	flags      uint8    as in the full format
+//	nBuckets   uint64
+//	count      int64
+//	seed       uint64   only present if the seeded flag is set
+//	nChanged   uint64
+//	changes    nChanged * (index uint64, bucket bucketBytes)
+//
+// The header mirrors the full format so that a delta can't be applied to a filter with different
+// parameters.
+var deltaMagic = [4]byte{'C', 'K', 'O', 'D'}
+
+// Writes the buckets that have changed since the previous call to SaveDelta to w, so that a
+// long-lived filter can be checkpointed by writing only what's new. Applying every delta in order
+// with ApplyDelta to a copy of the filter as it was before the first delta reproduces the filter.
+//
+// Change tracking starts with the first call to SaveDelta, which writes every bucket, and costs one
+// bit per bucket from then on.
+func (fl *Filter) SaveDelta(w io.Writer) (int64, error) {
+	if fl.dirty == nil {
+		fl.dirty = make([]uint64, (fl.nBuckets()+63)/64)
+		for i := range fl.dirty {
+			fl.dirty[i] = ^uint64(0)
+		}
+		if rem := fl.nBuckets() % 64; rem != 0 {
+			fl.dirty[len(fl.dirty)-1] = (uint64(1) << rem) - 1
+		}
+	}
+
+	nChanged := 0
+	for _, word := range fl.dirty {
+		nChanged += bits.OnesCount64(word)
+	}
+
+	h := fl.encodeHeader()
+	copy(h[0:4], deltaMagic[:])
+	var nBuf [8]byte
+	binary.LittleEndian.PutUint64(nBuf[:], uint64(nChanged))
+	h = append(h, nBuf[:]...)
+
+	written := int64(0)
+	n, err := w.Write(h)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	bw := fl.bucketBytes()
+	buf := make([]byte, 0, 4096)
+	var word [8]byte
+	for wi, dirty := range fl.dirty {
+		for dirty != 0 {
+			i := uint64(wi)*64 + uint64(bits.TrailingZeros64(dirty))
+			dirty &= dirty - 1
+			binary.LittleEndian.PutUint64(word[:], i)
+			buf = append(buf, word[:]...)
+			binary.LittleEndian.PutUint64(word[:], fl.loadBits(i))
+			buf = append(buf, word[:bw]...)
+			if len(buf)+8+bw > cap(buf) {
+				n, err := w.Write(buf)
+				written += int64(n)
+				if err != nil {
+					return written, err
+				}
+				buf = buf[:0]
+			}
+		}
+	}
+	if len(buf) > 0 {
+		n, err := w.Write(buf)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	// Only forget the changes once they've all been written, so that a failed SaveDelta can be
+	// retried.
+	for i := range fl.dirty {
+		fl.dirty[i] = 0
+	}
+	return written, nil
+}
+
+// Reads a delta written by SaveDelta from r and applies it to fl. fl must have the same parameters
+// as the filter that wrote the delta, and must already reflect all of the deltas that came before
+// it.
+//
+// If an error is returned, fl may have been partially updated.
+func (fl *Filter) ApplyDelta(r io.Reader) (int64, error) {
+	var hBuf [headerSize + seedSize]byte
+	h, read, err := readHeader(r, hBuf[:])
+	if err != nil {
+		return read, err
+	}
+	if !bytes.Equal(h[0:4], deltaMagic[:]) {
+		return read, errCorrupt
+	}
+	copy(h[0:4], serializeMagic[:])
+	hdr, err := decodeHeader(h)
+	if err != nil {
+		return read, err
+	}
+	if hdr.f != fl.f || hdr.b != fl.b || hdr.nBuckets != fl.nBuckets() ||
+		hdr.hashing != fl.hashing || hdr.seed != fl.seed || hdr.direct != fl.directPackable() {
+		return read, fmt.Errorf("cuckoo: delta was written by a filter with different parameters")
+	}
+	var nBuf [8]byte
+	n, err := io.ReadFull(r, nBuf[:])
+	read += int64(n)
+	if err != nil {
+		return read, noEOF(err)
+	}
+	nChanged := binary.LittleEndian.Uint64(nBuf[:])
+	if nChanged > fl.nBuckets() {
+		return read, errCorrupt
+	}
+
+	bw := fl.bucketBytes()
+	entry := make([]byte, 8+bw)
+	var word [8]byte
+	for j := uint64(0); j < nChanged; j++ {
+		n, err := io.ReadFull(r, entry)
+		read += int64(n)
+		if err != nil {
+			return read, noEOF(err)
+		}
+		i := binary.LittleEndian.Uint64(entry[:8])
+		if i >= fl.nBuckets() {
+			return read, errCorrupt
+		}
+		copy(word[:bw], entry[8:])
+		fl.storeBits(i, binary.LittleEndian.Uint64(word[:]))
+		if fl.dirty != nil {
+			fl.dirty[i/64] |= 1 << (i % 64)
+		}
+	}
+	fl.count = hdr.count
+	fl.overflowed = hdr.overflowed
+	return read, nil
+}
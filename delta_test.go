@@ -0,0 +1,79 @@
+package cuckoo
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeltaRoundTrip(t *testing.T) {
+	trand.RandomN(t, 20, func(t *testing.T, r *rand.Rand) {
+		n := r.Int()%2000 + 100
+		fl := New(n, 0.01)
+		replica := New(n, 0.01)
+		if r.Intn(2) == 0 {
+			seed := r.Uint64()
+			fl.SetSeed(seed)
+			replica.SetSeed(seed)
+		}
+
+		var items [][]byte
+		for round := 0; round < 5; round++ {
+			for i := 0; i < n/5; i++ {
+				var key [8]byte
+				_, _ = r.Read(key[:])
+				items = append(items, key[:])
+				fl.Add(key[:])
+			}
+			if round > 0 && len(items) > 0 {
+				j := r.Int() % len(items)
+				fl.Delete(items[j])
+				items = append(items[:j], items[j+1:]...)
+			}
+
+			var buf bytes.Buffer
+			written, err := fl.SaveDelta(&buf)
+			require.NoError(t, err)
+			require.Equal(t, int64(buf.Len()), written)
+			read, err := replica.ApplyDelta(&buf)
+			require.NoError(t, err)
+			require.Equal(t, written, read)
+
+			require.Equal(t, fl.Count(), replica.Count())
+			require.Equal(t, fl.Overflowed(), replica.Overflowed())
+			for i := uint64(0); i < fl.nBuckets(); i++ {
+				require.Equal(t, fl.loadBits(i), replica.loadBits(i))
+			}
+		}
+	})
+}
+
+func TestDeltaOnlyChanged(t *testing.T) {
+	fl := NewRaw(8, 4, 1024)
+	var buf bytes.Buffer
+	full, err := fl.SaveDelta(&buf)
+	require.NoError(t, err)
+
+	buf.Reset()
+	empty, err := fl.SaveDelta(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(headerSize+8), empty)
+	require.True(t, empty < full)
+
+	fl.Add([]byte{0x01})
+	buf.Reset()
+	one, err := fl.SaveDelta(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(headerSize+8+8+fl.bucketBytes()), one)
+}
+
+func TestDeltaMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewRaw(8, 4, 1024).SaveDelta(&buf)
+	require.NoError(t, err)
+	_, err = NewRaw(8, 4, 2048).ApplyDelta(&buf)
+	require.Error(t, err)
+}
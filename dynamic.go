@@ -0,0 +1,260 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+)
+
+// A filter that grows as items are added, for when the number of items isn't known up front. It
+// never overflows.
+//
+// A DynamicFilter is a chain of Filters. Items are added to the newest one until it reaches the
+// number of items it was sized for, and then a new one twice its size is started. Each new Filter
+// has half the false-positive rate of the one before, so that however long the chain gets, the
+// false-positive rate of the whole stays below the one it was created with, at least until the
+// newest Filters' fingerprints reach the 16-bit maximum. Lookups check every Filter in the chain,
+// but there are only about log2(Count/n) of them.
+type DynamicFilter struct {
+	// Oldest first.
+	fls []*Filter
+	// The number of items each of fls was sized for.
+	caps []int
+	// The false-positive rate of the newest Filter in fls.
+	fp float64
+	// The number of deleted items whose fingerprints were left in place, because they matched in more
+	// than one Filter. See TryDelete.
+	lingering int
+}
+
+// Returns a new DynamicFilter that starts out with room for n items, with an estimated
+// false-positive rate below fp however many items are added.
+func NewDynamic(n int, fp float64) *DynamicFilter {
+	if n < 1 {
+		n = 1
+	}
+	fp /= 2
+	return &DynamicFilter{fls: []*Filter{New(n, fp)}, caps: []int{n}, fp: fp}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (d *DynamicFilter) SetSeed(seed uint64) {
+	for _, fl := range d.fls {
+		fl.SetSeed(seed)
+	}
+}
+
+// Returns the seed set with SetSeed.
+func (d *DynamicFilter) Seed() uint64 {
+	return d.fls[0].Seed()
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (d *DynamicFilter) Add(x []byte) {
+	// Every Filter in the chain uses the same hash, so x only needs hashing once.
+	h := d.fls[0].hashItem(x)
+	last := d.fls[len(d.fls)-1]
+	if last.count < d.caps[len(d.caps)-1] && last.insert(last.hashToIdxs(h)) == nil {
+		return
+	}
+	last = d.grow()
+	last.add(last.hashToIdxs(h))
+}
+
+// Starts a new Filter at the end of the chain, and returns it.
+func (d *DynamicFilter) grow() *Filter {
+	n := 2 * d.caps[len(d.caps)-1]
+	d.fp /= 2
+	fl := New(n, d.fp)
+	fl.hashingFrom(d.fls[0])
+	d.fls = append(d.fls, fl)
+	d.caps = append(d.caps, n)
+	return fl
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (d *DynamicFilter) Contains(x []byte) Result {
+	h := d.fls[0].hashItem(x)
+	for _, fl := range d.fls {
+		if fl.contains(fl.hashToIdxs(h)) == Maybe {
+			return Maybe
+		}
+	}
+	return No
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (d *DynamicFilter) Delete(x []byte) {
+	if !d.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+//
+// Only the Filter that x was added to is safe to delete it from, since a match in any other is a
+// false positive, and deleting there would take out some other item's fingerprint. Nothing records
+// which Filter that was, but it's always one that matches, so when only one matches it's that one.
+// In the rare case that x matches in more than one, its fingerprint is left where it is, and x
+// goes on looking like a false positive: it no longer counts toward Count, but Contains(x) still
+// returns Maybe.
+func (d *DynamicFilter) TryDelete(x []byte) bool {
+	h := d.fls[0].hashItem(x)
+	owner := -1
+	for j, fl := range d.fls {
+		if !fl.lookup(fl.hashToIdxs(h)) {
+			continue
+		}
+		if owner >= 0 {
+			d.lingering++
+			return true
+		}
+		owner = j
+	}
+	if owner < 0 {
+		return false
+	}
+	fl := d.fls[owner]
+	fl.delete(fl.hashToIdxs(h))
+	if fl.count == 0 && owner < len(d.fls)-1 {
+		// Only the newest Filter takes new items, so an older one that's emptied out is just
+		// another lookup.
+		d.fls = append(d.fls[:owner], d.fls[owner+1:]...)
+		d.caps = append(d.caps[:owner], d.caps[owner+1:]...)
+	}
+	return true
+}
+
+// Returns the number of items in the filter.
+func (d *DynamicFilter) Count() int {
+	n := -d.lingering
+	for _, fl := range d.fls {
+		n += fl.count
+	}
+	return n
+}
+
+// Returns the number of Filters in the chain.
+func (d *DynamicFilter) NumFilters() int {
+	return len(d.fls)
+}
+
+// Returns the number of bytes used by the buckets of every Filter in the chain.
+func (d *DynamicFilter) SizeBytes() uint64 {
+	var n uint64
+	for _, fl := range d.fls {
+		n += fl.SizeBytes()
+	}
+	return n
+}
+
+// Returns an estimate of the filter's current false-positive rate: the chance that at least one
+// Filter in the chain returns Maybe for an item that was never added.
+func (d *DynamicFilter) EstimatedFalsePositiveRate() float64 {
+	none := 1.0
+	for _, fl := range d.fls {
+		none *= 1 - fl.EstimatedFalsePositiveRate()
+	}
+	return 1 - none
+}
+
+// Removes every item from the filter, going back to just the first Filter in the chain.
+func (d *DynamicFilter) Reset() {
+	// Each Filter has twice the capacity and half the false-positive rate of the one before, so the
+	// product of the two is the same for all of them, including any that were dropped.
+	d.fp *= float64(d.caps[len(d.caps)-1]) / float64(d.caps[0])
+	d.fls, d.caps = d.fls[:1], d.caps[:1]
+	d.fls[0].Reset()
+	d.lingering = 0
+}
+
+// Returns an independent copy of the filter.
+func (d *DynamicFilter) Clone() *DynamicFilter {
+	c := &DynamicFilter{caps: append([]int(nil), d.caps...), fp: d.fp, lingering: d.lingering}
+	for _, fl := range d.fls {
+		c.fls = append(c.fls, fl.Clone())
+	}
+	return c
+}
+
+// Serialized format, little-endian:
+//
+//	magic  [4]byte  "CKDY"
+//	fp     float64  the false-positive rate of the newest Filter
+//	n      uint32   the number of Filters
+//
+// followed by, for each Filter, oldest first, the number of items it was sized for as a uint64,
+// the length of its encoding as a uint64, and its encoding as written by MarshalBinary, and then,
+// only if any deleted items' fingerprints were left in place, the number of them as a uint64.
+var dynamicMagic = [4]byte{'C', 'K', 'D', 'Y'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (d *DynamicFilter) MarshalBinary() ([]byte, error) {
+	out := append([]byte(nil), dynamicMagic[:]...)
+	out = binary.LittleEndian.AppendUint64(out, math.Float64bits(d.fp))
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(d.fls)))
+	for j, fl := range d.fls {
+		data, err := fl.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = binary.LittleEndian.AppendUint64(out, uint64(d.caps[j]))
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(data)))
+		out = append(out, data...)
+	}
+	if d.lingering != 0 {
+		out = binary.LittleEndian.AppendUint64(out, uint64(d.lingering))
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of d with the filter encoded in
+// data.
+func (d *DynamicFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 || !bytes.Equal(data[:4], dynamicMagic[:]) {
+		return errCorrupt
+	}
+	result := &DynamicFilter{fp: math.Float64frombits(binary.LittleEndian.Uint64(data[4:]))}
+	n := binary.LittleEndian.Uint32(data[12:])
+	if n == 0 || !(result.fp > 0 && result.fp < 1) {
+		return errCorrupt
+	}
+	data = data[16:]
+	for j := uint32(0); j < n; j++ {
+		if len(data) < 16 {
+			return errCorrupt
+		}
+		capacity, size := binary.LittleEndian.Uint64(data), binary.LittleEndian.Uint64(data[8:])
+		data = data[16:]
+		if capacity == 0 || capacity > uint64(maxInt) || size > uint64(len(data)) {
+			return errCorrupt
+		}
+		fl := &Filter{}
+		if err := fl.UnmarshalBinary(data[:size]); err != nil {
+			return err
+		}
+		// Add and Contains hash items once for the whole chain, the way the default hash does.
+		if fl.hashing != hashXXH || (j > 0 && fl.seed != result.fls[0].seed) {
+			return errCorrupt
+		}
+		result.fls = append(result.fls, fl)
+		result.caps = append(result.caps, int(capacity))
+		data = data[size:]
+	}
+	if len(data) == 8 {
+		lingering := binary.LittleEndian.Uint64(data)
+		if lingering == 0 || lingering > uint64(result.Count()) {
+			return errCorrupt
+		}
+		result.lingering = int(lingering)
+		data = data[8:]
+	}
+	if len(data) != 0 {
+		return errCorrupt
+	}
+	*d = *result
+	return nil
+}
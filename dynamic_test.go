@@ -0,0 +1,139 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamic(t *testing.T) {
+	d := NewDynamic(100, 0.01)
+	keys := make([][]byte, 10000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		d.Add(keys[i])
+	}
+	require.Equal(t, len(keys), d.Count())
+	// 100 + 200 + ... + 6400 >= 10000.
+	require.Equal(t, 7, d.NumFilters())
+	for _, fl := range d.fls {
+		require.False(t, fl.Overflowed())
+	}
+	for _, x := range keys {
+		require.Equal(t, Maybe, d.Contains(x))
+	}
+	require.Less(t, d.EstimatedFalsePositiveRate(), 0.01)
+
+	fps := 0
+	for i := len(keys); i < len(keys)+100000; i++ {
+		if d.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/100000, 0.01)
+
+	for _, x := range keys[:100] {
+		d.Delete(x)
+	}
+	require.Equal(t, len(keys)-100, d.Count())
+	require.False(t, d.TryDelete([]byte("never added")))
+
+	c := d.Clone()
+	d.Reset()
+	require.Zero(t, d.Count())
+	require.Equal(t, 1, d.NumFilters())
+	require.Equal(t, No, d.Contains(keys[200]))
+	require.Equal(t, Maybe, c.Contains(keys[200]))
+	// The first Filter, with capacity 100 and rate 0.005, may have been emptied and dropped.
+	require.InDelta(t, 0.005*100/float64(c.caps[0]), d.fp, 1e-12)
+}
+
+func TestDynamicDropsEmptied(t *testing.T) {
+	// A low enough false-positive rate that no item's delete lands on another's fingerprint.
+	d := NewDynamic(10, 0.00001)
+	keys := make([][]byte, 100)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		d.Add(keys[i])
+	}
+	require.Equal(t, 4, d.NumFilters())
+
+	// Emptying out the first Filter drops it from the chain.
+	for _, x := range keys[:10] {
+		d.Delete(x)
+	}
+	require.Equal(t, 3, d.NumFilters())
+	require.Equal(t, []int{20, 40, 80}, d.caps)
+
+	// The newest one stays, since it's still taking new items.
+	for _, x := range keys[70:] {
+		d.Delete(x)
+	}
+	require.Equal(t, 3, d.NumFilters())
+	require.Zero(t, d.fls[2].Count())
+	for _, x := range keys[10:70] {
+		require.Equal(t, Maybe, d.Contains(x))
+	}
+
+	// Resetting goes back to the Filter that's now first, with its false-positive rate.
+	d.Reset()
+	require.Equal(t, []int{20}, d.caps)
+	require.InDelta(t, 0.00001/4, d.fp, 1e-15)
+}
+
+func TestDynamicSerialize(t *testing.T) {
+	d := NewDynamic(100, 0.001)
+	d.SetSeed(42)
+	for i := 0; i < 1000; i++ {
+		d.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := d.MarshalBinary()
+	require.NoError(t, err)
+
+	var d2 DynamicFilter
+	require.NoError(t, d2.UnmarshalBinary(data))
+	require.Equal(t, d.NumFilters(), d2.NumFilters())
+	require.Equal(t, d.caps, d2.caps)
+	require.Equal(t, d.fp, d2.fp)
+	require.Equal(t, uint64(42), d2.Seed())
+	for j := range d.fls {
+		require.True(t, d.fls[j].Equal(d2.fls[j]))
+	}
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, Maybe, d2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+	// It keeps growing the same way.
+	d.Add([]byte("x"))
+	d2.Add([]byte("x"))
+	require.Equal(t, d.NumFilters(), d2.NumFilters())
+
+	require.Error(t, d2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, d2.UnmarshalBinary(append(data, 0)))
+}
+
+func TestDynamicDeleteNoFalseNegatives(t *testing.T) {
+	const n = 50000
+	d := NewDynamic(1000, 0.01)
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for i := 0; i < n; i++ {
+		d.Add(key(i))
+	}
+	require.Greater(t, d.NumFilters(), 3)
+	for i := 0; i < n; i += 2 {
+		d.Delete(key(i))
+	}
+	require.Equal(t, n/2, d.Count())
+	for i := 1; i < n; i += 2 {
+		require.Equal(t, Maybe, d.Contains(key(i)))
+	}
+
+	// Deletes whose fingerprints were left in place survive a round trip.
+	require.NotZero(t, d.lingering)
+	data, err := d.MarshalBinary()
+	require.NoError(t, err)
+	var d2 DynamicFilter
+	require.NoError(t, d2.UnmarshalBinary(data))
+	require.Equal(t, n/2, d2.Count())
+	require.Equal(t, n/2, d.Clone().Count())
+}
@@ -0,0 +1,235 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// A cuckoo table that stores some extra bits alongside each fingerprint, for the filters that need
+// to keep something with each item: a counter for CountingFilter, or a value for ValueFilter.
+//
+// Entries are stored individually rather than through a bucketEncoding, since the packed encodings
+// only have room for fingerprints. Each keeps its slot until it's kicked, and moves with its extra
+// bits when it is.
+type entryTable struct {
+	// Holds the parameters, count, overflowed state, and hash settings, and maps items to
+	// fingerprints and buckets. Its own buckets are unused.
+	h *Filter
+	// The number of extra bits in each entry.
+	extra int
+	// b entries per bucket, each packed into f+extra bits: the fingerprint in the low f bits and the
+	// extra bits above it. 0 is an empty slot.
+	entries []uint64
+}
+
+func newEntryTable(f, b, extra, nBuckets int) entryTable {
+	return entryTable{
+		h:       newFilterWords(f, b, nBuckets, bucketEncodingFor(f, b), nil),
+		extra:   extra,
+		entries: make([]uint64, packedWords(uint64(f+extra), uint64(nBuckets*b))),
+	}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (t *entryTable) SetSeed(seed uint64) {
+	t.h.SetSeed(seed)
+}
+
+// Returns the seed set with SetSeed.
+func (t *entryTable) Seed() uint64 {
+	return t.h.Seed()
+}
+
+// True if the filter has overflowed, and now blindly returns Maybe for every query.
+func (t *entryTable) Overflowed() bool {
+	return t.h.overflowed
+}
+
+// Returns the number of bytes used by the filter's entries.
+func (t *entryTable) SizeBytes() uint64 {
+	return uint64(len(t.entries)) * 8
+}
+
+// Removes every item from the filter, reusing its memory.
+func (t *entryTable) Reset() {
+	for i := range t.entries {
+		t.entries[i] = 0
+	}
+	t.h.count = 0
+	t.h.overflowed = false
+}
+
+func (t *entryTable) clone() entryTable {
+	c := newEntryTable(t.h.f, t.h.b, t.extra, int(t.h.nBuckets()))
+	copy(c.entries, t.entries)
+	c.h.count = t.h.count
+	c.h.overflowed = t.h.overflowed
+	c.h.hashingFrom(t.h)
+	c.h.rngState = t.h.rngState
+	return c
+}
+
+// Returns the index of the entry in slot j of bucket i.
+func (t *entryTable) slot(i, j uint64) uint64 {
+	return i*uint64(t.h.b) + j
+}
+
+func (t *entryTable) entry(s uint64) uint64 {
+	return getPacked(t.entries, uint64(t.h.f+t.extra), s)
+}
+
+func (t *entryTable) setEntry(s, e uint64) {
+	setPacked(t.entries, uint64(t.h.f+t.extra), s, e)
+}
+
+// Returns the entry holding fingerprint f and extra bits x.
+func (t *entryTable) makeEntry(f fingerprint, x uint64) uint64 {
+	return uint64(f) | x<<uint(t.h.f)
+}
+
+// Returns the fingerprint in entry e.
+func (t *entryTable) fingerprint(e uint64) fingerprint {
+	return fingerprint(e & (uint64(1)<<uint(t.h.f) - 1))
+}
+
+// Returns the extra bits in entry e.
+func (t *entryTable) extraBits(e uint64) uint64 {
+	return e >> uint(t.h.f)
+}
+
+// Returns the index of an entry holding fingerprint f in bucket i1 or i2.
+func (t *entryTable) find(f fingerprint, i1, i2 uint64) (uint64, bool) {
+	for _, i := range [2]uint64{i1, i2} {
+		for s := t.slot(i, 0); s < t.slot(i+1, 0); s++ {
+			if t.fingerprint(t.entry(s)) == f {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Returns the index of an empty entry in bucket i.
+func (t *entryTable) empty(i uint64) (uint64, bool) {
+	for s := t.slot(i, 0); s < t.slot(i+1, 0); s++ {
+		if t.entry(s) == 0 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// A write made while kicking: e replaced the entry in slot s.
+type entryKick struct {
+	s, e uint64
+}
+
+// Places entry e, whose fingerprint's candidate buckets are i1 and i2, kicking other entries to
+// their other buckets to make room if necessary. Returns false if no room could be made, first
+// undoing the kicks if undo is true.
+func (t *entryTable) kick(e uint64, i1, i2 uint64, undo bool) bool {
+	for _, i := range [2]uint64{i1, i2} {
+		if s, ok := t.empty(i); ok {
+			t.setEntry(s, e)
+			return true
+		}
+	}
+
+	var pathBuf [16]entryKick
+	path := pathBuf[:0]
+	is := [2]uint64{i1, i2}
+	i := is[t.h.randInt()%len(is)]
+	for n := 0; n < maxNumKicks; n++ {
+		s := t.slot(i, uint64(t.h.randInt()%t.h.b))
+		victim := t.entry(s)
+		if undo {
+			path = append(path, entryKick{s: s, e: victim})
+		}
+		t.setEntry(s, e)
+		e = victim
+		i = t.h.otherIdx(t.fingerprint(e), i)
+		if s, ok := t.empty(i); ok {
+			t.setEntry(s, e)
+			return true
+		}
+	}
+
+	// Put back what each kick replaced, last first.
+	for j := len(path) - 1; j >= 0; j-- {
+		t.setEntry(path[j].s, path[j].e)
+	}
+	return false
+}
+
+// Returns the encoding of the table: a Filter header with magic in place of Filter's, followed by
+// the number of extra bits as a byte and then the packed entries as little-endian words.
+func (t *entryTable) marshal(magic [4]byte) []byte {
+	h := encodeVariantHeader(magic, t.h)
+	out := make([]byte, 0, len(h)+1+len(t.entries)*8)
+	out = append(out, h...)
+	out = append(out, byte(t.extra))
+	for _, w := range t.entries {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out
+}
+
+// Decodes a table encoded by marshal with the same magic.
+func unmarshalEntryTable(magic [4]byte, data []byte) (entryTable, error) {
+	hdr, data, err := decodeVariantHeader(magic, data)
+	if err != nil {
+		return entryTable{}, err
+	}
+	if len(data) < 1 || data[0] < 1 || data[0] > 16 {
+		return entryTable{}, errCorrupt
+	}
+	extra := int(data[0])
+	data = data[1:]
+	if uint64(len(data)) != packedWords(uint64(hdr.f+extra), hdr.nBuckets*uint64(hdr.b))*8 {
+		return entryTable{}, errCorrupt
+	}
+	t := newEntryTable(hdr.f, hdr.b, extra, int(hdr.nBuckets))
+	for i := range t.entries {
+		t.entries[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	hdr.restore(t.h)
+	return t, nil
+}
+
+// Returns the header of fl's encoding, with magic in place of Filter's, for the filter types built
+// on top of a Filter that hold their own buckets.
+func encodeVariantHeader(magic [4]byte, fl *Filter) []byte {
+	h := fl.encodeHeader()
+	copy(h, magic[:])
+	return h
+}
+
+// Decodes a header written by encodeVariantHeader with the same magic, returning it along with the
+// rest of data. Only the hash schemes such filters can be built with are accepted.
+func decodeVariantHeader(magic [4]byte, data []byte) (header, []byte, error) {
+	if len(data) < headerSize || !bytes.Equal(data[:len(magic)], magic[:]) {
+		return header{}, nil, errCorrupt
+	}
+	var hBuf [headerSize + seedSize]byte
+	h := hBuf[:copy(hBuf[:], data)]
+	copy(h, serializeMagic[:])
+	hdr, err := decodeHeader(h)
+	if err != nil {
+		return header{}, nil, err
+	}
+	if hdr.hashing != hashXXH && hdr.hashing != hashXXH128 {
+		return header{}, nil, errCorrupt
+	}
+	return hdr, data[headerLen(h):], nil
+}
+
+// Sets the count, overflowed state, and hash settings of fl to those described by h.
+func (h header) restore(fl *Filter) {
+	fl.count = h.count
+	fl.overflowed = h.overflowed
+	fl.hashing = h.hashing
+	fl.seed = h.seed
+	if h.seed != 0 {
+		fl.rngState = h.seed
+	}
+}
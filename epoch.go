@@ -0,0 +1,193 @@
+package cuckoo
+
+import (
+	"math/rand"
+	"sync/atomic"
+)
+
+// The number of buckets per page of an EpochFilter. A multiple of 64, so that pages are always a
+// whole number of words.
+const epochPageBuckets = 1024
+
+// Bucket storage split into pages, where pages that have been published to readers are never
+// modified again: writing to one replaces it with a private copy first.
+type pagedStorage struct {
+	// The size of each bucket in bits.
+	size  uint64
+	pages [][]uint64
+	// pages[p] may only be modified in place if pageGen[p] == gen, otherwise it is shared with a
+	// published epoch.
+	pageGen []uint64
+	gen     uint64
+}
+
+func newPagedStorage(size, nBuckets uint64) *pagedStorage {
+	nPages := (nBuckets + epochPageBuckets - 1) / epochPageBuckets
+	s := &pagedStorage{
+		size:    size,
+		pages:   make([][]uint64, nPages),
+		pageGen: make([]uint64, nPages),
+	}
+	for p := range s.pages {
+		s.pages[p] = make([]uint64, packedWords(size, epochPageBuckets))
+	}
+	return s
+}
+
+func (s *pagedStorage) load(i uint64) uint64 {
+	return getPacked(s.pages[i/epochPageBuckets], s.size, i%epochPageBuckets)
+}
+
+func (s *pagedStorage) store(i uint64, bits uint64) {
+	p := i / epochPageBuckets
+	if s.pageGen[p] != s.gen {
+		s.pages[p] = append([]uint64(nil), s.pages[p]...)
+		s.pageGen[p] = s.gen
+	}
+	setPacked(s.pages[p], s.size, i%epochPageBuckets, bits)
+}
+
+// Returns the current pages for readers and starts a new generation, so that none of them are
+// modified again.
+func (s *pagedStorage) freeze() [][]uint64 {
+	pages := append([][]uint64(nil), s.pages...)
+	s.gen++
+	return pages
+}
+
+// A published, immutable state of an EpochFilter.
+type epoch struct {
+	pages      [][]uint64
+	count      int
+	overflowed bool
+}
+
+// A filter with one writer goroutine and any number of reader goroutines, where readers are
+// wait-free: Contains never blocks or retries, no matter what the writer is doing.
+//
+// The writer's changes are made to private copies of the affected pages of buckets and become
+// visible to readers all at once when the writer calls Publish, in the style of read-copy-update.
+// Until then, readers keep seeing the previous epoch. Pages no longer referenced by any reader are
+// reclaimed by the garbage collector.
+//
+// Add, Delete, and Publish must only be called from one goroutine at a time. Contains, Count, and
+// Overflowed may be called from any goroutine and reflect the last published epoch.
+type EpochFilter struct {
+	published atomic.Pointer[epoch]
+	// The writer's view.
+	fl *Filter
+}
+
+// Returns a new EpochFilter capable of holding n items with an estimated false-positive rate of
+// fp. See New.
+func NewEpoch(n int, fp float64) *EpochFilter {
+	return NewEpochRaw(params(n, fp))
+}
+
+// Returns a new EpochFilter constructed using raw parameters. See NewRaw.
+func NewEpochRaw(f, b, n int) *EpochFilter {
+	n = rawBuckets(f, b, n)
+	enc := bucketEncodingFor(f, b)
+	e := &EpochFilter{
+		fl: &Filter{
+			paged:          newPagedStorage(enc.size(), uint64(n)),
+			n:              uint64(n),
+			f:              f,
+			b:              b,
+			bucketEncoding: enc,
+			hashing:        hashXXH,
+			rngState:       rand.Uint64(),
+		},
+	}
+	e.Publish()
+	return e
+}
+
+// Adds an item to the filter. Readers see it after the next Publish.
+func (e *EpochFilter) Add(x []byte) {
+	e.fl.Add(x)
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns false and
+// leaves the filter as it was, rather than overflowing it.
+func (e *EpochFilter) TryAdd(x []byte) bool {
+	return e.fl.TryAdd(x)
+}
+
+// Adds x to the filter, returning what Contains(x) would have returned just before if every write
+// so far had been published.
+func (e *EpochFilter) TestAndAdd(x []byte) Result {
+	return e.fl.TestAndAdd(x)
+}
+
+// Adds x to the filter only if Contains(x) would return No if every write so far had been
+// published, and returns true if it did so.
+func (e *EpochFilter) AddIfNotContains(x []byte) bool {
+	return e.fl.AddIfNotContains(x)
+}
+
+// Deletes x from the filter. x must have been previously added. Readers stop seeing it after the
+// next Publish.
+func (e *EpochFilter) Delete(x []byte) {
+	e.fl.Delete(x)
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (e *EpochFilter) TryDelete(x []byte) bool {
+	return e.fl.TryDelete(x)
+}
+
+// Makes every Add and Delete so far visible to readers at once.
+//
+// Publishing copies the table of page pointers, so it costs time proportional to the size of the
+// filter divided by the page size; publishing after every write is correct but slow for large
+// filters.
+func (e *EpochFilter) Publish() {
+	e.published.Store(&epoch{
+		pages:      e.fl.paged.freeze(),
+		count:      e.fl.count,
+		overflowed: e.fl.overflowed,
+	})
+}
+
+// Returns No if x was definitely not in the filter as of the last Publish, and Maybe if x might
+// have been.
+func (e *EpochFilter) Contains(x []byte) Result {
+	ep := e.published.Load()
+	if ep.overflowed {
+		return Maybe
+	}
+	f, i1, i2 := e.fl.itemToIdxs(x)
+	size := e.fl.paged.size
+	for _, i := range [2]uint64{i1, i2} {
+		bits := getPacked(ep.pages[i/epochPageBuckets], size, i%epochPageBuckets)
+		if e.fl.bitsContain(bits, f) {
+			return Maybe
+		}
+	}
+	return No
+}
+
+// Returns false if x is definitely not in the filter, and true if x might be in the filter. The
+// same as Contains(x).IsMaybe().
+func (e *EpochFilter) MayContain(x []byte) bool {
+	return e.Contains(x) == Maybe
+}
+
+// Returns the number of items in the filter as of the last Publish.
+func (e *EpochFilter) Count() int {
+	return e.published.Load().count
+}
+
+// True if the filter had overflowed as of the last Publish, and so blindly returns Maybe for every
+// query.
+func (e *EpochFilter) Overflowed() bool {
+	return e.published.Load().overflowed
+}
+
+// Returns the number of bytes used by the filter, not counting pages kept alive by readers of
+// earlier epochs.
+func (e *EpochFilter) SizeBytes() uint64 {
+	return e.fl.SizeBytes()
+}
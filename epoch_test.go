@@ -0,0 +1,65 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpoch(t *testing.T) {
+	const n = 50000
+	e := NewEpoch(n, 0.01)
+	key := func(i int) []byte {
+		return binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+
+	e.Add(key(1))
+	require.Equal(t, No, e.Contains(key(1)))
+	require.Equal(t, 0, e.Count())
+	e.Publish()
+	require.Equal(t, Maybe, e.Contains(key(1)))
+	require.Equal(t, 1, e.Count())
+	e.Delete(key(1))
+	require.Equal(t, Maybe, e.Contains(key(1)))
+	e.Publish()
+	require.Equal(t, 0, e.Count())
+
+	// Readers check that everything published so far is present while the writer keeps going.
+	var published atomic.Int64
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				upTo := int(published.Load())
+				for i := 0; i < upTo; i += 97 {
+					if e.Contains(key(i)) != Maybe {
+						panic("missing item")
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		e.Add(key(i))
+		if i%1000 == 999 {
+			e.Publish()
+			published.Store(int64(i + 1))
+		}
+	}
+	e.Publish()
+	stop.Store(true)
+	wg.Wait()
+
+	require.False(t, e.Overflowed())
+	require.Equal(t, n, e.Count())
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, e.Contains(key(i)))
+	}
+	require.NoError(t, e.fl.CheckInvariants())
+}
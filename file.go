@@ -0,0 +1,76 @@
+package cuckoo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+// Writes the filter to the file at path, in the same format as WriteTo.
+//
+// The filter is first written to a temporary file in the same directory, which is synced and then
+// renamed over path, so that after a crash path holds either the previous snapshot or the new one
+// and never a partially written one. The file is created with mode 0600.
+func (fl *Filter) SaveFile(path string) (err error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	w := bufio.NewWriterSize(tmp, 1<<16)
+	_, err = fl.WriteTo(w)
+	if err != nil {
+		return err
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	// The rename itself isn't durable until the directory is synced. Not every platform allows
+	// opening or syncing a directory, and the snapshot is already in place, so this is best-effort.
+	d, dirErr := os.Open(dir)
+	if dirErr == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+	return nil
+}
+
+// Reads a filter from the file at path, which was written by SaveFile or WriteTo.
+func LoadFile(path string) (*Filter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fl := &Filter{}
+	_, err = fl.ReadFrom(bufio.NewReaderSize(file, 1<<16))
+	if err != nil {
+		return nil, err
+	}
+	return fl, nil
+}
@@ -0,0 +1,37 @@
+package cuckoo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "filter")
+
+	fl := New(1000, 0.01)
+	for i := 0; i < 500; i++ {
+		fl.Add([]byte{byte(i), byte(i >> 8)})
+	}
+	require.NoError(t, fl.SaveFile(path))
+
+	// Overwrite an existing snapshot.
+	fl.Add([]byte("one more"))
+	require.NoError(t, fl.SaveFile(path))
+
+	loaded, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, fl.Count(), loaded.Count())
+	for i := 0; i < 500; i++ {
+		require.Equal(t, Maybe, loaded.Contains([]byte{byte(i), byte(i >> 8)}))
+	}
+	require.Equal(t, Maybe, loaded.Contains([]byte("one more")))
+
+	// No temp files are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
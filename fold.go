@@ -0,0 +1,126 @@
+package cuckoo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Returned by Fold for filters whose buckets can't be halved without losing items.
+var ErrCannotFold = errors.New("cuckoo: filter can't be folded")
+
+// Returns a copy of the filter with half as many buckets, for shrinking a filter that was sized for
+// far more items than it ended up holding before archiving it. fl is unchanged.
+//
+// The copy holds the same items with the same fingerprints, in half the memory, at twice the load,
+// so its false-positive rate is about twice fl's: EstimatedFalsePositiveRate on the result gives
+// the new rate. If the items don't all fit, which is likely once fl is more than about 45% full,
+// Fold returns ErrOverflowed. Overflowed filters can't be folded either.
+//
+// Any filter with a power-of-two number of buckets can be folded, which includes every filter from
+// New, NewRaw, and NewAligned. Those choose buckets from the high bits of the hash, and pair each
+// bucket with its alternate by XOR, so every fingerprint's pair of buckets in the smaller table is
+// its pair in fl with the low bit of each dropped, and the fingerprints can be moved without the
+// original items. Filters from NewSeiflotfy and DecodeCMU choose buckets from the low bits, so
+// theirs is the pair with the top bit dropped. Filters from NewExact and NewRawExact, whose number
+// of buckets usually isn't a power of two, pair buckets in a way that halving doesn't keep; Fold
+// returns ErrCannotFold for them.
+func (fl *Filter) Fold() (*Filter, error) {
+	if err := fl.foldable(); err != nil {
+		return nil, err
+	}
+	if fl.overflowed {
+		return nil, ErrOverflowed
+	}
+	half := fl.nBuckets() / 2
+	var folded *Filter
+	if fl.aligned != nil {
+		folded = newAlignedFilter(fl.f, fl.b, int(half))
+	} else {
+		folded = newFilterEncoded(fl.f, fl.b, int(half), fl.bucketEncoding)
+	}
+	folded.hashingFrom(fl)
+	folded.insertStrategy = fl.insertStrategy
+	folded.rngState = fl.rngState
+	ok := true
+	fl.each(func(f fingerprint, i uint64) {
+		// Dropping a bit of the index commutes with the XOR that finds the alternate bucket, so the
+		// other of an item's buckets folds onto its alternate here.
+		switch fl.hashing {
+		case hashSeiflotfy, hashCMU:
+			i %= half
+		default:
+			i >>= 1
+		}
+		ok = ok && folded.kick(f, i, folded.otherIdx(f, i), false)
+	})
+	if !ok {
+		return nil, ErrOverflowed
+	}
+	folded.count = fl.count
+	return folded, nil
+}
+
+// Returns an error wrapping ErrCannotFold if the filter's buckets can't be halved. See Fold.
+func (fl *Filter) foldable() error {
+	if fl.nBuckets() < 2 {
+		return fmt.Errorf("%w: it has only one bucket", ErrCannotFold)
+	}
+	if fl.nBuckets()&(fl.nBuckets()-1) != 0 {
+		return fmt.Errorf("%w: %d buckets isn't a power of two", ErrCannotFold, fl.nBuckets())
+	}
+	return nil
+}
+
+// Makes Delete, TryDelete, DeleteHash, and Subtract fold the filter in place whenever they leave it
+// less than minLoad full, so that a filter sized for a peak gives back its memory once the peak has
+// passed. Each fold halves the number of buckets and doubles the load; see Fold. The filter never
+// shrinks below room for n items, so that it can take as many again when the next peak comes.
+// Filters don't grow, so n should be the most items the filter will ever need to hold again.
+//
+// minLoad must be in (0, 0.45], so that the items still fit after a fold, or 0 to stop shrinking;
+// otherwise SetAutoShrink returns an error wrapping ErrInvalidParams. It returns an error wrapping
+// ErrCannotFold for a filter that Fold doesn't support, such as one from NewExact, or whose buckets
+// aren't on the Go heap, such as one from NewOffHeap. Either way the filter is left as it was.
+//
+// Thresholds registered with OnLoad keep the same loads, and their functions are called if a fold
+// takes the load past them. The filter doesn't shrink while it's logging with SetLog or tracking
+// changes for SaveDelta, since neither can describe a change in size, or while a ConcurrentFilter
+// snapshot is being taken.
+func (fl *Filter) SetAutoShrink(minLoad float64, n int) error {
+	if !(minLoad >= 0 && minLoad <= 0.45) {
+		return fmt.Errorf("%w: minimum load %v must be in [0, 0.45]", ErrInvalidParams, minLoad)
+	}
+	if err := fl.foldable(); err != nil {
+		return err
+	}
+	if fl.paged != nil || fl.release != nil {
+		return fmt.Errorf("%w: its buckets aren't on the Go heap", ErrCannotFold)
+	}
+	fl.shrinkBelow = minLoad
+	fl.shrinkFloor = uint64(math.Ceil(float64(n) / float64(fl.b) / 0.95))
+	return nil
+}
+
+// Called after Delete. Folds the filter in place if it's now less full than SetAutoShrink allows,
+// as long as that leaves it at least shrinkFloor buckets and nothing else is keeping track of its
+// buckets.
+func (fl *Filter) checkShrink() {
+	if fl.shrinkBelow == 0 || fl.load() >= fl.shrinkBelow || fl.nBuckets()/2 < fl.shrinkFloor ||
+		fl.log != nil || fl.dirty != nil || fl.onWrite != nil {
+		return
+	}
+	folded, err := fl.Fold()
+	if err != nil {
+		return
+	}
+	fl.words, fl.aligned, fl.perWord = folded.words, folded.aligned, folded.perWord
+	fl.n = folded.n
+	fl.rngState = folded.rngState
+	for i := range fl.thresholds {
+		fl.thresholds[i].at = (fl.thresholds[i].at + 1) / 2
+	}
+	if fl.thresholds != nil {
+		fl.checkLoad()
+	}
+}
@@ -0,0 +1,125 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFold(t *testing.T) {
+	const n = 3000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	seeded := NewRaw(12, 4, 2048)
+	seeded.SetSeed(3)
+	wide := NewRaw(12, 4, 2048)
+	wide.Use128BitHash()
+	for name, fl := range map[string]*Filter{
+		"Default":   NewRaw(12, 4, 2048),
+		"Seeded":    seeded,
+		"128Bit":    wide,
+		"Aligned":   NewRawAligned(12, 4, 2048),
+		"Seiflotfy": NewSeiflotfy(4 * 4096),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < n; i++ {
+				fl.Add(key(i))
+			}
+			folded, err := fl.Fold()
+			require.NoError(t, err)
+			require.NoError(t, folded.CheckInvariants())
+			require.Equal(t, fl.nBuckets()/2, folded.nBuckets())
+			require.Equal(t, n, folded.Count())
+			require.Equal(t, fl.SizeBytes()/2, folded.SizeBytes())
+			require.InEpsilon(t, 2*fl.EstimatedFalsePositiveRate(), folded.EstimatedFalsePositiveRate(),
+				0.01)
+			for i := 0; i < n; i++ {
+				require.Equal(t, Maybe, folded.Contains(key(i)))
+			}
+			// It's an ordinary filter from here on.
+			for i := 0; i < n; i++ {
+				folded.Delete(key(i))
+			}
+			require.Zero(t, folded.Count())
+			require.Equal(t, n, fl.Count())
+
+			// Each fold doubles the load, until the items no longer fit.
+			folded, err = fl.Fold()
+			require.NoError(t, err)
+			folded, err = folded.Fold()
+			require.NoError(t, err)
+			_, err = folded.Fold()
+			require.ErrorIs(t, err, ErrOverflowed)
+		})
+	}
+}
+
+func TestFoldExact(t *testing.T) {
+	fl := NewRawExact(12, 4, 1000)
+	fl.Add([]byte("x"))
+	_, err := fl.Fold()
+	require.ErrorIs(t, err, ErrCannotFold)
+
+	// An exact size that happens to be a power of two folds like any other.
+	fl = NewRawExact(12, 4, 1024)
+	fl.Add([]byte("x"))
+	folded, err := fl.Fold()
+	require.NoError(t, err)
+	require.Equal(t, Maybe, folded.Contains([]byte("x")))
+}
+
+func TestAutoShrink(t *testing.T) {
+	const n = 10000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for name, fl := range map[string]*Filter{
+		// Sized for a peak of 8n.
+		"Default":   New(8*n, 0.01),
+		"Seiflotfy": NewSeiflotfy(8 * n),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, fl.SetAutoShrink(0.2, n/2))
+			var loads []float64
+			fl.OnLoad(0.3, func(load float64) { loads = append(loads, load) })
+			for i := 0; i < n; i++ {
+				fl.Add(key(i))
+			}
+			size := fl.SizeBytes()
+			for i := 0; i < n-n/10; i++ {
+				fl.Delete(key(i))
+			}
+			require.NoError(t, fl.CheckInvariants())
+			require.Less(t, fl.SizeBytes(), size/2)
+			// It stops at room for n/2 items, even though it's now less full than asked.
+			require.Equal(t, uint64(1<<11), fl.nBuckets())
+			require.Less(t, fl.load(), 0.2)
+			for i := n - n/10; i < n; i++ {
+				require.Equal(t, Maybe, fl.Contains(key(i)))
+			}
+			// Folding took the load back past the threshold registered with OnLoad.
+			require.NotEmpty(t, loads)
+
+			// It still has room for n/2 items.
+			for i := 0; i < n/2-n/10; i++ {
+				require.True(t, fl.TryAdd(key(n+i)))
+			}
+		})
+	}
+}
+
+func TestAutoShrinkUnsupported(t *testing.T) {
+	const n = 1000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	fl := NewExact(n, 0.01)
+	require.ErrorIs(t, fl.SetAutoShrink(0.2, n/2), ErrCannotFold)
+	require.ErrorIs(t, New(n, 0.01).SetAutoShrink(0.5, n), ErrInvalidParams)
+	// It's left as it was, and carries on without shrinking.
+	nBuckets := fl.nBuckets()
+	for i := 0; i < n; i++ {
+		fl.Add(key(i))
+	}
+	for i := 0; i < n-n/10; i++ {
+		fl.Delete(key(i))
+	}
+	require.Equal(t, nBuckets, fl.nBuckets())
+	require.NoError(t, fl.CheckInvariants())
+}
@@ -0,0 +1,230 @@
+package cuckoo
+
+// A hash map from byte-string keys to byte-string values, using the same cuckoo table as Filter: each
+// key lives in one of two candidate buckets of 4 slots, and finds room by kicking other keys to
+// their alternates. Unlike a filter it stores keys and values exactly, so it never answers wrongly.
+//
+// Lookups touch at most two buckets. Each slot also keeps the key's 16-bit fingerprint, so keys are
+// only compared when their fingerprints match. When no room can be made for a key, the table
+// doubles and every key is placed again.
+type Map struct {
+	// Holds the parameters, count, and hash settings, and maps keys to fingerprints and buckets. Its
+	// own buckets are unused.
+	h *Filter
+	// The fingerprint, key, and value in each slot, b per bucket. A fingerprint of 0 is an empty
+	// slot.
+	fps  []fingerprint
+	keys []string
+	vals [][]byte
+}
+
+const (
+	mapF = 16
+	mapB = 4
+	// The load a Map starts out sized for.
+	mapLoad = 0.9
+)
+
+// Returns a new, empty Map with room for about n keys before it first grows.
+func NewMap(n int) *Map {
+	nBuckets := int(float64(n)/mapB/mapLoad) + 1
+	return newMap(nBuckets)
+}
+
+func newMap(nBuckets int) *Map {
+	return &Map{
+		h:    newFilterWords(mapF, mapB, nBuckets, bucketEncodingFor(mapF, mapB), nil),
+		fps:  make([]fingerprint, nBuckets*mapB),
+		keys: make([]string, nBuckets*mapB),
+		vals: make([][]byte, nBuckets*mapB),
+	}
+}
+
+// Sets the seed mixed into the hash of every key. See Filter.SetSeed.
+func (m *Map) SetSeed(seed uint64) {
+	m.h.SetSeed(seed)
+}
+
+// Returns the seed set with SetSeed.
+func (m *Map) Seed() uint64 {
+	return m.h.Seed()
+}
+
+// Returns the number of keys in the map.
+func (m *Map) Len() int {
+	return m.h.count
+}
+
+// Returns the index of the slot holding k, whose fingerprint and candidate buckets are f, i1, and
+// i2.
+func (m *Map) find(k []byte, f fingerprint, i1, i2 uint64) (int, bool) {
+	for _, i := range [2]uint64{i1, i2} {
+		for s := int(i) * mapB; s < int(i+1)*mapB; s++ {
+			if m.fps[s] == f && m.keys[s] == string(k) {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Returns the value for k, and whether k is in the map. The value must not be modified.
+func (m *Map) Get(k []byte) ([]byte, bool) {
+	f, i1, i2 := m.h.itemToIdxs(k)
+	s, ok := m.find(k, f, i1, i2)
+	if !ok {
+		return nil, false
+	}
+	return m.vals[s], true
+}
+
+// Sets the value for k to v, replacing any value it already had. The map keeps its own copy of k,
+// but keeps v itself, so v must not be modified afterward.
+func (m *Map) Set(k, v []byte) {
+	f, i1, i2 := m.h.itemToIdxs(k)
+	if s, ok := m.find(k, f, i1, i2); ok {
+		m.vals[s] = v
+		return
+	}
+	for !m.kick(f, i1, i2, string(k), v) {
+		m.grow()
+		f, i1, i2 = m.h.itemToIdxs(k)
+	}
+	m.h.count++
+}
+
+// Removes k from the map, and returns true if it was there.
+func (m *Map) Delete(k []byte) bool {
+	f, i1, i2 := m.h.itemToIdxs(k)
+	s, ok := m.find(k, f, i1, i2)
+	if !ok {
+		return false
+	}
+	m.fps[s], m.keys[s], m.vals[s] = 0, "", nil
+	m.h.count--
+	return true
+}
+
+// A slot's previous contents, overwritten while kicking.
+type mapKick struct {
+	s int
+	f fingerprint
+	k string
+	v []byte
+}
+
+// Places key k and value v, whose fingerprint and candidate buckets are f, i1, and i2, kicking other
+// keys to their other buckets to make room if necessary. Returns false, leaving the map as it was,
+// if no room could be made.
+func (m *Map) kick(f fingerprint, i1, i2 uint64, k string, v []byte) bool {
+	for _, i := range [2]uint64{i1, i2} {
+		if s, ok := m.empty(i); ok {
+			m.fps[s], m.keys[s], m.vals[s] = f, k, v
+			return true
+		}
+	}
+
+	var path []mapKick
+	is := [2]uint64{i1, i2}
+	i := is[m.h.randInt()%len(is)]
+	for n := 0; n < maxNumKicks; n++ {
+		s := int(i)*mapB + m.h.randInt()%mapB
+		path = append(path, mapKick{s: s, f: m.fps[s], k: m.keys[s], v: m.vals[s]})
+		f, m.fps[s] = m.fps[s], f
+		k, m.keys[s] = m.keys[s], k
+		v, m.vals[s] = m.vals[s], v
+		i = m.h.otherIdx(f, i)
+		if s, ok := m.empty(i); ok {
+			m.fps[s], m.keys[s], m.vals[s] = f, k, v
+			return true
+		}
+	}
+
+	// Put back what each kick replaced, last first.
+	for j := len(path) - 1; j >= 0; j-- {
+		p := path[j]
+		m.fps[p.s], m.keys[p.s], m.vals[p.s] = p.f, p.k, p.v
+	}
+	return false
+}
+
+// Returns the index of an empty slot in bucket i.
+func (m *Map) empty(i uint64) (int, bool) {
+	for s := int(i) * mapB; s < int(i+1)*mapB; s++ {
+		if m.fps[s] == 0 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// Doubles the number of buckets, placing every key again.
+func (m *Map) grow() {
+	nBuckets := int(m.h.nBuckets())
+	for {
+		nBuckets *= 2
+		bigger := newMap(nBuckets)
+		bigger.h.hashingFrom(m.h)
+		bigger.h.rngState = m.h.rngState
+		if bigger.placeAll(m) {
+			*m = *bigger
+			return
+		}
+	}
+}
+
+// Places every key of other, which must be hashed the same way, into m. Returns false if any of them
+// don't fit.
+func (m *Map) placeAll(other *Map) bool {
+	for s, f := range other.fps {
+		if f == 0 {
+			continue
+		}
+		k := other.keys[s]
+		f, i1, i2 := m.h.itemToIdxs([]byte(k))
+		if !m.kick(f, i1, i2, k, other.vals[s]) {
+			return false
+		}
+		m.h.count++
+	}
+	return true
+}
+
+// Returns an iterator over the keys and values in the map, in no particular order. The map must not
+// be modified during iteration, and neither the keys nor the values may be modified.
+func (m *Map) All() func(yield func(k, v []byte) bool) {
+	return func(yield func(k, v []byte) bool) {
+		for s, f := range m.fps {
+			if f != 0 && !yield([]byte(m.keys[s]), m.vals[s]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the number of bytes used by the map's slots, not counting the keys and values themselves.
+func (m *Map) SizeBytes() uint64 {
+	// A fingerprint, a string header, and a slice header.
+	const slotSize = 2 + 16 + 24
+	return uint64(len(m.fps)) * slotSize
+}
+
+// Removes every key from the map, keeping its size.
+func (m *Map) Reset() {
+	for s := range m.fps {
+		m.fps[s], m.keys[s], m.vals[s] = 0, "", nil
+	}
+	m.h.count = 0
+}
+
+// Returns a copy of the map. The values are shared with m rather than copied.
+func (m *Map) Clone() *Map {
+	c := newMap(int(m.h.nBuckets()))
+	c.h.hashingFrom(m.h)
+	c.h.rngState = m.h.rngState
+	c.h.count = m.h.count
+	copy(c.fps, m.fps)
+	copy(c.keys, m.keys)
+	copy(c.vals, m.vals)
+	return c
+}
@@ -0,0 +1,70 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap(t *testing.T) {
+	const n = 10000
+	m := NewMap(100)
+	m.SetSeed(3)
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for i := 0; i < n; i++ {
+		m.Set(key(i), key(i*2))
+	}
+	require.Equal(t, n, m.Len())
+	require.Panics(t, func() { m.SetSeed(4) })
+	require.Equal(t, uint64(3), m.Seed())
+	for i := 0; i < n; i++ {
+		v, ok := m.Get(key(i))
+		require.True(t, ok)
+		require.Equal(t, key(i*2), v)
+	}
+	for i := n; i < 2*n; i++ {
+		_, ok := m.Get(key(i))
+		require.False(t, ok)
+	}
+
+	// Setting a key that's already there replaces its value.
+	m.Set(key(5), []byte("five"))
+	require.Equal(t, n, m.Len())
+	v, _ := m.Get(key(5))
+	require.Equal(t, []byte("five"), v)
+
+	require.True(t, m.Delete(key(5)))
+	require.False(t, m.Delete(key(5)))
+	_, ok := m.Get(key(5))
+	require.False(t, ok)
+	require.Equal(t, n-1, m.Len())
+
+	c := m.Clone()
+	seen := 0
+	c.All()(func(k, v []byte) bool {
+		i := int(binary.LittleEndian.Uint64(k))
+		require.Equal(t, key(i*2), v)
+		seen++
+		return true
+	})
+	require.Equal(t, n-1, seen)
+
+	m.Reset()
+	require.Zero(t, m.Len())
+	_, ok = m.Get(key(1))
+	require.False(t, ok)
+	_, ok = c.Get(key(1))
+	require.True(t, ok)
+}
+
+func TestMapCopiesKeys(t *testing.T) {
+	m := NewMap(10)
+	k := []byte("key")
+	m.Set(k, []byte("value"))
+	k[0] = 'x'
+	_, ok := m.Get([]byte("key"))
+	require.True(t, ok)
+	_, ok = m.Get(k)
+	require.False(t, ok)
+}
@@ -0,0 +1,224 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// A Filter whose Contains never takes a lock, for read-heavy workloads where many goroutines query
+// while one goroutine at a time writes. Readers don't block writers, but can be delayed by them;
+// see Contains.
+//
+// Buckets are stored so that each one lies within a single 64-bit word, so readers always see
+// whole buckets. A fingerprint being kicked is briefly in neither of its buckets, so writers
+// publish kicks through a sequence counter and readers that overlap one simply retry.
+//
+// Writers (Add and Delete) are serialized with a mutex. Keeping buckets word-aligned costs some
+// space compared to Filter: up to nearly half for buckets just over 32 bits, but nothing for
+// buckets whose size divides 64.
+type LockFreeFilter struct {
+	// Serializes writers.
+	mu sync.Mutex
+	// Odd while a writer is kicking fingerprints between buckets.
+	seq        atomic.Uint64
+	overflowed atomic.Bool
+	count      atomic.Int64
+	fl         *Filter
+}
+
+// Returns a new LockFreeFilter capable of holding n items with an estimated false-positive rate of
+// fp. See New.
+func NewLockFree(n int, fp float64) *LockFreeFilter {
+	return NewLockFreeRaw(params(n, fp))
+}
+
+// Returns a new LockFreeFilter constructed using raw parameters. See NewRaw.
+func NewLockFreeRaw(f, b, n int) *LockFreeFilter {
+	return &LockFreeFilter{fl: newAlignedFilter(f, b, rawBuckets(f, b, n))}
+}
+
+// Returns a new, empty filter with exactly n buckets, stored so that no bucket straddles two words.
+func newAlignedFilter(f, b, n int) *Filter {
+	enc := bucketEncodingFor(f, b)
+	perWord := 64 / enc.size()
+	return &Filter{
+		aligned:        make([]uint64, (uint64(n)+perWord-1)/perWord),
+		perWord:        perWord,
+		n:              uint64(n),
+		f:              f,
+		b:              b,
+		bucketEncoding: enc,
+		hashing:        hashXXH,
+		rngState:       rand.Uint64(),
+	}
+}
+
+func (fl *Filter) loadAligned(i uint64) uint64 {
+	size := fl.bucketEncoding.size()
+	word := atomic.LoadUint64(&fl.aligned[i/fl.perWord])
+	return (word >> ((i % fl.perWord) * size)) & (^uint64(0) >> (64 - size))
+}
+
+// Only one goroutine may store at a time, but loads may happen concurrently.
+func (fl *Filter) storeAligned(i uint64, bits uint64) {
+	size := fl.bucketEncoding.size()
+	shift := (i % fl.perWord) * size
+	mask := (^uint64(0) >> (64 - size)) << shift
+	p := &fl.aligned[i/fl.perWord]
+	atomic.StoreUint64(p, (atomic.LoadUint64(p)&^mask)|(bits<<shift))
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (lf *LockFreeFilter) Add(x []byte) {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	lf.add(f, i1, i2)
+}
+
+// Adds fingerprint f, whose candidate buckets are i1 and i2. The caller must hold mu.
+func (lf *LockFreeFilter) add(f fingerprint, i1, i2 uint64) {
+	lf.count.Add(1)
+	if lf.fl.overflowed {
+		return
+	}
+	// Landing in an empty slot is a single word write, which readers can't observe half-done.
+	if lf.fl.placeNoKick(f, i1, i2) {
+		return
+	}
+	lf.seq.Add(1)
+	lf.fl.place(f, i1, i2)
+	lf.seq.Add(1)
+	if lf.fl.overflowed {
+		lf.overflowed.Store(true)
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns false and
+// leaves the filter as it was, rather than overflowing it.
+func (lf *LockFreeFilter) TryAdd(x []byte) bool {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if lf.overflowed.Load() {
+		return false
+	}
+	ok := lf.fl.placeNoKick(f, i1, i2)
+	if !ok {
+		lf.seq.Add(1)
+		ok = lf.fl.kick(f, i1, i2, true)
+		lf.seq.Add(1)
+	}
+	if ok {
+		lf.count.Add(1)
+	}
+	return ok
+}
+
+// Adds x to the filter, returning what Contains(x) would have returned just before, as one atomic
+// operation with respect to other writers.
+func (lf *LockFreeFilter) TestAndAdd(x []byte) Result {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	// Only writers modify buckets and they hold mu, so there's no need to check seq here.
+	r := lf.fl.contains(f, i1, i2)
+	lf.add(f, i1, i2)
+	return r
+}
+
+// Adds x to the filter only if Contains(x) would return No, and returns true if it did so, as one
+// atomic operation.
+func (lf *LockFreeFilter) AddIfNotContains(x []byte) bool {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if lf.fl.contains(f, i1, i2) == Maybe {
+		return false
+	}
+	lf.add(f, i1, i2)
+	return true
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (lf *LockFreeFilter) Delete(x []byte) {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	lf.count.Add(-1)
+	if lf.fl.overflowed {
+		return
+	}
+	if !lf.fl.remove(f, i1, i2) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking. The test and the delete are one atomic operation: of several goroutines
+// calling TryDelete with the same x at once, only as many succeed as there were copies of x.
+func (lf *LockFreeFilter) TryDelete(x []byte) bool {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if !lf.fl.overflowed && !lf.fl.remove(f, i1, i2) {
+		return false
+	}
+	lf.count.Add(-1)
+	return true
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter. Never
+// takes a lock, but a read that overlaps a kick is retried until it doesn't, yielding the
+// processor between tries, so it can be delayed for as long as writers keep kicking.
+func (lf *LockFreeFilter) Contains(x []byte) Result {
+	f, i1, i2 := lf.fl.itemToIdxs(x)
+	for {
+		seq := lf.seq.Load()
+		if seq&1 == 0 {
+			if lf.overflowed.Load() {
+				return Maybe
+			}
+			found := lf.fl.lookup(f, i1, i2)
+			if lf.seq.Load() == seq {
+				if found {
+					return Maybe
+				}
+				return No
+			}
+		}
+		// A kick is in progress, so what we read may be missing the fingerprint being moved.
+		runtime.Gosched()
+	}
+}
+
+// Returns false if x is definitely not in the filter, and true if x might be in the filter. The
+// same as Contains(x).IsMaybe().
+func (lf *LockFreeFilter) MayContain(x []byte) bool {
+	return lf.Contains(x) == Maybe
+}
+
+// True if the filter has overflowed, and now blindly returns Maybe for every query.
+func (lf *LockFreeFilter) Overflowed() bool {
+	return lf.overflowed.Load()
+}
+
+// Returns the number of items in the filter.
+func (lf *LockFreeFilter) Count() int {
+	return int(lf.count.Load())
+}
+
+// Returns the number of bytes used by the filter.
+func (lf *LockFreeFilter) SizeBytes() uint64 {
+	return lf.fl.SizeBytes()
+}
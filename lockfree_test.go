@@ -0,0 +1,71 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFreeStorage(t *testing.T) {
+	for _, fb := range [][2]int{{4, 4}, {8, 4}, {10, 4}, {16, 4}, {7, 3}, {2, 1}, {8, 8}} {
+		lf := NewLockFreeRaw(fb[0], fb[1], 100)
+		for i := 0; i < 200; i++ {
+			lf.Add([]byte{byte(i)})
+		}
+		for i := 0; i < 200; i++ {
+			require.Equal(t, Maybe, lf.Contains([]byte{byte(i)}))
+		}
+		stored, err := lf.fl.checkBuckets()
+		require.NoError(t, err)
+		if !lf.Overflowed() {
+			require.Equal(t, lf.Count(), stored)
+		}
+	}
+}
+
+func TestLockFreeConcurrentReaders(t *testing.T) {
+	const n = 50000
+	lf := NewLockFree(n, 0.01)
+	key := func(i int) []byte {
+		return binary.LittleEndian.AppendUint64(nil, uint64(i))
+	}
+
+	// Readers continuously check that everything the writer has finished adding is present, even
+	// while the writer is kicking other fingerprints around.
+	var added atomic.Int64
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				upTo := int(added.Load())
+				for i := 0; i < upTo; i += 97 {
+					if lf.Contains(key(i)) != Maybe {
+						panic("missing item")
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		lf.Add(key(i))
+		added.Store(int64(i + 1))
+	}
+	stop.Store(true)
+	wg.Wait()
+
+	require.False(t, lf.Overflowed())
+	require.Equal(t, n, lf.Count())
+	for i := 0; i < n; i += 2 {
+		lf.Delete(key(i))
+	}
+	for i := 1; i < n; i += 2 {
+		require.Equal(t, Maybe, lf.Contains(key(i)))
+	}
+}
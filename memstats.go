@@ -0,0 +1,41 @@
+package cuckoo
+
+import (
+	"unsafe"
+)
+
+// A breakdown of the memory used by a filter, in bytes.
+type MemStats struct {
+	// The buckets themselves, the same as SizeBytes.
+	Buckets uint64
+	// The table of pages used by filters with paged storage, such as the writer's view of an
+	// EpochFilter. Doesn't include old pages still held by readers of a published epoch.
+	PageTable uint64
+	// Change tracking, once SaveDelta has been called.
+	DeltaTracking uint64
+	// The Filter struct and the state kept for SetLog, not counting anything the log's writer holds.
+	Overhead uint64
+}
+
+// Returns the total number of bytes used by the filter.
+func (s MemStats) Total() uint64 {
+	return s.Buckets + s.PageTable + s.DeltaTracking + s.Overhead
+}
+
+// Returns a breakdown of the memory used by the filter.
+func (fl *Filter) MemStats() MemStats {
+	s := MemStats{
+		Buckets:       fl.SizeBytes(),
+		DeltaTracking: uint64(len(fl.dirty)) * 8,
+		Overhead:      uint64(unsafe.Sizeof(*fl)),
+	}
+	if fl.paged != nil {
+		s.PageTable = uint64(unsafe.Sizeof(*fl.paged)) +
+			uint64(len(fl.paged.pages))*uint64(unsafe.Sizeof(fl.paged.pages[0])) +
+			uint64(len(fl.paged.pageGen))*8
+	}
+	if fl.log != nil {
+		s.Overhead += uint64(unsafe.Sizeof(*fl.log))
+	}
+	return s
+}
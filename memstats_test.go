@@ -0,0 +1,25 @@
+package cuckoo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemStats(t *testing.T) {
+	fl := NewRaw(8, 4, 1000)
+	s := fl.MemStats()
+	// 1024 buckets of 4 8-bit fingerprints, packed into 28 bits each.
+	require.Equal(t, uint64(1024*28/8), s.Buckets)
+	require.Equal(t, uint64(0), s.PageTable)
+	require.Equal(t, uint64(0), s.DeltaTracking)
+	require.NotZero(t, s.Overhead)
+	require.Equal(t, s.Buckets+s.Overhead, s.Total())
+
+	_, err := fl.SaveDelta(&bytes.Buffer{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1024/8), fl.MemStats().DeltaTracking)
+
+	require.NotZero(t, NewEpochRaw(8, 4, 1000).fl.MemStats().PageTable)
+}
@@ -0,0 +1,179 @@
+package cuckoo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Returned when combining filters whose parameters or hashes differ, so that the same item would
+// have a different fingerprint or buckets in each.
+var ErrIncompatible = errors.New("cuckoo: filters are incompatible")
+
+// Returns an error wrapping ErrIncompatible if fl and other don't have the same parameters, number
+// of buckets, and hash settings. Filters using a Hasher must also be using the same one, which can't
+// be checked.
+func (fl *Filter) compatible(other *Filter) error {
+	if fl.f != other.f || fl.b != other.b || fl.nBuckets() != other.nBuckets() {
+		return fmt.Errorf("%w: f=%d, b=%d, %d buckets and f=%d, b=%d, %d buckets", ErrIncompatible,
+			fl.f, fl.b, fl.nBuckets(), other.f, other.b, other.nBuckets())
+	}
+	if fl.hashing != other.hashing || fl.cmu != other.cmu || fl.seed != other.seed {
+		return fmt.Errorf("%w: different hashes", ErrIncompatible)
+	}
+	return nil
+}
+
+// Calls fn with every fingerprint in the filter and the bucket it's in.
+func (fl *Filter) each(fn func(f fingerprint, i uint64)) {
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		x := fl.loadBits(i)
+		if x == 0 {
+			continue
+		}
+		b := fl.bucketEncoding.decode(x)
+		for _, f := range b.entries[:b.l] {
+			if f != 0 {
+				fn(f, i)
+			}
+		}
+	}
+}
+
+// Adds every item in other to fl, so that fl holds the union of the two, as if each item had been
+// added to fl with Add. This lets filters for the shards of a data set be built separately and
+// combined at the end. other must have been made with the same parameters as fl, and have the same
+// seed or Hasher, or Merge returns an error wrapping ErrIncompatible and leaves fl unchanged.
+//
+// Each of other's fingerprints goes into whichever of its buckets in fl has room, kicking others
+// to make room where both are full. If that fails, or other has overflowed, fl is overflowed too
+// and Merge returns ErrOverflowed.
+func (fl *Filter) Merge(other *Filter) error {
+	if err := fl.compatible(other); err != nil {
+		return err
+	}
+	if other == fl {
+		other = fl.Clone()
+	}
+	n := 0
+	other.each(func(f fingerprint, i uint64) {
+		fl.add(f, i, fl.otherIdx(f, i))
+		n++
+	})
+	if other.overflowed {
+		// The items other lost track of can't be added, but they still count.
+		fl.count += other.count - n
+		fl.overflowed = true
+	}
+	if fl.overflowed {
+		return ErrOverflowed
+	}
+	return nil
+}
+
+// Like Merge, but never overflows fl: a fingerprint of other's that can't be placed is left out
+// instead, and MergeFrom returns how many were left out, counting the items other lost track of if
+// it has overflowed. If that's more than zero, fl no longer holds every item of other, and the
+// caller can merge both into a larger filter instead. Items that were placed stay in fl, so
+// merging other into fl again would add them twice.
+//
+// Returns an error wrapping ErrIncompatible, and leaves fl unchanged, if other wasn't made with the
+// same parameters as fl.
+func (fl *Filter) MergeFrom(other *Filter) (int, error) {
+	if err := fl.compatible(other); err != nil {
+		return 0, err
+	}
+	if other == fl {
+		other = fl.Clone()
+	}
+	n, placed := 0, 0
+	other.each(func(f fingerprint, i uint64) {
+		n++
+		if fl.insert(f, i, fl.otherIdx(f, i)) == nil {
+			placed++
+		}
+	})
+	if other.overflowed {
+		n = other.count
+	}
+	return n - placed, nil
+}
+
+// Like Merge, but reads the filter to merge from r, in the format written by WriteTo, a few
+// kilobytes at a time rather than decoding all of it first. Returns the number of bytes read.
+//
+// Returns an error wrapping ErrIncompatible if the encoded filter's parameters differ from fl's,
+// before anything is merged. If reading fails partway, or the rest of the encoding is corrupt, fl
+// keeps the items merged up to that point.
+func (fl *Filter) MergeReader(r io.Reader) (int64, error) {
+	var hBuf [headerSize + seedSize]byte
+	h, read, err := readHeader(r, hBuf[:])
+	if err != nil {
+		return read, err
+	}
+	hdr, err := decodeHeader(h)
+	if err != nil {
+		return read, err
+	}
+	if hdr.hashing == hashCMU {
+		return read, errCMUSerialize
+	}
+	enc := hdr.encoding()
+	src := newFilterWords(hdr.f, hdr.b, int(hdr.nBuckets), enc, nil)
+	src.hashing, src.seed = hdr.hashing, hdr.seed
+	if err := fl.compatible(src); err != nil {
+		return read, err
+	}
+	placed := 0
+	n, err := readBuckets(r, hdr, func(i, bits uint64) {
+		if bits == 0 {
+			return
+		}
+		b := enc.decode(bits)
+		for _, f := range b.entries[:b.l] {
+			if f != 0 {
+				fl.add(f, i, fl.otherIdx(f, i))
+				placed++
+			}
+		}
+	})
+	read += n
+	if err != nil {
+		return read, err
+	}
+	if hdr.overflowed {
+		fl.count += hdr.count - placed
+		fl.overflowed = true
+	}
+	if fl.overflowed {
+		return read, ErrOverflowed
+	}
+	return read, nil
+}
+
+// Returns the union of the filters encoded in srcs, in the format written by WriteTo, which must
+// all have the same parameters. The first is decoded as the start of the result, and each of the
+// rest is read a few kilobytes at a time and merged into it as it's read, so that combining the
+// snapshots of hundreds of shards needs only enough memory for the result. See Merge and
+// MergeReader.
+//
+// The result has the parameters of the first filter. If it overflows, MergeReaders carries on and
+// returns it along with ErrOverflowed. Any other error stops the merge.
+func MergeReaders(srcs ...io.Reader) (*Filter, error) {
+	if len(srcs) == 0 {
+		return nil, errors.New("cuckoo: no filters to merge")
+	}
+	result := &Filter{}
+	if _, err := result.ReadFrom(srcs[0]); err != nil {
+		return nil, err
+	}
+	for _, r := range srcs[1:] {
+		if _, err := result.MergeReader(r); err != nil && err != ErrOverflowed {
+			return nil, err
+		}
+	}
+	if result.overflowed {
+		return result, ErrOverflowed
+	}
+	return result, nil
+}
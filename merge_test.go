@@ -0,0 +1,151 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	const n = 10000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	a := New(n, 0.01)
+	b := New(n, 0.01)
+	for i := 0; i < n/2; i++ {
+		a.Add(key(i))
+		b.Add(key(n/2 + i))
+	}
+	require.NoError(t, a.Merge(b))
+	require.Equal(t, n, a.Count())
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, a.Contains(key(i)))
+	}
+	// b is untouched.
+	require.Equal(t, n/2, b.Count())
+	require.Equal(t, No, b.Contains(key(0)))
+
+	// The merged filter's items can be deleted as usual.
+	for i := 0; i < n; i++ {
+		a.Delete(key(i))
+	}
+	require.Zero(t, a.Count())
+}
+
+func TestMergeSelf(t *testing.T) {
+	fl := New(100, 0.01)
+	fl.Add([]byte("x"))
+	require.NoError(t, fl.Merge(fl))
+	require.Equal(t, 2, fl.Count())
+	fl.Delete([]byte("x"))
+	fl.Delete([]byte("x"))
+	require.Equal(t, No, fl.Contains([]byte("x")))
+}
+
+func TestMergeOverflow(t *testing.T) {
+	a := NewRaw(8, 4, 8)
+	b := NewRaw(8, 4, 8)
+	for i := 0; i < 40; i++ {
+		a.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		b.Add(binary.LittleEndian.AppendUint64(nil, uint64(1000+i)))
+	}
+	require.ErrorIs(t, a.Merge(b), ErrOverflowed)
+	require.True(t, a.Overflowed())
+	require.Equal(t, 80, a.Count())
+}
+
+func TestMergeIncompatible(t *testing.T) {
+	a := New(1000, 0.01)
+	a.Add([]byte("x"))
+	require.ErrorIs(t, a.Merge(New(2000, 0.01)), ErrIncompatible)
+	require.ErrorIs(t, a.Merge(New(1000, 0.0001)), ErrIncompatible)
+	seeded := New(1000, 0.01)
+	seeded.SetSeed(1)
+	require.ErrorIs(t, a.Merge(seeded), ErrIncompatible)
+	require.Equal(t, 1, a.Count())
+}
+
+func TestMergeFrom(t *testing.T) {
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	a := NewRaw(8, 4, 8)
+	b := NewRaw(8, 4, 8)
+	for i := 0; i < 40; i++ {
+		a.Add(key(i))
+		b.Add(key(1000 + i))
+	}
+	left, err := a.MergeFrom(b)
+	require.NoError(t, err)
+	require.Greater(t, left, 0)
+	require.False(t, a.Overflowed())
+	require.Equal(t, 80-left, a.Count())
+	// Nothing a held before is lost.
+	for i := 0; i < 40; i++ {
+		require.Equal(t, Maybe, a.Contains(key(i)))
+	}
+
+	// Retrying into a larger filter places everything.
+	c := NewRaw(8, 4, 64)
+	d := NewRaw(8, 4, 64)
+	for i := 0; i < 40; i++ {
+		c.Add(key(i))
+		d.Add(key(1000 + i))
+	}
+	left, err = c.MergeFrom(d)
+	require.NoError(t, err)
+	require.Zero(t, left)
+	require.Equal(t, 80, c.Count())
+	for i := 0; i < 40; i++ {
+		require.Equal(t, Maybe, c.Contains(key(1000+i)))
+	}
+
+	_, err = c.MergeFrom(a)
+	require.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestMergeReaders(t *testing.T) {
+	const shards, n = 8, 1000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	var srcs []io.Reader
+	// The first filter is decoded as it is, and the rest are merged into it.
+	want := &Filter{}
+	for s := 0; s < shards; s++ {
+		fl := NewExact(shards*n, 0.01)
+		fl.SetSeed(5)
+		for i := s * n; i < (s+1)*n; i++ {
+			fl.Add(key(i))
+		}
+		var buf bytes.Buffer
+		_, err := fl.WriteTo(&buf)
+		require.NoError(t, err)
+		if s == 0 {
+			require.NoError(t, want.UnmarshalBinary(buf.Bytes()))
+		} else {
+			require.NoError(t, want.Merge(fl))
+		}
+		srcs = append(srcs, &buf)
+	}
+	merged, err := MergeReaders(srcs...)
+	require.NoError(t, err)
+	require.True(t, merged.Equal(want))
+	require.Equal(t, uint64(5), merged.Seed())
+	for i := 0; i < shards*n; i++ {
+		require.Equal(t, Maybe, merged.Contains(key(i)))
+	}
+	require.NoError(t, merged.CheckInvariants())
+
+	// Merging into an existing filter checks the parameters first.
+	data, err := New(100, 0.01).MarshalBinary()
+	require.NoError(t, err)
+	_, err = merged.MergeReader(bytes.NewReader(data))
+	require.ErrorIs(t, err, ErrIncompatible)
+	require.True(t, merged.Equal(want))
+
+	data, err = want.MarshalBinary()
+	require.NoError(t, err)
+	_, err = merged.MergeReader(bytes.NewReader(data[:len(data)-1]))
+	require.Error(t, err)
+	_, err = MergeReaders()
+	require.Error(t, err)
+}
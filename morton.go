@@ -0,0 +1,418 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// A cuckoo filter laid out as a Morton filter, for read-heavy workloads. See
+// https://www.vldb.org/pvldb/vol11/p1041-breslow.pdf.
+//
+// The table is split into blocks of one 64-byte cache line each. A block holds many small buckets
+// of up to 3 fingerprints, but rather than reserving room for 3 in every bucket, it stores its
+// fingerprints back to back and keeps a 2-bit count for each bucket. Space goes to the buckets that
+// need it, so a MortonFilter fills further than a Filter of the same size before it overflows.
+//
+// Items go in their first bucket whenever there's room, and each block has a few overflow bits
+// recording which of its buckets have had items pushed out to their second bucket. Looking up an
+// item whose first bucket hasn't overflowed, which is most of them, reads a single cache line, and
+// so does looking up an item that's absent. Deleting items doesn't clear overflow bits, since other
+// items may share them.
+//
+// Items hash the same way as in a Filter, and a MortonFilter's encoding shares Filter's header.
+type MortonFilter struct {
+	// Holds the parameters, count, overflowed state, and hash settings, and maps items to
+	// fingerprints and buckets. Its own buckets are unused.
+	h *Filter
+	// mortonBlockWords words per block, laid out as described by mortonLayout.
+	blocks []uint64
+	// The number of buckets in each block, and the number of fingerprints they share.
+	l, s uint64
+}
+
+const (
+	mortonBlockWords = 8
+	mortonBlockBits  = 64 * mortonBlockWords
+	// The number of overflow bits at the start of each block. Buckets share them, bucket k of a
+	// block using bit k % mortonOverflowBits.
+	mortonOverflowBits = 16
+	// The most fingerprints a bucket holds, which its 2-bit count limits to 3.
+	mortonBucketSize = 3
+	// The fraction of its fingerprint slots NewMorton expects a filter to be able to fill.
+	mortonLoadFactor = 0.95
+)
+
+// Each block is, from its lowest bits:
+//
+//	overflow bits   mortonOverflowBits
+//	counts          2 bits for each of l buckets
+//	fingerprints    f bits for each of s slots, each bucket's fingerprints following the previous
+//	                bucket's
+//
+// mortonLayout returns l and s for f-bit fingerprints. Blocks have about 3/4 of a fingerprint slot
+// per bucket, which is about where a block's slots and its buckets' 3 entries tend to run out at the
+// same time.
+func mortonLayout(f int) (l, s uint64) {
+	const room = mortonBlockBits - mortonOverflowBits
+	l = room * 4 / (8 + 3*uint64(f))
+	s = (room - 2*l) / uint64(f)
+	return l, s
+}
+
+// Returns a new MortonFilter capable of holding n items with an estimated false-positive rate of
+// fp.
+func NewMorton(n int, fp float64) *MortonFilter {
+	f := int(math.Min(math.Max(math.Ceil(math.Log2(2*mortonBucketSize/fp)), 4), 16))
+	return NewMortonRaw(f, int(float64(n)/mortonLoadFactor))
+}
+
+// Returns a new MortonFilter with f-bit fingerprints, where f is in [2, 16], and at least n
+// fingerprint slots. With f of 8 or more, nearly all of the slots can be filled before the filter
+// overflows. Shorter fingerprints give items fewer places to move to, so less of the filter fills:
+// about 90% with f=4.
+func NewMortonRaw(f, n int) *MortonFilter {
+	if f < 2 || f > 16 {
+		panic(fmt.Errorf("%w: fingerprint length f=%d must be in [2, 16]", ErrInvalidParams, f))
+	}
+	if n < 0 {
+		panic(fmt.Errorf("%w: number of slots n=%d must not be negative", ErrInvalidParams, n))
+	}
+	_, s := mortonLayout(f)
+	nBlocks := (uint64(n) + s - 1) / s
+	if nBlocks == 0 {
+		nBlocks = 1
+	}
+	return newMortonFilter(f, nBlocks)
+}
+
+func newMortonFilter(f int, nBlocks uint64) *MortonFilter {
+	l, s := mortonLayout(f)
+	return &MortonFilter{
+		h: newFilterWords(f, mortonBucketSize, int(nBlocks*l),
+			bucketEncodingFor(f, mortonBucketSize), nil),
+		blocks: make([]uint64, nBlocks*mortonBlockWords),
+		l:      l,
+		s:      s,
+	}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (mf *MortonFilter) SetSeed(seed uint64) {
+	mf.h.SetSeed(seed)
+}
+
+// Returns the seed set with SetSeed.
+func (mf *MortonFilter) Seed() uint64 {
+	return mf.h.Seed()
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (mf *MortonFilter) Add(x []byte) {
+	f, i1, i2 := mf.h.itemToIdxs(x)
+	mf.h.count++
+	if !mf.h.overflowed && !mf.kick(f, i1, i2, false) {
+		mf.h.overflowed = true
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns ErrOverflowed
+// and leaves the filter as it was, rather than overflowing it.
+func (mf *MortonFilter) Insert(x []byte) error {
+	f, i1, i2 := mf.h.itemToIdxs(x)
+	if mf.h.overflowed || !mf.kick(f, i1, i2, true) {
+		return ErrOverflowed
+	}
+	mf.h.count++
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (mf *MortonFilter) TryAdd(x []byte) bool {
+	return mf.Insert(x) == nil
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (mf *MortonFilter) Delete(x []byte) {
+	if !mf.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (mf *MortonFilter) TryDelete(x []byte) bool {
+	f, i1, i2 := mf.h.itemToIdxs(x)
+	if !mf.h.overflowed && !mf.removeFrom(i1, f) && !(mf.overflow(i1) && mf.removeFrom(i2, f)) {
+		return false
+	}
+	mf.h.count--
+	return true
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (mf *MortonFilter) Contains(x []byte) Result {
+	f, i1, i2 := mf.h.itemToIdxs(x)
+	if mf.h.overflowed || mf.bucketContains(i1, f) ||
+		(mf.overflow(i1) && mf.bucketContains(i2, f)) {
+		return Maybe
+	}
+	return No
+}
+
+// True if the filter has overflowed, and now blindly returns Maybe for every query.
+func (mf *MortonFilter) Overflowed() bool {
+	return mf.h.overflowed
+}
+
+// Returns the number of items in the filter.
+func (mf *MortonFilter) Count() int {
+	return mf.h.count
+}
+
+// Returns the number of bytes used by the filter's blocks.
+func (mf *MortonFilter) SizeBytes() uint64 {
+	return uint64(len(mf.blocks)) * 8
+}
+
+// Returns Count as a fraction of the number of fingerprint slots in the filter.
+func (mf *MortonFilter) Load() float64 {
+	return float64(mf.h.count) / float64(uint64(len(mf.blocks))/mortonBlockWords*mf.s)
+}
+
+// Removes every item from the filter, reusing its memory.
+func (mf *MortonFilter) Reset() {
+	for i := range mf.blocks {
+		mf.blocks[i] = 0
+	}
+	mf.h.count = 0
+	mf.h.overflowed = false
+}
+
+// Returns an independent copy of the filter.
+func (mf *MortonFilter) Clone() *MortonFilter {
+	c := newMortonFilter(mf.h.f, uint64(len(mf.blocks))/mortonBlockWords)
+	copy(c.blocks, mf.blocks)
+	c.h.count = mf.h.count
+	c.h.overflowed = mf.h.overflowed
+	c.h.hashingFrom(mf.h)
+	c.h.rngState = mf.h.rngState
+	return c
+}
+
+// A move made while kicking: f was written to bucket i, making room by taking vf out of bucket v.
+type mortonKick struct {
+	i, v  uint64
+	f, vf fingerprint
+}
+
+// Places fingerprint f in bucket i1, or failing that i2, kicking other fingerprints to their other
+// buckets to make room if necessary. Returns false if no room could be made, first undoing the
+// kicks if undo is true.
+func (mf *MortonFilter) kick(f fingerprint, i1, i2 uint64, undo bool) bool {
+	if mf.hasRoom(i1) {
+		mf.insertAt(i1, f)
+		return true
+	}
+	// Whatever happens from here, f may end up in i2.
+	mf.setOverflow(i1)
+	if mf.hasRoom(i2) {
+		mf.insertAt(i2, f)
+		return true
+	}
+
+	var pathBuf [16]mortonKick
+	path := pathBuf[:0]
+	is := [2]uint64{i1, i2}
+	i := is[mf.h.randInt()%len(is)]
+	for n := 0; n < maxNumKicks; n++ {
+		// Evict a fingerprint from i, or if i is empty, which means its block has run out of slots,
+		// from another bucket in the block.
+		v := i
+		if mf.bucketLen(i) == 0 {
+			v = mf.nonEmptyNear(i)
+		}
+		vf := mf.entry(v, uint64(mf.h.randInt())%mf.bucketLen(v))
+		mf.removeFrom(v, vf)
+		mf.insertAt(i, f)
+		if undo {
+			path = append(path, mortonKick{i: i, v: v, f: f, vf: vf})
+		}
+		// vf may be leaving its first bucket.
+		mf.setOverflow(v)
+		f, i = vf, mf.h.otherIdx(vf, v)
+		if mf.hasRoom(i) {
+			mf.insertAt(i, f)
+			return true
+		}
+	}
+
+	// Walk the kicks backwards. Overflow bits set along the way stay set, which only costs lookups
+	// of the affected buckets an extra read.
+	for j := len(path) - 1; j >= 0; j-- {
+		mf.removeFrom(path[j].i, path[j].f)
+		mf.insertAt(path[j].v, path[j].vf)
+	}
+	return false
+}
+
+// Returns the block holding bucket i, and i's index within it.
+func (mf *MortonFilter) block(i uint64) ([]uint64, uint64) {
+	start := i / mf.l * mortonBlockWords
+	return mf.blocks[start : start+mortonBlockWords : start+mortonBlockWords], i % mf.l
+}
+
+// Returns the position in a block of the first bit of fingerprint slot j.
+func (mf *MortonFilter) slotBit(j uint64) uint64 {
+	return mortonOverflowBits + 2*mf.l + j*uint64(mf.h.f)
+}
+
+// Returns the number of fingerprints in bucket i.
+func (mf *MortonFilter) bucketLen(i uint64) uint64 {
+	b, k := mf.block(i)
+	return getBits(b, 2, mortonOverflowBits+2*k)
+}
+
+// Returns the total of the counts of the first k buckets of block b, which is the slot that bucket
+// k's fingerprints start at.
+func (mf *MortonFilter) slotsBefore(b []uint64, k uint64) uint64 {
+	var total uint64
+	for bit, end := uint64(mortonOverflowBits), mortonOverflowBits+2*k; bit < end; bit += 64 {
+		n := end - bit
+		if n > 64 {
+			n = 64
+		}
+		x := getBits(b, n, bit)
+		total += uint64(bits.OnesCount64(x&0x5555555555555555)) +
+			2*uint64(bits.OnesCount64(x&0xAAAAAAAAAAAAAAAA))
+	}
+	return total
+}
+
+// True if a fingerprint can be added to bucket i without kicking anything.
+func (mf *MortonFilter) hasRoom(i uint64) bool {
+	b, k := mf.block(i)
+	return getBits(b, 2, mortonOverflowBits+2*k) < mortonBucketSize && mf.slotsBefore(b, mf.l) < mf.s
+}
+
+// Returns the e-th fingerprint in bucket i.
+func (mf *MortonFilter) entry(i, e uint64) fingerprint {
+	b, k := mf.block(i)
+	return fingerprint(getBits(b, uint64(mf.h.f), mf.slotBit(mf.slotsBefore(b, k)+e)))
+}
+
+// True if bucket i contains fingerprint f.
+func (mf *MortonFilter) bucketContains(i uint64, f fingerprint) bool {
+	b, k := mf.block(i)
+	start := mf.slotsBefore(b, k)
+	end := start + getBits(b, 2, mortonOverflowBits+2*k)
+	for j := start; j < end; j++ {
+		if fingerprint(getBits(b, uint64(mf.h.f), mf.slotBit(j))) == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Adds fingerprint f to bucket i, which must have room, shifting the fingerprints of the buckets
+// after it in the block up a slot.
+func (mf *MortonFilter) insertAt(i uint64, f fingerprint) {
+	b, k := mf.block(i)
+	fBits := uint64(mf.h.f)
+	c := getBits(b, 2, mortonOverflowBits+2*k)
+	at := mf.slotsBefore(b, k) + c
+	for j := mf.slotsBefore(b, mf.l); j > at; j-- {
+		setBits(b, fBits, mf.slotBit(j), getBits(b, fBits, mf.slotBit(j-1)))
+	}
+	setBits(b, fBits, mf.slotBit(at), uint64(f))
+	setBits(b, 2, mortonOverflowBits+2*k, c+1)
+}
+
+// Removes one instance of fingerprint f from bucket i, shifting the fingerprints after it in the
+// block down a slot. Returns false if i doesn't contain f.
+func (mf *MortonFilter) removeFrom(i uint64, f fingerprint) bool {
+	b, k := mf.block(i)
+	fBits := uint64(mf.h.f)
+	c := getBits(b, 2, mortonOverflowBits+2*k)
+	start := mf.slotsBefore(b, k)
+	for j := start; j < start+c; j++ {
+		if fingerprint(getBits(b, fBits, mf.slotBit(j))) != f {
+			continue
+		}
+		last := mf.slotsBefore(b, mf.l) - 1
+		for ; j < last; j++ {
+			setBits(b, fBits, mf.slotBit(j), getBits(b, fBits, mf.slotBit(j+1)))
+		}
+		// Keep unused slots zero, so that filters holding the same fingerprints encode the same.
+		setBits(b, fBits, mf.slotBit(last), 0)
+		setBits(b, 2, mortonOverflowBits+2*k, c-1)
+		return true
+	}
+	return false
+}
+
+// Returns the index of a non-empty bucket in the same block as bucket i, chosen at random, which
+// must exist.
+func (mf *MortonFilter) nonEmptyNear(i uint64) uint64 {
+	b, _ := mf.block(i)
+	first := i - i%mf.l
+	k := uint64(mf.h.randInt()) % mf.l
+	for getBits(b, 2, mortonOverflowBits+2*k) == 0 {
+		k = (k + 1) % mf.l
+	}
+	return first + k
+}
+
+// True if some item whose first bucket is i may be in its second bucket instead.
+func (mf *MortonFilter) overflow(i uint64) bool {
+	b, k := mf.block(i)
+	return getBits(b, 1, k%mortonOverflowBits) != 0
+}
+
+// Records that some item whose first bucket is i may be in its second bucket.
+func (mf *MortonFilter) setOverflow(i uint64) {
+	b, k := mf.block(i)
+	setBits(b, 1, k%mortonOverflowBits, 1)
+}
+
+// A MortonFilter's encoding is a Filter header with this magic in place of Filter's, giving the
+// total number of buckets and a bucket size of mortonBucketSize, followed by the blocks, each as
+// mortonBlockWords little-endian words.
+var mortonMagic = [4]byte{'C', 'K', 'M', 'O'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (mf *MortonFilter) MarshalBinary() ([]byte, error) {
+	h := encodeVariantHeader(mortonMagic, mf.h)
+	out := make([]byte, 0, len(h)+len(mf.blocks)*8)
+	out = append(out, h...)
+	for _, w := range mf.blocks {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of mf with the filter encoded in
+// data.
+func (mf *MortonFilter) UnmarshalBinary(data []byte) error {
+	hdr, data, err := decodeVariantHeader(mortonMagic, data)
+	if err != nil {
+		return err
+	}
+	l, _ := mortonLayout(hdr.f)
+	if hdr.b != mortonBucketSize || hdr.nBuckets%l != 0 {
+		return errCorrupt
+	}
+	nBlocks := hdr.nBuckets / l
+	if uint64(len(data)) != nBlocks*mortonBlockWords*8 {
+		return errCorrupt
+	}
+	result := newMortonFilter(hdr.f, nBlocks)
+	for i := range result.blocks {
+		result.blocks[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	hdr.restore(result.h)
+	*mf = *result
+	return nil
+}
@@ -0,0 +1,160 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Checks that each block's counts fit in its slots, and that its unused slots are zero.
+func checkMortonBlocks(t *testing.T, mf *MortonFilter) {
+	nBlocks := uint64(len(mf.blocks)) / mortonBlockWords
+	total := 0
+	for blk := uint64(0); blk < nBlocks; blk++ {
+		b, _ := mf.block(blk * mf.l)
+		used := mf.slotsBefore(b, mf.l)
+		require.LessOrEqual(t, used, mf.s)
+		for j := used; j < mf.s; j++ {
+			require.Zero(t, getBits(b, uint64(mf.h.f), mf.slotBit(j)))
+		}
+		total += int(used)
+	}
+	if !mf.Overflowed() {
+		require.Equal(t, mf.Count(), total)
+	}
+}
+
+// Returns the fingerprints in bucket i, sorted.
+func mortonBucket(mf *MortonFilter, i uint64) []fingerprint {
+	fs := []fingerprint{}
+	for e := uint64(0); e < mf.bucketLen(i); e++ {
+		fs = append(fs, mf.entry(i, e))
+	}
+	sort.Slice(fs, func(a, b int) bool { return fs[a] < fs[b] })
+	return fs
+}
+
+func TestMortonLayout(t *testing.T) {
+	for f := 2; f <= 16; f++ {
+		l, s := mortonLayout(f)
+		require.LessOrEqual(t, mortonOverflowBits+2*l+uint64(f)*s, uint64(mortonBlockBits), "f=%d", f)
+		require.GreaterOrEqual(t, l, uint64(mortonOverflowBits))
+		require.Greater(t, s, l/2)
+	}
+}
+
+func TestMorton(t *testing.T) {
+	mf := NewMorton(10000, 0.01)
+	keys := make([][]byte, 10000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		mf.Add(keys[i])
+	}
+	require.False(t, mf.Overflowed())
+	require.Equal(t, len(keys), mf.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, mf.Contains(x))
+	}
+	checkMortonBlocks(t, mf)
+
+	fps := 0
+	for i := len(keys); i < len(keys)+100000; i++ {
+		if mf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/100000, 0.01)
+
+	for _, x := range keys[:len(keys)/2] {
+		mf.Delete(x)
+	}
+	require.Equal(t, len(keys)/2, mf.Count())
+	for _, x := range keys[len(keys)/2:] {
+		require.Equal(t, Maybe, mf.Contains(x))
+	}
+	checkMortonBlocks(t, mf)
+	require.False(t, mf.TryDelete([]byte("never added")))
+	require.Panics(t, func() { mf.Delete([]byte("never added")) })
+
+	mf.Reset()
+	require.Zero(t, mf.Count())
+	require.Equal(t, No, mf.Contains(keys[0]))
+}
+
+func TestMortonFull(t *testing.T) {
+	for _, f := range []int{4, 8, 16} {
+		mf := NewMortonRaw(f, 1000)
+		var added [][]byte
+		for i := 0; ; i++ {
+			x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+			before := mf.Clone()
+			if !mf.TryAdd(x) {
+				// A failed insert leaves every bucket holding what it did, though maybe in a
+				// different order, and can only have added overflow bits.
+				for i := uint64(0); i < mf.h.nBuckets(); i++ {
+					require.Equal(t, mortonBucket(before, i), mortonBucket(mf, i))
+					require.True(t, mf.overflow(i) || !before.overflow(i))
+				}
+				break
+			}
+			added = append(added, x)
+		}
+		require.False(t, mf.Overflowed())
+		require.Equal(t, len(added), mf.Count())
+		if f >= 8 {
+			require.Greater(t, mf.Load(), 0.95)
+		}
+		for _, x := range added {
+			require.Equal(t, Maybe, mf.Contains(x))
+		}
+		checkMortonBlocks(t, mf)
+
+		mf.Add([]byte("one too many"))
+		for i := 0; i < 100; i++ {
+			mf.Add(binary.LittleEndian.AppendUint64(nil, uint64(len(added)+i)))
+		}
+		require.True(t, mf.Overflowed())
+		require.Equal(t, Maybe, mf.Contains([]byte("never added")))
+	}
+}
+
+func TestMortonSerialize(t *testing.T) {
+	mf := NewMorton(1000, 0.001)
+	mf.SetSeed(12345)
+	for i := 0; i < 900; i++ {
+		mf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := mf.MarshalBinary()
+	require.NoError(t, err)
+
+	var mf2 MortonFilter
+	require.NoError(t, mf2.UnmarshalBinary(data))
+	require.Equal(t, mf.blocks, mf2.blocks)
+	require.Equal(t, mf.Count(), mf2.Count())
+	require.Equal(t, uint64(12345), mf2.Seed())
+	for i := 0; i < 900; i++ {
+		require.Equal(t, Maybe, mf2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, mf2.UnmarshalBinary(data[:len(data)-1]))
+	var fl Filter
+	require.Error(t, fl.UnmarshalBinary(data))
+	flData, err := NewRaw(8, 3, 64).MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, mf2.UnmarshalBinary(flData))
+}
+
+func BenchmarkMortonContains(b *testing.B) {
+	mf := NewMorton(1<<20, 0.001)
+	items := allocItems(1 << 20)
+	for _, item := range items[:len(items)/2] {
+		mf.Add(item)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mf.Contains(items[i%len(items)])
+	}
+}
@@ -0,0 +1,44 @@
+package cuckoo
+
+// Like New, but allocates the buckets outside the Go heap. See NewRawOffHeap.
+func NewOffHeap(n int, fp float64) (*Filter, error) {
+	return NewRawOffHeap(params(n, fp))
+}
+
+// Like TryNewRaw, but allocates the buckets in memory mapped directly from the operating system
+// rather than on the Go heap. The garbage collector never scans or accounts for them, so a very
+// large filter doesn't lengthen GC cycles or count toward GOGC's heap target. Pages are only backed
+// by physical memory once they're written.
+//
+// The memory must be freed by calling Close once the filter is no longer used. Using the filter
+// after Close panics or worse. Filters derived from it, such as with Clone, are ordinary filters on
+// the Go heap.
+//
+// On platforms without mmap, the buckets are allocated on the Go heap as usual and Close does
+// nothing.
+func NewRawOffHeap(f, b, n int) (*Filter, error) {
+	nBuckets, err := checkRaw(f, b, n)
+	if err != nil {
+		return nil, err
+	}
+	enc := bucketEncodingFor(f, b)
+	words, release, err := allocOffHeap(int(packedWords(enc.size(), uint64(nBuckets))))
+	if err != nil {
+		return nil, err
+	}
+	fl := newFilterWords(f, b, nBuckets, enc, words)
+	fl.release = release
+	return fl, nil
+}
+
+// Frees the filter's buckets if they were allocated outside the Go heap by NewRawOffHeap, after
+// which the filter must not be used. Otherwise does nothing. Calling Close again does nothing.
+func (fl *Filter) Close() error {
+	if fl.release == nil {
+		return nil
+	}
+	release := fl.release
+	fl.release = nil
+	fl.words = nil
+	return release()
+}
@@ -0,0 +1,33 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOffHeap(t *testing.T) {
+	const n = 100000
+	fl, err := NewOffHeap(n, 0.01)
+	require.NoError(t, err)
+	require.Equal(t, New(n, 0.01).SizeBytes(), fl.SizeBytes())
+	for i := 0; i < n; i++ {
+		fl.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	require.False(t, fl.Overflowed())
+	require.NoError(t, fl.CheckInvariants())
+
+	// Clones are ordinary filters, which outlive the original.
+	c := fl.Clone()
+	require.NoError(t, fl.Close())
+	require.NoError(t, fl.Close())
+	require.NoError(t, c.Close())
+	require.Equal(t, n, c.Count())
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, c.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	_, err = NewRawOffHeap(17, 4, n)
+	require.ErrorIs(t, err, ErrInvalidParams)
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package cuckoo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Returns n zeroed words from an anonymous private mapping, along with a function that unmaps them.
+func allocOffHeap(n int) ([]uint64, func() error, error) {
+	if n == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	mem, err := syscall.Mmap(-1, 0, n*8, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Mappings are page-aligned, so mem is aligned for uint64.
+	words := unsafe.Slice((*uint64)(unsafe.Pointer(&mem[0])), n)
+	return words, func() error { return syscall.Munmap(mem) }, nil
+}
@@ -0,0 +1,218 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// Partitioned format, for storage engines that keep a separate filter for each block or segment of
+// a file and only want to read the one for the block they're about to read. All multi-byte fields
+// are little-endian.
+//
+//	magic    [4]byte  "CKPT"
+//	filters  each block's Filter, as written by WriteTo, one after another
+//	index    for each block in increasing order of ID: ID uint64, offset uint64, length uint64
+//	footer   index offset uint64, number of blocks uint64, CRC-32C of the index uint32, and the
+//	         magic again
+//
+// The footer is a fixed size at the very end, so a reader can find the index without knowing
+// anything else about the file.
+const (
+	partitionEntrySize  = 8 + 8 + 8
+	partitionFooterSize = 8 + 8 + 4 + 4
+)
+
+var partitionMagic = [4]byte{'C', 'K', 'P', 'T'}
+
+// Returned by PartitionedFilter when asked about a block that has no filter.
+var ErrUnknownBlock = errors.New("cuckoo: no filter for block")
+
+// A block's filter within a partitioned file.
+type partitionEntry struct {
+	id     uint64
+	offset uint64
+	length uint64
+}
+
+// Writes a filter for each of a sequence of blocks to an io.Writer, in a format that
+// OpenPartitioned can read back one block at a time. Blocks can be added in any order, and each
+// block's filter is written as soon as it's added, so only the index is held in memory.
+type PartitionedWriter struct {
+	w      io.Writer
+	fp     float64
+	offset uint64
+	index  []partitionEntry
+	ids    map[uint64]struct{}
+	// The first error returned by w, after which nothing more is written.
+	err error
+}
+
+// Returns a PartitionedWriter that writes to w, and builds the filters for blocks added with
+// AddBlock with an estimated false-positive rate of fp.
+func NewPartitionedWriter(w io.Writer, fp float64) *PartitionedWriter {
+	pw := &PartitionedWriter{w: w, fp: fp, ids: make(map[uint64]struct{})}
+	pw.write(partitionMagic[:])
+	return pw
+}
+
+func (pw *PartitionedWriter) write(b []byte) {
+	if pw.err != nil {
+		return
+	}
+	var n int
+	n, pw.err = pw.w.Write(b)
+	pw.offset += uint64(n)
+}
+
+// Writes a filter holding keys for the block with the given ID, sized for exactly that many keys.
+func (pw *PartitionedWriter) AddBlock(id uint64, keys [][]byte) error {
+	fl := New(len(keys), pw.fp)
+	for _, x := range keys {
+		fl.Add(x)
+	}
+	return pw.AddFilter(id, fl)
+}
+
+// Writes fl as the filter for the block with the given ID, for blocks whose filters need different
+// parameters or are built some other way. fl can be modified or reused once AddFilter returns.
+func (pw *PartitionedWriter) AddFilter(id uint64, fl *Filter) error {
+	if _, ok := pw.ids[id]; ok {
+		return fmt.Errorf("cuckoo: block %d already has a filter", id)
+	}
+	if pw.err != nil {
+		return pw.err
+	}
+	start := pw.offset
+	n, err := fl.WriteTo(pw.w)
+	pw.offset += uint64(n)
+	if err != nil {
+		pw.err = err
+		return err
+	}
+	pw.ids[id] = struct{}{}
+	pw.index = append(pw.index, partitionEntry{id: id, offset: start, length: uint64(n)})
+	return nil
+}
+
+// Writes the index and footer, after which no more blocks can be added. Doesn't close the
+// underlying io.Writer.
+func (pw *PartitionedWriter) Close() error {
+	sort.Slice(pw.index, func(a, b int) bool { return pw.index[a].id < pw.index[b].id })
+	indexOffset := pw.offset
+	crc := crc32.New(crc32c)
+	var buf [partitionEntrySize]byte
+	for _, e := range pw.index {
+		binary.LittleEndian.PutUint64(buf[0:], e.id)
+		binary.LittleEndian.PutUint64(buf[8:], e.offset)
+		binary.LittleEndian.PutUint64(buf[16:], e.length)
+		_, _ = crc.Write(buf[:])
+		pw.write(buf[:])
+	}
+	var footer [partitionFooterSize]byte
+	binary.LittleEndian.PutUint64(footer[0:], indexOffset)
+	binary.LittleEndian.PutUint64(footer[8:], uint64(len(pw.index)))
+	binary.LittleEndian.PutUint32(footer[16:], crc.Sum32())
+	copy(footer[20:], partitionMagic[:])
+	pw.write(footer[:])
+	if pw.err == nil {
+		pw.err = errors.New("cuckoo: PartitionedWriter is closed")
+		return nil
+	}
+	return pw.err
+}
+
+// The per-block filters of a file written by PartitionedWriter, of which only the index is held in
+// memory. Each block's filter is read from the file when it's asked about.
+//
+// A PartitionedFilter is safe for concurrent use if its io.ReaderAt is, which those from os.File and
+// bytes.Reader are.
+type PartitionedFilter struct {
+	r     io.ReaderAt
+	index []partitionEntry
+}
+
+// Reads the index of the partitioned file of the given size that r reads from.
+func OpenPartitioned(r io.ReaderAt, size int64) (*PartitionedFilter, error) {
+	if size < int64(len(partitionMagic)+partitionFooterSize) {
+		return nil, errCorrupt
+	}
+	var footer [partitionFooterSize]byte
+	if _, err := r.ReadAt(footer[:], size-partitionFooterSize); err != nil {
+		return nil, noEOF(err)
+	}
+	indexOffset := binary.LittleEndian.Uint64(footer[0:])
+	n := binary.LittleEndian.Uint64(footer[8:])
+	end := uint64(size - partitionFooterSize)
+	if !bytes.Equal(footer[20:], partitionMagic[:]) || indexOffset < uint64(len(partitionMagic)) ||
+		indexOffset > end || n != (end-indexOffset)/partitionEntrySize ||
+		(end-indexOffset)%partitionEntrySize != 0 {
+		return nil, errCorrupt
+	}
+	buf := make([]byte, end-indexOffset)
+	if _, err := r.ReadAt(buf, int64(indexOffset)); err != nil {
+		return nil, noEOF(err)
+	}
+	if crc32.Checksum(buf, crc32c) != binary.LittleEndian.Uint32(footer[16:]) {
+		return nil, errCorrupt
+	}
+	p := &PartitionedFilter{r: r, index: make([]partitionEntry, n)}
+	for j := range p.index {
+		e := &p.index[j]
+		e.id = binary.LittleEndian.Uint64(buf[j*partitionEntrySize:])
+		e.offset = binary.LittleEndian.Uint64(buf[j*partitionEntrySize+8:])
+		e.length = binary.LittleEndian.Uint64(buf[j*partitionEntrySize+16:])
+		if (j > 0 && e.id <= p.index[j-1].id) || e.offset < uint64(len(partitionMagic)) ||
+			e.offset > indexOffset || e.length > indexOffset-e.offset {
+			return nil, errCorrupt
+		}
+	}
+	return p, nil
+}
+
+// Returns the number of blocks with a filter.
+func (p *PartitionedFilter) NumBlocks() int {
+	return len(p.index)
+}
+
+// Returns the IDs of the blocks with a filter, in increasing order.
+func (p *PartitionedFilter) Blocks() []uint64 {
+	ids := make([]uint64, len(p.index))
+	for j, e := range p.index {
+		ids[j] = e.id
+	}
+	return ids
+}
+
+// Reads the filter for the block with the given ID. Returns ErrUnknownBlock if it has none.
+// Callers that query the same block many times should Load its filter once and keep it.
+func (p *PartitionedFilter) Load(id uint64) (*Filter, error) {
+	j := sort.Search(len(p.index), func(j int) bool { return p.index[j].id >= id })
+	if j == len(p.index) || p.index[j].id != id {
+		return nil, fmt.Errorf("%w %d", ErrUnknownBlock, id)
+	}
+	e := p.index[j]
+	data := make([]byte, e.length)
+	if _, err := p.r.ReadAt(data, int64(e.offset)); err != nil {
+		return nil, noEOF(err)
+	}
+	fl := &Filter{}
+	if err := fl.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return fl, nil
+}
+
+// Returns No if x is definitely not in the block with the given ID, and Maybe if x might be in it,
+// reading only that block's filter. Returns ErrUnknownBlock if the block has no filter.
+func (p *PartitionedFilter) Contains(id uint64, x []byte) (Result, error) {
+	fl, err := p.Load(id)
+	if err != nil {
+		return No, err
+	}
+	return fl.Contains(x), nil
+}
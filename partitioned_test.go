@@ -0,0 +1,115 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Counts the bytes read through it, to check what OpenPartitioned and Load read.
+type countingReaderAt struct {
+	r    *bytes.Reader
+	read int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.r.ReadAt(p, off)
+	c.read += n
+	return n, err
+}
+
+func TestPartitioned(t *testing.T) {
+	var buf bytes.Buffer
+	pw := NewPartitionedWriter(&buf, 0.01)
+	key := func(block, i int) []byte {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(block))
+		return binary.LittleEndian.AppendUint64(x, uint64(i))
+	}
+	// Out of order, and of different sizes.
+	for _, block := range []int{7, 3, 100, 0, 42} {
+		keys := make([][]byte, 100*(block%5+1))
+		for i := range keys {
+			keys[i] = key(block, i)
+		}
+		require.NoError(t, pw.AddBlock(uint64(block), keys))
+	}
+	require.Error(t, pw.AddBlock(3, nil))
+	custom := NewRaw(12, 4, 16)
+	custom.Add([]byte("x"))
+	require.NoError(t, pw.AddFilter(5, custom))
+	require.NoError(t, pw.Close())
+	require.Error(t, pw.AddBlock(6, nil))
+
+	r := &countingReaderAt{r: bytes.NewReader(buf.Bytes())}
+	p, err := OpenPartitioned(r, int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 3, 5, 7, 42, 100}, p.Blocks())
+	require.Equal(t, 6, p.NumBlocks())
+	require.Less(t, r.read, 256)
+
+	for _, block := range []int{0, 3, 7, 42, 100} {
+		before := r.read
+		fl, err := p.Load(uint64(block))
+		require.NoError(t, err)
+		require.Equal(t, fl.serializedSize(), r.read-before)
+		n := 100 * (block%5 + 1)
+		require.Equal(t, n, fl.Count())
+		for i := 0; i < n; i++ {
+			require.Equal(t, Maybe, fl.Contains(key(block, i)))
+		}
+		fps := 0
+		for i := n; i < n+10000; i++ {
+			if fl.Contains(key(block, i)) == Maybe {
+				fps++
+			}
+		}
+		require.Less(t, float64(fps)/10000, 0.02)
+	}
+
+	result, err := p.Contains(5, []byte("x"))
+	require.NoError(t, err)
+	require.Equal(t, Maybe, result)
+	_, err = p.Contains(6, []byte("x"))
+	require.True(t, errors.Is(err, ErrUnknownBlock))
+}
+
+func TestPartitionedEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewPartitionedWriter(&buf, 0.01).Close())
+	p, err := OpenPartitioned(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Zero(t, p.NumBlocks())
+	_, err = p.Load(0)
+	require.True(t, errors.Is(err, ErrUnknownBlock))
+}
+
+func TestPartitionedCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+	pw := NewPartitionedWriter(&buf, 0.01)
+	require.NoError(t, pw.AddBlock(1, [][]byte{[]byte("a"), []byte("b")}))
+	require.NoError(t, pw.AddBlock(2, [][]byte{[]byte("c")}))
+	require.NoError(t, pw.Close())
+	data := buf.Bytes()
+
+	_, err := OpenPartitioned(bytes.NewReader(data[:len(data)-1]), int64(len(data)-1))
+	require.Error(t, err)
+
+	// Flip a bit in the index.
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-partitionFooterSize-1] ^= 1
+	_, err = OpenPartitioned(bytes.NewReader(corrupt), int64(len(corrupt)))
+	require.Error(t, err)
+
+	// Flip a bit in a filter's header, which isn't noticed until it's loaded.
+	corrupt = append([]byte(nil), data...)
+	corrupt[len(partitionMagic)] ^= 0xFF
+	p, err := OpenPartitioned(bytes.NewReader(corrupt), int64(len(corrupt)))
+	require.NoError(t, err)
+	_, err = p.Load(1)
+	require.Error(t, err)
+	_, err = p.Load(2)
+	require.NoError(t, err)
+}
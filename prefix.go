@@ -0,0 +1,260 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// A filter that keeps a separate sub-filter for each prefix of its items, as chosen by a function
+// given by the caller, such as the tenant ID at the start of a key. It presents the same Add and
+// Contains as a single filter, but each prefix can be dropped on its own with Evict, and Prefixes
+// reports the count and size of each.
+//
+// Each sub-filter is a DynamicFilter, so prefixes with very different numbers of items each take
+// only the memory they need, and none of them overflow. An item whose prefix has no sub-filter is
+// definitely not in the filter, so lookups for unknown prefixes are No without any false positives.
+type PrefixFilter struct {
+	prefix func(x []byte) []byte
+	// The number of items and false-positive rate each new sub-filter starts out with.
+	n    int
+	fp   float64
+	seed uint64
+	subs map[string]*DynamicFilter
+}
+
+// Returns a new PrefixFilter whose sub-filters each start out with room for n items and have an
+// estimated false-positive rate below fp. prefix returns the prefix of an item, and is called on
+// every item passed to the filter. It can return a subslice of its argument.
+func NewPrefix(n int, fp float64, prefix func(x []byte) []byte) *PrefixFilter {
+	return &PrefixFilter{prefix: prefix, n: n, fp: fp, subs: make(map[string]*DynamicFilter)}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (pf *PrefixFilter) SetSeed(seed uint64) {
+	if len(pf.subs) != 0 {
+		panic("cuckoo: SetSeed must be called before adding any items")
+	}
+	pf.seed = seed
+}
+
+// Returns the seed set with SetSeed.
+func (pf *PrefixFilter) Seed() uint64 {
+	return pf.seed
+}
+
+// Adds an item to the filter, starting a sub-filter for its prefix if there isn't one. After
+// Add(x) returns, Contains(x) returns Maybe until x's prefix is evicted.
+func (pf *PrefixFilter) Add(x []byte) {
+	p := pf.prefix(x)
+	sub, ok := pf.subs[string(p)]
+	if !ok {
+		sub = NewDynamic(pf.n, pf.fp)
+		sub.SetSeed(pf.seed)
+		pf.subs[string(p)] = sub
+	}
+	sub.Add(x)
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (pf *PrefixFilter) Contains(x []byte) Result {
+	sub, ok := pf.subs[string(pf.prefix(x))]
+	if !ok {
+		return No
+	}
+	return sub.Contains(x)
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (pf *PrefixFilter) Delete(x []byte) {
+	if !pf.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking. A prefix's sub-filter is kept even once it's empty; use Evict to drop it.
+func (pf *PrefixFilter) TryDelete(x []byte) bool {
+	sub, ok := pf.subs[string(pf.prefix(x))]
+	return ok && sub.TryDelete(x)
+}
+
+// Drops the sub-filter for prefix, and with it every item with that prefix. Returns false if there
+// was no sub-filter for prefix.
+func (pf *PrefixFilter) Evict(prefix []byte) bool {
+	if _, ok := pf.subs[string(prefix)]; !ok {
+		return false
+	}
+	delete(pf.subs, string(prefix))
+	return true
+}
+
+// Returns the number of items in the filter.
+func (pf *PrefixFilter) Count() int {
+	n := 0
+	for _, sub := range pf.subs {
+		n += sub.Count()
+	}
+	return n
+}
+
+// Returns the number of bytes used by the buckets of every sub-filter.
+func (pf *PrefixFilter) SizeBytes() uint64 {
+	var n uint64
+	for _, sub := range pf.subs {
+		n += sub.SizeBytes()
+	}
+	return n
+}
+
+// Statistics for one prefix's sub-filter, as produced by Prefixes.
+type PrefixInfo struct {
+	Prefix []byte
+	// The number of items with the prefix.
+	Count int
+	// The number of bytes used by the sub-filter's buckets.
+	SizeBytes uint64
+	// The sub-filter's estimated false-positive rate, for items with the prefix.
+	EstimatedFalsePositiveRate float64
+}
+
+// Returns an iterator over the prefixes with a sub-filter, in increasing order. The filter must not
+// be modified during iteration.
+func (pf *PrefixFilter) Prefixes() func(yield func(PrefixInfo) bool) {
+	return func(yield func(PrefixInfo) bool) {
+		for _, p := range pf.sortedPrefixes() {
+			sub := pf.subs[p]
+			info := PrefixInfo{
+				Prefix:                     []byte(p),
+				Count:                      sub.Count(),
+				SizeBytes:                  sub.SizeBytes(),
+				EstimatedFalsePositiveRate: sub.EstimatedFalsePositiveRate(),
+			}
+			if !yield(info) {
+				return
+			}
+		}
+	}
+}
+
+func (pf *PrefixFilter) sortedPrefixes() []string {
+	ps := make([]string, 0, len(pf.subs))
+	for p := range pf.subs {
+		ps = append(ps, p)
+	}
+	sort.Strings(ps)
+	return ps
+}
+
+// Removes every item from the filter, dropping every sub-filter.
+func (pf *PrefixFilter) Reset() {
+	pf.subs = make(map[string]*DynamicFilter)
+}
+
+// Returns an independent copy of the filter, which uses the same prefix function.
+func (pf *PrefixFilter) Clone() *PrefixFilter {
+	c := *pf
+	c.subs = make(map[string]*DynamicFilter, len(pf.subs))
+	for p, sub := range pf.subs {
+		c.subs[p] = sub.Clone()
+	}
+	return &c
+}
+
+// Serialized format, little-endian:
+//
+//	magic  [4]byte  "CKPX"
+//	n      uint64   the number of items new sub-filters start out with room for
+//	fp     float64
+//	seed   uint64
+//	count  uint32   the number of sub-filters
+//
+// followed by, for each sub-filter in increasing order of prefix, the length of the prefix as a
+// uint32, the prefix, the length of the sub-filter's encoding as a uint64, and its encoding as
+// written by DynamicFilter.MarshalBinary. The prefix function isn't part of the encoding.
+var prefixMagic = [4]byte{'C', 'K', 'P', 'X'}
+
+const prefixHeaderSize = 4 + 8 + 8 + 8 + 4
+
+// Returned by PrefixFilter.UnmarshalBinary on a PrefixFilter that wasn't made by NewPrefix.
+var errNoPrefixFunc = errors.New(
+	"cuckoo: can only unmarshal into a PrefixFilter from NewPrefix, which has a prefix function")
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (pf *PrefixFilter) MarshalBinary() ([]byte, error) {
+	out := append([]byte(nil), prefixMagic[:]...)
+	out = binary.LittleEndian.AppendUint64(out, uint64(pf.n))
+	out = binary.LittleEndian.AppendUint64(out, math.Float64bits(pf.fp))
+	out = binary.LittleEndian.AppendUint64(out, pf.seed)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(pf.subs)))
+	for _, p := range pf.sortedPrefixes() {
+		data, err := pf.subs[p].MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(p)))
+		out = append(out, p...)
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(data)))
+		out = append(out, data...)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of pf with the filter encoded in
+// data. pf must have been made by NewPrefix, and keeps its prefix function, which should be the
+// same as the encoded filter's.
+func (pf *PrefixFilter) UnmarshalBinary(data []byte) error {
+	if pf.prefix == nil {
+		return errNoPrefixFunc
+	}
+	if len(data) < prefixHeaderSize || !bytes.Equal(data[:4], prefixMagic[:]) {
+		return errCorrupt
+	}
+	n := binary.LittleEndian.Uint64(data[4:])
+	result := &PrefixFilter{
+		prefix: pf.prefix,
+		fp:     math.Float64frombits(binary.LittleEndian.Uint64(data[12:])),
+		seed:   binary.LittleEndian.Uint64(data[20:]),
+		subs:   make(map[string]*DynamicFilter),
+	}
+	count := binary.LittleEndian.Uint32(data[28:])
+	if n > uint64(maxInt) || !(result.fp > 0 && result.fp < 1) {
+		return errCorrupt
+	}
+	result.n = int(n)
+	data = data[prefixHeaderSize:]
+	for j := uint32(0); j < count; j++ {
+		if len(data) < 4 {
+			return errCorrupt
+		}
+		pLen := binary.LittleEndian.Uint32(data)
+		data = data[4:]
+		if uint64(pLen)+8 > uint64(len(data)) {
+			return errCorrupt
+		}
+		p := string(data[:pLen])
+		size := binary.LittleEndian.Uint64(data[pLen:])
+		data = data[pLen+8:]
+		if _, ok := result.subs[p]; ok || size > uint64(len(data)) {
+			return errCorrupt
+		}
+		sub := &DynamicFilter{}
+		if err := sub.UnmarshalBinary(data[:size]); err != nil {
+			return err
+		}
+		if sub.Seed() != result.seed {
+			return errCorrupt
+		}
+		result.subs[p] = sub
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return errCorrupt
+	}
+	*pf = *result
+	return nil
+}
@@ -0,0 +1,105 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The tenant of a key from tenantKey.
+func tenantPrefix(x []byte) []byte {
+	return x[:4]
+}
+
+func tenantKey(tenant uint32, i int) []byte {
+	return binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32(nil, tenant), uint64(i))
+}
+
+func TestPrefix(t *testing.T) {
+	pf := NewPrefix(100, 0.01, tenantPrefix)
+	// Tenants of very different sizes.
+	sizes := map[uint32]int{1: 10, 2: 1000, 3: 20000}
+	for tenant, n := range sizes {
+		for i := 0; i < n; i++ {
+			pf.Add(tenantKey(tenant, i))
+		}
+	}
+	require.Equal(t, 21010, pf.Count())
+	for tenant, n := range sizes {
+		for i := 0; i < n; i++ {
+			require.Equal(t, Maybe, pf.Contains(tenantKey(tenant, i)))
+		}
+		fps := 0
+		for i := n; i < n+10000; i++ {
+			if pf.Contains(tenantKey(tenant, i)) == Maybe {
+				fps++
+			}
+		}
+		require.Less(t, float64(fps)/10000, 0.01, fmt.Sprint(tenant))
+	}
+	// A tenant that was never seen has no false positives at all.
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, No, pf.Contains(tenantKey(4, i)))
+	}
+
+	var infos []PrefixInfo
+	pf.Prefixes()(func(info PrefixInfo) bool {
+		infos = append(infos, info)
+		return true
+	})
+	require.Len(t, infos, 3)
+	var total uint64
+	for j, info := range infos {
+		tenant := uint32(j + 1)
+		require.Equal(t, binary.LittleEndian.AppendUint32(nil, tenant), info.Prefix)
+		require.Equal(t, sizes[tenant], info.Count)
+		require.Less(t, info.EstimatedFalsePositiveRate, 0.01)
+		total += info.SizeBytes
+	}
+	require.Equal(t, pf.SizeBytes(), total)
+	require.Less(t, infos[0].SizeBytes, infos[2].SizeBytes)
+
+	pf.Delete(tenantKey(1, 0))
+	require.Equal(t, 21009, pf.Count())
+	require.False(t, pf.TryDelete(tenantKey(4, 0)))
+
+	require.True(t, pf.Evict(binary.LittleEndian.AppendUint32(nil, 3)))
+	require.False(t, pf.Evict(binary.LittleEndian.AppendUint32(nil, 3)))
+	require.Equal(t, 1009, pf.Count())
+	require.Equal(t, No, pf.Contains(tenantKey(3, 0)))
+	require.Equal(t, Maybe, pf.Contains(tenantKey(2, 0)))
+
+	pf.Reset()
+	require.Zero(t, pf.Count())
+	require.Zero(t, pf.SizeBytes())
+}
+
+func TestPrefixSerialize(t *testing.T) {
+	pf := NewPrefix(100, 0.01, tenantPrefix)
+	pf.SetSeed(99)
+	for i := 0; i < 1000; i++ {
+		pf.Add(tenantKey(uint32(i%7), i))
+	}
+	require.Panics(t, func() { pf.SetSeed(1) })
+	data, err := pf.MarshalBinary()
+	require.NoError(t, err)
+
+	var bare PrefixFilter
+	require.Error(t, bare.UnmarshalBinary(data))
+
+	pf2 := NewPrefix(1, 0.5, tenantPrefix)
+	require.NoError(t, pf2.UnmarshalBinary(data))
+	require.Equal(t, pf.Count(), pf2.Count())
+	require.Equal(t, uint64(99), pf2.Seed())
+	require.Equal(t, 100, pf2.n)
+	for i := 0; i < 2000; i++ {
+		x := tenantKey(uint32(i%7), i)
+		require.Equal(t, pf.Contains(x), pf2.Contains(x))
+	}
+
+	require.Error(t, pf2.UnmarshalBinary(data[:len(data)-1]))
+	var d DynamicFilter
+	require.Error(t, d.UnmarshalBinary(data))
+}
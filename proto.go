@@ -0,0 +1,167 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Field numbers of the Filter message in cuckoo.proto.
+const (
+	protoFieldF          = 1
+	protoFieldB          = 2
+	protoFieldNBuckets   = 3
+	protoFieldCount      = 4
+	protoFieldOverflowed = 5
+	protoFieldHashing    = 6
+	protoFieldBuckets    = 7
+	protoFieldSeed       = 8
+	protoFieldDirect     = 9
+)
+
+// Protobuf wire types.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		// proto3 omits default values.
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|protoVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+// Returns the protobuf wire encoding of the Filter message defined in cuckoo.proto. The result can
+// be placed in a bytes field, or unmarshaled into the Go type generated from cuckoo.proto with
+// proto.Unmarshal and embedded in other messages.
+func (fl *Filter) ToProto() ([]byte, error) {
+	data, err := fl.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buckets := data[headerLen(data):]
+
+	out := make([]byte, 0, len(buckets)+48)
+	out = appendProtoVarint(out, protoFieldF, uint64(fl.f))
+	out = appendProtoVarint(out, protoFieldB, uint64(fl.b))
+	out = appendProtoVarint(out, protoFieldNBuckets, fl.nBuckets())
+	out = appendProtoVarint(out, protoFieldCount, uint64(int64(fl.count)))
+	if fl.overflowed {
+		out = appendProtoVarint(out, protoFieldOverflowed, 1)
+	}
+	out = appendProtoVarint(out, protoFieldHashing, uint64(fl.hashing))
+	out = appendProtoVarint(out, protoFieldSeed, fl.seed)
+	if fl.directPackable() {
+		out = appendProtoVarint(out, protoFieldDirect, 1)
+	}
+	out = binary.AppendUvarint(out, protoFieldBuckets<<3|protoBytes)
+	out = binary.AppendUvarint(out, uint64(len(buckets)))
+	out = append(out, buckets...)
+	return out, nil
+}
+
+// Decodes a filter from the protobuf wire encoding of the Filter message defined in cuckoo.proto,
+// as produced by ToProto or by proto.Marshal of the generated Go type.
+func FromProto(data []byte) (*Filter, error) {
+	var (
+		f, b, nBuckets, count, hashing, seed uint64
+		overflowed, direct                   bool
+		buckets                              []byte
+	)
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errCorrupt
+		}
+		data = data[n:]
+		field, wireType := tag>>3, tag&0x7
+
+		var v uint64
+		var bytesV []byte
+		switch wireType {
+		case protoVarint:
+			v, n = binary.Uvarint(data)
+			if n <= 0 {
+				return nil, errCorrupt
+			}
+			data = data[n:]
+		case protoBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || l > uint64(len(data)-n) {
+				return nil, errCorrupt
+			}
+			bytesV = data[n : n+int(l)]
+			data = data[n+int(l):]
+		case protoFixed64:
+			if len(data) < 8 {
+				return nil, errCorrupt
+			}
+			data = data[8:]
+		case protoFixed32:
+			if len(data) < 4 {
+				return nil, errCorrupt
+			}
+			data = data[4:]
+		default:
+			return nil, fmt.Errorf("cuckoo: unsupported protobuf wire type %d", wireType)
+		}
+
+		switch field {
+		case protoFieldF:
+			f = v
+		case protoFieldB:
+			b = v
+		case protoFieldNBuckets:
+			nBuckets = v
+		case protoFieldCount:
+			count = v
+		case protoFieldOverflowed:
+			overflowed = v != 0
+		case protoFieldHashing:
+			hashing = v
+		case protoFieldBuckets:
+			buckets = bytesV
+		case protoFieldSeed:
+			seed = v
+		case protoFieldDirect:
+			direct = v != 0
+		default:
+			// Unknown fields are skipped, as protobuf requires.
+		}
+	}
+
+	if f > 0xFF || b > 0xFF || hashing > 0xF {
+		return nil, errCorrupt
+	}
+	// Reuse the binary format's validation by rebuilding its header.
+	h := make([]byte, headerSize, headerSize+seedSize+len(buckets))
+	copy(h[0:4], serializeMagic[:])
+	h[4] = serializeVersion
+	h[5] = byte(f)
+	h[6] = byte(b)
+	if overflowed {
+		h[7] |= flagOverflowed
+	}
+	if direct {
+		h[7] |= flagDirect
+	}
+	h[7] |= byte(hashing) << flagHashingShift
+	binary.LittleEndian.PutUint64(h[8:16], nBuckets)
+	binary.LittleEndian.PutUint64(h[16:24], count)
+	if seed != 0 {
+		h[7] |= flagSeeded
+		h = binary.LittleEndian.AppendUint64(h, seed)
+	}
+
+	fl := &Filter{}
+	err := fl.UnmarshalBinary(append(h, buckets...))
+	if err != nil {
+		return nil, err
+	}
+	return fl, nil
+}
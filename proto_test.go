@@ -0,0 +1,53 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	fl := New(1000, 0.01)
+	for i := 0; i < 500; i++ {
+		fl.Add([]byte{byte(i), byte(i >> 8)})
+	}
+	data, err := fl.ToProto()
+	require.NoError(t, err)
+
+	fl2, err := FromProto(data)
+	require.NoError(t, err)
+	require.Equal(t, fl.Count(), fl2.Count())
+	for i := 0; i < 500; i++ {
+		require.Equal(t, Maybe, fl2.Contains([]byte{byte(i), byte(i >> 8)}))
+	}
+}
+
+func TestProtoWireFormat(t *testing.T) {
+	fl := NewRaw(8, 2, 1)
+	fl.setBucket(0, bucket{l: 2, entries: [8]fingerprint{0x12, 0x34}})
+	fl.count = 2
+
+	data, err := fl.ToProto()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"0808"+ // fingerprint_bits = 8
+			"1002"+ // bucket_size = 2
+			"1802"+ // num_buckets = 2
+			"2002"+ // count = 2, and hash_scheme is 0 for xxHash, so it's omitted
+			"3a04"+"1234"+"0000", // buckets
+		hex.EncodeToString(data),
+	)
+
+	// Fields in a different order, plus an unknown field, as another encoder might produce.
+	other, err := hex.DecodeString("3a04123400001802" + "f80101" + "08081002" + "2002")
+	require.NoError(t, err)
+	fl2, err := FromProto(other)
+	require.NoError(t, err)
+	require.Equal(t, 2, fl2.Count())
+	require.Equal(t, fl.getBucket(0), fl2.getBucket(0))
+
+	_, err = FromProto(data[:len(data)-1])
+	require.Error(t, err)
+}
@@ -0,0 +1,540 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// A quotient filter, an alternative to Filter that keeps fingerprints in order in one flat table,
+// see https://www.vldb.org/pvldb/vol5/p1627_michaelabender_vldb2012.pdf.
+//
+// Each item's p-bit fingerprint is split into a q-bit quotient, which picks its home slot, and an
+// r-bit remainder, which is stored as close after the home slot as there's room, sorted with the
+// remainders of the items that share or neighbor its home. Three bits per slot track where each
+// remainder belongs. Lookups scan a short cluster of adjacent slots, so a lookup touches one or two
+// cache lines, and the table is read and written sequentially, which suits disks.
+//
+// Since every fingerprint can be recovered from the table, the filter can double in size without
+// the items, by moving a bit from each remainder to its quotient, and two filters with the same
+// fingerprint length can be merged. Both cost a bit of remainder, doubling the false-positive rate.
+// Add does this itself when the table gets too full, so it never overflows until the remainders
+// are used up.
+type QuotientFilter struct {
+	// 2^q slots, each packed into r+3 bits: the remainder above the occupied, continuation, and
+	// shifted bits.
+	slots []uint64
+	q, r  uint
+	count int
+	seed  uint64
+	// True once the filter is full and has no remainder bits left to grow with. It then returns
+	// Maybe for every query.
+	overflowed bool
+}
+
+const (
+	// Set on slot i if some item's quotient is i.
+	qfOccupied = 1 << iota
+	// Set if the slot's remainder belongs to the same run as the one before it.
+	qfContinuation
+	// Set if the slot's remainder isn't in its home slot.
+	qfShifted
+	qfFlags = 3
+)
+
+// The fraction of slots that can be full before Add doubles the table. Lookups slow down quickly as
+// clusters grow past this.
+const qfMaxLoad = 0.9
+
+// Returns a new QuotientFilter capable of holding n items with an estimated false-positive rate of
+// fp before it has to grow.
+func NewQuotient(n int, fp float64) *QuotientFilter {
+	if n < 1 {
+		n = 1
+	}
+	q := uint(math.Ceil(math.Log2(float64(n) / qfMaxLoad)))
+	// A query matches each item with its quotient with probability 2^-r, and there are about as many
+	// of those as the load.
+	r := uint(math.Max(math.Ceil(math.Log2(qfMaxLoad/fp)), 1))
+	return NewQuotientRaw(q, r)
+}
+
+// Returns a new QuotientFilter with 2^q slots and r-bit remainders. q+r must be at most 64, and r
+// at least 1.
+func NewQuotientRaw(q, r uint) *QuotientFilter {
+	if r < 1 || q+r > 64 || q > 48 {
+		panic(fmt.Errorf("%w: quotient q=%d and remainder r=%d must have r >= 1, q <= 48, and "+
+			"q+r <= 64", ErrInvalidParams, q, r))
+	}
+	return &QuotientFilter{
+		slots: make([]uint64, packedWords(uint64(r+qfFlags), uint64(1)<<q)),
+		q:     q,
+		r:     r,
+	}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (qf *QuotientFilter) SetSeed(seed uint64) {
+	if qf.count != 0 {
+		panic("cuckoo: SetSeed must be called before adding any items")
+	}
+	qf.seed = seed
+}
+
+// Returns the seed set with SetSeed.
+func (qf *QuotientFilter) Seed() uint64 {
+	return qf.seed
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (qf *QuotientFilter) Add(x []byte) {
+	qf.count++
+	if qf.overflowed {
+		return
+	}
+	// Without room to grow, keep filling the table, just with longer clusters, but leave a slot
+	// empty so that clusters end.
+	if float64(qf.count) > qfMaxLoad*float64(qf.size()) && qf.Grow() != nil &&
+		uint64(qf.count) >= qf.size() {
+		qf.overflowed = true
+		return
+	}
+	qf.insert(qf.fingerprint(x))
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (qf *QuotientFilter) Contains(x []byte) Result {
+	if qf.overflowed || qf.lookup(qf.fingerprint(x)) {
+		return Maybe
+	}
+	return No
+}
+
+// True if fingerprint fp is in the table.
+func (qf *QuotientFilter) lookup(fp uint64) bool {
+	fq, fr := qf.split(fp)
+	if qf.get(fq)&qfOccupied == 0 {
+		return false
+	}
+	s := qf.runStart(fq)
+	for {
+		if rem := qf.get(s) >> qfFlags; rem == fr {
+			return true
+		} else if rem > fr {
+			return false
+		}
+		s = qf.next(s)
+		if qf.get(s)&qfContinuation == 0 {
+			return false
+		}
+	}
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (qf *QuotientFilter) Delete(x []byte) {
+	if !qf.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (qf *QuotientFilter) TryDelete(x []byte) bool {
+	if !qf.overflowed && !qf.remove(qf.fingerprint(x)) {
+		return false
+	}
+	qf.count--
+	return true
+}
+
+// Returns the number of items in the filter.
+func (qf *QuotientFilter) Count() int {
+	return qf.count
+}
+
+// True if the filter has overflowed, and now blindly returns Maybe for every query.
+func (qf *QuotientFilter) Overflowed() bool {
+	return qf.overflowed
+}
+
+// Returns the number of bytes used by the filter's slots.
+func (qf *QuotientFilter) SizeBytes() uint64 {
+	return uint64(len(qf.slots)) * 8
+}
+
+// Returns an estimate of the filter's current false-positive rate, based on how full it is.
+func (qf *QuotientFilter) EstimatedFalsePositiveRate() float64 {
+	if qf.overflowed {
+		return 1
+	}
+	// The chance that a query's quotient is occupied, times the expected number of remainders there,
+	// each of which matches with probability 2^-r.
+	load := float64(qf.count) / float64(qf.size())
+	return 1 - math.Exp(-load*math.Exp2(-float64(qf.r)))
+}
+
+// Removes every item from the filter, reusing its memory.
+func (qf *QuotientFilter) Reset() {
+	for i := range qf.slots {
+		qf.slots[i] = 0
+	}
+	qf.count = 0
+	qf.overflowed = false
+}
+
+// Returns an independent copy of the filter.
+func (qf *QuotientFilter) Clone() *QuotientFilter {
+	c := *qf
+	c.slots = append([]uint64(nil), qf.slots...)
+	return &c
+}
+
+// Returned by Grow and Merge when the filters have no remainder bits left to give up.
+var ErrNoRemainderBits = errors.New("cuckoo: no remainder bits left")
+
+// Doubles the number of slots in the filter, moving a bit of each remainder to its quotient. This
+// doubles the false-positive rate for the items already in the filter, and the filter holds twice
+// as many before it's as full. Returns ErrNoRemainderBits if the remainders are down to one bit.
+func (qf *QuotientFilter) Grow() error {
+	if qf.r <= 1 || qf.q >= 48 {
+		return ErrNoRemainderBits
+	}
+	qf.rebuild(qf.q+1, qf.r-1, qf)
+	return nil
+}
+
+// Adds every item in other to qf, which must have the same fingerprint length, q+r, and the same
+// seed. qf grows as needed to hold the items of both, and takes on other's larger table if it has
+// one. Returns ErrNoRemainderBits if qf can't grow enough, or an error if the filters' fingerprints
+// don't line up, leaving qf unchanged in either case.
+func (qf *QuotientFilter) Merge(other *QuotientFilter) error {
+	if qf.q+qf.r != other.q+other.r || qf.seed != other.seed {
+		return fmt.Errorf("cuckoo: can't merge quotient filters with different fingerprints")
+	}
+	if qf.overflowed || other.overflowed {
+		qf.count += other.count
+		qf.overflowed = true
+		return nil
+	}
+	q := qf.q
+	if other.q > q {
+		q = other.q
+	}
+	for float64(qf.count+other.count) > qfMaxLoad*float64(uint64(1)<<q) {
+		q++
+	}
+	p := qf.q + qf.r
+	if q >= p || q > 48 {
+		return ErrNoRemainderBits
+	}
+	qf.rebuild(q, p-q, qf, other)
+	return nil
+}
+
+// Replaces qf's slots with 2^q slots of r-bit remainders holding every fingerprint in srcs.
+func (qf *QuotientFilter) rebuild(q, r uint, srcs ...*QuotientFilter) {
+	result := NewQuotientRaw(q, r)
+	result.seed = qf.seed
+	for _, src := range srcs {
+		src.each(func(fp uint64) {
+			result.insert(fp)
+		})
+		result.count += src.count
+	}
+	*qf = *result
+}
+
+// Calls fn with the fingerprint of every item in the filter.
+func (qf *QuotientFilter) each(fn func(fp uint64)) {
+	// Start just after an empty slot, which is before the start of a cluster. There always is one,
+	// since inserts stop short of filling the table.
+	start := uint64(0)
+	for qf.get(start) != 0 {
+		start++
+		if start == qf.size() {
+			return
+		}
+	}
+	var quotient uint64
+	for j := uint64(1); j <= qf.size(); j++ {
+		s := (start + j) & (qf.size() - 1)
+		e := qf.get(s)
+		if e&(qfContinuation|qfShifted) == 0 {
+			if e&qfOccupied == 0 {
+				continue
+			}
+			// The start of a cluster, which is in its home slot.
+			quotient = s
+		} else if e&qfContinuation == 0 {
+			// The start of the next run in the cluster, whose quotient is the next occupied slot.
+			quotient = qf.next(quotient)
+			for qf.get(quotient)&qfOccupied == 0 {
+				quotient = qf.next(quotient)
+			}
+		}
+		fn(quotient<<qf.r | e>>qfFlags)
+	}
+}
+
+func (qf *QuotientFilter) size() uint64 {
+	return uint64(1) << qf.q
+}
+
+func (qf *QuotientFilter) get(s uint64) uint64 {
+	return getPacked(qf.slots, uint64(qf.r+qfFlags), s)
+}
+
+func (qf *QuotientFilter) set(s, e uint64) {
+	setPacked(qf.slots, uint64(qf.r+qfFlags), s, e)
+}
+
+func (qf *QuotientFilter) next(s uint64) uint64 {
+	return (s + 1) & (qf.size() - 1)
+}
+
+func (qf *QuotientFilter) prev(s uint64) uint64 {
+	return (s - 1) & (qf.size() - 1)
+}
+
+// Returns x's fingerprint, the top q+r bits of its hash.
+func (qf *QuotientFilter) fingerprint(x []byte) uint64 {
+	return xxhash64(x, qf.seed) >> (64 - qf.q - qf.r)
+}
+
+// Splits fingerprint fp into its quotient and remainder.
+func (qf *QuotientFilter) split(fp uint64) (uint64, uint64) {
+	return fp >> qf.r, fp & (uint64(1)<<qf.r - 1)
+}
+
+// Returns the slot where the run of remainders with quotient fq starts, or would start.
+func (qf *QuotientFilter) runStart(fq uint64) uint64 {
+	// Back up to the start of the cluster, counting the runs to skip over on the way.
+	b := fq
+	for qf.get(b)&qfShifted != 0 {
+		b = qf.prev(b)
+	}
+	s := b
+	for b != fq {
+		for {
+			s = qf.next(s)
+			if qf.get(s)&qfContinuation == 0 {
+				break
+			}
+		}
+		for {
+			b = qf.next(b)
+			if qf.get(b)&qfOccupied != 0 {
+				break
+			}
+		}
+	}
+	return s
+}
+
+// Adds fingerprint fp to the table, which must have an empty slot.
+func (qf *QuotientFilter) insert(fp uint64) {
+	fq, fr := qf.split(fp)
+	home := qf.get(fq)
+	e := fr << qfFlags
+	if home == 0 {
+		qf.set(fq, e|qfOccupied)
+		return
+	}
+	if home&qfOccupied == 0 {
+		qf.set(fq, home|qfOccupied)
+	}
+	start := qf.runStart(fq)
+	s := start
+	if home&qfOccupied != 0 {
+		// Find where fr goes in its sorted run, after any equal remainders.
+		for {
+			if qf.get(s)>>qfFlags > fr {
+				break
+			}
+			s = qf.next(s)
+			if qf.get(s)&qfContinuation == 0 {
+				break
+			}
+		}
+		if s == start {
+			// fr starts the run, so the old start continues it.
+			qf.set(start, qf.get(start)|qfContinuation)
+		} else {
+			e |= qfContinuation
+		}
+	}
+	if s != fq {
+		e |= qfShifted
+	}
+	qf.shiftIn(s, e)
+}
+
+// Puts e in slot s, shifting everything from s up to the next empty slot along by one. The
+// occupied bits stay where they are, since they belong to the slots rather than the remainders.
+func (qf *QuotientFilter) shiftIn(s, e uint64) {
+	for {
+		prev := qf.get(s)
+		if prev == 0 {
+			qf.set(s, e)
+			return
+		}
+		prev |= qfShifted
+		if prev&qfOccupied != 0 {
+			e |= qfOccupied
+			prev &^= qfOccupied
+		}
+		qf.set(s, e)
+		e = prev
+		s = qf.next(s)
+	}
+}
+
+// True if e starts a run.
+func qfRunStart(e uint64) bool {
+	return e&qfContinuation == 0 && e&(qfOccupied|qfShifted) != 0
+}
+
+// True if e starts a cluster.
+func qfClusterStart(e uint64) bool {
+	return e&qfOccupied != 0 && e&(qfContinuation|qfShifted) == 0
+}
+
+// Removes one copy of fingerprint fp from the table. Returns false if it isn't there.
+func (qf *QuotientFilter) remove(fp uint64) bool {
+	fq, fr := qf.split(fp)
+	home := qf.get(fq)
+	if home&qfOccupied == 0 {
+		return false
+	}
+	s := qf.runStart(fq)
+	for {
+		rem := qf.get(s) >> qfFlags
+		if rem == fr {
+			break
+		} else if rem > fr {
+			return false
+		}
+		s = qf.next(s)
+		if qf.get(s)&qfContinuation == 0 {
+			return false
+		}
+	}
+
+	kill := qf.get(s)
+	replaceRunStart := qfRunStart(kill)
+	if replaceRunStart && qf.get(qf.next(s))&qfContinuation == 0 {
+		// fr was the only remainder with quotient fq.
+		qf.set(fq, qf.get(fq)&^qfOccupied)
+	}
+	qf.shiftOut(s, fq)
+	if replaceRunStart {
+		// Whatever slid into s now starts fq's run.
+		next := qf.get(s)
+		updated := next
+		if next&qfContinuation != 0 {
+			updated &^= qfContinuation
+		}
+		if s == fq && qfRunStart(updated) {
+			updated &^= qfShifted
+		}
+		if updated != next {
+			qf.set(s, updated)
+		}
+	}
+	return true
+}
+
+// Removes the remainder in slot s, which has quotient quot, shifting the rest of its cluster back
+// by one slot. Remainders that slide back into their home slots stop being shifted.
+func (qf *QuotientFilter) shiftOut(s, quot uint64) {
+	orig := s
+	curr := qf.get(s)
+	sp := qf.next(s)
+	for {
+		next := qf.get(sp)
+		currOccupied := curr&qfOccupied != 0
+		if next == 0 || qfClusterStart(next) || sp == orig {
+			qf.set(s, 0)
+			return
+		}
+		updated := next
+		if qfRunStart(next) {
+			for {
+				quot = qf.next(quot)
+				if qf.get(quot)&qfOccupied != 0 {
+					break
+				}
+			}
+			if currOccupied && quot == s {
+				updated &^= qfShifted
+			}
+		}
+		if currOccupied {
+			updated |= qfOccupied
+		} else {
+			updated &^= qfOccupied
+		}
+		qf.set(s, updated)
+		s = sp
+		sp = qf.next(sp)
+		curr = next
+	}
+}
+
+// Serialized format, little-endian:
+//
+//	magic   [4]byte  "CKQF"
+//	q       uint8    log2 of the number of slots
+//	r       uint8    the number of bits in each remainder
+//	flags   uint8    1 if the filter has overflowed
+//	count   uint64
+//	seed    uint64
+//	slots   []uint64 the packed slots
+var quotientMagic = [4]byte{'C', 'K', 'Q', 'F'}
+
+const quotientHeaderSize = 4 + 3 + 8 + 8
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (qf *QuotientFilter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, quotientHeaderSize+len(qf.slots)*8)
+	out = append(out, quotientMagic[:]...)
+	var flags byte
+	if qf.overflowed {
+		flags = 1
+	}
+	out = append(out, byte(qf.q), byte(qf.r), flags)
+	out = binary.LittleEndian.AppendUint64(out, uint64(qf.count))
+	out = binary.LittleEndian.AppendUint64(out, qf.seed)
+	for _, w := range qf.slots {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of qf with the filter encoded in
+// data.
+func (qf *QuotientFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < quotientHeaderSize || !bytes.Equal(data[:4], quotientMagic[:]) {
+		return errCorrupt
+	}
+	q, r, flags := uint(data[4]), uint(data[5]), data[6]
+	count := binary.LittleEndian.Uint64(data[7:])
+	seed := binary.LittleEndian.Uint64(data[15:])
+	data = data[quotientHeaderSize:]
+	if r < 1 || q+r > 64 || q > 48 || flags > 1 || count > uint64(maxInt) ||
+		uint64(len(data)) != packedWords(uint64(r+qfFlags), uint64(1)<<q)*8 {
+		return errCorrupt
+	}
+	result := NewQuotientRaw(q, r)
+	for i := range result.slots {
+		result.slots[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	result.count = int(count)
+	result.seed = seed
+	result.overflowed = flags == 1
+	*qf = *result
+	return nil
+}
@@ -0,0 +1,137 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Returns every fingerprint in qf, sorted.
+func quotientFingerprints(qf *QuotientFilter) []uint64 {
+	var fps []uint64
+	qf.each(func(fp uint64) { fps = append(fps, fp) })
+	sort.Slice(fps, func(i, j int) bool { return fps[i] < fps[j] })
+	return fps
+}
+
+func TestQuotientRandom(t *testing.T) {
+	// Small fingerprints and a small table, so that runs and clusters are long and wrap around.
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 200; iter++ {
+		qf := NewQuotientRaw(5, 3)
+		var want []uint64
+		for op := 0; op < 200; op++ {
+			if len(want) > 0 && (r.Intn(2) == 0 || len(want) >= 28) {
+				j := r.Intn(len(want))
+				require.True(t, qf.remove(want[j]))
+				want = append(want[:j], want[j+1:]...)
+			} else {
+				fp := uint64(r.Intn(1 << 8))
+				qf.insert(fp)
+				want = append(want, fp)
+			}
+			sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+			got := quotientFingerprints(qf)
+			if len(want) == 0 {
+				require.Empty(t, got)
+			} else {
+				require.Equal(t, want, got, "iter %d op %d", iter, op)
+			}
+			for fp := uint64(0); fp < 1<<8; fp++ {
+				i := sort.Search(len(want), func(i int) bool { return want[i] >= fp })
+				require.Equal(t, i < len(want) && want[i] == fp, qf.lookup(fp))
+			}
+		}
+	}
+}
+
+func TestQuotient(t *testing.T) {
+	const fp = 0.01
+	qf := NewQuotient(1000, fp)
+	keys := make([][]byte, 10000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		qf.Add(keys[i])
+	}
+	// It grew past the 1000 items it was sized for.
+	require.False(t, qf.Overflowed())
+	require.Equal(t, len(keys), qf.Count())
+	for _, x := range keys {
+		require.Equal(t, Maybe, qf.Contains(x))
+	}
+	fps := 0
+	const tries = 100000
+	for i := len(keys); i < len(keys)+tries; i++ {
+		if qf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.InDelta(t, qf.EstimatedFalsePositiveRate(), float64(fps)/tries, 0.01)
+
+	for _, x := range keys[:5000] {
+		qf.Delete(x)
+	}
+	require.Equal(t, 5000, qf.Count())
+	for _, x := range keys[5000:] {
+		require.Equal(t, Maybe, qf.Contains(x))
+	}
+
+	c := qf.Clone()
+	qf.Reset()
+	require.Zero(t, qf.Count())
+	require.Equal(t, No, qf.Contains(keys[9999]))
+	require.Equal(t, Maybe, c.Contains(keys[9999]))
+}
+
+func TestQuotientOverflow(t *testing.T) {
+	qf := NewQuotientRaw(4, 1)
+	for i := 0; i < 15; i++ {
+		qf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	require.False(t, qf.Overflowed())
+	qf.Add([]byte("one more"))
+	require.True(t, qf.Overflowed())
+	require.Equal(t, Maybe, qf.Contains([]byte("anything")))
+}
+
+func TestQuotientMerge(t *testing.T) {
+	a := NewQuotient(1000, 0.001)
+	// A bigger table with the same fingerprint length.
+	b := NewQuotientRaw(12, a.q+a.r-12)
+	for i := 0; i < 1000; i++ {
+		a.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		b.Add(binary.LittleEndian.AppendUint64(nil, uint64(i+1000)))
+	}
+	require.NoError(t, a.Merge(b))
+	require.Equal(t, 2000, a.Count())
+	require.Equal(t, uint(12), a.q)
+	for i := 0; i < 2000; i++ {
+		require.Equal(t, Maybe, a.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, a.Merge(NewQuotientRaw(a.q, a.r+1)))
+	seeded := NewQuotientRaw(a.q, a.r)
+	seeded.SetSeed(1)
+	require.Error(t, a.Merge(seeded))
+	require.ErrorIs(t, NewQuotientRaw(10, 1).Grow(), ErrNoRemainderBits)
+}
+
+func TestQuotientSerialize(t *testing.T) {
+	qf := NewQuotient(1000, 0.01)
+	qf.SetSeed(42)
+	for i := 0; i < 1000; i++ {
+		qf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := qf.MarshalBinary()
+	require.NoError(t, err)
+
+	var qf2 QuotientFilter
+	require.NoError(t, qf2.UnmarshalBinary(data))
+	require.Equal(t, qf, &qf2)
+
+	require.Error(t, qf2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, qf2.UnmarshalBinary(append(data, 0)))
+}
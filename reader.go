@@ -0,0 +1,56 @@
+package cuckoo
+
+import (
+	"errors"
+	"io"
+)
+
+// Returned by AddReader and ContainsReader for filters whose hash can't be computed incrementally.
+var errNotStreamable = errors.New(
+	"cuckoo: items can only be read from an io.Reader with the xxHash64 hash schemes")
+
+// Like Add, but the item is everything read from r until io.EOF. The item is hashed as it's read
+// rather than buffered, so this can be used to dedupe large blobs, such as file contents, by their
+// content. AddReader(r) is equivalent to Add of everything in r.
+//
+// Returns an error if reading from r fails, in which case nothing is added, or if the filter's hash
+// can't be computed a piece at a time, which is the case for a Hasher and for filters decoded from
+// other implementations.
+func (fl *Filter) AddReader(r io.Reader) error {
+	f, i1, i2, err := fl.readerToIdxs(r)
+	if err != nil {
+		return err
+	}
+	fl.add(f, i1, i2)
+	return nil
+}
+
+// Like Contains, but the item is everything read from r until io.EOF. See AddReader.
+func (fl *Filter) ContainsReader(r io.Reader) (Result, error) {
+	f, i1, i2, err := fl.readerToIdxs(r)
+	if err != nil {
+		return No, err
+	}
+	return fl.contains(f, i1, i2), nil
+}
+
+// Like itemToIdxs, but for an item read from r.
+func (fl *Filter) readerToIdxs(r io.Reader) (fingerprint, uint64, uint64, error) {
+	switch fl.hashing {
+	case hashXXH:
+		d := newXXH64Digest(fl.seed)
+		if _, err := io.Copy(d, r); err != nil {
+			return 0, 0, 0, err
+		}
+		f, i1, i2 := fl.hashToIdxs(d.Sum64())
+		return f, i1, i2, nil
+	case hashXXH128:
+		lo, hi := newXXH64Digest(fl.seed), newXXH64Digest(fl.seed^xxh128Lane)
+		if _, err := io.Copy(io.MultiWriter(lo, hi), r); err != nil {
+			return 0, 0, 0, err
+		}
+		f, i1, i2 := fl.hash128ToIdxs(lo.Sum64(), hi.Sum64())
+		return f, i1, i2, nil
+	}
+	return 0, 0, 0, errNotStreamable
+}
@@ -0,0 +1,52 @@
+package cuckoo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	for _, setup := range []func(fl *Filter){
+		func(fl *Filter) {},
+		func(fl *Filter) { fl.SetSeed(7) },
+		func(fl *Filter) { fl.Use128BitHash() },
+	} {
+		fl := New(1000, 0.01)
+		setup(fl)
+		blob := func(i int) []byte {
+			return bytes.Repeat([]byte(fmt.Sprintf("blob-%d;", i)), i*13)
+		}
+		for i := 0; i < 500; i++ {
+			// Half through Add and half through AddReader, one byte at a time, to check that
+			// they're interchangeable.
+			if i%2 == 0 {
+				fl.Add(blob(i))
+			} else {
+				require.NoError(t, fl.AddReader(iotest.OneByteReader(bytes.NewReader(blob(i)))))
+			}
+		}
+		for i := 0; i < 500; i++ {
+			require.Equal(t, Maybe, fl.Contains(blob(i)))
+			r, err := fl.ContainsReader(bytes.NewReader(blob(i)))
+			require.NoError(t, err)
+			require.Equal(t, Maybe, r)
+		}
+	}
+
+	fl := New(1000, 0.01)
+	errRead := errors.New("read failed")
+	require.ErrorIs(t, fl.AddReader(io.MultiReader(
+		bytes.NewReader([]byte("abc")),
+		iotest.ErrReader(errRead),
+	)), errRead)
+	require.Equal(t, 0, fl.Count())
+
+	fl.SetHasher(HasherFunc(func(x []byte) uint64 { return xxhash64(x, 1) }))
+	require.Error(t, fl.AddReader(bytes.NewReader([]byte("abc"))))
+}
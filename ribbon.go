@@ -0,0 +1,362 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// An immutable filter built once from a complete set of keys, using close to the least space any
+// filter can for its false-positive rate, for sets that are fixed when they're written, like the
+// keys of an SSTable.
+//
+// This is a Ribbon filter with bumping, after BuRR, see https://arxiv.org/abs/2109.01892. Each key
+// picks a starting row and a 64-bit band of coefficients, and the table is solved so that for every
+// key, the XOR of the f-bit values in the rows its band selects is its fingerprint. A key that
+// wasn't in the set matches with probability 2^-f.
+//
+// Where too many keys start in the same stretch of rows for the equations to have a solution, the
+// keys with the earliest starts in that stretch are bumped to another, much smaller Ribbon filter,
+// and 2 bits for each stretch of 64 rows record how many starts were bumped. That lets the table
+// have only about 1% more rows than there are keys, where a XorFilter needs 12.5%, for about 2% more
+// space than f bits per key all told. Looking up a bumped key looks in the next filter along, but
+// most keys are in the first.
+type RibbonFilter struct {
+	// The first holds most keys, and each after holds the keys bumped from the one before.
+	layers []ribbonLayer
+	f      int
+	// The number of keys the filter was built from.
+	count int
+	seed  uint64
+}
+
+type ribbonLayer struct {
+	// The number of rows.
+	m uint64
+	// f columns of m bits each, each with a word of padding at the end so that a 64-bit band can be
+	// read from any row.
+	columns []uint64
+	// An index into ribbonThresholds for each bucket of ribbonWidth rows, packed 2 bits each. Keys
+	// whose start is less than the threshold into their bucket were bumped.
+	thresholds []uint64
+}
+
+const (
+	// The width of each key's band of coefficients, and the number of rows in each bucket.
+	ribbonWidth = 64
+	// The fraction of extra rows beyond one per key.
+	ribbonOverhead = 0.01
+	// The number of bits in each bucket's threshold.
+	ribbonThresholdBits = 2
+	// The most bits in a fingerprint.
+	ribbonMaxFingerprintBits = 32
+)
+
+// The thresholds a bucket can have. Bumping all of a bucket's keys always leaves a solution.
+var ribbonThresholds = [1 << ribbonThresholdBits]uint64{0, 16, 32, ribbonWidth}
+
+// Returns a new RibbonFilter containing every key produced by keys, with a false-positive rate of
+// about fp.
+//
+// keys is called once, and should call yield for each key until it runs out or yield returns
+// false. The key passed to yield may be reused once yield returns. While building, about 60 bytes
+// per key are held in memory.
+func BuildRibbon(keys func(yield func(key []byte) bool), fp float64) *RibbonFilter {
+	return BuildRibbonSeeded(keys, fp, 0)
+}
+
+// Like BuildRibbon, but mixes seed into the hash of every item. See Filter.SetSeed.
+func BuildRibbonSeeded(
+	keys func(yield func(key []byte) bool),
+	fp float64,
+	seed uint64,
+) *RibbonFilter {
+	rf := &RibbonFilter{
+		f:    int(math.Min(math.Max(math.Ceil(math.Log2(1/fp)), 1), ribbonMaxFingerprintBits)),
+		seed: seed,
+	}
+	var hashes []uint64
+	keys(func(key []byte) bool {
+		hashes = append(hashes, xxhash64(key, seed))
+		return true
+	})
+	rf.count = len(hashes)
+	for layer := 0; len(hashes) > 0 || layer == 0; layer++ {
+		var l ribbonLayer
+		l, hashes = rf.buildLayer(layer, hashes)
+		rf.layers = append(rf.layers, l)
+	}
+	return rf
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (rf *RibbonFilter) Contains(x []byte) Result {
+	h := xxhash64(x, rf.seed)
+	for j := range rf.layers {
+		l := &rf.layers[j]
+		s, c, r := rf.band(j, l.m, h)
+		if s%ribbonWidth < l.threshold(s/ribbonWidth) {
+			continue
+		}
+		if rf.result(l, s, c) == r {
+			return Maybe
+		}
+		return No
+	}
+	return No
+}
+
+// Returns the number of keys the filter was built from, counting repeats.
+func (rf *RibbonFilter) Count() int {
+	return rf.count
+}
+
+// Returns the seed given to BuildRibbonSeeded.
+func (rf *RibbonFilter) Seed() uint64 {
+	return rf.seed
+}
+
+// Returns the number of bytes used by the filter's tables.
+func (rf *RibbonFilter) SizeBytes() uint64 {
+	var n uint64
+	for _, l := range rf.layers {
+		n += uint64(len(l.columns)+len(l.thresholds)) * 8
+	}
+	return n
+}
+
+// Returns the filter's false-positive rate.
+func (rf *RibbonFilter) EstimatedFalsePositiveRate() float64 {
+	return math.Exp2(-float64(rf.f))
+}
+
+// Returns the starting row, the band of coefficients, and the fingerprint of the item whose hash is
+// h in the given layer, which has m rows. Bit j of the band is the coefficient of row s+j, and bit
+// 0 is always set.
+func (rf *RibbonFilter) band(layer int, m uint64, h uint64) (s, c, r uint64) {
+	// Each layer remixes the hash, so that the keys bumped together from one layer spread out in
+	// the next.
+	seed := uint64(layer)
+	h = ribbonMix(h + splitMix64(&seed))
+	s, _ = bits.Mul64(h, m-ribbonWidth+1)
+	c = ribbonMix(h^0x9E3779B97F4A7C15) | 1
+	r = ribbonMix(h^0xBF58476D1CE4E5B9) & (uint64(1)<<uint(rf.f) - 1)
+	return s, c, r
+}
+
+// The finalizer of MurmurHash3.
+func ribbonMix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	return h ^ (h >> 33)
+}
+
+// Returns the XOR of the rows of l selected by band c starting at row s.
+func (rf *RibbonFilter) result(l *ribbonLayer, s, c uint64) uint64 {
+	colWords := ribbonColumnWords(l.m)
+	var r uint64
+	for b := 0; b < rf.f; b++ {
+		col := l.columns[uint64(b)*colWords : uint64(b+1)*colWords]
+		r |= uint64(bits.OnesCount64(getBits(col, ribbonWidth, s)&c)&1) << uint(b)
+	}
+	return r
+}
+
+// Returns the number of words in each column of a layer with m rows.
+func ribbonColumnWords(m uint64) uint64 {
+	return packedWords(1, m) + 1
+}
+
+// Returns the threshold of bucket i.
+func (l *ribbonLayer) threshold(i uint64) uint64 {
+	return ribbonThresholds[getPacked(l.thresholds, ribbonThresholdBits, i)]
+}
+
+// Returns a layer holding the keys with the given hashes, and the hashes of the keys it bumped.
+//
+// Gaussian elimination puts each key's equation into the table of rows, one row per first
+// coefficient: if the row the band starts at is already taken, XORing with the equation there
+// clears the band's first coefficient, and it moves on to its next one. An equation that clears
+// completely depended on others already in the table, and if its fingerprint cleared too it's
+// satisfied along with them. If not, there's no solution, and that bucket's keys are taken back out
+// and put in again with a higher threshold. Back substitution then sets each row's values from the
+// rows after it.
+func (rf *RibbonFilter) buildLayer(layer int, hashes []uint64) (ribbonLayer, []uint64) {
+	m := uint64(math.Ceil(float64(len(hashes))*(1+ribbonOverhead))) + ribbonWidth
+	nBuckets := (m + ribbonWidth - 1) / ribbonWidth
+	l := ribbonLayer{
+		m:          m,
+		columns:    make([]uint64, uint64(rf.f)*ribbonColumnWords(m)),
+		thresholds: make([]uint64, packedWords(ribbonThresholdBits, nBuckets)),
+	}
+
+	type equation struct {
+		s, c, r, h uint64
+	}
+	eqs := make([]equation, len(hashes))
+	for i, h := range hashes {
+		s, c, r := rf.band(layer, m, h)
+		eqs[i] = equation{s: s, c: c, r: r, h: h}
+	}
+	sort.Slice(eqs, func(i, j int) bool { return eqs[i].s < eqs[j].s })
+
+	rows := make([]uint64, m)
+	rhs := make([]uint64, m)
+	var touched []uint64
+	add := func(e equation) bool {
+		s, c, r := e.s, e.c, e.r
+		for {
+			if rows[s] == 0 {
+				rows[s], rhs[s] = c, r
+				touched = append(touched, s)
+				return true
+			}
+			c ^= rows[s]
+			r ^= rhs[s]
+			if c == 0 {
+				return r == 0
+			}
+			tz := uint64(bits.TrailingZeros64(c))
+			s += tz
+			c >>= tz
+		}
+	}
+
+	var bumped []uint64
+	for start := 0; start < len(eqs); {
+		bucket := eqs[start].s / ribbonWidth
+		end := start
+		for end < len(eqs) && eqs[end].s/ribbonWidth == bucket {
+			end++
+		}
+		for t, threshold := range ribbonThresholds {
+			touched = touched[:0]
+			ok := true
+			for _, e := range eqs[start:end] {
+				if e.s%ribbonWidth >= threshold && !add(e) {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				setPacked(l.thresholds, ribbonThresholdBits, bucket, uint64(t))
+				for _, e := range eqs[start:end] {
+					if e.s%ribbonWidth < threshold {
+						bumped = append(bumped, e.h)
+					}
+				}
+				break
+			}
+			for _, s := range touched {
+				rows[s], rhs[s] = 0, 0
+			}
+		}
+		start = end
+	}
+
+	colWords := ribbonColumnWords(m)
+	for i := m; i > 0; i-- {
+		s := i - 1
+		if rows[s] == 0 {
+			// No equation starts here, so any value works.
+			continue
+		}
+		for b := 0; b < rf.f; b++ {
+			col := l.columns[uint64(b)*colWords : uint64(b+1)*colWords]
+			v := (rhs[s] >> uint(b)) & 1
+			v ^= uint64(bits.OnesCount64(rows[s]>>1&getBits(col, ribbonWidth-1, s+1)) & 1)
+			col[s/64] |= v << (s % 64)
+		}
+	}
+	return l, bumped
+}
+
+// Serialized format, little-endian:
+//
+//	magic       [4]byte  "CKRB"
+//	f           uint8    the number of bits in each fingerprint
+//	count       uint64   the number of keys
+//	seed        uint64
+//	nLayers     uint32
+//
+// followed by, for each layer, the number of rows m as a uint64, then its f columns of m bits, each
+// followed by a word of padding, and then its packed thresholds, all as little-endian words.
+var ribbonMagic = [4]byte{'C', 'K', 'R', 'B'}
+
+const ribbonHeaderSize = 4 + 1 + 8 + 8 + 4
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (rf *RibbonFilter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, ribbonHeaderSize+len(rf.layers)*8+int(rf.SizeBytes()))
+	out = append(out, ribbonMagic[:]...)
+	out = append(out, byte(rf.f))
+	out = binary.LittleEndian.AppendUint64(out, uint64(rf.count))
+	out = binary.LittleEndian.AppendUint64(out, rf.seed)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(rf.layers)))
+	for _, l := range rf.layers {
+		out = binary.LittleEndian.AppendUint64(out, l.m)
+		for _, w := range l.columns {
+			out = binary.LittleEndian.AppendUint64(out, w)
+		}
+		for _, w := range l.thresholds {
+			out = binary.LittleEndian.AppendUint64(out, w)
+		}
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of rf with the filter encoded in
+// data.
+func (rf *RibbonFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < ribbonHeaderSize || !bytes.Equal(data[:4], ribbonMagic[:]) {
+		return errCorrupt
+	}
+	result := &RibbonFilter{
+		f:    int(data[4]),
+		seed: binary.LittleEndian.Uint64(data[13:]),
+	}
+	count := binary.LittleEndian.Uint64(data[5:])
+	nLayers := binary.LittleEndian.Uint32(data[21:])
+	data = data[ribbonHeaderSize:]
+	if result.f < 1 || result.f > ribbonMaxFingerprintBits || count > uint64(maxInt) || nLayers == 0 {
+		return errCorrupt
+	}
+	result.count = int(count)
+	readWords := func(n uint64) []uint64 {
+		words := make([]uint64, n)
+		for i := range words {
+			words[i] = binary.LittleEndian.Uint64(data[i*8:])
+		}
+		data = data[n*8:]
+		return words
+	}
+	for j := uint32(0); j < nLayers; j++ {
+		if len(data) < 8 {
+			return errCorrupt
+		}
+		m := binary.LittleEndian.Uint64(data)
+		data = data[8:]
+		if m < ribbonWidth || m > uint64(len(data))*8 {
+			return errCorrupt
+		}
+		nColumns := uint64(result.f) * ribbonColumnWords(m)
+		nThresholds := packedWords(ribbonThresholdBits, (m+ribbonWidth-1)/ribbonWidth)
+		if uint64(len(data))/8 < nColumns+nThresholds {
+			return errCorrupt
+		}
+		result.layers = append(result.layers, ribbonLayer{
+			m:          m,
+			columns:    readWords(nColumns),
+			thresholds: readWords(nThresholds),
+		})
+	}
+	if len(data) != 0 {
+		return errCorrupt
+	}
+	*rf = *result
+	return nil
+}
@@ -0,0 +1,66 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRibbon(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 1000, 100000} {
+		rf := BuildRibbon(xorKeys(n), 0.01)
+		require.Equal(t, n, rf.Count())
+		for i := 0; i < n; i++ {
+			require.Equal(t, Maybe, rf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+		}
+	}
+
+	const n = 100000
+	rf := BuildRibbon(xorKeys(n), 1.0/256)
+	require.Equal(t, 8, rf.f)
+	// Some keys were bumped, to a much smaller second layer.
+	require.Greater(t, len(rf.layers), 1)
+	require.Less(t, rf.layers[1].m, rf.layers[0].m/10)
+
+	fps := 0
+	const tries = 1000000
+	for i := n; i < n+tries; i++ {
+		if rf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.InDelta(t, rf.EstimatedFalsePositiveRate(), float64(fps)/tries, 0.0005)
+
+	// Within 5% of f bits per key.
+	require.Less(t, float64(rf.SizeBytes()*8)/n, 1.05*8)
+}
+
+func TestRibbonDuplicates(t *testing.T) {
+	rf := BuildRibbon(func(yield func(key []byte) bool) {
+		for i := 0; i < 100; i++ {
+			yield([]byte("a"))
+			yield([]byte("b"))
+		}
+	}, 0.01)
+	require.Equal(t, 200, rf.Count())
+	require.Equal(t, Maybe, rf.Contains([]byte("a")))
+	require.Equal(t, Maybe, rf.Contains([]byte("b")))
+}
+
+func TestRibbonSerialize(t *testing.T) {
+	rf := BuildRibbonSeeded(xorKeys(10000), 0.001, 42)
+	data, err := rf.MarshalBinary()
+	require.NoError(t, err)
+
+	var rf2 RibbonFilter
+	require.NoError(t, rf2.UnmarshalBinary(data))
+	require.Equal(t, rf, &rf2)
+	require.Equal(t, uint64(42), rf2.Seed())
+	for i := 0; i < 10000; i++ {
+		require.Equal(t, Maybe, rf2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, rf2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, rf2.UnmarshalBinary(append(data, 0)))
+}
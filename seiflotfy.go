@@ -0,0 +1,103 @@
+package cuckoo
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Compatibility with github.com/seiflotfy/cuckoofilter. That package always uses 8-bit
+// fingerprints and 4-entry buckets, hashes items with MetroHash64 seeded with 1337, and serializes a
+// filter as just the fingerprints of every bucket in order, one byte each.
+const (
+	seiflotfyF    = 8
+	seiflotfyB    = 4
+	seiflotfySeed = 1337
+)
+
+// The alternate-bucket hash of each possible fingerprint.
+var seiflotfyAltHash [256]uint64
+
+func init() {
+	for i := range seiflotfyAltHash {
+		seiflotfyAltHash[i] = metroHash64([]byte{byte(i)}, seiflotfySeed)
+	}
+}
+
+// Returns a new, empty filter that hashes and lays out items the same way as a filter created by
+// github.com/seiflotfy/cuckoofilter's NewFilter(n), so that it can be exported with
+// EncodeSeiflotfy.
+func NewSeiflotfy(n int) *Filter {
+	nBuckets := 1
+	if n > seiflotfyB {
+		nBuckets = (1 << uint(bits.Len64(uint64(n-1)))) / seiflotfyB
+	}
+	fl := newFilter(seiflotfyF, seiflotfyB, nBuckets)
+	fl.hashing = hashSeiflotfy
+	return fl
+}
+
+// Decodes the output of github.com/seiflotfy/cuckoofilter's Filter.Encode into a Filter that
+// answers Contains the same way the original did, so that persisted filters can be migrated
+// without rebuilding them from the source data.
+func DecodeSeiflotfy(data []byte) (*Filter, error) {
+	if len(data) == 0 || len(data)%seiflotfyB != 0 {
+		return nil, fmt.Errorf(
+			"cuckoo: seiflotfy-encoded filter must be a non-empty multiple of %d bytes, got %d",
+			seiflotfyB, len(data),
+		)
+	}
+	nBuckets := len(data) / seiflotfyB
+	if nBuckets&(nBuckets-1) != 0 {
+		return nil, fmt.Errorf(
+			"cuckoo: seiflotfy-encoded filter must have a power-of-two number of buckets, got %d",
+			nBuckets,
+		)
+	}
+
+	fl := newFilter(seiflotfyF, seiflotfyB, nBuckets)
+	fl.hashing = hashSeiflotfy
+	for i := 0; i < nBuckets; i++ {
+		b := bucket{l: seiflotfyB}
+		for j := 0; j < seiflotfyB; j++ {
+			b.entries[j] = fingerprint(data[i*seiflotfyB+j])
+			if b.entries[j] != 0 {
+				fl.count++
+			}
+		}
+		fl.setBucket(uint64(i), b)
+	}
+	return fl, nil
+}
+
+// Encodes the filter in the format read by github.com/seiflotfy/cuckoofilter's Decode. Only
+// filters created by NewSeiflotfy or DecodeSeiflotfy can be encoded this way, since otherwise the
+// other package would hash items differently.
+func (fl *Filter) EncodeSeiflotfy() ([]byte, error) {
+	if fl.hashing != hashSeiflotfy {
+		return nil, fmt.Errorf("cuckoo: only filters from NewSeiflotfy or DecodeSeiflotfy can be " +
+			"encoded for seiflotfy/cuckoofilter")
+	}
+	if fl.overflowed {
+		return nil, fmt.Errorf("cuckoo: cannot encode an overflowed filter for " +
+			"seiflotfy/cuckoofilter")
+	}
+	out := make([]byte, 0, int(fl.nBuckets())*seiflotfyB)
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		b := fl.getBucket(i)
+		for j := 0; j < seiflotfyB; j++ {
+			out = append(out, byte(b.entries[j]))
+		}
+	}
+	return out, nil
+}
+
+func (fl *Filter) seiflotfyHashToIdxs(h uint64) (fingerprint, uint64, uint64) {
+	f := fingerprint(h%255 + 1)
+	i1 := (h >> 32) & (fl.nBuckets() - 1)
+	return f, i1, fl.seiflotfyOtherIdx(f, i1)
+}
+
+func (fl *Filter) seiflotfyOtherIdx(f fingerprint, i1 uint64) uint64 {
+	mask := fl.nBuckets() - 1
+	return (i1 & mask) ^ (seiflotfyAltHash[f] & mask)
+}
@@ -0,0 +1,81 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetroHash64(t *testing.T) {
+	// Expected values from github.com/dgryski/go-metro.
+	require.Equal(t, uint64(0xe2f700c7be596c30), metroHash64([]byte(""), 1337))
+	require.Equal(t, uint64(0xd924f06e80703f5f), metroHash64([]byte("a"), 1337))
+	require.Equal(t, uint64(0x5e610dafc45f24a6), metroHash64([]byte("ab"), 1337))
+	require.Equal(t, uint64(0x316b9ca09733cfe3), metroHash64([]byte("abcdefgh"), 1337))
+	require.Equal(
+		t,
+		uint64(0xbafd023a7d22af7c),
+		metroHash64([]byte("0123456789abcdef0123456789abcdef0123456789"), 1337),
+	)
+}
+
+func TestDecodeSeiflotfy(t *testing.T) {
+	// The output of Encode() from github.com/seiflotfy/cuckoofilter after NewFilter(32) and
+	// inserting each of these keys.
+	keys := []string{"apple", "banana", "cherry", "durian", "elderberry", "fig", "grape"}
+	data, err := hex.DecodeString("93240c007d000000370000008795000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	fl, err := DecodeSeiflotfy(data)
+	require.NoError(t, err)
+	require.Equal(t, len(keys), fl.Count())
+	for _, key := range keys {
+		require.Equal(t, Maybe, fl.Contains([]byte(key)), key)
+	}
+	require.Equal(t, No, fl.Contains([]byte("kiwi")))
+
+	encoded, err := fl.EncodeSeiflotfy()
+	require.NoError(t, err)
+	sortedBuckets := func(data []byte) []bucket {
+		var result []bucket
+		for i := 0; i < len(data); i += 4 {
+			b := bucket{l: 4}
+			for j := 0; j < 4; j++ {
+				b.entries[j] = fingerprint(data[i+j])
+			}
+			b.sort()
+			result = append(result, b)
+		}
+		return result
+	}
+	require.Equal(t, sortedBuckets(data), sortedBuckets(encoded))
+
+	fl2 := NewSeiflotfy(32)
+	for _, key := range keys {
+		fl2.Add([]byte(key))
+	}
+	encoded, err = fl2.EncodeSeiflotfy()
+	require.NoError(t, err)
+	require.Equal(t, sortedBuckets(data), sortedBuckets(encoded))
+
+	_, err = New(100, 0.01).EncodeSeiflotfy()
+	require.Error(t, err)
+	_, err = DecodeSeiflotfy(data[:31])
+	require.Error(t, err)
+	_, err = DecodeSeiflotfy(data[:12])
+	require.Error(t, err)
+}
+
+func TestSeiflotfySerializeRoundTrip(t *testing.T) {
+	fl := NewSeiflotfy(100)
+	fl.Add([]byte("x"))
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+
+	var fl2 Filter
+	require.NoError(t, fl2.UnmarshalBinary(data))
+	require.Equal(t, Maybe, fl2.Contains([]byte("x")))
+	_, err = fl2.EncodeSeiflotfy()
+	require.NoError(t, err)
+}
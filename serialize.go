@@ -0,0 +1,383 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Serialized format. All multi-byte fields are little-endian regardless of the platform the filter
+// was built on, so a filter written on amd64 reads back identically on arm64 or a 32-bit platform.
+//
+//	magic      [4]byte  "CKOO"
+//	version    uint8
+//	f          uint8    fingerprint length in bits
+//	b          uint8    bucket size in entries
+//	flags      uint8    bit 0: overflowed, bit 1: seeded, bit 2: direct buckets, bit 3: unused,
+//	                    bits 4-7: hash scheme
+//	nBuckets   uint64
+//	count      int64
+//	seed       uint64   only present if the seeded flag is set
+//	buckets    nBuckets * bucketBytes, each bucket's encoded bits as a little-endian integer
+//
+// where bucketBytes is the encoded bucket size in bits rounded up to a whole byte. The seed is only
+// written when it's non-zero, which saves 8 bytes for the common unseeded filter. Buckets that can
+// be packed (see bucketEncodingFor) are, unless the direct flag says they're directly encoded, as
+// for a filter from NewRawByteAligned.
+const (
+	serializeVersion = 1
+	// The size of the header without a seed.
+	headerSize = 4 + 1 + 1 + 1 + 1 + 8 + 8
+	seedSize   = 8
+
+	flagOverflowed   = 1 << 0
+	flagSeeded       = 1 << 1
+	flagDirect       = 1 << 2
+	flagHashingShift = 4
+)
+
+var serializeMagic = [4]byte{'C', 'K', 'O', 'O'}
+
+// Returned when a serialized filter cannot be decoded.
+var errCorrupt = errors.New("cuckoo: corrupt serialized filter")
+
+// The CMU hasher's state isn't part of the format, so those filters can only be moved around with
+// EncodeCMU and DecodeCMU.
+var errCMUSerialize = errors.New("cuckoo: filters from DecodeCMU cannot be serialized, use EncodeCMU")
+
+// Returns the number of bytes used to store a single encoded bucket in the serialized format.
+func (fl *Filter) bucketBytes() int {
+	return int((fl.bucketEncoding.size() + 7) / 8)
+}
+
+func (fl *Filter) encodeHeader() []byte {
+	var h [headerSize + seedSize]byte
+	copy(h[0:4], serializeMagic[:])
+	h[4] = serializeVersion
+	h[5] = byte(fl.f)
+	h[6] = byte(fl.b)
+	if fl.overflowed {
+		h[7] |= flagOverflowed
+	}
+	if fl.directPackable() {
+		h[7] |= flagDirect
+	}
+	h[7] |= byte(fl.hashing) << flagHashingShift
+	binary.LittleEndian.PutUint64(h[8:16], fl.nBuckets())
+	binary.LittleEndian.PutUint64(h[16:24], uint64(int64(fl.count)))
+	if fl.seed != 0 {
+		h[7] |= flagSeeded
+		binary.LittleEndian.PutUint64(h[24:32], fl.seed)
+		return h[:]
+	}
+	return h[:headerSize]
+}
+
+// Returns the full length of the header that starts with h, which must be at least headerSize
+// bytes.
+func headerLen(h []byte) int {
+	if h[7]&flagSeeded != 0 {
+		return headerSize + seedSize
+	}
+	return headerSize
+}
+
+// Reads a header from r into h, which must have room for a seed, and returns the part of h that
+// was filled.
+func readHeader(r io.Reader, h []byte) ([]byte, int64, error) {
+	n, err := io.ReadFull(r, h[:headerSize])
+	read := int64(n)
+	if err != nil {
+		return nil, read, noEOF(err)
+	}
+	l := headerLen(h)
+	n, err = io.ReadFull(r, h[headerSize:l])
+	read += int64(n)
+	if err != nil {
+		return nil, read, noEOF(err)
+	}
+	return h[:l], read, nil
+}
+
+// The parameters described by a serialized header.
+type header struct {
+	f, b       int
+	nBuckets   uint64
+	count      int
+	overflowed bool
+	hashing    hashScheme
+	seed       uint64
+	direct     bool
+}
+
+// Returns the encoding of the buckets that follow h.
+func (h header) encoding() bucketEncoding {
+	if h.direct {
+		return directEncoding(h.f, h.b)
+	}
+	return bucketEncodingFor(h.f, h.b)
+}
+
+// Returns an empty filter with the parameters described by h.
+func (h header) newFilter() *Filter {
+	fl := newFilterEncoded(h.f, h.b, int(h.nBuckets), h.encoding())
+	h.restore(fl)
+	return fl
+}
+
+// Returns the number of bytes of bucket data that follow the header.
+func (h header) dataSize() uint64 {
+	return h.nBuckets * ((h.encoding().size() + 7) / 8)
+}
+
+// Parses a serialized header.
+func decodeHeader(h []byte) (header, error) {
+	if len(h) < headerSize || !bytes.Equal(h[0:4], serializeMagic[:]) {
+		return header{}, errCorrupt
+	}
+	if len(h) < headerLen(h) {
+		return header{}, errCorrupt
+	}
+	if h[4] != serializeVersion {
+		return header{}, fmt.Errorf("cuckoo: unsupported serialization version %d", h[4])
+	}
+	f, b := int(h[5]), int(h[6])
+	if f < 2 || f > 16 || b < 1 || b > 8 || f*b > 64 {
+		return header{}, fmt.Errorf("cuckoo: invalid params in serialized filter (f=%d, b=%d)", f, b)
+	}
+	hashing := hashScheme(h[7] >> flagHashingShift)
+	if hashing > hashCMU {
+		return header{}, fmt.Errorf("cuckoo: unknown hash scheme %d in serialized filter", hashing)
+	}
+	nBuckets := binary.LittleEndian.Uint64(h[8:16])
+	// The schemes of other implementations mask the hash, so they need a power of two. See
+	// NewRawExact.
+	masked := hashing == hashSeiflotfy || hashing == hashCMU
+	if nBuckets == 0 || (masked && nBuckets&(nBuckets-1) != 0) || nBuckets > uint64(maxInt)/8 {
+		return header{}, fmt.Errorf("cuckoo: invalid bucket count %d in serialized filter", nBuckets)
+	}
+	var seed uint64
+	if h[7]&flagSeeded != 0 {
+		if hashing != hashXXH && hashing != hashXXH128 {
+			return header{}, fmt.Errorf("cuckoo: hash scheme %d in serialized filter can't be seeded",
+				hashing)
+		}
+		seed = binary.LittleEndian.Uint64(h[headerSize : headerSize+seedSize])
+	}
+	direct := h[7]&flagDirect != 0
+	if direct && !bucketEncodingFor(f, b).isPacked {
+		return header{}, fmt.Errorf(
+			"cuckoo: serialized filter (f=%d, b=%d) can't be flagged as directly encoded", f, b)
+	}
+	return header{
+		f:          f,
+		b:          b,
+		nBuckets:   nBuckets,
+		count:      int(int64(binary.LittleEndian.Uint64(h[16:24]))),
+		overflowed: h[7]&flagOverflowed != 0,
+		hashing:    hashing,
+		seed:       seed,
+		direct:     direct,
+	}, nil
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// Returns the number of bytes in the serialized form of the filter.
+func (fl *Filter) serializedSize() int {
+	return len(fl.encodeHeader()) + int(fl.nBuckets())*fl.bucketBytes()
+}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (fl *Filter) MarshalBinary() ([]byte, error) {
+	if fl.hashing == hashCMU {
+		return nil, errCMUSerialize
+	}
+	out := make([]byte, 0, fl.serializedSize())
+	out = append(out, fl.encodeHeader()...)
+	w := fl.bucketBytes()
+	var buf [8]byte
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		binary.LittleEndian.PutUint64(buf[:], fl.loadBits(i))
+		out = append(out, buf[:w]...)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of fl with the filter encoded in
+// data.
+func (fl *Filter) UnmarshalBinary(data []byte) error {
+	h, err := decodeHeader(data)
+	if err != nil {
+		return err
+	}
+	if h.hashing == hashCMU {
+		return errCMUSerialize
+	}
+	data = data[headerLen(data):]
+	if uint64(len(data)) != h.dataSize() {
+		return errCorrupt
+	}
+	result := h.newFilter()
+	w := result.bucketBytes()
+	var buf [8]byte
+	for i := uint64(0); i < result.nBuckets(); i++ {
+		copy(buf[:w], data[int(i)*w:int(i+1)*w])
+		result.storeBits(i, binary.LittleEndian.Uint64(buf[:]))
+	}
+	*fl = *result
+	return nil
+}
+
+// Implements encoding.TextMarshaler as the standard base64 encoding of MarshalBinary, so that
+// filters can be embedded in YAML, TOML, JSON, or environment variables.
+func (fl *Filter) MarshalText() ([]byte, error) {
+	data, err := fl.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(out, data)
+	return out, nil
+}
+
+// Implements encoding.TextUnmarshaler, replacing the contents of fl with the filter encoded in text
+// by MarshalText.
+func (fl *Filter) UnmarshalText(text []byte) error {
+	data := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(data, text)
+	if err != nil {
+		return fmt.Errorf("cuckoo: invalid base64 in text-encoded filter: %w", err)
+	}
+	return fl.UnmarshalBinary(data[:n])
+}
+
+// Implements io.WriterTo, writing the same encoding as MarshalBinary to w without materializing it
+// all in memory at once.
+func (fl *Filter) WriteTo(w io.Writer) (int64, error) {
+	if fl.hashing == hashCMU {
+		return 0, errCMUSerialize
+	}
+	written := int64(0)
+	n, err := w.Write(fl.encodeHeader())
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	bw := fl.bucketBytes()
+	buf := make([]byte, 0, 4096)
+	var word [8]byte
+	for i := uint64(0); i < fl.nBuckets(); i++ {
+		binary.LittleEndian.PutUint64(word[:], fl.loadBits(i))
+		buf = append(buf, word[:bw]...)
+		if len(buf)+bw > cap(buf) || i == fl.nBuckets()-1 {
+			n, err := w.Write(buf)
+			written += int64(n)
+			if err != nil {
+				return written, err
+			}
+			buf = buf[:0]
+		}
+	}
+	return written, nil
+}
+
+// Implements io.ReaderFrom, replacing the contents of fl with a filter read from r in the encoding
+// written by WriteTo or MarshalBinary.
+func (fl *Filter) ReadFrom(r io.Reader) (int64, error) {
+	var hBuf [headerSize + seedSize]byte
+	h, read, err := readHeader(r, hBuf[:])
+	if err != nil {
+		return read, err
+	}
+	hdr, err := decodeHeader(h)
+	if err != nil {
+		return read, err
+	}
+	if hdr.hashing == hashCMU {
+		return read, errCMUSerialize
+	}
+	result, n, err := readFilter(r, hdr)
+	read += n
+	if err != nil {
+		return read, err
+	}
+	*fl = *result
+	return read, nil
+}
+
+// Reads the buckets that follow header h from r into a new filter with the parameters h describes,
+// and returns it along with the number of bytes read. The header alone can't be trusted, so rather
+// than allocating every bucket h claims up front, the filter's words grow as the buckets arrive: a
+// stream that's truncated or lies about its size costs no more than about twice what it holds.
+func readFilter(r io.Reader, h header) (*Filter, int64, error) {
+	enc := h.encoding()
+	k := enc.size()
+	total := packedWords(k, h.nBuckets)
+	result := newFilterWords(h.f, h.b, int(h.nBuckets), enc, nil)
+	h.restore(result)
+	read, err := readBuckets(r, h, func(i, bits uint64) {
+		result.growWords(packedWords(k, i+1), total)
+		result.storeBits(i, bits)
+	})
+	if err != nil {
+		return nil, read, err
+	}
+	result.growWords(total, total)
+	return result, read, nil
+}
+
+// Extends fl.words to at least need words, but no more than total, for filters whose words are
+// filled in as their buckets are read. Grows by at least double, so that growing one bucket at a
+// time takes amortized constant time.
+func (fl *Filter) growWords(need, total uint64) {
+	if need <= uint64(len(fl.words)) {
+		return
+	}
+	n := 2 * uint64(len(fl.words))
+	if n < need {
+		n = need
+	}
+	if n > total {
+		n = total
+	}
+	fl.words = append(fl.words, make([]uint64, n-uint64(len(fl.words)))...)
+}
+
+// Reads the buckets that follow header h from r, calling fn with the index and encoded bits of each
+// in turn, and returns the number of bytes read.
+func readBuckets(r io.Reader, h header, fn func(i, bits uint64)) (int64, error) {
+	bw := int((h.encoding().size() + 7) / 8)
+	buf := make([]byte, 4096/bw*bw)
+	var word [8]byte
+	var read int64
+	for i := uint64(0); i < h.nBuckets; {
+		chunk := buf
+		if remaining := (h.nBuckets - i) * uint64(bw); remaining < uint64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := io.ReadFull(r, chunk)
+		read += int64(n)
+		if err != nil {
+			return read, noEOF(err)
+		}
+		for j := 0; j < len(chunk); j += bw {
+			copy(word[:bw], chunk[j:j+bw])
+			fn(i, binary.LittleEndian.Uint64(word[:]))
+			i++
+		}
+	}
+	return read, nil
+}
+
+// A truncated stream is a corrupt filter, not a clean end of input.
+func noEOF(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errCorrupt
+	}
+	return err
+}
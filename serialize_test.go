@@ -0,0 +1,180 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeRoundTrip(t *testing.T) {
+	trand.RandomN(t, 50, func(t *testing.T, r *rand.Rand) {
+		f := r.Int()%15 + 2
+		b := r.Int()%8 + 1
+		if f*b > 64 {
+			b = 64 / f
+		}
+		n := r.Int()%500 + 1
+		fl := NewRaw(f, b, n)
+		if r.Intn(2) == 0 {
+			fl.SetSeed(r.Uint64())
+		}
+		items := make([][]byte, n)
+		for i := range items {
+			var key [8]byte
+			_, _ = r.Read(key[:])
+			items[i] = key[:]
+			fl.Add(items[i])
+		}
+
+		data, err := fl.MarshalBinary()
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		written, err := fl.WriteTo(&buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(data)), written)
+		require.Equal(t, data, buf.Bytes())
+
+		var fl2 Filter
+		require.NoError(t, fl2.UnmarshalBinary(data))
+		var fl3 Filter
+		read, err := fl3.ReadFrom(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, written, read)
+
+		for _, other := range []*Filter{&fl2, &fl3} {
+			require.Equal(t, fl.Count(), other.Count())
+			require.Equal(t, fl.Overflowed(), other.Overflowed())
+			require.Equal(t, fl.Seed(), other.Seed())
+			require.Equal(t, fl.SizeBytes(), other.SizeBytes())
+			for i := uint64(0); i < fl.nBuckets(); i++ {
+				require.Equal(t, fl.loadBits(i), other.loadBits(i))
+			}
+			for _, item := range items {
+				require.Equal(t, fl.Contains(item), other.Contains(item))
+			}
+			require.NoError(t, other.CheckInvariants())
+		}
+	})
+}
+
+func TestSerializePacked8(t *testing.T) {
+	packed := newFilter(8, 8, 64)
+	direct := NewRawByteAligned(8, 8, 64)
+	require.Less(t, packed.SizeBytes(), direct.SizeBytes())
+	for i := 0; i < 400; i++ {
+		packed.Add([]byte{byte(i), byte(i >> 8)})
+		direct.Add([]byte{byte(i), byte(i >> 8)})
+	}
+	for _, fl := range []*Filter{packed, direct} {
+		data, err := fl.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, fl == direct, data[7]&flagDirect != 0)
+		var fl2 Filter
+		require.NoError(t, fl2.UnmarshalBinary(data))
+		require.Equal(t, fl.bucketEncoding, fl2.bucketEncoding)
+
+		data, err = fl.ToProto()
+		require.NoError(t, err)
+		fl3, err := FromProto(data)
+		require.NoError(t, err)
+		require.Equal(t, fl.bucketEncoding, fl3.bucketEncoding)
+
+		for _, other := range []*Filter{&fl2, fl3, fl.Clone()} {
+			require.True(t, fl.Equal(other))
+			for i := 0; i < 400; i++ {
+				require.Equal(t, Maybe, other.Contains([]byte{byte(i), byte(i >> 8)}))
+			}
+			require.NoError(t, other.CheckInvariants())
+		}
+	}
+}
+
+func TestSerializeLittleEndian(t *testing.T) {
+	// The encoding must not depend on the platform's byte order, so pin it down exactly.
+	fl := NewRaw(8, 2, 1)
+	fl.setBucket(0, bucket{l: 2, entries: [8]fingerprint{0x12, 0x34}})
+	fl.setBucket(1, bucket{l: 2, entries: [8]fingerprint{0xAB, 0x00}})
+	fl.count = 3
+
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"434b4f4f"+"01"+"08"+"02"+"00"+
+			"0200000000000000"+
+			"0300000000000000"+
+			"1234"+"ab00",
+		hex.EncodeToString(data),
+	)
+}
+
+func TestSerializeCorrupt(t *testing.T) {
+	fl := NewRaw(4, 4, 16)
+	fl.Add([]byte{0x01})
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+
+	var fl2 Filter
+	require.Error(t, fl2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, fl2.UnmarshalBinary(data[:headerSize-1]))
+	_, err = fl2.ReadFrom(bytes.NewReader(data[:len(data)-1]))
+	require.Error(t, err)
+
+	bad := append([]byte{}, data...)
+	bad[0] = 'X'
+	require.Error(t, fl2.UnmarshalBinary(bad))
+
+	bad = append([]byte{}, data...)
+	bad[5] = 17
+	require.Error(t, fl2.UnmarshalBinary(bad))
+}
+
+func TestSerializeHugeHeader(t *testing.T) {
+	fl := New(1000, 0.01)
+	fl.Add([]byte("a"))
+	data, err := fl.MarshalBinary()
+	require.NoError(t, err)
+	// Claims 2^27 buckets, hundreds of megabytes and about the most a 32-bit platform accepts, but
+	// holds only a few kilobytes. Reading it mustn't try to allocate what the header claims.
+	binary.LittleEndian.PutUint64(data[8:16], 1<<27)
+
+	var fl2 Filter
+	_, err = fl2.ReadFrom(bytes.NewReader(data))
+	require.ErrorIs(t, err, errCorrupt)
+	_, err = MergeReaders(bytes.NewReader(data))
+	require.ErrorIs(t, err, errCorrupt)
+	_, err = Recover(bytes.NewReader(data), bytes.NewReader(nil))
+	require.ErrorIs(t, err, errCorrupt)
+}
+
+func TestSerializeText(t *testing.T) {
+	fl := New(100, 0.01)
+	fl.Add([]byte("a"))
+	fl.Add([]byte("b"))
+
+	text, err := fl.MarshalText()
+	require.NoError(t, err)
+	var fl2 Filter
+	require.NoError(t, fl2.UnmarshalText(text))
+	require.Equal(t, 2, fl2.Count())
+	require.Equal(t, Maybe, fl2.Contains([]byte("a")))
+	require.Equal(t, Maybe, fl2.Contains([]byte("b")))
+
+	// Works when embedded in other text formats.
+	type config struct {
+		Filter *Filter
+	}
+	data, err := json.Marshal(config{Filter: fl})
+	require.NoError(t, err)
+	var c config
+	require.NoError(t, json.Unmarshal(data, &c))
+	require.Equal(t, Maybe, c.Filter.Contains([]byte("a")))
+
+	require.Error(t, fl2.UnmarshalText([]byte("not base64!")))
+}
@@ -0,0 +1,108 @@
+package cuckoo
+
+import "math"
+
+// Identifies an item by what a filter keeps of it: its fingerprint and its pair of candidate
+// buckets, named by the lower of the two. Items that match in two filters with the same parameters
+// are the same item, or one of the rare pairs of different items that collide.
+func (fl *Filter) itemKey(f fingerprint, i uint64) uint64 {
+	alt := fl.otherIdx(f, i)
+	if alt < i {
+		i = alt
+	}
+	return i<<16 | uint64(f)
+}
+
+// Returns the number of items that fl and other have in common, judging by their fingerprints, as a
+// multiset: an item added twice to each is counted twice.
+func (fl *Filter) matches(other *Filter) int {
+	counts := make(map[uint64]int, fl.count)
+	fl.each(func(f fingerprint, i uint64) {
+		counts[fl.itemKey(f, i)]++
+	})
+	n := 0
+	other.each(func(f fingerprint, i uint64) {
+		k := other.itemKey(f, i)
+		if counts[k] > 0 {
+			counts[k]--
+			n++
+		}
+	})
+	return n
+}
+
+// Returns an estimate of the number of items in both fl and other, which must have been made with
+// the same parameters and seed or Hasher. Items are matched by their fingerprints and buckets
+// without any of the original items, so this is cheap enough to compare, say, each day's set of
+// keys with the day before's. The estimate corrects for the different items whose fingerprints happen to
+// match, which is only a noticeable share of the matches when the true overlap is small.
+//
+// Returns an error wrapping ErrIncompatible if the filters have different parameters, and
+// ErrOverflowed if either has overflowed, since neither knows all of its items.
+func (fl *Filter) EstimateIntersection(other *Filter) (float64, error) {
+	if err := fl.compatible(other); err != nil {
+		return 0, err
+	}
+	if fl.overflowed || other.overflowed {
+		return 0, ErrOverflowed
+	}
+	m := float64(fl.matches(other))
+	a, b := float64(fl.count), float64(other.count)
+	// The chance that two different items match: the same fingerprint, and the second item's first
+	// bucket is either of the first's.
+	p := 2 / (float64(uint64(1)<<uint(fl.f)-1) * float64(fl.nBuckets()))
+	// Each of the (a-n)(b-n) pairs of items not in both matches with chance p, so
+	// m ≈ n + (a-n)(b-n)p. Dropping the n²p term, which is far smaller than n, and solving for n:
+	n := (m - a*b*p) / (1 - (a+b)*p)
+	return math.Max(0, math.Min(n, math.Min(a, b))), nil
+}
+
+// Removes from fl every item that's also in other, which must have been made with the same
+// parameters and seed or Hasher, and returns how many were removed. This turns, say, a filter of
+// today's keys into one of the keys that are new since yesterday, without the keys themselves.
+//
+// An item is removed once for each time it's in other, and only if fl holds it. Like
+// EstimateIntersection, this goes by fingerprints, so an item of fl whose fingerprint and buckets
+// happen to match one of other's is removed too, and fl then returns No for it: about as often as
+// fl's false-positive rate for each item in other. If other has overflowed, only the items it
+// still holds are removed.
+//
+// Returns an error wrapping ErrIncompatible if the filters have different parameters, and
+// ErrOverflowed if fl has overflowed, leaving fl unchanged in either case.
+func (fl *Filter) Subtract(other *Filter) (int, error) {
+	if err := fl.compatible(other); err != nil {
+		return 0, err
+	}
+	if fl.overflowed {
+		return 0, ErrOverflowed
+	}
+	if other == fl {
+		other = fl.Clone()
+	}
+	n := 0
+	other.each(func(f fingerprint, i uint64) {
+		i2 := fl.otherIdx(f, i)
+		if fl.lookup(f, i, i2) {
+			fl.delete(f, i, i2)
+			n++
+		}
+	})
+	fl.checkShrink()
+	return n, nil
+}
+
+// Returns an estimate of the Jaccard index of fl and other, the number of items in both over the
+// number in either, from 0 for filters with nothing in common to 1 for filters of the same items.
+// Two empty filters have a similarity of 1. See EstimateIntersection, which this is based on, for
+// the filters it works with and the errors it returns.
+func (fl *Filter) Similarity(other *Filter) (float64, error) {
+	both, err := fl.EstimateIntersection(other)
+	if err != nil {
+		return 0, err
+	}
+	either := float64(fl.count+other.count) - both
+	if either == 0 {
+		return 1, nil
+	}
+	return both / either, nil
+}
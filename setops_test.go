@@ -0,0 +1,97 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateIntersection(t *testing.T) {
+	const n = 20000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for _, overlap := range []int{0, 1000, 10000, n} {
+		a := New(n, 0.01)
+		b := New(n, 0.01)
+		for i := 0; i < n; i++ {
+			a.Add(key(i))
+			b.Add(key(n - overlap + i))
+		}
+		est, err := a.EstimateIntersection(b)
+		require.NoError(t, err)
+		require.InDelta(t, overlap, est, 0.01*n, "overlap %d", overlap)
+		est2, err := b.EstimateIntersection(a)
+		require.NoError(t, err)
+		require.InDelta(t, est, est2, 1e-9)
+	}
+
+	a := New(n, 0.01)
+	_, err := a.EstimateIntersection(New(n, 0.001))
+	require.ErrorIs(t, err, ErrIncompatible)
+	b := NewRaw(8, 4, 8)
+	for i := 0; i < 100; i++ {
+		b.Add(key(i))
+	}
+	_, err = b.EstimateIntersection(NewRaw(8, 4, 8))
+	require.ErrorIs(t, err, ErrOverflowed)
+}
+
+func TestSubtract(t *testing.T) {
+	const n = 10000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	today := New(n, 0.001)
+	yesterday := New(n, 0.001)
+	for i := 0; i < n; i++ {
+		today.Add(key(n/2 + i))
+		yesterday.Add(key(i))
+	}
+	removed, err := today.Subtract(yesterday)
+	require.NoError(t, err)
+	// The n/2 shared keys, and perhaps a few that collide with yesterday's.
+	require.InDelta(t, n/2, removed, 20)
+	require.Equal(t, n-removed, today.Count())
+	lost := 0
+	for i := n; i < n+n/2; i++ {
+		if today.Contains(key(i)) == No {
+			lost++
+		}
+	}
+	require.Equal(t, removed-n/2, lost)
+	stillThere := 0
+	for i := n / 2; i < n; i++ {
+		if today.Contains(key(i)) == Maybe {
+			stillThere++
+		}
+	}
+	require.Less(t, stillThere, 20)
+
+	removed, err = yesterday.Subtract(yesterday)
+	require.NoError(t, err)
+	require.Equal(t, n, removed)
+	require.Zero(t, yesterday.Count())
+
+	_, err = today.Subtract(New(n, 0.01))
+	require.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestSimilarity(t *testing.T) {
+	const n = 10000
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for _, overlap := range []int{0, 2000, 5000, n} {
+		a := New(n, 0.01)
+		b := New(n, 0.01)
+		for i := 0; i < n; i++ {
+			a.Add(key(i))
+			b.Add(key(n - overlap + i))
+		}
+		s, err := a.Similarity(b)
+		require.NoError(t, err)
+		require.InDelta(t, float64(overlap)/float64(2*n-overlap), s, 0.01, "overlap %d", overlap)
+	}
+
+	s, err := New(100, 0.01).Similarity(New(100, 0.01))
+	require.NoError(t, err)
+	require.Equal(t, 1.0, s)
+	_, err = New(100, 0.01).Similarity(New(1000, 0.01))
+	require.ErrorIs(t, err, ErrIncompatible)
+}
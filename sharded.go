@@ -0,0 +1,151 @@
+package cuckoo
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+)
+
+// Seed for the hash that picks an item's shard, chosen so that it's independent of the hashes used
+// within each shard.
+const shardSeed = 0x5348415244
+
+// A filter made of independent sub-filters, each with its own lock, for workloads with many
+// goroutines adding and querying at once. Each item belongs to exactly one shard, chosen by hashing
+// the item.
+type ShardedFilter struct {
+	shards []filterShard
+}
+
+type filterShard struct {
+	mu sync.RWMutex
+	fl *Filter
+	// Keep each shard's lock on its own cache line.
+	_ [64 - 24 - 8]byte
+}
+
+// Returns a new ShardedFilter capable of holding n items with an estimated false-positive rate of
+// fp, split across the given number of shards. Each shard is sized with enough headroom that an
+// uneven split of items between shards is very unlikely to overflow one of them.
+func NewSharded(n int, fp float64, shards int) *ShardedFilter {
+	if shards < 1 {
+		shards = 1
+	}
+	perShard := float64(n) / float64(shards)
+	perShard += 4 * math.Sqrt(perShard)
+	s := &ShardedFilter{shards: make([]filterShard, shards)}
+	for i := range s.shards {
+		s.shards[i].fl = New(int(math.Ceil(perShard)), fp)
+	}
+	return s
+}
+
+func (s *ShardedFilter) shard(x []byte) *filterShard {
+	h := metroHash64(x, shardSeed)
+	// Map h onto [0, len(shards)) without a division.
+	hi, _ := bits.Mul64(h, uint64(len(s.shards)))
+	return &s.shards[hi]
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (s *ShardedFilter) Add(x []byte) {
+	sh := s.shard(x)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.fl.Add(x)
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns false and
+// leaves the filter as it was, rather than overflowing it.
+func (s *ShardedFilter) TryAdd(x []byte) bool {
+	sh := s.shard(x)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.fl.TryAdd(x)
+}
+
+// Adds x to the filter, returning what Contains(x) would have returned just before, as one atomic
+// operation.
+func (s *ShardedFilter) TestAndAdd(x []byte) Result {
+	sh := s.shard(x)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.fl.TestAndAdd(x)
+}
+
+// Adds x to the filter only if Contains(x) would return No, and returns true if it did so, as one
+// atomic operation.
+func (s *ShardedFilter) AddIfNotContains(x []byte) bool {
+	sh := s.shard(x)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.fl.AddIfNotContains(x)
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (s *ShardedFilter) Delete(x []byte) {
+	sh := s.shard(x)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.fl.Delete(x)
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking. The test and the delete are one atomic operation: of several goroutines
+// calling TryDelete with the same x at once, only as many succeed as there were copies of x.
+func (s *ShardedFilter) TryDelete(x []byte) bool {
+	sh := s.shard(x)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.fl.TryDelete(x)
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (s *ShardedFilter) Contains(x []byte) Result {
+	sh := s.shard(x)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.fl.Contains(x)
+}
+
+// Returns false if x is definitely not in the filter, and true if x might be in the filter. The
+// same as Contains(x).IsMaybe().
+func (s *ShardedFilter) MayContain(x []byte) bool {
+	return s.Contains(x) == Maybe
+}
+
+// True if any shard has overflowed. Items in an overflowed shard, and only those, get Maybe for
+// every query.
+func (s *ShardedFilter) Overflowed() bool {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		overflowed := sh.fl.Overflowed()
+		sh.mu.RUnlock()
+		if overflowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the number of items in the filter.
+func (s *ShardedFilter) Count() int {
+	count := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		count += sh.fl.Count()
+		sh.mu.RUnlock()
+	}
+	return count
+}
+
+// Returns the number of bytes used by the filter.
+func (s *ShardedFilter) SizeBytes() uint64 {
+	size := uint64(0)
+	for i := range s.shards {
+		size += s.shards[i].fl.SizeBytes()
+	}
+	return size
+}
@@ -0,0 +1,60 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharded(t *testing.T) {
+	const (
+		goroutines = 8
+		perRoutine = 20000
+	)
+	s := NewSharded(goroutines*perRoutine, 0.01, 16)
+	key := func(g, i int) []byte {
+		var b [8]byte
+		binary.LittleEndian.PutUint32(b[:4], uint32(g))
+		binary.LittleEndian.PutUint32(b[4:], uint32(i))
+		return b[:]
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perRoutine; i++ {
+				s.Add(key(g, i))
+				if s.Contains(key(g, i)) != Maybe {
+					panic("missing item")
+				}
+			}
+			for i := 0; i < perRoutine; i += 2 {
+				s.Delete(key(g, i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.False(t, s.Overflowed())
+	require.Equal(t, goroutines*perRoutine/2, s.Count())
+	for g := 0; g < goroutines; g++ {
+		for i := 1; i < perRoutine; i += 2 {
+			require.Equal(t, Maybe, s.Contains(key(g, i)))
+		}
+	}
+
+	// Items are spread across all of the shards.
+	for i := range s.shards {
+		require.True(t, s.shards[i].fl.Count() > 0)
+	}
+	total := uint64(0)
+	for i := range s.shards {
+		total += s.shards[i].fl.SizeBytes()
+	}
+	require.Equal(t, total, s.SizeBytes())
+}
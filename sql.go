@@ -0,0 +1,27 @@
+package cuckoo
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Implements driver.Valuer, so that a filter can be stored directly in a BLOB column. The stored
+// value is the same as MarshalBinary.
+func (fl *Filter) Value() (driver.Value, error) {
+	return fl.MarshalBinary()
+}
+
+// Implements sql.Scanner, so that a filter can be loaded directly from a BLOB column written by
+// Value.
+func (fl *Filter) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return fl.UnmarshalBinary(src)
+	case string:
+		return fl.UnmarshalBinary([]byte(src))
+	case nil:
+		return fmt.Errorf("cuckoo: cannot scan NULL into a Filter")
+	default:
+		return fmt.Errorf("cuckoo: cannot scan %T into a Filter", src)
+	}
+}
@@ -0,0 +1,37 @@
+package cuckoo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ driver.Valuer = &Filter{}
+	_ sql.Scanner   = &Filter{}
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	fl := New(100, 0.01)
+	fl.Add([]byte("a"))
+	fl.Add([]byte("b"))
+
+	v, err := fl.Value()
+	require.NoError(t, err)
+	require.IsType(t, []byte{}, v)
+
+	var fl2 Filter
+	require.NoError(t, fl2.Scan(v))
+	require.Equal(t, 2, fl2.Count())
+	require.Equal(t, Maybe, fl2.Contains([]byte("a")))
+	require.Equal(t, Maybe, fl2.Contains([]byte("b")))
+
+	var fl3 Filter
+	require.NoError(t, fl3.Scan(string(v.([]byte))))
+	require.Equal(t, 2, fl3.Count())
+
+	require.Error(t, fl3.Scan(nil))
+	require.Error(t, fl3.Scan(int64(5)))
+}
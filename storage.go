@@ -0,0 +1,52 @@
+package cuckoo
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Returns the number of 64-bit words of bucket storage a filter from NewRaw(f, b, n) uses, which is
+// how many NewRawWithStorage needs.
+func StorageWords(f, b, n int) (int, error) {
+	nBuckets, err := checkRaw(f, b, n)
+	if err != nil {
+		return 0, err
+	}
+	return int(packedWords(bucketEncodingFor(f, b).size(), uint64(nBuckets))), nil
+}
+
+// Like TryNewRaw, but stores the buckets in words instead of allocating them, for callers that
+// manage memory themselves: allocating from an arena, reusing storage from a pool, or placing the
+// filter in shared or pinned memory.
+//
+// words must be at least StorageWords(f, b, n) long, and any words past that are left alone. The
+// filter starts out empty, so NewRawWithStorage zeroes the words it uses, and from then on the
+// filter owns them: the caller must not read or write them until it's done with the filter. They
+// hold the buckets in the same layout NewRaw uses in memory, which isn't the serialized encoding.
+func NewRawWithStorage(f, b, n int, words []uint64) (*Filter, error) {
+	need, err := StorageWords(f, b, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) < need {
+		return nil, fmt.Errorf("%w: storage is %d words, need %d", ErrInvalidParams, len(words), need)
+	}
+	words = words[:need:need]
+	for i := range words {
+		words[i] = 0
+	}
+	return newFilterWords(f, b, rawBuckets(f, b, n), bucketEncodingFor(f, b), words), nil
+}
+
+// Like NewRawWithStorage, but with storage given as bytes, such as a region of shared memory. buf
+// must be 8-byte aligned and at least 8*StorageWords(f, b, n) bytes long.
+func NewRawWithByteStorage(f, b, n int, buf []byte) (*Filter, error) {
+	if len(buf) == 0 {
+		return NewRawWithStorage(f, b, n, nil)
+	}
+	if uintptr(unsafe.Pointer(&buf[0]))%8 != 0 {
+		return nil, fmt.Errorf("%w: storage must be 8-byte aligned", ErrInvalidParams)
+	}
+	words := unsafe.Slice((*uint64)(unsafe.Pointer(&buf[0])), len(buf)/8)
+	return NewRawWithStorage(f, b, n, words)
+}
@@ -0,0 +1,65 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRawWithStorage(t *testing.T) {
+	const n = 1000
+	need, err := StorageWords(12, 4, n)
+	require.NoError(t, err)
+	require.Equal(t, (NewRaw(12, 4, n).SizeBytes()+7)/8, uint64(need))
+
+	_, err = NewRawWithStorage(12, 4, n, make([]uint64, need-1))
+	require.ErrorIs(t, err, ErrInvalidParams)
+	_, err = NewRawWithStorage(17, 4, n, make([]uint64, need))
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	// Leftovers from a previous user of the storage, plus one word the filter shouldn't touch.
+	words := make([]uint64, need+1)
+	for i := range words {
+		words[i] = ^uint64(0)
+	}
+	fl, err := NewRawWithStorage(12, 4, n, words)
+	require.NoError(t, err)
+	require.Equal(t, 0, fl.Count())
+	ref := NewRaw(12, 4, n)
+	// So that the two make the same choices of what to kick.
+	ref.rngState = fl.rngState
+	for i := 0; i < n; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		fl.Add(x)
+		ref.Add(x)
+	}
+	require.True(t, fl.Equal(ref))
+	require.NoError(t, fl.CheckInvariants())
+	require.Equal(t, ^uint64(0), words[need])
+	// The filter really lives in words.
+	for i := range words[:need] {
+		words[i] = 0
+	}
+	require.Equal(t, No, fl.Contains(binary.LittleEndian.AppendUint64(nil, 0)))
+}
+
+func TestNewRawWithByteStorage(t *testing.T) {
+	need, err := StorageWords(8, 4, 100)
+	require.NoError(t, err)
+	backing := make([]uint64, need+1)
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&backing[0])), len(backing)*8)
+
+	_, err = NewRawWithByteStorage(8, 4, 100, buf[1:])
+	require.ErrorIs(t, err, ErrInvalidParams)
+	fl, err := NewRawWithByteStorage(8, 4, 100, buf)
+	require.NoError(t, err)
+	fl.Add([]byte("x"))
+	require.Equal(t, Maybe, fl.Contains([]byte("x")))
+	used := false
+	for _, w := range backing[:need] {
+		used = used || w != 0
+	}
+	require.True(t, used)
+}
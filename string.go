@@ -0,0 +1,85 @@
+package cuckoo
+
+import (
+	"unsafe"
+)
+
+// Returns the bytes of s without copying them. The result must not be modified.
+func stringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// Like Add, but for a string item. Equivalent to Add([]byte(x)) without the conversion.
+func (fl *Filter) AddString(x string) {
+	fl.Add(stringBytes(x))
+}
+
+// Like Contains, but for a string item. Equivalent to Contains([]byte(x)) without the conversion.
+func (fl *Filter) ContainsString(x string) Result {
+	return fl.Contains(stringBytes(x))
+}
+
+// Like Delete, but for a string item. Equivalent to Delete([]byte(x)) without the conversion.
+func (fl *Filter) DeleteString(x string) {
+	fl.Delete(stringBytes(x))
+}
+
+// Like Add, but for a string item. Equivalent to Add([]byte(x)) without the conversion.
+func (c *ConcurrentFilter) AddString(x string) {
+	c.Add(stringBytes(x))
+}
+
+// Like Contains, but for a string item. Equivalent to Contains([]byte(x)) without the conversion.
+func (c *ConcurrentFilter) ContainsString(x string) Result {
+	return c.Contains(stringBytes(x))
+}
+
+// Like Delete, but for a string item. Equivalent to Delete([]byte(x)) without the conversion.
+func (c *ConcurrentFilter) DeleteString(x string) {
+	c.Delete(stringBytes(x))
+}
+
+// Like Add, but for a string item. Equivalent to Add([]byte(x)) without the conversion.
+func (lf *LockFreeFilter) AddString(x string) {
+	lf.Add(stringBytes(x))
+}
+
+// Like Contains, but for a string item. Equivalent to Contains([]byte(x)) without the conversion.
+func (lf *LockFreeFilter) ContainsString(x string) Result {
+	return lf.Contains(stringBytes(x))
+}
+
+// Like Delete, but for a string item. Equivalent to Delete([]byte(x)) without the conversion.
+func (lf *LockFreeFilter) DeleteString(x string) {
+	lf.Delete(stringBytes(x))
+}
+
+// Like Add, but for a string item. Equivalent to Add([]byte(x)) without the conversion.
+func (e *EpochFilter) AddString(x string) {
+	e.Add(stringBytes(x))
+}
+
+// Like Contains, but for a string item. Equivalent to Contains([]byte(x)) without the conversion.
+func (e *EpochFilter) ContainsString(x string) Result {
+	return e.Contains(stringBytes(x))
+}
+
+// Like Delete, but for a string item. Equivalent to Delete([]byte(x)) without the conversion.
+func (e *EpochFilter) DeleteString(x string) {
+	e.Delete(stringBytes(x))
+}
+
+// Like Add, but for a string item. Equivalent to Add([]byte(x)) without the conversion.
+func (s *ShardedFilter) AddString(x string) {
+	s.Add(stringBytes(x))
+}
+
+// Like Contains, but for a string item. Equivalent to Contains([]byte(x)) without the conversion.
+func (s *ShardedFilter) ContainsString(x string) Result {
+	return s.Contains(stringBytes(x))
+}
+
+// Like Delete, but for a string item. Equivalent to Delete([]byte(x)) without the conversion.
+func (s *ShardedFilter) DeleteString(x string) {
+	s.Delete(stringBytes(x))
+}
@@ -0,0 +1,28 @@
+package cuckoo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringMethods(t *testing.T) {
+	fl := New(100, 0.0001)
+	long := strings.Repeat("x", 100)
+	fl.AddString(long)
+	require.Equal(t, Maybe, fl.Contains([]byte(long)))
+	require.Equal(t, Maybe, fl.ContainsString(long))
+	require.Equal(t, No, fl.ContainsString("y"))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		fl.AddString(long)
+		fl.ContainsString(long)
+		fl.DeleteString(long)
+	})
+	require.Equal(t, 0.0, allocs)
+
+	fl.DeleteString(long)
+	require.Equal(t, No, fl.ContainsString(long))
+	require.Equal(t, 0, fl.Count())
+}
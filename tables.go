@@ -1,7759 +0,0 @@
-package cuckoo
-
-var lookupBitsToFingerprints = [...]uint16{
-	0x000: 0x0000,
-	0x001: 0x0001,
-	0x002: 0x0002,
-	0x003: 0x0003,
-	0x004: 0x0004,
-	0x005: 0x0005,
-	0x006: 0x0006,
-	0x007: 0x0007,
-	0x008: 0x0008,
-	0x009: 0x0009,
-	0x00a: 0x000a,
-	0x00b: 0x000b,
-	0x00c: 0x000c,
-	0x00d: 0x000d,
-	0x00e: 0x000e,
-	0x00f: 0x000f,
-	0x010: 0x0011,
-	0x011: 0x0012,
-	0x012: 0x0013,
-	0x013: 0x0014,
-	0x014: 0x0015,
-	0x015: 0x0016,
-	0x016: 0x0017,
-	0x017: 0x0018,
-	0x018: 0x0019,
-	0x019: 0x001a,
-	0x01a: 0x001b,
-	0x01b: 0x001c,
-	0x01c: 0x001d,
-	0x01d: 0x001e,
-	0x01e: 0x001f,
-	0x01f: 0x0022,
-	0x020: 0x0023,
-	0x021: 0x0024,
-	0x022: 0x0025,
-	0x023: 0x0026,
-	0x024: 0x0027,
-	0x025: 0x0028,
-	0x026: 0x0029,
-	0x027: 0x002a,
-	0x028: 0x002b,
-	0x029: 0x002c,
-	0x02a: 0x002d,
-	0x02b: 0x002e,
-	0x02c: 0x002f,
-	0x02d: 0x0033,
-	0x02e: 0x0034,
-	0x02f: 0x0035,
-	0x030: 0x0036,
-	0x031: 0x0037,
-	0x032: 0x0038,
-	0x033: 0x0039,
-	0x034: 0x003a,
-	0x035: 0x003b,
-	0x036: 0x003c,
-	0x037: 0x003d,
-	0x038: 0x003e,
-	0x039: 0x003f,
-	0x03a: 0x0044,
-	0x03b: 0x0045,
-	0x03c: 0x0046,
-	0x03d: 0x0047,
-	0x03e: 0x0048,
-	0x03f: 0x0049,
-	0x040: 0x004a,
-	0x041: 0x004b,
-	0x042: 0x004c,
-	0x043: 0x004d,
-	0x044: 0x004e,
-	0x045: 0x004f,
-	0x046: 0x0055,
-	0x047: 0x0056,
-	0x048: 0x0057,
-	0x049: 0x0058,
-	0x04a: 0x0059,
-	0x04b: 0x005a,
-	0x04c: 0x005b,
-	0x04d: 0x005c,
-	0x04e: 0x005d,
-	0x04f: 0x005e,
-	0x050: 0x005f,
-	0x051: 0x0066,
-	0x052: 0x0067,
-	0x053: 0x0068,
-	0x054: 0x0069,
-	0x055: 0x006a,
-	0x056: 0x006b,
-	0x057: 0x006c,
-	0x058: 0x006d,
-	0x059: 0x006e,
-	0x05a: 0x006f,
-	0x05b: 0x0077,
-	0x05c: 0x0078,
-	0x05d: 0x0079,
-	0x05e: 0x007a,
-	0x05f: 0x007b,
-	0x060: 0x007c,
-	0x061: 0x007d,
-	0x062: 0x007e,
-	0x063: 0x007f,
-	0x064: 0x0088,
-	0x065: 0x0089,
-	0x066: 0x008a,
-	0x067: 0x008b,
-	0x068: 0x008c,
-	0x069: 0x008d,
-	0x06a: 0x008e,
-	0x06b: 0x008f,
-	0x06c: 0x0099,
-	0x06d: 0x009a,
-	0x06e: 0x009b,
-	0x06f: 0x009c,
-	0x070: 0x009d,
-	0x071: 0x009e,
-	0x072: 0x009f,
-	0x073: 0x00aa,
-	0x074: 0x00ab,
-	0x075: 0x00ac,
-	0x076: 0x00ad,
-	0x077: 0x00ae,
-	0x078: 0x00af,
-	0x079: 0x00bb,
-	0x07a: 0x00bc,
-	0x07b: 0x00bd,
-	0x07c: 0x00be,
-	0x07d: 0x00bf,
-	0x07e: 0x00cc,
-	0x07f: 0x00cd,
-	0x080: 0x00ce,
-	0x081: 0x00cf,
-	0x082: 0x00dd,
-	0x083: 0x00de,
-	0x084: 0x00df,
-	0x085: 0x00ee,
-	0x086: 0x00ef,
-	0x087: 0x00ff,
-	0x088: 0x0111,
-	0x089: 0x0112,
-	0x08a: 0x0113,
-	0x08b: 0x0114,
-	0x08c: 0x0115,
-	0x08d: 0x0116,
-	0x08e: 0x0117,
-	0x08f: 0x0118,
-	0x090: 0x0119,
-	0x091: 0x011a,
-	0x092: 0x011b,
-	0x093: 0x011c,
-	0x094: 0x011d,
-	0x095: 0x011e,
-	0x096: 0x011f,
-	0x097: 0x0122,
-	0x098: 0x0123,
-	0x099: 0x0124,
-	0x09a: 0x0125,
-	0x09b: 0x0126,
-	0x09c: 0x0127,
-	0x09d: 0x0128,
-	0x09e: 0x0129,
-	0x09f: 0x012a,
-	0x0a0: 0x012b,
-	0x0a1: 0x012c,
-	0x0a2: 0x012d,
-	0x0a3: 0x012e,
-	0x0a4: 0x012f,
-	0x0a5: 0x0133,
-	0x0a6: 0x0134,
-	0x0a7: 0x0135,
-	0x0a8: 0x0136,
-	0x0a9: 0x0137,
-	0x0aa: 0x0138,
-	0x0ab: 0x0139,
-	0x0ac: 0x013a,
-	0x0ad: 0x013b,
-	0x0ae: 0x013c,
-	0x0af: 0x013d,
-	0x0b0: 0x013e,
-	0x0b1: 0x013f,
-	0x0b2: 0x0144,
-	0x0b3: 0x0145,
-	0x0b4: 0x0146,
-	0x0b5: 0x0147,
-	0x0b6: 0x0148,
-	0x0b7: 0x0149,
-	0x0b8: 0x014a,
-	0x0b9: 0x014b,
-	0x0ba: 0x014c,
-	0x0bb: 0x014d,
-	0x0bc: 0x014e,
-	0x0bd: 0x014f,
-	0x0be: 0x0155,
-	0x0bf: 0x0156,
-	0x0c0: 0x0157,
-	0x0c1: 0x0158,
-	0x0c2: 0x0159,
-	0x0c3: 0x015a,
-	0x0c4: 0x015b,
-	0x0c5: 0x015c,
-	0x0c6: 0x015d,
-	0x0c7: 0x015e,
-	0x0c8: 0x015f,
-	0x0c9: 0x0166,
-	0x0ca: 0x0167,
-	0x0cb: 0x0168,
-	0x0cc: 0x0169,
-	0x0cd: 0x016a,
-	0x0ce: 0x016b,
-	0x0cf: 0x016c,
-	0x0d0: 0x016d,
-	0x0d1: 0x016e,
-	0x0d2: 0x016f,
-	0x0d3: 0x0177,
-	0x0d4: 0x0178,
-	0x0d5: 0x0179,
-	0x0d6: 0x017a,
-	0x0d7: 0x017b,
-	0x0d8: 0x017c,
-	0x0d9: 0x017d,
-	0x0da: 0x017e,
-	0x0db: 0x017f,
-	0x0dc: 0x0188,
-	0x0dd: 0x0189,
-	0x0de: 0x018a,
-	0x0df: 0x018b,
-	0x0e0: 0x018c,
-	0x0e1: 0x018d,
-	0x0e2: 0x018e,
-	0x0e3: 0x018f,
-	0x0e4: 0x0199,
-	0x0e5: 0x019a,
-	0x0e6: 0x019b,
-	0x0e7: 0x019c,
-	0x0e8: 0x019d,
-	0x0e9: 0x019e,
-	0x0ea: 0x019f,
-	0x0eb: 0x01aa,
-	0x0ec: 0x01ab,
-	0x0ed: 0x01ac,
-	0x0ee: 0x01ad,
-	0x0ef: 0x01ae,
-	0x0f0: 0x01af,
-	0x0f1: 0x01bb,
-	0x0f2: 0x01bc,
-	0x0f3: 0x01bd,
-	0x0f4: 0x01be,
-	0x0f5: 0x01bf,
-	0x0f6: 0x01cc,
-	0x0f7: 0x01cd,
-	0x0f8: 0x01ce,
-	0x0f9: 0x01cf,
-	0x0fa: 0x01dd,
-	0x0fb: 0x01de,
-	0x0fc: 0x01df,
-	0x0fd: 0x01ee,
-	0x0fe: 0x01ef,
-	0x0ff: 0x01ff,
-	0x100: 0x0222,
-	0x101: 0x0223,
-	0x102: 0x0224,
-	0x103: 0x0225,
-	0x104: 0x0226,
-	0x105: 0x0227,
-	0x106: 0x0228,
-	0x107: 0x0229,
-	0x108: 0x022a,
-	0x109: 0x022b,
-	0x10a: 0x022c,
-	0x10b: 0x022d,
-	0x10c: 0x022e,
-	0x10d: 0x022f,
-	0x10e: 0x0233,
-	0x10f: 0x0234,
-	0x110: 0x0235,
-	0x111: 0x0236,
-	0x112: 0x0237,
-	0x113: 0x0238,
-	0x114: 0x0239,
-	0x115: 0x023a,
-	0x116: 0x023b,
-	0x117: 0x023c,
-	0x118: 0x023d,
-	0x119: 0x023e,
-	0x11a: 0x023f,
-	0x11b: 0x0244,
-	0x11c: 0x0245,
-	0x11d: 0x0246,
-	0x11e: 0x0247,
-	0x11f: 0x0248,
-	0x120: 0x0249,
-	0x121: 0x024a,
-	0x122: 0x024b,
-	0x123: 0x024c,
-	0x124: 0x024d,
-	0x125: 0x024e,
-	0x126: 0x024f,
-	0x127: 0x0255,
-	0x128: 0x0256,
-	0x129: 0x0257,
-	0x12a: 0x0258,
-	0x12b: 0x0259,
-	0x12c: 0x025a,
-	0x12d: 0x025b,
-	0x12e: 0x025c,
-	0x12f: 0x025d,
-	0x130: 0x025e,
-	0x131: 0x025f,
-	0x132: 0x0266,
-	0x133: 0x0267,
-	0x134: 0x0268,
-	0x135: 0x0269,
-	0x136: 0x026a,
-	0x137: 0x026b,
-	0x138: 0x026c,
-	0x139: 0x026d,
-	0x13a: 0x026e,
-	0x13b: 0x026f,
-	0x13c: 0x0277,
-	0x13d: 0x0278,
-	0x13e: 0x0279,
-	0x13f: 0x027a,
-	0x140: 0x027b,
-	0x141: 0x027c,
-	0x142: 0x027d,
-	0x143: 0x027e,
-	0x144: 0x027f,
-	0x145: 0x0288,
-	0x146: 0x0289,
-	0x147: 0x028a,
-	0x148: 0x028b,
-	0x149: 0x028c,
-	0x14a: 0x028d,
-	0x14b: 0x028e,
-	0x14c: 0x028f,
-	0x14d: 0x0299,
-	0x14e: 0x029a,
-	0x14f: 0x029b,
-	0x150: 0x029c,
-	0x151: 0x029d,
-	0x152: 0x029e,
-	0x153: 0x029f,
-	0x154: 0x02aa,
-	0x155: 0x02ab,
-	0x156: 0x02ac,
-	0x157: 0x02ad,
-	0x158: 0x02ae,
-	0x159: 0x02af,
-	0x15a: 0x02bb,
-	0x15b: 0x02bc,
-	0x15c: 0x02bd,
-	0x15d: 0x02be,
-	0x15e: 0x02bf,
-	0x15f: 0x02cc,
-	0x160: 0x02cd,
-	0x161: 0x02ce,
-	0x162: 0x02cf,
-	0x163: 0x02dd,
-	0x164: 0x02de,
-	0x165: 0x02df,
-	0x166: 0x02ee,
-	0x167: 0x02ef,
-	0x168: 0x02ff,
-	0x169: 0x0333,
-	0x16a: 0x0334,
-	0x16b: 0x0335,
-	0x16c: 0x0336,
-	0x16d: 0x0337,
-	0x16e: 0x0338,
-	0x16f: 0x0339,
-	0x170: 0x033a,
-	0x171: 0x033b,
-	0x172: 0x033c,
-	0x173: 0x033d,
-	0x174: 0x033e,
-	0x175: 0x033f,
-	0x176: 0x0344,
-	0x177: 0x0345,
-	0x178: 0x0346,
-	0x179: 0x0347,
-	0x17a: 0x0348,
-	0x17b: 0x0349,
-	0x17c: 0x034a,
-	0x17d: 0x034b,
-	0x17e: 0x034c,
-	0x17f: 0x034d,
-	0x180: 0x034e,
-	0x181: 0x034f,
-	0x182: 0x0355,
-	0x183: 0x0356,
-	0x184: 0x0357,
-	0x185: 0x0358,
-	0x186: 0x0359,
-	0x187: 0x035a,
-	0x188: 0x035b,
-	0x189: 0x035c,
-	0x18a: 0x035d,
-	0x18b: 0x035e,
-	0x18c: 0x035f,
-	0x18d: 0x0366,
-	0x18e: 0x0367,
-	0x18f: 0x0368,
-	0x190: 0x0369,
-	0x191: 0x036a,
-	0x192: 0x036b,
-	0x193: 0x036c,
-	0x194: 0x036d,
-	0x195: 0x036e,
-	0x196: 0x036f,
-	0x197: 0x0377,
-	0x198: 0x0378,
-	0x199: 0x0379,
-	0x19a: 0x037a,
-	0x19b: 0x037b,
-	0x19c: 0x037c,
-	0x19d: 0x037d,
-	0x19e: 0x037e,
-	0x19f: 0x037f,
-	0x1a0: 0x0388,
-	0x1a1: 0x0389,
-	0x1a2: 0x038a,
-	0x1a3: 0x038b,
-	0x1a4: 0x038c,
-	0x1a5: 0x038d,
-	0x1a6: 0x038e,
-	0x1a7: 0x038f,
-	0x1a8: 0x0399,
-	0x1a9: 0x039a,
-	0x1aa: 0x039b,
-	0x1ab: 0x039c,
-	0x1ac: 0x039d,
-	0x1ad: 0x039e,
-	0x1ae: 0x039f,
-	0x1af: 0x03aa,
-	0x1b0: 0x03ab,
-	0x1b1: 0x03ac,
-	0x1b2: 0x03ad,
-	0x1b3: 0x03ae,
-	0x1b4: 0x03af,
-	0x1b5: 0x03bb,
-	0x1b6: 0x03bc,
-	0x1b7: 0x03bd,
-	0x1b8: 0x03be,
-	0x1b9: 0x03bf,
-	0x1ba: 0x03cc,
-	0x1bb: 0x03cd,
-	0x1bc: 0x03ce,
-	0x1bd: 0x03cf,
-	0x1be: 0x03dd,
-	0x1bf: 0x03de,
-	0x1c0: 0x03df,
-	0x1c1: 0x03ee,
-	0x1c2: 0x03ef,
-	0x1c3: 0x03ff,
-	0x1c4: 0x0444,
-	0x1c5: 0x0445,
-	0x1c6: 0x0446,
-	0x1c7: 0x0447,
-	0x1c8: 0x0448,
-	0x1c9: 0x0449,
-	0x1ca: 0x044a,
-	0x1cb: 0x044b,
-	0x1cc: 0x044c,
-	0x1cd: 0x044d,
-	0x1ce: 0x044e,
-	0x1cf: 0x044f,
-	0x1d0: 0x0455,
-	0x1d1: 0x0456,
-	0x1d2: 0x0457,
-	0x1d3: 0x0458,
-	0x1d4: 0x0459,
-	0x1d5: 0x045a,
-	0x1d6: 0x045b,
-	0x1d7: 0x045c,
-	0x1d8: 0x045d,
-	0x1d9: 0x045e,
-	0x1da: 0x045f,
-	0x1db: 0x0466,
-	0x1dc: 0x0467,
-	0x1dd: 0x0468,
-	0x1de: 0x0469,
-	0x1df: 0x046a,
-	0x1e0: 0x046b,
-	0x1e1: 0x046c,
-	0x1e2: 0x046d,
-	0x1e3: 0x046e,
-	0x1e4: 0x046f,
-	0x1e5: 0x0477,
-	0x1e6: 0x0478,
-	0x1e7: 0x0479,
-	0x1e8: 0x047a,
-	0x1e9: 0x047b,
-	0x1ea: 0x047c,
-	0x1eb: 0x047d,
-	0x1ec: 0x047e,
-	0x1ed: 0x047f,
-	0x1ee: 0x0488,
-	0x1ef: 0x0489,
-	0x1f0: 0x048a,
-	0x1f1: 0x048b,
-	0x1f2: 0x048c,
-	0x1f3: 0x048d,
-	0x1f4: 0x048e,
-	0x1f5: 0x048f,
-	0x1f6: 0x0499,
-	0x1f7: 0x049a,
-	0x1f8: 0x049b,
-	0x1f9: 0x049c,
-	0x1fa: 0x049d,
-	0x1fb: 0x049e,
-	0x1fc: 0x049f,
-	0x1fd: 0x04aa,
-	0x1fe: 0x04ab,
-	0x1ff: 0x04ac,
-	0x200: 0x04ad,
-	0x201: 0x04ae,
-	0x202: 0x04af,
-	0x203: 0x04bb,
-	0x204: 0x04bc,
-	0x205: 0x04bd,
-	0x206: 0x04be,
-	0x207: 0x04bf,
-	0x208: 0x04cc,
-	0x209: 0x04cd,
-	0x20a: 0x04ce,
-	0x20b: 0x04cf,
-	0x20c: 0x04dd,
-	0x20d: 0x04de,
-	0x20e: 0x04df,
-	0x20f: 0x04ee,
-	0x210: 0x04ef,
-	0x211: 0x04ff,
-	0x212: 0x0555,
-	0x213: 0x0556,
-	0x214: 0x0557,
-	0x215: 0x0558,
-	0x216: 0x0559,
-	0x217: 0x055a,
-	0x218: 0x055b,
-	0x219: 0x055c,
-	0x21a: 0x055d,
-	0x21b: 0x055e,
-	0x21c: 0x055f,
-	0x21d: 0x0566,
-	0x21e: 0x0567,
-	0x21f: 0x0568,
-	0x220: 0x0569,
-	0x221: 0x056a,
-	0x222: 0x056b,
-	0x223: 0x056c,
-	0x224: 0x056d,
-	0x225: 0x056e,
-	0x226: 0x056f,
-	0x227: 0x0577,
-	0x228: 0x0578,
-	0x229: 0x0579,
-	0x22a: 0x057a,
-	0x22b: 0x057b,
-	0x22c: 0x057c,
-	0x22d: 0x057d,
-	0x22e: 0x057e,
-	0x22f: 0x057f,
-	0x230: 0x0588,
-	0x231: 0x0589,
-	0x232: 0x058a,
-	0x233: 0x058b,
-	0x234: 0x058c,
-	0x235: 0x058d,
-	0x236: 0x058e,
-	0x237: 0x058f,
-	0x238: 0x0599,
-	0x239: 0x059a,
-	0x23a: 0x059b,
-	0x23b: 0x059c,
-	0x23c: 0x059d,
-	0x23d: 0x059e,
-	0x23e: 0x059f,
-	0x23f: 0x05aa,
-	0x240: 0x05ab,
-	0x241: 0x05ac,
-	0x242: 0x05ad,
-	0x243: 0x05ae,
-	0x244: 0x05af,
-	0x245: 0x05bb,
-	0x246: 0x05bc,
-	0x247: 0x05bd,
-	0x248: 0x05be,
-	0x249: 0x05bf,
-	0x24a: 0x05cc,
-	0x24b: 0x05cd,
-	0x24c: 0x05ce,
-	0x24d: 0x05cf,
-	0x24e: 0x05dd,
-	0x24f: 0x05de,
-	0x250: 0x05df,
-	0x251: 0x05ee,
-	0x252: 0x05ef,
-	0x253: 0x05ff,
-	0x254: 0x0666,
-	0x255: 0x0667,
-	0x256: 0x0668,
-	0x257: 0x0669,
-	0x258: 0x066a,
-	0x259: 0x066b,
-	0x25a: 0x066c,
-	0x25b: 0x066d,
-	0x25c: 0x066e,
-	0x25d: 0x066f,
-	0x25e: 0x0677,
-	0x25f: 0x0678,
-	0x260: 0x0679,
-	0x261: 0x067a,
-	0x262: 0x067b,
-	0x263: 0x067c,
-	0x264: 0x067d,
-	0x265: 0x067e,
-	0x266: 0x067f,
-	0x267: 0x0688,
-	0x268: 0x0689,
-	0x269: 0x068a,
-	0x26a: 0x068b,
-	0x26b: 0x068c,
-	0x26c: 0x068d,
-	0x26d: 0x068e,
-	0x26e: 0x068f,
-	0x26f: 0x0699,
-	0x270: 0x069a,
-	0x271: 0x069b,
-	0x272: 0x069c,
-	0x273: 0x069d,
-	0x274: 0x069e,
-	0x275: 0x069f,
-	0x276: 0x06aa,
-	0x277: 0x06ab,
-	0x278: 0x06ac,
-	0x279: 0x06ad,
-	0x27a: 0x06ae,
-	0x27b: 0x06af,
-	0x27c: 0x06bb,
-	0x27d: 0x06bc,
-	0x27e: 0x06bd,
-	0x27f: 0x06be,
-	0x280: 0x06bf,
-	0x281: 0x06cc,
-	0x282: 0x06cd,
-	0x283: 0x06ce,
-	0x284: 0x06cf,
-	0x285: 0x06dd,
-	0x286: 0x06de,
-	0x287: 0x06df,
-	0x288: 0x06ee,
-	0x289: 0x06ef,
-	0x28a: 0x06ff,
-	0x28b: 0x0777,
-	0x28c: 0x0778,
-	0x28d: 0x0779,
-	0x28e: 0x077a,
-	0x28f: 0x077b,
-	0x290: 0x077c,
-	0x291: 0x077d,
-	0x292: 0x077e,
-	0x293: 0x077f,
-	0x294: 0x0788,
-	0x295: 0x0789,
-	0x296: 0x078a,
-	0x297: 0x078b,
-	0x298: 0x078c,
-	0x299: 0x078d,
-	0x29a: 0x078e,
-	0x29b: 0x078f,
-	0x29c: 0x0799,
-	0x29d: 0x079a,
-	0x29e: 0x079b,
-	0x29f: 0x079c,
-	0x2a0: 0x079d,
-	0x2a1: 0x079e,
-	0x2a2: 0x079f,
-	0x2a3: 0x07aa,
-	0x2a4: 0x07ab,
-	0x2a5: 0x07ac,
-	0x2a6: 0x07ad,
-	0x2a7: 0x07ae,
-	0x2a8: 0x07af,
-	0x2a9: 0x07bb,
-	0x2aa: 0x07bc,
-	0x2ab: 0x07bd,
-	0x2ac: 0x07be,
-	0x2ad: 0x07bf,
-	0x2ae: 0x07cc,
-	0x2af: 0x07cd,
-	0x2b0: 0x07ce,
-	0x2b1: 0x07cf,
-	0x2b2: 0x07dd,
-	0x2b3: 0x07de,
-	0x2b4: 0x07df,
-	0x2b5: 0x07ee,
-	0x2b6: 0x07ef,
-	0x2b7: 0x07ff,
-	0x2b8: 0x0888,
-	0x2b9: 0x0889,
-	0x2ba: 0x088a,
-	0x2bb: 0x088b,
-	0x2bc: 0x088c,
-	0x2bd: 0x088d,
-	0x2be: 0x088e,
-	0x2bf: 0x088f,
-	0x2c0: 0x0899,
-	0x2c1: 0x089a,
-	0x2c2: 0x089b,
-	0x2c3: 0x089c,
-	0x2c4: 0x089d,
-	0x2c5: 0x089e,
-	0x2c6: 0x089f,
-	0x2c7: 0x08aa,
-	0x2c8: 0x08ab,
-	0x2c9: 0x08ac,
-	0x2ca: 0x08ad,
-	0x2cb: 0x08ae,
-	0x2cc: 0x08af,
-	0x2cd: 0x08bb,
-	0x2ce: 0x08bc,
-	0x2cf: 0x08bd,
-	0x2d0: 0x08be,
-	0x2d1: 0x08bf,
-	0x2d2: 0x08cc,
-	0x2d3: 0x08cd,
-	0x2d4: 0x08ce,
-	0x2d5: 0x08cf,
-	0x2d6: 0x08dd,
-	0x2d7: 0x08de,
-	0x2d8: 0x08df,
-	0x2d9: 0x08ee,
-	0x2da: 0x08ef,
-	0x2db: 0x08ff,
-	0x2dc: 0x0999,
-	0x2dd: 0x099a,
-	0x2de: 0x099b,
-	0x2df: 0x099c,
-	0x2e0: 0x099d,
-	0x2e1: 0x099e,
-	0x2e2: 0x099f,
-	0x2e3: 0x09aa,
-	0x2e4: 0x09ab,
-	0x2e5: 0x09ac,
-	0x2e6: 0x09ad,
-	0x2e7: 0x09ae,
-	0x2e8: 0x09af,
-	0x2e9: 0x09bb,
-	0x2ea: 0x09bc,
-	0x2eb: 0x09bd,
-	0x2ec: 0x09be,
-	0x2ed: 0x09bf,
-	0x2ee: 0x09cc,
-	0x2ef: 0x09cd,
-	0x2f0: 0x09ce,
-	0x2f1: 0x09cf,
-	0x2f2: 0x09dd,
-	0x2f3: 0x09de,
-	0x2f4: 0x09df,
-	0x2f5: 0x09ee,
-	0x2f6: 0x09ef,
-	0x2f7: 0x09ff,
-	0x2f8: 0x0aaa,
-	0x2f9: 0x0aab,
-	0x2fa: 0x0aac,
-	0x2fb: 0x0aad,
-	0x2fc: 0x0aae,
-	0x2fd: 0x0aaf,
-	0x2fe: 0x0abb,
-	0x2ff: 0x0abc,
-	0x300: 0x0abd,
-	0x301: 0x0abe,
-	0x302: 0x0abf,
-	0x303: 0x0acc,
-	0x304: 0x0acd,
-	0x305: 0x0ace,
-	0x306: 0x0acf,
-	0x307: 0x0add,
-	0x308: 0x0ade,
-	0x309: 0x0adf,
-	0x30a: 0x0aee,
-	0x30b: 0x0aef,
-	0x30c: 0x0aff,
-	0x30d: 0x0bbb,
-	0x30e: 0x0bbc,
-	0x30f: 0x0bbd,
-	0x310: 0x0bbe,
-	0x311: 0x0bbf,
-	0x312: 0x0bcc,
-	0x313: 0x0bcd,
-	0x314: 0x0bce,
-	0x315: 0x0bcf,
-	0x316: 0x0bdd,
-	0x317: 0x0bde,
-	0x318: 0x0bdf,
-	0x319: 0x0bee,
-	0x31a: 0x0bef,
-	0x31b: 0x0bff,
-	0x31c: 0x0ccc,
-	0x31d: 0x0ccd,
-	0x31e: 0x0cce,
-	0x31f: 0x0ccf,
-	0x320: 0x0cdd,
-	0x321: 0x0cde,
-	0x322: 0x0cdf,
-	0x323: 0x0cee,
-	0x324: 0x0cef,
-	0x325: 0x0cff,
-	0x326: 0x0ddd,
-	0x327: 0x0dde,
-	0x328: 0x0ddf,
-	0x329: 0x0dee,
-	0x32a: 0x0def,
-	0x32b: 0x0dff,
-	0x32c: 0x0eee,
-	0x32d: 0x0eef,
-	0x32e: 0x0eff,
-	0x32f: 0x0fff,
-	0x330: 0x1111,
-	0x331: 0x1112,
-	0x332: 0x1113,
-	0x333: 0x1114,
-	0x334: 0x1115,
-	0x335: 0x1116,
-	0x336: 0x1117,
-	0x337: 0x1118,
-	0x338: 0x1119,
-	0x339: 0x111a,
-	0x33a: 0x111b,
-	0x33b: 0x111c,
-	0x33c: 0x111d,
-	0x33d: 0x111e,
-	0x33e: 0x111f,
-	0x33f: 0x1122,
-	0x340: 0x1123,
-	0x341: 0x1124,
-	0x342: 0x1125,
-	0x343: 0x1126,
-	0x344: 0x1127,
-	0x345: 0x1128,
-	0x346: 0x1129,
-	0x347: 0x112a,
-	0x348: 0x112b,
-	0x349: 0x112c,
-	0x34a: 0x112d,
-	0x34b: 0x112e,
-	0x34c: 0x112f,
-	0x34d: 0x1133,
-	0x34e: 0x1134,
-	0x34f: 0x1135,
-	0x350: 0x1136,
-	0x351: 0x1137,
-	0x352: 0x1138,
-	0x353: 0x1139,
-	0x354: 0x113a,
-	0x355: 0x113b,
-	0x356: 0x113c,
-	0x357: 0x113d,
-	0x358: 0x113e,
-	0x359: 0x113f,
-	0x35a: 0x1144,
-	0x35b: 0x1145,
-	0x35c: 0x1146,
-	0x35d: 0x1147,
-	0x35e: 0x1148,
-	0x35f: 0x1149,
-	0x360: 0x114a,
-	0x361: 0x114b,
-	0x362: 0x114c,
-	0x363: 0x114d,
-	0x364: 0x114e,
-	0x365: 0x114f,
-	0x366: 0x1155,
-	0x367: 0x1156,
-	0x368: 0x1157,
-	0x369: 0x1158,
-	0x36a: 0x1159,
-	0x36b: 0x115a,
-	0x36c: 0x115b,
-	0x36d: 0x115c,
-	0x36e: 0x115d,
-	0x36f: 0x115e,
-	0x370: 0x115f,
-	0x371: 0x1166,
-	0x372: 0x1167,
-	0x373: 0x1168,
-	0x374: 0x1169,
-	0x375: 0x116a,
-	0x376: 0x116b,
-	0x377: 0x116c,
-	0x378: 0x116d,
-	0x379: 0x116e,
-	0x37a: 0x116f,
-	0x37b: 0x1177,
-	0x37c: 0x1178,
-	0x37d: 0x1179,
-	0x37e: 0x117a,
-	0x37f: 0x117b,
-	0x380: 0x117c,
-	0x381: 0x117d,
-	0x382: 0x117e,
-	0x383: 0x117f,
-	0x384: 0x1188,
-	0x385: 0x1189,
-	0x386: 0x118a,
-	0x387: 0x118b,
-	0x388: 0x118c,
-	0x389: 0x118d,
-	0x38a: 0x118e,
-	0x38b: 0x118f,
-	0x38c: 0x1199,
-	0x38d: 0x119a,
-	0x38e: 0x119b,
-	0x38f: 0x119c,
-	0x390: 0x119d,
-	0x391: 0x119e,
-	0x392: 0x119f,
-	0x393: 0x11aa,
-	0x394: 0x11ab,
-	0x395: 0x11ac,
-	0x396: 0x11ad,
-	0x397: 0x11ae,
-	0x398: 0x11af,
-	0x399: 0x11bb,
-	0x39a: 0x11bc,
-	0x39b: 0x11bd,
-	0x39c: 0x11be,
-	0x39d: 0x11bf,
-	0x39e: 0x11cc,
-	0x39f: 0x11cd,
-	0x3a0: 0x11ce,
-	0x3a1: 0x11cf,
-	0x3a2: 0x11dd,
-	0x3a3: 0x11de,
-	0x3a4: 0x11df,
-	0x3a5: 0x11ee,
-	0x3a6: 0x11ef,
-	0x3a7: 0x11ff,
-	0x3a8: 0x1222,
-	0x3a9: 0x1223,
-	0x3aa: 0x1224,
-	0x3ab: 0x1225,
-	0x3ac: 0x1226,
-	0x3ad: 0x1227,
-	0x3ae: 0x1228,
-	0x3af: 0x1229,
-	0x3b0: 0x122a,
-	0x3b1: 0x122b,
-	0x3b2: 0x122c,
-	0x3b3: 0x122d,
-	0x3b4: 0x122e,
-	0x3b5: 0x122f,
-	0x3b6: 0x1233,
-	0x3b7: 0x1234,
-	0x3b8: 0x1235,
-	0x3b9: 0x1236,
-	0x3ba: 0x1237,
-	0x3bb: 0x1238,
-	0x3bc: 0x1239,
-	0x3bd: 0x123a,
-	0x3be: 0x123b,
-	0x3bf: 0x123c,
-	0x3c0: 0x123d,
-	0x3c1: 0x123e,
-	0x3c2: 0x123f,
-	0x3c3: 0x1244,
-	0x3c4: 0x1245,
-	0x3c5: 0x1246,
-	0x3c6: 0x1247,
-	0x3c7: 0x1248,
-	0x3c8: 0x1249,
-	0x3c9: 0x124a,
-	0x3ca: 0x124b,
-	0x3cb: 0x124c,
-	0x3cc: 0x124d,
-	0x3cd: 0x124e,
-	0x3ce: 0x124f,
-	0x3cf: 0x1255,
-	0x3d0: 0x1256,
-	0x3d1: 0x1257,
-	0x3d2: 0x1258,
-	0x3d3: 0x1259,
-	0x3d4: 0x125a,
-	0x3d5: 0x125b,
-	0x3d6: 0x125c,
-	0x3d7: 0x125d,
-	0x3d8: 0x125e,
-	0x3d9: 0x125f,
-	0x3da: 0x1266,
-	0x3db: 0x1267,
-	0x3dc: 0x1268,
-	0x3dd: 0x1269,
-	0x3de: 0x126a,
-	0x3df: 0x126b,
-	0x3e0: 0x126c,
-	0x3e1: 0x126d,
-	0x3e2: 0x126e,
-	0x3e3: 0x126f,
-	0x3e4: 0x1277,
-	0x3e5: 0x1278,
-	0x3e6: 0x1279,
-	0x3e7: 0x127a,
-	0x3e8: 0x127b,
-	0x3e9: 0x127c,
-	0x3ea: 0x127d,
-	0x3eb: 0x127e,
-	0x3ec: 0x127f,
-	0x3ed: 0x1288,
-	0x3ee: 0x1289,
-	0x3ef: 0x128a,
-	0x3f0: 0x128b,
-	0x3f1: 0x128c,
-	0x3f2: 0x128d,
-	0x3f3: 0x128e,
-	0x3f4: 0x128f,
-	0x3f5: 0x1299,
-	0x3f6: 0x129a,
-	0x3f7: 0x129b,
-	0x3f8: 0x129c,
-	0x3f9: 0x129d,
-	0x3fa: 0x129e,
-	0x3fb: 0x129f,
-	0x3fc: 0x12aa,
-	0x3fd: 0x12ab,
-	0x3fe: 0x12ac,
-	0x3ff: 0x12ad,
-	0x400: 0x12ae,
-	0x401: 0x12af,
-	0x402: 0x12bb,
-	0x403: 0x12bc,
-	0x404: 0x12bd,
-	0x405: 0x12be,
-	0x406: 0x12bf,
-	0x407: 0x12cc,
-	0x408: 0x12cd,
-	0x409: 0x12ce,
-	0x40a: 0x12cf,
-	0x40b: 0x12dd,
-	0x40c: 0x12de,
-	0x40d: 0x12df,
-	0x40e: 0x12ee,
-	0x40f: 0x12ef,
-	0x410: 0x12ff,
-	0x411: 0x1333,
-	0x412: 0x1334,
-	0x413: 0x1335,
-	0x414: 0x1336,
-	0x415: 0x1337,
-	0x416: 0x1338,
-	0x417: 0x1339,
-	0x418: 0x133a,
-	0x419: 0x133b,
-	0x41a: 0x133c,
-	0x41b: 0x133d,
-	0x41c: 0x133e,
-	0x41d: 0x133f,
-	0x41e: 0x1344,
-	0x41f: 0x1345,
-	0x420: 0x1346,
-	0x421: 0x1347,
-	0x422: 0x1348,
-	0x423: 0x1349,
-	0x424: 0x134a,
-	0x425: 0x134b,
-	0x426: 0x134c,
-	0x427: 0x134d,
-	0x428: 0x134e,
-	0x429: 0x134f,
-	0x42a: 0x1355,
-	0x42b: 0x1356,
-	0x42c: 0x1357,
-	0x42d: 0x1358,
-	0x42e: 0x1359,
-	0x42f: 0x135a,
-	0x430: 0x135b,
-	0x431: 0x135c,
-	0x432: 0x135d,
-	0x433: 0x135e,
-	0x434: 0x135f,
-	0x435: 0x1366,
-	0x436: 0x1367,
-	0x437: 0x1368,
-	0x438: 0x1369,
-	0x439: 0x136a,
-	0x43a: 0x136b,
-	0x43b: 0x136c,
-	0x43c: 0x136d,
-	0x43d: 0x136e,
-	0x43e: 0x136f,
-	0x43f: 0x1377,
-	0x440: 0x1378,
-	0x441: 0x1379,
-	0x442: 0x137a,
-	0x443: 0x137b,
-	0x444: 0x137c,
-	0x445: 0x137d,
-	0x446: 0x137e,
-	0x447: 0x137f,
-	0x448: 0x1388,
-	0x449: 0x1389,
-	0x44a: 0x138a,
-	0x44b: 0x138b,
-	0x44c: 0x138c,
-	0x44d: 0x138d,
-	0x44e: 0x138e,
-	0x44f: 0x138f,
-	0x450: 0x1399,
-	0x451: 0x139a,
-	0x452: 0x139b,
-	0x453: 0x139c,
-	0x454: 0x139d,
-	0x455: 0x139e,
-	0x456: 0x139f,
-	0x457: 0x13aa,
-	0x458: 0x13ab,
-	0x459: 0x13ac,
-	0x45a: 0x13ad,
-	0x45b: 0x13ae,
-	0x45c: 0x13af,
-	0x45d: 0x13bb,
-	0x45e: 0x13bc,
-	0x45f: 0x13bd,
-	0x460: 0x13be,
-	0x461: 0x13bf,
-	0x462: 0x13cc,
-	0x463: 0x13cd,
-	0x464: 0x13ce,
-	0x465: 0x13cf,
-	0x466: 0x13dd,
-	0x467: 0x13de,
-	0x468: 0x13df,
-	0x469: 0x13ee,
-	0x46a: 0x13ef,
-	0x46b: 0x13ff,
-	0x46c: 0x1444,
-	0x46d: 0x1445,
-	0x46e: 0x1446,
-	0x46f: 0x1447,
-	0x470: 0x1448,
-	0x471: 0x1449,
-	0x472: 0x144a,
-	0x473: 0x144b,
-	0x474: 0x144c,
-	0x475: 0x144d,
-	0x476: 0x144e,
-	0x477: 0x144f,
-	0x478: 0x1455,
-	0x479: 0x1456,
-	0x47a: 0x1457,
-	0x47b: 0x1458,
-	0x47c: 0x1459,
-	0x47d: 0x145a,
-	0x47e: 0x145b,
-	0x47f: 0x145c,
-	0x480: 0x145d,
-	0x481: 0x145e,
-	0x482: 0x145f,
-	0x483: 0x1466,
-	0x484: 0x1467,
-	0x485: 0x1468,
-	0x486: 0x1469,
-	0x487: 0x146a,
-	0x488: 0x146b,
-	0x489: 0x146c,
-	0x48a: 0x146d,
-	0x48b: 0x146e,
-	0x48c: 0x146f,
-	0x48d: 0x1477,
-	0x48e: 0x1478,
-	0x48f: 0x1479,
-	0x490: 0x147a,
-	0x491: 0x147b,
-	0x492: 0x147c,
-	0x493: 0x147d,
-	0x494: 0x147e,
-	0x495: 0x147f,
-	0x496: 0x1488,
-	0x497: 0x1489,
-	0x498: 0x148a,
-	0x499: 0x148b,
-	0x49a: 0x148c,
-	0x49b: 0x148d,
-	0x49c: 0x148e,
-	0x49d: 0x148f,
-	0x49e: 0x1499,
-	0x49f: 0x149a,
-	0x4a0: 0x149b,
-	0x4a1: 0x149c,
-	0x4a2: 0x149d,
-	0x4a3: 0x149e,
-	0x4a4: 0x149f,
-	0x4a5: 0x14aa,
-	0x4a6: 0x14ab,
-	0x4a7: 0x14ac,
-	0x4a8: 0x14ad,
-	0x4a9: 0x14ae,
-	0x4aa: 0x14af,
-	0x4ab: 0x14bb,
-	0x4ac: 0x14bc,
-	0x4ad: 0x14bd,
-	0x4ae: 0x14be,
-	0x4af: 0x14bf,
-	0x4b0: 0x14cc,
-	0x4b1: 0x14cd,
-	0x4b2: 0x14ce,
-	0x4b3: 0x14cf,
-	0x4b4: 0x14dd,
-	0x4b5: 0x14de,
-	0x4b6: 0x14df,
-	0x4b7: 0x14ee,
-	0x4b8: 0x14ef,
-	0x4b9: 0x14ff,
-	0x4ba: 0x1555,
-	0x4bb: 0x1556,
-	0x4bc: 0x1557,
-	0x4bd: 0x1558,
-	0x4be: 0x1559,
-	0x4bf: 0x155a,
-	0x4c0: 0x155b,
-	0x4c1: 0x155c,
-	0x4c2: 0x155d,
-	0x4c3: 0x155e,
-	0x4c4: 0x155f,
-	0x4c5: 0x1566,
-	0x4c6: 0x1567,
-	0x4c7: 0x1568,
-	0x4c8: 0x1569,
-	0x4c9: 0x156a,
-	0x4ca: 0x156b,
-	0x4cb: 0x156c,
-	0x4cc: 0x156d,
-	0x4cd: 0x156e,
-	0x4ce: 0x156f,
-	0x4cf: 0x1577,
-	0x4d0: 0x1578,
-	0x4d1: 0x1579,
-	0x4d2: 0x157a,
-	0x4d3: 0x157b,
-	0x4d4: 0x157c,
-	0x4d5: 0x157d,
-	0x4d6: 0x157e,
-	0x4d7: 0x157f,
-	0x4d8: 0x1588,
-	0x4d9: 0x1589,
-	0x4da: 0x158a,
-	0x4db: 0x158b,
-	0x4dc: 0x158c,
-	0x4dd: 0x158d,
-	0x4de: 0x158e,
-	0x4df: 0x158f,
-	0x4e0: 0x1599,
-	0x4e1: 0x159a,
-	0x4e2: 0x159b,
-	0x4e3: 0x159c,
-	0x4e4: 0x159d,
-	0x4e5: 0x159e,
-	0x4e6: 0x159f,
-	0x4e7: 0x15aa,
-	0x4e8: 0x15ab,
-	0x4e9: 0x15ac,
-	0x4ea: 0x15ad,
-	0x4eb: 0x15ae,
-	0x4ec: 0x15af,
-	0x4ed: 0x15bb,
-	0x4ee: 0x15bc,
-	0x4ef: 0x15bd,
-	0x4f0: 0x15be,
-	0x4f1: 0x15bf,
-	0x4f2: 0x15cc,
-	0x4f3: 0x15cd,
-	0x4f4: 0x15ce,
-	0x4f5: 0x15cf,
-	0x4f6: 0x15dd,
-	0x4f7: 0x15de,
-	0x4f8: 0x15df,
-	0x4f9: 0x15ee,
-	0x4fa: 0x15ef,
-	0x4fb: 0x15ff,
-	0x4fc: 0x1666,
-	0x4fd: 0x1667,
-	0x4fe: 0x1668,
-	0x4ff: 0x1669,
-	0x500: 0x166a,
-	0x501: 0x166b,
-	0x502: 0x166c,
-	0x503: 0x166d,
-	0x504: 0x166e,
-	0x505: 0x166f,
-	0x506: 0x1677,
-	0x507: 0x1678,
-	0x508: 0x1679,
-	0x509: 0x167a,
-	0x50a: 0x167b,
-	0x50b: 0x167c,
-	0x50c: 0x167d,
-	0x50d: 0x167e,
-	0x50e: 0x167f,
-	0x50f: 0x1688,
-	0x510: 0x1689,
-	0x511: 0x168a,
-	0x512: 0x168b,
-	0x513: 0x168c,
-	0x514: 0x168d,
-	0x515: 0x168e,
-	0x516: 0x168f,
-	0x517: 0x1699,
-	0x518: 0x169a,
-	0x519: 0x169b,
-	0x51a: 0x169c,
-	0x51b: 0x169d,
-	0x51c: 0x169e,
-	0x51d: 0x169f,
-	0x51e: 0x16aa,
-	0x51f: 0x16ab,
-	0x520: 0x16ac,
-	0x521: 0x16ad,
-	0x522: 0x16ae,
-	0x523: 0x16af,
-	0x524: 0x16bb,
-	0x525: 0x16bc,
-	0x526: 0x16bd,
-	0x527: 0x16be,
-	0x528: 0x16bf,
-	0x529: 0x16cc,
-	0x52a: 0x16cd,
-	0x52b: 0x16ce,
-	0x52c: 0x16cf,
-	0x52d: 0x16dd,
-	0x52e: 0x16de,
-	0x52f: 0x16df,
-	0x530: 0x16ee,
-	0x531: 0x16ef,
-	0x532: 0x16ff,
-	0x533: 0x1777,
-	0x534: 0x1778,
-	0x535: 0x1779,
-	0x536: 0x177a,
-	0x537: 0x177b,
-	0x538: 0x177c,
-	0x539: 0x177d,
-	0x53a: 0x177e,
-	0x53b: 0x177f,
-	0x53c: 0x1788,
-	0x53d: 0x1789,
-	0x53e: 0x178a,
-	0x53f: 0x178b,
-	0x540: 0x178c,
-	0x541: 0x178d,
-	0x542: 0x178e,
-	0x543: 0x178f,
-	0x544: 0x1799,
-	0x545: 0x179a,
-	0x546: 0x179b,
-	0x547: 0x179c,
-	0x548: 0x179d,
-	0x549: 0x179e,
-	0x54a: 0x179f,
-	0x54b: 0x17aa,
-	0x54c: 0x17ab,
-	0x54d: 0x17ac,
-	0x54e: 0x17ad,
-	0x54f: 0x17ae,
-	0x550: 0x17af,
-	0x551: 0x17bb,
-	0x552: 0x17bc,
-	0x553: 0x17bd,
-	0x554: 0x17be,
-	0x555: 0x17bf,
-	0x556: 0x17cc,
-	0x557: 0x17cd,
-	0x558: 0x17ce,
-	0x559: 0x17cf,
-	0x55a: 0x17dd,
-	0x55b: 0x17de,
-	0x55c: 0x17df,
-	0x55d: 0x17ee,
-	0x55e: 0x17ef,
-	0x55f: 0x17ff,
-	0x560: 0x1888,
-	0x561: 0x1889,
-	0x562: 0x188a,
-	0x563: 0x188b,
-	0x564: 0x188c,
-	0x565: 0x188d,
-	0x566: 0x188e,
-	0x567: 0x188f,
-	0x568: 0x1899,
-	0x569: 0x189a,
-	0x56a: 0x189b,
-	0x56b: 0x189c,
-	0x56c: 0x189d,
-	0x56d: 0x189e,
-	0x56e: 0x189f,
-	0x56f: 0x18aa,
-	0x570: 0x18ab,
-	0x571: 0x18ac,
-	0x572: 0x18ad,
-	0x573: 0x18ae,
-	0x574: 0x18af,
-	0x575: 0x18bb,
-	0x576: 0x18bc,
-	0x577: 0x18bd,
-	0x578: 0x18be,
-	0x579: 0x18bf,
-	0x57a: 0x18cc,
-	0x57b: 0x18cd,
-	0x57c: 0x18ce,
-	0x57d: 0x18cf,
-	0x57e: 0x18dd,
-	0x57f: 0x18de,
-	0x580: 0x18df,
-	0x581: 0x18ee,
-	0x582: 0x18ef,
-	0x583: 0x18ff,
-	0x584: 0x1999,
-	0x585: 0x199a,
-	0x586: 0x199b,
-	0x587: 0x199c,
-	0x588: 0x199d,
-	0x589: 0x199e,
-	0x58a: 0x199f,
-	0x58b: 0x19aa,
-	0x58c: 0x19ab,
-	0x58d: 0x19ac,
-	0x58e: 0x19ad,
-	0x58f: 0x19ae,
-	0x590: 0x19af,
-	0x591: 0x19bb,
-	0x592: 0x19bc,
-	0x593: 0x19bd,
-	0x594: 0x19be,
-	0x595: 0x19bf,
-	0x596: 0x19cc,
-	0x597: 0x19cd,
-	0x598: 0x19ce,
-	0x599: 0x19cf,
-	0x59a: 0x19dd,
-	0x59b: 0x19de,
-	0x59c: 0x19df,
-	0x59d: 0x19ee,
-	0x59e: 0x19ef,
-	0x59f: 0x19ff,
-	0x5a0: 0x1aaa,
-	0x5a1: 0x1aab,
-	0x5a2: 0x1aac,
-	0x5a3: 0x1aad,
-	0x5a4: 0x1aae,
-	0x5a5: 0x1aaf,
-	0x5a6: 0x1abb,
-	0x5a7: 0x1abc,
-	0x5a8: 0x1abd,
-	0x5a9: 0x1abe,
-	0x5aa: 0x1abf,
-	0x5ab: 0x1acc,
-	0x5ac: 0x1acd,
-	0x5ad: 0x1ace,
-	0x5ae: 0x1acf,
-	0x5af: 0x1add,
-	0x5b0: 0x1ade,
-	0x5b1: 0x1adf,
-	0x5b2: 0x1aee,
-	0x5b3: 0x1aef,
-	0x5b4: 0x1aff,
-	0x5b5: 0x1bbb,
-	0x5b6: 0x1bbc,
-	0x5b7: 0x1bbd,
-	0x5b8: 0x1bbe,
-	0x5b9: 0x1bbf,
-	0x5ba: 0x1bcc,
-	0x5bb: 0x1bcd,
-	0x5bc: 0x1bce,
-	0x5bd: 0x1bcf,
-	0x5be: 0x1bdd,
-	0x5bf: 0x1bde,
-	0x5c0: 0x1bdf,
-	0x5c1: 0x1bee,
-	0x5c2: 0x1bef,
-	0x5c3: 0x1bff,
-	0x5c4: 0x1ccc,
-	0x5c5: 0x1ccd,
-	0x5c6: 0x1cce,
-	0x5c7: 0x1ccf,
-	0x5c8: 0x1cdd,
-	0x5c9: 0x1cde,
-	0x5ca: 0x1cdf,
-	0x5cb: 0x1cee,
-	0x5cc: 0x1cef,
-	0x5cd: 0x1cff,
-	0x5ce: 0x1ddd,
-	0x5cf: 0x1dde,
-	0x5d0: 0x1ddf,
-	0x5d1: 0x1dee,
-	0x5d2: 0x1def,
-	0x5d3: 0x1dff,
-	0x5d4: 0x1eee,
-	0x5d5: 0x1eef,
-	0x5d6: 0x1eff,
-	0x5d7: 0x1fff,
-	0x5d8: 0x2222,
-	0x5d9: 0x2223,
-	0x5da: 0x2224,
-	0x5db: 0x2225,
-	0x5dc: 0x2226,
-	0x5dd: 0x2227,
-	0x5de: 0x2228,
-	0x5df: 0x2229,
-	0x5e0: 0x222a,
-	0x5e1: 0x222b,
-	0x5e2: 0x222c,
-	0x5e3: 0x222d,
-	0x5e4: 0x222e,
-	0x5e5: 0x222f,
-	0x5e6: 0x2233,
-	0x5e7: 0x2234,
-	0x5e8: 0x2235,
-	0x5e9: 0x2236,
-	0x5ea: 0x2237,
-	0x5eb: 0x2238,
-	0x5ec: 0x2239,
-	0x5ed: 0x223a,
-	0x5ee: 0x223b,
-	0x5ef: 0x223c,
-	0x5f0: 0x223d,
-	0x5f1: 0x223e,
-	0x5f2: 0x223f,
-	0x5f3: 0x2244,
-	0x5f4: 0x2245,
-	0x5f5: 0x2246,
-	0x5f6: 0x2247,
-	0x5f7: 0x2248,
-	0x5f8: 0x2249,
-	0x5f9: 0x224a,
-	0x5fa: 0x224b,
-	0x5fb: 0x224c,
-	0x5fc: 0x224d,
-	0x5fd: 0x224e,
-	0x5fe: 0x224f,
-	0x5ff: 0x2255,
-	0x600: 0x2256,
-	0x601: 0x2257,
-	0x602: 0x2258,
-	0x603: 0x2259,
-	0x604: 0x225a,
-	0x605: 0x225b,
-	0x606: 0x225c,
-	0x607: 0x225d,
-	0x608: 0x225e,
-	0x609: 0x225f,
-	0x60a: 0x2266,
-	0x60b: 0x2267,
-	0x60c: 0x2268,
-	0x60d: 0x2269,
-	0x60e: 0x226a,
-	0x60f: 0x226b,
-	0x610: 0x226c,
-	0x611: 0x226d,
-	0x612: 0x226e,
-	0x613: 0x226f,
-	0x614: 0x2277,
-	0x615: 0x2278,
-	0x616: 0x2279,
-	0x617: 0x227a,
-	0x618: 0x227b,
-	0x619: 0x227c,
-	0x61a: 0x227d,
-	0x61b: 0x227e,
-	0x61c: 0x227f,
-	0x61d: 0x2288,
-	0x61e: 0x2289,
-	0x61f: 0x228a,
-	0x620: 0x228b,
-	0x621: 0x228c,
-	0x622: 0x228d,
-	0x623: 0x228e,
-	0x624: 0x228f,
-	0x625: 0x2299,
-	0x626: 0x229a,
-	0x627: 0x229b,
-	0x628: 0x229c,
-	0x629: 0x229d,
-	0x62a: 0x229e,
-	0x62b: 0x229f,
-	0x62c: 0x22aa,
-	0x62d: 0x22ab,
-	0x62e: 0x22ac,
-	0x62f: 0x22ad,
-	0x630: 0x22ae,
-	0x631: 0x22af,
-	0x632: 0x22bb,
-	0x633: 0x22bc,
-	0x634: 0x22bd,
-	0x635: 0x22be,
-	0x636: 0x22bf,
-	0x637: 0x22cc,
-	0x638: 0x22cd,
-	0x639: 0x22ce,
-	0x63a: 0x22cf,
-	0x63b: 0x22dd,
-	0x63c: 0x22de,
-	0x63d: 0x22df,
-	0x63e: 0x22ee,
-	0x63f: 0x22ef,
-	0x640: 0x22ff,
-	0x641: 0x2333,
-	0x642: 0x2334,
-	0x643: 0x2335,
-	0x644: 0x2336,
-	0x645: 0x2337,
-	0x646: 0x2338,
-	0x647: 0x2339,
-	0x648: 0x233a,
-	0x649: 0x233b,
-	0x64a: 0x233c,
-	0x64b: 0x233d,
-	0x64c: 0x233e,
-	0x64d: 0x233f,
-	0x64e: 0x2344,
-	0x64f: 0x2345,
-	0x650: 0x2346,
-	0x651: 0x2347,
-	0x652: 0x2348,
-	0x653: 0x2349,
-	0x654: 0x234a,
-	0x655: 0x234b,
-	0x656: 0x234c,
-	0x657: 0x234d,
-	0x658: 0x234e,
-	0x659: 0x234f,
-	0x65a: 0x2355,
-	0x65b: 0x2356,
-	0x65c: 0x2357,
-	0x65d: 0x2358,
-	0x65e: 0x2359,
-	0x65f: 0x235a,
-	0x660: 0x235b,
-	0x661: 0x235c,
-	0x662: 0x235d,
-	0x663: 0x235e,
-	0x664: 0x235f,
-	0x665: 0x2366,
-	0x666: 0x2367,
-	0x667: 0x2368,
-	0x668: 0x2369,
-	0x669: 0x236a,
-	0x66a: 0x236b,
-	0x66b: 0x236c,
-	0x66c: 0x236d,
-	0x66d: 0x236e,
-	0x66e: 0x236f,
-	0x66f: 0x2377,
-	0x670: 0x2378,
-	0x671: 0x2379,
-	0x672: 0x237a,
-	0x673: 0x237b,
-	0x674: 0x237c,
-	0x675: 0x237d,
-	0x676: 0x237e,
-	0x677: 0x237f,
-	0x678: 0x2388,
-	0x679: 0x2389,
-	0x67a: 0x238a,
-	0x67b: 0x238b,
-	0x67c: 0x238c,
-	0x67d: 0x238d,
-	0x67e: 0x238e,
-	0x67f: 0x238f,
-	0x680: 0x2399,
-	0x681: 0x239a,
-	0x682: 0x239b,
-	0x683: 0x239c,
-	0x684: 0x239d,
-	0x685: 0x239e,
-	0x686: 0x239f,
-	0x687: 0x23aa,
-	0x688: 0x23ab,
-	0x689: 0x23ac,
-	0x68a: 0x23ad,
-	0x68b: 0x23ae,
-	0x68c: 0x23af,
-	0x68d: 0x23bb,
-	0x68e: 0x23bc,
-	0x68f: 0x23bd,
-	0x690: 0x23be,
-	0x691: 0x23bf,
-	0x692: 0x23cc,
-	0x693: 0x23cd,
-	0x694: 0x23ce,
-	0x695: 0x23cf,
-	0x696: 0x23dd,
-	0x697: 0x23de,
-	0x698: 0x23df,
-	0x699: 0x23ee,
-	0x69a: 0x23ef,
-	0x69b: 0x23ff,
-	0x69c: 0x2444,
-	0x69d: 0x2445,
-	0x69e: 0x2446,
-	0x69f: 0x2447,
-	0x6a0: 0x2448,
-	0x6a1: 0x2449,
-	0x6a2: 0x244a,
-	0x6a3: 0x244b,
-	0x6a4: 0x244c,
-	0x6a5: 0x244d,
-	0x6a6: 0x244e,
-	0x6a7: 0x244f,
-	0x6a8: 0x2455,
-	0x6a9: 0x2456,
-	0x6aa: 0x2457,
-	0x6ab: 0x2458,
-	0x6ac: 0x2459,
-	0x6ad: 0x245a,
-	0x6ae: 0x245b,
-	0x6af: 0x245c,
-	0x6b0: 0x245d,
-	0x6b1: 0x245e,
-	0x6b2: 0x245f,
-	0x6b3: 0x2466,
-	0x6b4: 0x2467,
-	0x6b5: 0x2468,
-	0x6b6: 0x2469,
-	0x6b7: 0x246a,
-	0x6b8: 0x246b,
-	0x6b9: 0x246c,
-	0x6ba: 0x246d,
-	0x6bb: 0x246e,
-	0x6bc: 0x246f,
-	0x6bd: 0x2477,
-	0x6be: 0x2478,
-	0x6bf: 0x2479,
-	0x6c0: 0x247a,
-	0x6c1: 0x247b,
-	0x6c2: 0x247c,
-	0x6c3: 0x247d,
-	0x6c4: 0x247e,
-	0x6c5: 0x247f,
-	0x6c6: 0x2488,
-	0x6c7: 0x2489,
-	0x6c8: 0x248a,
-	0x6c9: 0x248b,
-	0x6ca: 0x248c,
-	0x6cb: 0x248d,
-	0x6cc: 0x248e,
-	0x6cd: 0x248f,
-	0x6ce: 0x2499,
-	0x6cf: 0x249a,
-	0x6d0: 0x249b,
-	0x6d1: 0x249c,
-	0x6d2: 0x249d,
-	0x6d3: 0x249e,
-	0x6d4: 0x249f,
-	0x6d5: 0x24aa,
-	0x6d6: 0x24ab,
-	0x6d7: 0x24ac,
-	0x6d8: 0x24ad,
-	0x6d9: 0x24ae,
-	0x6da: 0x24af,
-	0x6db: 0x24bb,
-	0x6dc: 0x24bc,
-	0x6dd: 0x24bd,
-	0x6de: 0x24be,
-	0x6df: 0x24bf,
-	0x6e0: 0x24cc,
-	0x6e1: 0x24cd,
-	0x6e2: 0x24ce,
-	0x6e3: 0x24cf,
-	0x6e4: 0x24dd,
-	0x6e5: 0x24de,
-	0x6e6: 0x24df,
-	0x6e7: 0x24ee,
-	0x6e8: 0x24ef,
-	0x6e9: 0x24ff,
-	0x6ea: 0x2555,
-	0x6eb: 0x2556,
-	0x6ec: 0x2557,
-	0x6ed: 0x2558,
-	0x6ee: 0x2559,
-	0x6ef: 0x255a,
-	0x6f0: 0x255b,
-	0x6f1: 0x255c,
-	0x6f2: 0x255d,
-	0x6f3: 0x255e,
-	0x6f4: 0x255f,
-	0x6f5: 0x2566,
-	0x6f6: 0x2567,
-	0x6f7: 0x2568,
-	0x6f8: 0x2569,
-	0x6f9: 0x256a,
-	0x6fa: 0x256b,
-	0x6fb: 0x256c,
-	0x6fc: 0x256d,
-	0x6fd: 0x256e,
-	0x6fe: 0x256f,
-	0x6ff: 0x2577,
-	0x700: 0x2578,
-	0x701: 0x2579,
-	0x702: 0x257a,
-	0x703: 0x257b,
-	0x704: 0x257c,
-	0x705: 0x257d,
-	0x706: 0x257e,
-	0x707: 0x257f,
-	0x708: 0x2588,
-	0x709: 0x2589,
-	0x70a: 0x258a,
-	0x70b: 0x258b,
-	0x70c: 0x258c,
-	0x70d: 0x258d,
-	0x70e: 0x258e,
-	0x70f: 0x258f,
-	0x710: 0x2599,
-	0x711: 0x259a,
-	0x712: 0x259b,
-	0x713: 0x259c,
-	0x714: 0x259d,
-	0x715: 0x259e,
-	0x716: 0x259f,
-	0x717: 0x25aa,
-	0x718: 0x25ab,
-	0x719: 0x25ac,
-	0x71a: 0x25ad,
-	0x71b: 0x25ae,
-	0x71c: 0x25af,
-	0x71d: 0x25bb,
-	0x71e: 0x25bc,
-	0x71f: 0x25bd,
-	0x720: 0x25be,
-	0x721: 0x25bf,
-	0x722: 0x25cc,
-	0x723: 0x25cd,
-	0x724: 0x25ce,
-	0x725: 0x25cf,
-	0x726: 0x25dd,
-	0x727: 0x25de,
-	0x728: 0x25df,
-	0x729: 0x25ee,
-	0x72a: 0x25ef,
-	0x72b: 0x25ff,
-	0x72c: 0x2666,
-	0x72d: 0x2667,
-	0x72e: 0x2668,
-	0x72f: 0x2669,
-	0x730: 0x266a,
-	0x731: 0x266b,
-	0x732: 0x266c,
-	0x733: 0x266d,
-	0x734: 0x266e,
-	0x735: 0x266f,
-	0x736: 0x2677,
-	0x737: 0x2678,
-	0x738: 0x2679,
-	0x739: 0x267a,
-	0x73a: 0x267b,
-	0x73b: 0x267c,
-	0x73c: 0x267d,
-	0x73d: 0x267e,
-	0x73e: 0x267f,
-	0x73f: 0x2688,
-	0x740: 0x2689,
-	0x741: 0x268a,
-	0x742: 0x268b,
-	0x743: 0x268c,
-	0x744: 0x268d,
-	0x745: 0x268e,
-	0x746: 0x268f,
-	0x747: 0x2699,
-	0x748: 0x269a,
-	0x749: 0x269b,
-	0x74a: 0x269c,
-	0x74b: 0x269d,
-	0x74c: 0x269e,
-	0x74d: 0x269f,
-	0x74e: 0x26aa,
-	0x74f: 0x26ab,
-	0x750: 0x26ac,
-	0x751: 0x26ad,
-	0x752: 0x26ae,
-	0x753: 0x26af,
-	0x754: 0x26bb,
-	0x755: 0x26bc,
-	0x756: 0x26bd,
-	0x757: 0x26be,
-	0x758: 0x26bf,
-	0x759: 0x26cc,
-	0x75a: 0x26cd,
-	0x75b: 0x26ce,
-	0x75c: 0x26cf,
-	0x75d: 0x26dd,
-	0x75e: 0x26de,
-	0x75f: 0x26df,
-	0x760: 0x26ee,
-	0x761: 0x26ef,
-	0x762: 0x26ff,
-	0x763: 0x2777,
-	0x764: 0x2778,
-	0x765: 0x2779,
-	0x766: 0x277a,
-	0x767: 0x277b,
-	0x768: 0x277c,
-	0x769: 0x277d,
-	0x76a: 0x277e,
-	0x76b: 0x277f,
-	0x76c: 0x2788,
-	0x76d: 0x2789,
-	0x76e: 0x278a,
-	0x76f: 0x278b,
-	0x770: 0x278c,
-	0x771: 0x278d,
-	0x772: 0x278e,
-	0x773: 0x278f,
-	0x774: 0x2799,
-	0x775: 0x279a,
-	0x776: 0x279b,
-	0x777: 0x279c,
-	0x778: 0x279d,
-	0x779: 0x279e,
-	0x77a: 0x279f,
-	0x77b: 0x27aa,
-	0x77c: 0x27ab,
-	0x77d: 0x27ac,
-	0x77e: 0x27ad,
-	0x77f: 0x27ae,
-	0x780: 0x27af,
-	0x781: 0x27bb,
-	0x782: 0x27bc,
-	0x783: 0x27bd,
-	0x784: 0x27be,
-	0x785: 0x27bf,
-	0x786: 0x27cc,
-	0x787: 0x27cd,
-	0x788: 0x27ce,
-	0x789: 0x27cf,
-	0x78a: 0x27dd,
-	0x78b: 0x27de,
-	0x78c: 0x27df,
-	0x78d: 0x27ee,
-	0x78e: 0x27ef,
-	0x78f: 0x27ff,
-	0x790: 0x2888,
-	0x791: 0x2889,
-	0x792: 0x288a,
-	0x793: 0x288b,
-	0x794: 0x288c,
-	0x795: 0x288d,
-	0x796: 0x288e,
-	0x797: 0x288f,
-	0x798: 0x2899,
-	0x799: 0x289a,
-	0x79a: 0x289b,
-	0x79b: 0x289c,
-	0x79c: 0x289d,
-	0x79d: 0x289e,
-	0x79e: 0x289f,
-	0x79f: 0x28aa,
-	0x7a0: 0x28ab,
-	0x7a1: 0x28ac,
-	0x7a2: 0x28ad,
-	0x7a3: 0x28ae,
-	0x7a4: 0x28af,
-	0x7a5: 0x28bb,
-	0x7a6: 0x28bc,
-	0x7a7: 0x28bd,
-	0x7a8: 0x28be,
-	0x7a9: 0x28bf,
-	0x7aa: 0x28cc,
-	0x7ab: 0x28cd,
-	0x7ac: 0x28ce,
-	0x7ad: 0x28cf,
-	0x7ae: 0x28dd,
-	0x7af: 0x28de,
-	0x7b0: 0x28df,
-	0x7b1: 0x28ee,
-	0x7b2: 0x28ef,
-	0x7b3: 0x28ff,
-	0x7b4: 0x2999,
-	0x7b5: 0x299a,
-	0x7b6: 0x299b,
-	0x7b7: 0x299c,
-	0x7b8: 0x299d,
-	0x7b9: 0x299e,
-	0x7ba: 0x299f,
-	0x7bb: 0x29aa,
-	0x7bc: 0x29ab,
-	0x7bd: 0x29ac,
-	0x7be: 0x29ad,
-	0x7bf: 0x29ae,
-	0x7c0: 0x29af,
-	0x7c1: 0x29bb,
-	0x7c2: 0x29bc,
-	0x7c3: 0x29bd,
-	0x7c4: 0x29be,
-	0x7c5: 0x29bf,
-	0x7c6: 0x29cc,
-	0x7c7: 0x29cd,
-	0x7c8: 0x29ce,
-	0x7c9: 0x29cf,
-	0x7ca: 0x29dd,
-	0x7cb: 0x29de,
-	0x7cc: 0x29df,
-	0x7cd: 0x29ee,
-	0x7ce: 0x29ef,
-	0x7cf: 0x29ff,
-	0x7d0: 0x2aaa,
-	0x7d1: 0x2aab,
-	0x7d2: 0x2aac,
-	0x7d3: 0x2aad,
-	0x7d4: 0x2aae,
-	0x7d5: 0x2aaf,
-	0x7d6: 0x2abb,
-	0x7d7: 0x2abc,
-	0x7d8: 0x2abd,
-	0x7d9: 0x2abe,
-	0x7da: 0x2abf,
-	0x7db: 0x2acc,
-	0x7dc: 0x2acd,
-	0x7dd: 0x2ace,
-	0x7de: 0x2acf,
-	0x7df: 0x2add,
-	0x7e0: 0x2ade,
-	0x7e1: 0x2adf,
-	0x7e2: 0x2aee,
-	0x7e3: 0x2aef,
-	0x7e4: 0x2aff,
-	0x7e5: 0x2bbb,
-	0x7e6: 0x2bbc,
-	0x7e7: 0x2bbd,
-	0x7e8: 0x2bbe,
-	0x7e9: 0x2bbf,
-	0x7ea: 0x2bcc,
-	0x7eb: 0x2bcd,
-	0x7ec: 0x2bce,
-	0x7ed: 0x2bcf,
-	0x7ee: 0x2bdd,
-	0x7ef: 0x2bde,
-	0x7f0: 0x2bdf,
-	0x7f1: 0x2bee,
-	0x7f2: 0x2bef,
-	0x7f3: 0x2bff,
-	0x7f4: 0x2ccc,
-	0x7f5: 0x2ccd,
-	0x7f6: 0x2cce,
-	0x7f7: 0x2ccf,
-	0x7f8: 0x2cdd,
-	0x7f9: 0x2cde,
-	0x7fa: 0x2cdf,
-	0x7fb: 0x2cee,
-	0x7fc: 0x2cef,
-	0x7fd: 0x2cff,
-	0x7fe: 0x2ddd,
-	0x7ff: 0x2dde,
-	0x800: 0x2ddf,
-	0x801: 0x2dee,
-	0x802: 0x2def,
-	0x803: 0x2dff,
-	0x804: 0x2eee,
-	0x805: 0x2eef,
-	0x806: 0x2eff,
-	0x807: 0x2fff,
-	0x808: 0x3333,
-	0x809: 0x3334,
-	0x80a: 0x3335,
-	0x80b: 0x3336,
-	0x80c: 0x3337,
-	0x80d: 0x3338,
-	0x80e: 0x3339,
-	0x80f: 0x333a,
-	0x810: 0x333b,
-	0x811: 0x333c,
-	0x812: 0x333d,
-	0x813: 0x333e,
-	0x814: 0x333f,
-	0x815: 0x3344,
-	0x816: 0x3345,
-	0x817: 0x3346,
-	0x818: 0x3347,
-	0x819: 0x3348,
-	0x81a: 0x3349,
-	0x81b: 0x334a,
-	0x81c: 0x334b,
-	0x81d: 0x334c,
-	0x81e: 0x334d,
-	0x81f: 0x334e,
-	0x820: 0x334f,
-	0x821: 0x3355,
-	0x822: 0x3356,
-	0x823: 0x3357,
-	0x824: 0x3358,
-	0x825: 0x3359,
-	0x826: 0x335a,
-	0x827: 0x335b,
-	0x828: 0x335c,
-	0x829: 0x335d,
-	0x82a: 0x335e,
-	0x82b: 0x335f,
-	0x82c: 0x3366,
-	0x82d: 0x3367,
-	0x82e: 0x3368,
-	0x82f: 0x3369,
-	0x830: 0x336a,
-	0x831: 0x336b,
-	0x832: 0x336c,
-	0x833: 0x336d,
-	0x834: 0x336e,
-	0x835: 0x336f,
-	0x836: 0x3377,
-	0x837: 0x3378,
-	0x838: 0x3379,
-	0x839: 0x337a,
-	0x83a: 0x337b,
-	0x83b: 0x337c,
-	0x83c: 0x337d,
-	0x83d: 0x337e,
-	0x83e: 0x337f,
-	0x83f: 0x3388,
-	0x840: 0x3389,
-	0x841: 0x338a,
-	0x842: 0x338b,
-	0x843: 0x338c,
-	0x844: 0x338d,
-	0x845: 0x338e,
-	0x846: 0x338f,
-	0x847: 0x3399,
-	0x848: 0x339a,
-	0x849: 0x339b,
-	0x84a: 0x339c,
-	0x84b: 0x339d,
-	0x84c: 0x339e,
-	0x84d: 0x339f,
-	0x84e: 0x33aa,
-	0x84f: 0x33ab,
-	0x850: 0x33ac,
-	0x851: 0x33ad,
-	0x852: 0x33ae,
-	0x853: 0x33af,
-	0x854: 0x33bb,
-	0x855: 0x33bc,
-	0x856: 0x33bd,
-	0x857: 0x33be,
-	0x858: 0x33bf,
-	0x859: 0x33cc,
-	0x85a: 0x33cd,
-	0x85b: 0x33ce,
-	0x85c: 0x33cf,
-	0x85d: 0x33dd,
-	0x85e: 0x33de,
-	0x85f: 0x33df,
-	0x860: 0x33ee,
-	0x861: 0x33ef,
-	0x862: 0x33ff,
-	0x863: 0x3444,
-	0x864: 0x3445,
-	0x865: 0x3446,
-	0x866: 0x3447,
-	0x867: 0x3448,
-	0x868: 0x3449,
-	0x869: 0x344a,
-	0x86a: 0x344b,
-	0x86b: 0x344c,
-	0x86c: 0x344d,
-	0x86d: 0x344e,
-	0x86e: 0x344f,
-	0x86f: 0x3455,
-	0x870: 0x3456,
-	0x871: 0x3457,
-	0x872: 0x3458,
-	0x873: 0x3459,
-	0x874: 0x345a,
-	0x875: 0x345b,
-	0x876: 0x345c,
-	0x877: 0x345d,
-	0x878: 0x345e,
-	0x879: 0x345f,
-	0x87a: 0x3466,
-	0x87b: 0x3467,
-	0x87c: 0x3468,
-	0x87d: 0x3469,
-	0x87e: 0x346a,
-	0x87f: 0x346b,
-	0x880: 0x346c,
-	0x881: 0x346d,
-	0x882: 0x346e,
-	0x883: 0x346f,
-	0x884: 0x3477,
-	0x885: 0x3478,
-	0x886: 0x3479,
-	0x887: 0x347a,
-	0x888: 0x347b,
-	0x889: 0x347c,
-	0x88a: 0x347d,
-	0x88b: 0x347e,
-	0x88c: 0x347f,
-	0x88d: 0x3488,
-	0x88e: 0x3489,
-	0x88f: 0x348a,
-	0x890: 0x348b,
-	0x891: 0x348c,
-	0x892: 0x348d,
-	0x893: 0x348e,
-	0x894: 0x348f,
-	0x895: 0x3499,
-	0x896: 0x349a,
-	0x897: 0x349b,
-	0x898: 0x349c,
-	0x899: 0x349d,
-	0x89a: 0x349e,
-	0x89b: 0x349f,
-	0x89c: 0x34aa,
-	0x89d: 0x34ab,
-	0x89e: 0x34ac,
-	0x89f: 0x34ad,
-	0x8a0: 0x34ae,
-	0x8a1: 0x34af,
-	0x8a2: 0x34bb,
-	0x8a3: 0x34bc,
-	0x8a4: 0x34bd,
-	0x8a5: 0x34be,
-	0x8a6: 0x34bf,
-	0x8a7: 0x34cc,
-	0x8a8: 0x34cd,
-	0x8a9: 0x34ce,
-	0x8aa: 0x34cf,
-	0x8ab: 0x34dd,
-	0x8ac: 0x34de,
-	0x8ad: 0x34df,
-	0x8ae: 0x34ee,
-	0x8af: 0x34ef,
-	0x8b0: 0x34ff,
-	0x8b1: 0x3555,
-	0x8b2: 0x3556,
-	0x8b3: 0x3557,
-	0x8b4: 0x3558,
-	0x8b5: 0x3559,
-	0x8b6: 0x355a,
-	0x8b7: 0x355b,
-	0x8b8: 0x355c,
-	0x8b9: 0x355d,
-	0x8ba: 0x355e,
-	0x8bb: 0x355f,
-	0x8bc: 0x3566,
-	0x8bd: 0x3567,
-	0x8be: 0x3568,
-	0x8bf: 0x3569,
-	0x8c0: 0x356a,
-	0x8c1: 0x356b,
-	0x8c2: 0x356c,
-	0x8c3: 0x356d,
-	0x8c4: 0x356e,
-	0x8c5: 0x356f,
-	0x8c6: 0x3577,
-	0x8c7: 0x3578,
-	0x8c8: 0x3579,
-	0x8c9: 0x357a,
-	0x8ca: 0x357b,
-	0x8cb: 0x357c,
-	0x8cc: 0x357d,
-	0x8cd: 0x357e,
-	0x8ce: 0x357f,
-	0x8cf: 0x3588,
-	0x8d0: 0x3589,
-	0x8d1: 0x358a,
-	0x8d2: 0x358b,
-	0x8d3: 0x358c,
-	0x8d4: 0x358d,
-	0x8d5: 0x358e,
-	0x8d6: 0x358f,
-	0x8d7: 0x3599,
-	0x8d8: 0x359a,
-	0x8d9: 0x359b,
-	0x8da: 0x359c,
-	0x8db: 0x359d,
-	0x8dc: 0x359e,
-	0x8dd: 0x359f,
-	0x8de: 0x35aa,
-	0x8df: 0x35ab,
-	0x8e0: 0x35ac,
-	0x8e1: 0x35ad,
-	0x8e2: 0x35ae,
-	0x8e3: 0x35af,
-	0x8e4: 0x35bb,
-	0x8e5: 0x35bc,
-	0x8e6: 0x35bd,
-	0x8e7: 0x35be,
-	0x8e8: 0x35bf,
-	0x8e9: 0x35cc,
-	0x8ea: 0x35cd,
-	0x8eb: 0x35ce,
-	0x8ec: 0x35cf,
-	0x8ed: 0x35dd,
-	0x8ee: 0x35de,
-	0x8ef: 0x35df,
-	0x8f0: 0x35ee,
-	0x8f1: 0x35ef,
-	0x8f2: 0x35ff,
-	0x8f3: 0x3666,
-	0x8f4: 0x3667,
-	0x8f5: 0x3668,
-	0x8f6: 0x3669,
-	0x8f7: 0x366a,
-	0x8f8: 0x366b,
-	0x8f9: 0x366c,
-	0x8fa: 0x366d,
-	0x8fb: 0x366e,
-	0x8fc: 0x366f,
-	0x8fd: 0x3677,
-	0x8fe: 0x3678,
-	0x8ff: 0x3679,
-	0x900: 0x367a,
-	0x901: 0x367b,
-	0x902: 0x367c,
-	0x903: 0x367d,
-	0x904: 0x367e,
-	0x905: 0x367f,
-	0x906: 0x3688,
-	0x907: 0x3689,
-	0x908: 0x368a,
-	0x909: 0x368b,
-	0x90a: 0x368c,
-	0x90b: 0x368d,
-	0x90c: 0x368e,
-	0x90d: 0x368f,
-	0x90e: 0x3699,
-	0x90f: 0x369a,
-	0x910: 0x369b,
-	0x911: 0x369c,
-	0x912: 0x369d,
-	0x913: 0x369e,
-	0x914: 0x369f,
-	0x915: 0x36aa,
-	0x916: 0x36ab,
-	0x917: 0x36ac,
-	0x918: 0x36ad,
-	0x919: 0x36ae,
-	0x91a: 0x36af,
-	0x91b: 0x36bb,
-	0x91c: 0x36bc,
-	0x91d: 0x36bd,
-	0x91e: 0x36be,
-	0x91f: 0x36bf,
-	0x920: 0x36cc,
-	0x921: 0x36cd,
-	0x922: 0x36ce,
-	0x923: 0x36cf,
-	0x924: 0x36dd,
-	0x925: 0x36de,
-	0x926: 0x36df,
-	0x927: 0x36ee,
-	0x928: 0x36ef,
-	0x929: 0x36ff,
-	0x92a: 0x3777,
-	0x92b: 0x3778,
-	0x92c: 0x3779,
-	0x92d: 0x377a,
-	0x92e: 0x377b,
-	0x92f: 0x377c,
-	0x930: 0x377d,
-	0x931: 0x377e,
-	0x932: 0x377f,
-	0x933: 0x3788,
-	0x934: 0x3789,
-	0x935: 0x378a,
-	0x936: 0x378b,
-	0x937: 0x378c,
-	0x938: 0x378d,
-	0x939: 0x378e,
-	0x93a: 0x378f,
-	0x93b: 0x3799,
-	0x93c: 0x379a,
-	0x93d: 0x379b,
-	0x93e: 0x379c,
-	0x93f: 0x379d,
-	0x940: 0x379e,
-	0x941: 0x379f,
-	0x942: 0x37aa,
-	0x943: 0x37ab,
-	0x944: 0x37ac,
-	0x945: 0x37ad,
-	0x946: 0x37ae,
-	0x947: 0x37af,
-	0x948: 0x37bb,
-	0x949: 0x37bc,
-	0x94a: 0x37bd,
-	0x94b: 0x37be,
-	0x94c: 0x37bf,
-	0x94d: 0x37cc,
-	0x94e: 0x37cd,
-	0x94f: 0x37ce,
-	0x950: 0x37cf,
-	0x951: 0x37dd,
-	0x952: 0x37de,
-	0x953: 0x37df,
-	0x954: 0x37ee,
-	0x955: 0x37ef,
-	0x956: 0x37ff,
-	0x957: 0x3888,
-	0x958: 0x3889,
-	0x959: 0x388a,
-	0x95a: 0x388b,
-	0x95b: 0x388c,
-	0x95c: 0x388d,
-	0x95d: 0x388e,
-	0x95e: 0x388f,
-	0x95f: 0x3899,
-	0x960: 0x389a,
-	0x961: 0x389b,
-	0x962: 0x389c,
-	0x963: 0x389d,
-	0x964: 0x389e,
-	0x965: 0x389f,
-	0x966: 0x38aa,
-	0x967: 0x38ab,
-	0x968: 0x38ac,
-	0x969: 0x38ad,
-	0x96a: 0x38ae,
-	0x96b: 0x38af,
-	0x96c: 0x38bb,
-	0x96d: 0x38bc,
-	0x96e: 0x38bd,
-	0x96f: 0x38be,
-	0x970: 0x38bf,
-	0x971: 0x38cc,
-	0x972: 0x38cd,
-	0x973: 0x38ce,
-	0x974: 0x38cf,
-	0x975: 0x38dd,
-	0x976: 0x38de,
-	0x977: 0x38df,
-	0x978: 0x38ee,
-	0x979: 0x38ef,
-	0x97a: 0x38ff,
-	0x97b: 0x3999,
-	0x97c: 0x399a,
-	0x97d: 0x399b,
-	0x97e: 0x399c,
-	0x97f: 0x399d,
-	0x980: 0x399e,
-	0x981: 0x399f,
-	0x982: 0x39aa,
-	0x983: 0x39ab,
-	0x984: 0x39ac,
-	0x985: 0x39ad,
-	0x986: 0x39ae,
-	0x987: 0x39af,
-	0x988: 0x39bb,
-	0x989: 0x39bc,
-	0x98a: 0x39bd,
-	0x98b: 0x39be,
-	0x98c: 0x39bf,
-	0x98d: 0x39cc,
-	0x98e: 0x39cd,
-	0x98f: 0x39ce,
-	0x990: 0x39cf,
-	0x991: 0x39dd,
-	0x992: 0x39de,
-	0x993: 0x39df,
-	0x994: 0x39ee,
-	0x995: 0x39ef,
-	0x996: 0x39ff,
-	0x997: 0x3aaa,
-	0x998: 0x3aab,
-	0x999: 0x3aac,
-	0x99a: 0x3aad,
-	0x99b: 0x3aae,
-	0x99c: 0x3aaf,
-	0x99d: 0x3abb,
-	0x99e: 0x3abc,
-	0x99f: 0x3abd,
-	0x9a0: 0x3abe,
-	0x9a1: 0x3abf,
-	0x9a2: 0x3acc,
-	0x9a3: 0x3acd,
-	0x9a4: 0x3ace,
-	0x9a5: 0x3acf,
-	0x9a6: 0x3add,
-	0x9a7: 0x3ade,
-	0x9a8: 0x3adf,
-	0x9a9: 0x3aee,
-	0x9aa: 0x3aef,
-	0x9ab: 0x3aff,
-	0x9ac: 0x3bbb,
-	0x9ad: 0x3bbc,
-	0x9ae: 0x3bbd,
-	0x9af: 0x3bbe,
-	0x9b0: 0x3bbf,
-	0x9b1: 0x3bcc,
-	0x9b2: 0x3bcd,
-	0x9b3: 0x3bce,
-	0x9b4: 0x3bcf,
-	0x9b5: 0x3bdd,
-	0x9b6: 0x3bde,
-	0x9b7: 0x3bdf,
-	0x9b8: 0x3bee,
-	0x9b9: 0x3bef,
-	0x9ba: 0x3bff,
-	0x9bb: 0x3ccc,
-	0x9bc: 0x3ccd,
-	0x9bd: 0x3cce,
-	0x9be: 0x3ccf,
-	0x9bf: 0x3cdd,
-	0x9c0: 0x3cde,
-	0x9c1: 0x3cdf,
-	0x9c2: 0x3cee,
-	0x9c3: 0x3cef,
-	0x9c4: 0x3cff,
-	0x9c5: 0x3ddd,
-	0x9c6: 0x3dde,
-	0x9c7: 0x3ddf,
-	0x9c8: 0x3dee,
-	0x9c9: 0x3def,
-	0x9ca: 0x3dff,
-	0x9cb: 0x3eee,
-	0x9cc: 0x3eef,
-	0x9cd: 0x3eff,
-	0x9ce: 0x3fff,
-	0x9cf: 0x4444,
-	0x9d0: 0x4445,
-	0x9d1: 0x4446,
-	0x9d2: 0x4447,
-	0x9d3: 0x4448,
-	0x9d4: 0x4449,
-	0x9d5: 0x444a,
-	0x9d6: 0x444b,
-	0x9d7: 0x444c,
-	0x9d8: 0x444d,
-	0x9d9: 0x444e,
-	0x9da: 0x444f,
-	0x9db: 0x4455,
-	0x9dc: 0x4456,
-	0x9dd: 0x4457,
-	0x9de: 0x4458,
-	0x9df: 0x4459,
-	0x9e0: 0x445a,
-	0x9e1: 0x445b,
-	0x9e2: 0x445c,
-	0x9e3: 0x445d,
-	0x9e4: 0x445e,
-	0x9e5: 0x445f,
-	0x9e6: 0x4466,
-	0x9e7: 0x4467,
-	0x9e8: 0x4468,
-	0x9e9: 0x4469,
-	0x9ea: 0x446a,
-	0x9eb: 0x446b,
-	0x9ec: 0x446c,
-	0x9ed: 0x446d,
-	0x9ee: 0x446e,
-	0x9ef: 0x446f,
-	0x9f0: 0x4477,
-	0x9f1: 0x4478,
-	0x9f2: 0x4479,
-	0x9f3: 0x447a,
-	0x9f4: 0x447b,
-	0x9f5: 0x447c,
-	0x9f6: 0x447d,
-	0x9f7: 0x447e,
-	0x9f8: 0x447f,
-	0x9f9: 0x4488,
-	0x9fa: 0x4489,
-	0x9fb: 0x448a,
-	0x9fc: 0x448b,
-	0x9fd: 0x448c,
-	0x9fe: 0x448d,
-	0x9ff: 0x448e,
-	0xa00: 0x448f,
-	0xa01: 0x4499,
-	0xa02: 0x449a,
-	0xa03: 0x449b,
-	0xa04: 0x449c,
-	0xa05: 0x449d,
-	0xa06: 0x449e,
-	0xa07: 0x449f,
-	0xa08: 0x44aa,
-	0xa09: 0x44ab,
-	0xa0a: 0x44ac,
-	0xa0b: 0x44ad,
-	0xa0c: 0x44ae,
-	0xa0d: 0x44af,
-	0xa0e: 0x44bb,
-	0xa0f: 0x44bc,
-	0xa10: 0x44bd,
-	0xa11: 0x44be,
-	0xa12: 0x44bf,
-	0xa13: 0x44cc,
-	0xa14: 0x44cd,
-	0xa15: 0x44ce,
-	0xa16: 0x44cf,
-	0xa17: 0x44dd,
-	0xa18: 0x44de,
-	0xa19: 0x44df,
-	0xa1a: 0x44ee,
-	0xa1b: 0x44ef,
-	0xa1c: 0x44ff,
-	0xa1d: 0x4555,
-	0xa1e: 0x4556,
-	0xa1f: 0x4557,
-	0xa20: 0x4558,
-	0xa21: 0x4559,
-	0xa22: 0x455a,
-	0xa23: 0x455b,
-	0xa24: 0x455c,
-	0xa25: 0x455d,
-	0xa26: 0x455e,
-	0xa27: 0x455f,
-	0xa28: 0x4566,
-	0xa29: 0x4567,
-	0xa2a: 0x4568,
-	0xa2b: 0x4569,
-	0xa2c: 0x456a,
-	0xa2d: 0x456b,
-	0xa2e: 0x456c,
-	0xa2f: 0x456d,
-	0xa30: 0x456e,
-	0xa31: 0x456f,
-	0xa32: 0x4577,
-	0xa33: 0x4578,
-	0xa34: 0x4579,
-	0xa35: 0x457a,
-	0xa36: 0x457b,
-	0xa37: 0x457c,
-	0xa38: 0x457d,
-	0xa39: 0x457e,
-	0xa3a: 0x457f,
-	0xa3b: 0x4588,
-	0xa3c: 0x4589,
-	0xa3d: 0x458a,
-	0xa3e: 0x458b,
-	0xa3f: 0x458c,
-	0xa40: 0x458d,
-	0xa41: 0x458e,
-	0xa42: 0x458f,
-	0xa43: 0x4599,
-	0xa44: 0x459a,
-	0xa45: 0x459b,
-	0xa46: 0x459c,
-	0xa47: 0x459d,
-	0xa48: 0x459e,
-	0xa49: 0x459f,
-	0xa4a: 0x45aa,
-	0xa4b: 0x45ab,
-	0xa4c: 0x45ac,
-	0xa4d: 0x45ad,
-	0xa4e: 0x45ae,
-	0xa4f: 0x45af,
-	0xa50: 0x45bb,
-	0xa51: 0x45bc,
-	0xa52: 0x45bd,
-	0xa53: 0x45be,
-	0xa54: 0x45bf,
-	0xa55: 0x45cc,
-	0xa56: 0x45cd,
-	0xa57: 0x45ce,
-	0xa58: 0x45cf,
-	0xa59: 0x45dd,
-	0xa5a: 0x45de,
-	0xa5b: 0x45df,
-	0xa5c: 0x45ee,
-	0xa5d: 0x45ef,
-	0xa5e: 0x45ff,
-	0xa5f: 0x4666,
-	0xa60: 0x4667,
-	0xa61: 0x4668,
-	0xa62: 0x4669,
-	0xa63: 0x466a,
-	0xa64: 0x466b,
-	0xa65: 0x466c,
-	0xa66: 0x466d,
-	0xa67: 0x466e,
-	0xa68: 0x466f,
-	0xa69: 0x4677,
-	0xa6a: 0x4678,
-	0xa6b: 0x4679,
-	0xa6c: 0x467a,
-	0xa6d: 0x467b,
-	0xa6e: 0x467c,
-	0xa6f: 0x467d,
-	0xa70: 0x467e,
-	0xa71: 0x467f,
-	0xa72: 0x4688,
-	0xa73: 0x4689,
-	0xa74: 0x468a,
-	0xa75: 0x468b,
-	0xa76: 0x468c,
-	0xa77: 0x468d,
-	0xa78: 0x468e,
-	0xa79: 0x468f,
-	0xa7a: 0x4699,
-	0xa7b: 0x469a,
-	0xa7c: 0x469b,
-	0xa7d: 0x469c,
-	0xa7e: 0x469d,
-	0xa7f: 0x469e,
-	0xa80: 0x469f,
-	0xa81: 0x46aa,
-	0xa82: 0x46ab,
-	0xa83: 0x46ac,
-	0xa84: 0x46ad,
-	0xa85: 0x46ae,
-	0xa86: 0x46af,
-	0xa87: 0x46bb,
-	0xa88: 0x46bc,
-	0xa89: 0x46bd,
-	0xa8a: 0x46be,
-	0xa8b: 0x46bf,
-	0xa8c: 0x46cc,
-	0xa8d: 0x46cd,
-	0xa8e: 0x46ce,
-	0xa8f: 0x46cf,
-	0xa90: 0x46dd,
-	0xa91: 0x46de,
-	0xa92: 0x46df,
-	0xa93: 0x46ee,
-	0xa94: 0x46ef,
-	0xa95: 0x46ff,
-	0xa96: 0x4777,
-	0xa97: 0x4778,
-	0xa98: 0x4779,
-	0xa99: 0x477a,
-	0xa9a: 0x477b,
-	0xa9b: 0x477c,
-	0xa9c: 0x477d,
-	0xa9d: 0x477e,
-	0xa9e: 0x477f,
-	0xa9f: 0x4788,
-	0xaa0: 0x4789,
-	0xaa1: 0x478a,
-	0xaa2: 0x478b,
-	0xaa3: 0x478c,
-	0xaa4: 0x478d,
-	0xaa5: 0x478e,
-	0xaa6: 0x478f,
-	0xaa7: 0x4799,
-	0xaa8: 0x479a,
-	0xaa9: 0x479b,
-	0xaaa: 0x479c,
-	0xaab: 0x479d,
-	0xaac: 0x479e,
-	0xaad: 0x479f,
-	0xaae: 0x47aa,
-	0xaaf: 0x47ab,
-	0xab0: 0x47ac,
-	0xab1: 0x47ad,
-	0xab2: 0x47ae,
-	0xab3: 0x47af,
-	0xab4: 0x47bb,
-	0xab5: 0x47bc,
-	0xab6: 0x47bd,
-	0xab7: 0x47be,
-	0xab8: 0x47bf,
-	0xab9: 0x47cc,
-	0xaba: 0x47cd,
-	0xabb: 0x47ce,
-	0xabc: 0x47cf,
-	0xabd: 0x47dd,
-	0xabe: 0x47de,
-	0xabf: 0x47df,
-	0xac0: 0x47ee,
-	0xac1: 0x47ef,
-	0xac2: 0x47ff,
-	0xac3: 0x4888,
-	0xac4: 0x4889,
-	0xac5: 0x488a,
-	0xac6: 0x488b,
-	0xac7: 0x488c,
-	0xac8: 0x488d,
-	0xac9: 0x488e,
-	0xaca: 0x488f,
-	0xacb: 0x4899,
-	0xacc: 0x489a,
-	0xacd: 0x489b,
-	0xace: 0x489c,
-	0xacf: 0x489d,
-	0xad0: 0x489e,
-	0xad1: 0x489f,
-	0xad2: 0x48aa,
-	0xad3: 0x48ab,
-	0xad4: 0x48ac,
-	0xad5: 0x48ad,
-	0xad6: 0x48ae,
-	0xad7: 0x48af,
-	0xad8: 0x48bb,
-	0xad9: 0x48bc,
-	0xada: 0x48bd,
-	0xadb: 0x48be,
-	0xadc: 0x48bf,
-	0xadd: 0x48cc,
-	0xade: 0x48cd,
-	0xadf: 0x48ce,
-	0xae0: 0x48cf,
-	0xae1: 0x48dd,
-	0xae2: 0x48de,
-	0xae3: 0x48df,
-	0xae4: 0x48ee,
-	0xae5: 0x48ef,
-	0xae6: 0x48ff,
-	0xae7: 0x4999,
-	0xae8: 0x499a,
-	0xae9: 0x499b,
-	0xaea: 0x499c,
-	0xaeb: 0x499d,
-	0xaec: 0x499e,
-	0xaed: 0x499f,
-	0xaee: 0x49aa,
-	0xaef: 0x49ab,
-	0xaf0: 0x49ac,
-	0xaf1: 0x49ad,
-	0xaf2: 0x49ae,
-	0xaf3: 0x49af,
-	0xaf4: 0x49bb,
-	0xaf5: 0x49bc,
-	0xaf6: 0x49bd,
-	0xaf7: 0x49be,
-	0xaf8: 0x49bf,
-	0xaf9: 0x49cc,
-	0xafa: 0x49cd,
-	0xafb: 0x49ce,
-	0xafc: 0x49cf,
-	0xafd: 0x49dd,
-	0xafe: 0x49de,
-	0xaff: 0x49df,
-	0xb00: 0x49ee,
-	0xb01: 0x49ef,
-	0xb02: 0x49ff,
-	0xb03: 0x4aaa,
-	0xb04: 0x4aab,
-	0xb05: 0x4aac,
-	0xb06: 0x4aad,
-	0xb07: 0x4aae,
-	0xb08: 0x4aaf,
-	0xb09: 0x4abb,
-	0xb0a: 0x4abc,
-	0xb0b: 0x4abd,
-	0xb0c: 0x4abe,
-	0xb0d: 0x4abf,
-	0xb0e: 0x4acc,
-	0xb0f: 0x4acd,
-	0xb10: 0x4ace,
-	0xb11: 0x4acf,
-	0xb12: 0x4add,
-	0xb13: 0x4ade,
-	0xb14: 0x4adf,
-	0xb15: 0x4aee,
-	0xb16: 0x4aef,
-	0xb17: 0x4aff,
-	0xb18: 0x4bbb,
-	0xb19: 0x4bbc,
-	0xb1a: 0x4bbd,
-	0xb1b: 0x4bbe,
-	0xb1c: 0x4bbf,
-	0xb1d: 0x4bcc,
-	0xb1e: 0x4bcd,
-	0xb1f: 0x4bce,
-	0xb20: 0x4bcf,
-	0xb21: 0x4bdd,
-	0xb22: 0x4bde,
-	0xb23: 0x4bdf,
-	0xb24: 0x4bee,
-	0xb25: 0x4bef,
-	0xb26: 0x4bff,
-	0xb27: 0x4ccc,
-	0xb28: 0x4ccd,
-	0xb29: 0x4cce,
-	0xb2a: 0x4ccf,
-	0xb2b: 0x4cdd,
-	0xb2c: 0x4cde,
-	0xb2d: 0x4cdf,
-	0xb2e: 0x4cee,
-	0xb2f: 0x4cef,
-	0xb30: 0x4cff,
-	0xb31: 0x4ddd,
-	0xb32: 0x4dde,
-	0xb33: 0x4ddf,
-	0xb34: 0x4dee,
-	0xb35: 0x4def,
-	0xb36: 0x4dff,
-	0xb37: 0x4eee,
-	0xb38: 0x4eef,
-	0xb39: 0x4eff,
-	0xb3a: 0x4fff,
-	0xb3b: 0x5555,
-	0xb3c: 0x5556,
-	0xb3d: 0x5557,
-	0xb3e: 0x5558,
-	0xb3f: 0x5559,
-	0xb40: 0x555a,
-	0xb41: 0x555b,
-	0xb42: 0x555c,
-	0xb43: 0x555d,
-	0xb44: 0x555e,
-	0xb45: 0x555f,
-	0xb46: 0x5566,
-	0xb47: 0x5567,
-	0xb48: 0x5568,
-	0xb49: 0x5569,
-	0xb4a: 0x556a,
-	0xb4b: 0x556b,
-	0xb4c: 0x556c,
-	0xb4d: 0x556d,
-	0xb4e: 0x556e,
-	0xb4f: 0x556f,
-	0xb50: 0x5577,
-	0xb51: 0x5578,
-	0xb52: 0x5579,
-	0xb53: 0x557a,
-	0xb54: 0x557b,
-	0xb55: 0x557c,
-	0xb56: 0x557d,
-	0xb57: 0x557e,
-	0xb58: 0x557f,
-	0xb59: 0x5588,
-	0xb5a: 0x5589,
-	0xb5b: 0x558a,
-	0xb5c: 0x558b,
-	0xb5d: 0x558c,
-	0xb5e: 0x558d,
-	0xb5f: 0x558e,
-	0xb60: 0x558f,
-	0xb61: 0x5599,
-	0xb62: 0x559a,
-	0xb63: 0x559b,
-	0xb64: 0x559c,
-	0xb65: 0x559d,
-	0xb66: 0x559e,
-	0xb67: 0x559f,
-	0xb68: 0x55aa,
-	0xb69: 0x55ab,
-	0xb6a: 0x55ac,
-	0xb6b: 0x55ad,
-	0xb6c: 0x55ae,
-	0xb6d: 0x55af,
-	0xb6e: 0x55bb,
-	0xb6f: 0x55bc,
-	0xb70: 0x55bd,
-	0xb71: 0x55be,
-	0xb72: 0x55bf,
-	0xb73: 0x55cc,
-	0xb74: 0x55cd,
-	0xb75: 0x55ce,
-	0xb76: 0x55cf,
-	0xb77: 0x55dd,
-	0xb78: 0x55de,
-	0xb79: 0x55df,
-	0xb7a: 0x55ee,
-	0xb7b: 0x55ef,
-	0xb7c: 0x55ff,
-	0xb7d: 0x5666,
-	0xb7e: 0x5667,
-	0xb7f: 0x5668,
-	0xb80: 0x5669,
-	0xb81: 0x566a,
-	0xb82: 0x566b,
-	0xb83: 0x566c,
-	0xb84: 0x566d,
-	0xb85: 0x566e,
-	0xb86: 0x566f,
-	0xb87: 0x5677,
-	0xb88: 0x5678,
-	0xb89: 0x5679,
-	0xb8a: 0x567a,
-	0xb8b: 0x567b,
-	0xb8c: 0x567c,
-	0xb8d: 0x567d,
-	0xb8e: 0x567e,
-	0xb8f: 0x567f,
-	0xb90: 0x5688,
-	0xb91: 0x5689,
-	0xb92: 0x568a,
-	0xb93: 0x568b,
-	0xb94: 0x568c,
-	0xb95: 0x568d,
-	0xb96: 0x568e,
-	0xb97: 0x568f,
-	0xb98: 0x5699,
-	0xb99: 0x569a,
-	0xb9a: 0x569b,
-	0xb9b: 0x569c,
-	0xb9c: 0x569d,
-	0xb9d: 0x569e,
-	0xb9e: 0x569f,
-	0xb9f: 0x56aa,
-	0xba0: 0x56ab,
-	0xba1: 0x56ac,
-	0xba2: 0x56ad,
-	0xba3: 0x56ae,
-	0xba4: 0x56af,
-	0xba5: 0x56bb,
-	0xba6: 0x56bc,
-	0xba7: 0x56bd,
-	0xba8: 0x56be,
-	0xba9: 0x56bf,
-	0xbaa: 0x56cc,
-	0xbab: 0x56cd,
-	0xbac: 0x56ce,
-	0xbad: 0x56cf,
-	0xbae: 0x56dd,
-	0xbaf: 0x56de,
-	0xbb0: 0x56df,
-	0xbb1: 0x56ee,
-	0xbb2: 0x56ef,
-	0xbb3: 0x56ff,
-	0xbb4: 0x5777,
-	0xbb5: 0x5778,
-	0xbb6: 0x5779,
-	0xbb7: 0x577a,
-	0xbb8: 0x577b,
-	0xbb9: 0x577c,
-	0xbba: 0x577d,
-	0xbbb: 0x577e,
-	0xbbc: 0x577f,
-	0xbbd: 0x5788,
-	0xbbe: 0x5789,
-	0xbbf: 0x578a,
-	0xbc0: 0x578b,
-	0xbc1: 0x578c,
-	0xbc2: 0x578d,
-	0xbc3: 0x578e,
-	0xbc4: 0x578f,
-	0xbc5: 0x5799,
-	0xbc6: 0x579a,
-	0xbc7: 0x579b,
-	0xbc8: 0x579c,
-	0xbc9: 0x579d,
-	0xbca: 0x579e,
-	0xbcb: 0x579f,
-	0xbcc: 0x57aa,
-	0xbcd: 0x57ab,
-	0xbce: 0x57ac,
-	0xbcf: 0x57ad,
-	0xbd0: 0x57ae,
-	0xbd1: 0x57af,
-	0xbd2: 0x57bb,
-	0xbd3: 0x57bc,
-	0xbd4: 0x57bd,
-	0xbd5: 0x57be,
-	0xbd6: 0x57bf,
-	0xbd7: 0x57cc,
-	0xbd8: 0x57cd,
-	0xbd9: 0x57ce,
-	0xbda: 0x57cf,
-	0xbdb: 0x57dd,
-	0xbdc: 0x57de,
-	0xbdd: 0x57df,
-	0xbde: 0x57ee,
-	0xbdf: 0x57ef,
-	0xbe0: 0x57ff,
-	0xbe1: 0x5888,
-	0xbe2: 0x5889,
-	0xbe3: 0x588a,
-	0xbe4: 0x588b,
-	0xbe5: 0x588c,
-	0xbe6: 0x588d,
-	0xbe7: 0x588e,
-	0xbe8: 0x588f,
-	0xbe9: 0x5899,
-	0xbea: 0x589a,
-	0xbeb: 0x589b,
-	0xbec: 0x589c,
-	0xbed: 0x589d,
-	0xbee: 0x589e,
-	0xbef: 0x589f,
-	0xbf0: 0x58aa,
-	0xbf1: 0x58ab,
-	0xbf2: 0x58ac,
-	0xbf3: 0x58ad,
-	0xbf4: 0x58ae,
-	0xbf5: 0x58af,
-	0xbf6: 0x58bb,
-	0xbf7: 0x58bc,
-	0xbf8: 0x58bd,
-	0xbf9: 0x58be,
-	0xbfa: 0x58bf,
-	0xbfb: 0x58cc,
-	0xbfc: 0x58cd,
-	0xbfd: 0x58ce,
-	0xbfe: 0x58cf,
-	0xbff: 0x58dd,
-	0xc00: 0x58de,
-	0xc01: 0x58df,
-	0xc02: 0x58ee,
-	0xc03: 0x58ef,
-	0xc04: 0x58ff,
-	0xc05: 0x5999,
-	0xc06: 0x599a,
-	0xc07: 0x599b,
-	0xc08: 0x599c,
-	0xc09: 0x599d,
-	0xc0a: 0x599e,
-	0xc0b: 0x599f,
-	0xc0c: 0x59aa,
-	0xc0d: 0x59ab,
-	0xc0e: 0x59ac,
-	0xc0f: 0x59ad,
-	0xc10: 0x59ae,
-	0xc11: 0x59af,
-	0xc12: 0x59bb,
-	0xc13: 0x59bc,
-	0xc14: 0x59bd,
-	0xc15: 0x59be,
-	0xc16: 0x59bf,
-	0xc17: 0x59cc,
-	0xc18: 0x59cd,
-	0xc19: 0x59ce,
-	0xc1a: 0x59cf,
-	0xc1b: 0x59dd,
-	0xc1c: 0x59de,
-	0xc1d: 0x59df,
-	0xc1e: 0x59ee,
-	0xc1f: 0x59ef,
-	0xc20: 0x59ff,
-	0xc21: 0x5aaa,
-	0xc22: 0x5aab,
-	0xc23: 0x5aac,
-	0xc24: 0x5aad,
-	0xc25: 0x5aae,
-	0xc26: 0x5aaf,
-	0xc27: 0x5abb,
-	0xc28: 0x5abc,
-	0xc29: 0x5abd,
-	0xc2a: 0x5abe,
-	0xc2b: 0x5abf,
-	0xc2c: 0x5acc,
-	0xc2d: 0x5acd,
-	0xc2e: 0x5ace,
-	0xc2f: 0x5acf,
-	0xc30: 0x5add,
-	0xc31: 0x5ade,
-	0xc32: 0x5adf,
-	0xc33: 0x5aee,
-	0xc34: 0x5aef,
-	0xc35: 0x5aff,
-	0xc36: 0x5bbb,
-	0xc37: 0x5bbc,
-	0xc38: 0x5bbd,
-	0xc39: 0x5bbe,
-	0xc3a: 0x5bbf,
-	0xc3b: 0x5bcc,
-	0xc3c: 0x5bcd,
-	0xc3d: 0x5bce,
-	0xc3e: 0x5bcf,
-	0xc3f: 0x5bdd,
-	0xc40: 0x5bde,
-	0xc41: 0x5bdf,
-	0xc42: 0x5bee,
-	0xc43: 0x5bef,
-	0xc44: 0x5bff,
-	0xc45: 0x5ccc,
-	0xc46: 0x5ccd,
-	0xc47: 0x5cce,
-	0xc48: 0x5ccf,
-	0xc49: 0x5cdd,
-	0xc4a: 0x5cde,
-	0xc4b: 0x5cdf,
-	0xc4c: 0x5cee,
-	0xc4d: 0x5cef,
-	0xc4e: 0x5cff,
-	0xc4f: 0x5ddd,
-	0xc50: 0x5dde,
-	0xc51: 0x5ddf,
-	0xc52: 0x5dee,
-	0xc53: 0x5def,
-	0xc54: 0x5dff,
-	0xc55: 0x5eee,
-	0xc56: 0x5eef,
-	0xc57: 0x5eff,
-	0xc58: 0x5fff,
-	0xc59: 0x6666,
-	0xc5a: 0x6667,
-	0xc5b: 0x6668,
-	0xc5c: 0x6669,
-	0xc5d: 0x666a,
-	0xc5e: 0x666b,
-	0xc5f: 0x666c,
-	0xc60: 0x666d,
-	0xc61: 0x666e,
-	0xc62: 0x666f,
-	0xc63: 0x6677,
-	0xc64: 0x6678,
-	0xc65: 0x6679,
-	0xc66: 0x667a,
-	0xc67: 0x667b,
-	0xc68: 0x667c,
-	0xc69: 0x667d,
-	0xc6a: 0x667e,
-	0xc6b: 0x667f,
-	0xc6c: 0x6688,
-	0xc6d: 0x6689,
-	0xc6e: 0x668a,
-	0xc6f: 0x668b,
-	0xc70: 0x668c,
-	0xc71: 0x668d,
-	0xc72: 0x668e,
-	0xc73: 0x668f,
-	0xc74: 0x6699,
-	0xc75: 0x669a,
-	0xc76: 0x669b,
-	0xc77: 0x669c,
-	0xc78: 0x669d,
-	0xc79: 0x669e,
-	0xc7a: 0x669f,
-	0xc7b: 0x66aa,
-	0xc7c: 0x66ab,
-	0xc7d: 0x66ac,
-	0xc7e: 0x66ad,
-	0xc7f: 0x66ae,
-	0xc80: 0x66af,
-	0xc81: 0x66bb,
-	0xc82: 0x66bc,
-	0xc83: 0x66bd,
-	0xc84: 0x66be,
-	0xc85: 0x66bf,
-	0xc86: 0x66cc,
-	0xc87: 0x66cd,
-	0xc88: 0x66ce,
-	0xc89: 0x66cf,
-	0xc8a: 0x66dd,
-	0xc8b: 0x66de,
-	0xc8c: 0x66df,
-	0xc8d: 0x66ee,
-	0xc8e: 0x66ef,
-	0xc8f: 0x66ff,
-	0xc90: 0x6777,
-	0xc91: 0x6778,
-	0xc92: 0x6779,
-	0xc93: 0x677a,
-	0xc94: 0x677b,
-	0xc95: 0x677c,
-	0xc96: 0x677d,
-	0xc97: 0x677e,
-	0xc98: 0x677f,
-	0xc99: 0x6788,
-	0xc9a: 0x6789,
-	0xc9b: 0x678a,
-	0xc9c: 0x678b,
-	0xc9d: 0x678c,
-	0xc9e: 0x678d,
-	0xc9f: 0x678e,
-	0xca0: 0x678f,
-	0xca1: 0x6799,
-	0xca2: 0x679a,
-	0xca3: 0x679b,
-	0xca4: 0x679c,
-	0xca5: 0x679d,
-	0xca6: 0x679e,
-	0xca7: 0x679f,
-	0xca8: 0x67aa,
-	0xca9: 0x67ab,
-	0xcaa: 0x67ac,
-	0xcab: 0x67ad,
-	0xcac: 0x67ae,
-	0xcad: 0x67af,
-	0xcae: 0x67bb,
-	0xcaf: 0x67bc,
-	0xcb0: 0x67bd,
-	0xcb1: 0x67be,
-	0xcb2: 0x67bf,
-	0xcb3: 0x67cc,
-	0xcb4: 0x67cd,
-	0xcb5: 0x67ce,
-	0xcb6: 0x67cf,
-	0xcb7: 0x67dd,
-	0xcb8: 0x67de,
-	0xcb9: 0x67df,
-	0xcba: 0x67ee,
-	0xcbb: 0x67ef,
-	0xcbc: 0x67ff,
-	0xcbd: 0x6888,
-	0xcbe: 0x6889,
-	0xcbf: 0x688a,
-	0xcc0: 0x688b,
-	0xcc1: 0x688c,
-	0xcc2: 0x688d,
-	0xcc3: 0x688e,
-	0xcc4: 0x688f,
-	0xcc5: 0x6899,
-	0xcc6: 0x689a,
-	0xcc7: 0x689b,
-	0xcc8: 0x689c,
-	0xcc9: 0x689d,
-	0xcca: 0x689e,
-	0xccb: 0x689f,
-	0xccc: 0x68aa,
-	0xccd: 0x68ab,
-	0xcce: 0x68ac,
-	0xccf: 0x68ad,
-	0xcd0: 0x68ae,
-	0xcd1: 0x68af,
-	0xcd2: 0x68bb,
-	0xcd3: 0x68bc,
-	0xcd4: 0x68bd,
-	0xcd5: 0x68be,
-	0xcd6: 0x68bf,
-	0xcd7: 0x68cc,
-	0xcd8: 0x68cd,
-	0xcd9: 0x68ce,
-	0xcda: 0x68cf,
-	0xcdb: 0x68dd,
-	0xcdc: 0x68de,
-	0xcdd: 0x68df,
-	0xcde: 0x68ee,
-	0xcdf: 0x68ef,
-	0xce0: 0x68ff,
-	0xce1: 0x6999,
-	0xce2: 0x699a,
-	0xce3: 0x699b,
-	0xce4: 0x699c,
-	0xce5: 0x699d,
-	0xce6: 0x699e,
-	0xce7: 0x699f,
-	0xce8: 0x69aa,
-	0xce9: 0x69ab,
-	0xcea: 0x69ac,
-	0xceb: 0x69ad,
-	0xcec: 0x69ae,
-	0xced: 0x69af,
-	0xcee: 0x69bb,
-	0xcef: 0x69bc,
-	0xcf0: 0x69bd,
-	0xcf1: 0x69be,
-	0xcf2: 0x69bf,
-	0xcf3: 0x69cc,
-	0xcf4: 0x69cd,
-	0xcf5: 0x69ce,
-	0xcf6: 0x69cf,
-	0xcf7: 0x69dd,
-	0xcf8: 0x69de,
-	0xcf9: 0x69df,
-	0xcfa: 0x69ee,
-	0xcfb: 0x69ef,
-	0xcfc: 0x69ff,
-	0xcfd: 0x6aaa,
-	0xcfe: 0x6aab,
-	0xcff: 0x6aac,
-	0xd00: 0x6aad,
-	0xd01: 0x6aae,
-	0xd02: 0x6aaf,
-	0xd03: 0x6abb,
-	0xd04: 0x6abc,
-	0xd05: 0x6abd,
-	0xd06: 0x6abe,
-	0xd07: 0x6abf,
-	0xd08: 0x6acc,
-	0xd09: 0x6acd,
-	0xd0a: 0x6ace,
-	0xd0b: 0x6acf,
-	0xd0c: 0x6add,
-	0xd0d: 0x6ade,
-	0xd0e: 0x6adf,
-	0xd0f: 0x6aee,
-	0xd10: 0x6aef,
-	0xd11: 0x6aff,
-	0xd12: 0x6bbb,
-	0xd13: 0x6bbc,
-	0xd14: 0x6bbd,
-	0xd15: 0x6bbe,
-	0xd16: 0x6bbf,
-	0xd17: 0x6bcc,
-	0xd18: 0x6bcd,
-	0xd19: 0x6bce,
-	0xd1a: 0x6bcf,
-	0xd1b: 0x6bdd,
-	0xd1c: 0x6bde,
-	0xd1d: 0x6bdf,
-	0xd1e: 0x6bee,
-	0xd1f: 0x6bef,
-	0xd20: 0x6bff,
-	0xd21: 0x6ccc,
-	0xd22: 0x6ccd,
-	0xd23: 0x6cce,
-	0xd24: 0x6ccf,
-	0xd25: 0x6cdd,
-	0xd26: 0x6cde,
-	0xd27: 0x6cdf,
-	0xd28: 0x6cee,
-	0xd29: 0x6cef,
-	0xd2a: 0x6cff,
-	0xd2b: 0x6ddd,
-	0xd2c: 0x6dde,
-	0xd2d: 0x6ddf,
-	0xd2e: 0x6dee,
-	0xd2f: 0x6def,
-	0xd30: 0x6dff,
-	0xd31: 0x6eee,
-	0xd32: 0x6eef,
-	0xd33: 0x6eff,
-	0xd34: 0x6fff,
-	0xd35: 0x7777,
-	0xd36: 0x7778,
-	0xd37: 0x7779,
-	0xd38: 0x777a,
-	0xd39: 0x777b,
-	0xd3a: 0x777c,
-	0xd3b: 0x777d,
-	0xd3c: 0x777e,
-	0xd3d: 0x777f,
-	0xd3e: 0x7788,
-	0xd3f: 0x7789,
-	0xd40: 0x778a,
-	0xd41: 0x778b,
-	0xd42: 0x778c,
-	0xd43: 0x778d,
-	0xd44: 0x778e,
-	0xd45: 0x778f,
-	0xd46: 0x7799,
-	0xd47: 0x779a,
-	0xd48: 0x779b,
-	0xd49: 0x779c,
-	0xd4a: 0x779d,
-	0xd4b: 0x779e,
-	0xd4c: 0x779f,
-	0xd4d: 0x77aa,
-	0xd4e: 0x77ab,
-	0xd4f: 0x77ac,
-	0xd50: 0x77ad,
-	0xd51: 0x77ae,
-	0xd52: 0x77af,
-	0xd53: 0x77bb,
-	0xd54: 0x77bc,
-	0xd55: 0x77bd,
-	0xd56: 0x77be,
-	0xd57: 0x77bf,
-	0xd58: 0x77cc,
-	0xd59: 0x77cd,
-	0xd5a: 0x77ce,
-	0xd5b: 0x77cf,
-	0xd5c: 0x77dd,
-	0xd5d: 0x77de,
-	0xd5e: 0x77df,
-	0xd5f: 0x77ee,
-	0xd60: 0x77ef,
-	0xd61: 0x77ff,
-	0xd62: 0x7888,
-	0xd63: 0x7889,
-	0xd64: 0x788a,
-	0xd65: 0x788b,
-	0xd66: 0x788c,
-	0xd67: 0x788d,
-	0xd68: 0x788e,
-	0xd69: 0x788f,
-	0xd6a: 0x7899,
-	0xd6b: 0x789a,
-	0xd6c: 0x789b,
-	0xd6d: 0x789c,
-	0xd6e: 0x789d,
-	0xd6f: 0x789e,
-	0xd70: 0x789f,
-	0xd71: 0x78aa,
-	0xd72: 0x78ab,
-	0xd73: 0x78ac,
-	0xd74: 0x78ad,
-	0xd75: 0x78ae,
-	0xd76: 0x78af,
-	0xd77: 0x78bb,
-	0xd78: 0x78bc,
-	0xd79: 0x78bd,
-	0xd7a: 0x78be,
-	0xd7b: 0x78bf,
-	0xd7c: 0x78cc,
-	0xd7d: 0x78cd,
-	0xd7e: 0x78ce,
-	0xd7f: 0x78cf,
-	0xd80: 0x78dd,
-	0xd81: 0x78de,
-	0xd82: 0x78df,
-	0xd83: 0x78ee,
-	0xd84: 0x78ef,
-	0xd85: 0x78ff,
-	0xd86: 0x7999,
-	0xd87: 0x799a,
-	0xd88: 0x799b,
-	0xd89: 0x799c,
-	0xd8a: 0x799d,
-	0xd8b: 0x799e,
-	0xd8c: 0x799f,
-	0xd8d: 0x79aa,
-	0xd8e: 0x79ab,
-	0xd8f: 0x79ac,
-	0xd90: 0x79ad,
-	0xd91: 0x79ae,
-	0xd92: 0x79af,
-	0xd93: 0x79bb,
-	0xd94: 0x79bc,
-	0xd95: 0x79bd,
-	0xd96: 0x79be,
-	0xd97: 0x79bf,
-	0xd98: 0x79cc,
-	0xd99: 0x79cd,
-	0xd9a: 0x79ce,
-	0xd9b: 0x79cf,
-	0xd9c: 0x79dd,
-	0xd9d: 0x79de,
-	0xd9e: 0x79df,
-	0xd9f: 0x79ee,
-	0xda0: 0x79ef,
-	0xda1: 0x79ff,
-	0xda2: 0x7aaa,
-	0xda3: 0x7aab,
-	0xda4: 0x7aac,
-	0xda5: 0x7aad,
-	0xda6: 0x7aae,
-	0xda7: 0x7aaf,
-	0xda8: 0x7abb,
-	0xda9: 0x7abc,
-	0xdaa: 0x7abd,
-	0xdab: 0x7abe,
-	0xdac: 0x7abf,
-	0xdad: 0x7acc,
-	0xdae: 0x7acd,
-	0xdaf: 0x7ace,
-	0xdb0: 0x7acf,
-	0xdb1: 0x7add,
-	0xdb2: 0x7ade,
-	0xdb3: 0x7adf,
-	0xdb4: 0x7aee,
-	0xdb5: 0x7aef,
-	0xdb6: 0x7aff,
-	0xdb7: 0x7bbb,
-	0xdb8: 0x7bbc,
-	0xdb9: 0x7bbd,
-	0xdba: 0x7bbe,
-	0xdbb: 0x7bbf,
-	0xdbc: 0x7bcc,
-	0xdbd: 0x7bcd,
-	0xdbe: 0x7bce,
-	0xdbf: 0x7bcf,
-	0xdc0: 0x7bdd,
-	0xdc1: 0x7bde,
-	0xdc2: 0x7bdf,
-	0xdc3: 0x7bee,
-	0xdc4: 0x7bef,
-	0xdc5: 0x7bff,
-	0xdc6: 0x7ccc,
-	0xdc7: 0x7ccd,
-	0xdc8: 0x7cce,
-	0xdc9: 0x7ccf,
-	0xdca: 0x7cdd,
-	0xdcb: 0x7cde,
-	0xdcc: 0x7cdf,
-	0xdcd: 0x7cee,
-	0xdce: 0x7cef,
-	0xdcf: 0x7cff,
-	0xdd0: 0x7ddd,
-	0xdd1: 0x7dde,
-	0xdd2: 0x7ddf,
-	0xdd3: 0x7dee,
-	0xdd4: 0x7def,
-	0xdd5: 0x7dff,
-	0xdd6: 0x7eee,
-	0xdd7: 0x7eef,
-	0xdd8: 0x7eff,
-	0xdd9: 0x7fff,
-	0xdda: 0x8888,
-	0xddb: 0x8889,
-	0xddc: 0x888a,
-	0xddd: 0x888b,
-	0xdde: 0x888c,
-	0xddf: 0x888d,
-	0xde0: 0x888e,
-	0xde1: 0x888f,
-	0xde2: 0x8899,
-	0xde3: 0x889a,
-	0xde4: 0x889b,
-	0xde5: 0x889c,
-	0xde6: 0x889d,
-	0xde7: 0x889e,
-	0xde8: 0x889f,
-	0xde9: 0x88aa,
-	0xdea: 0x88ab,
-	0xdeb: 0x88ac,
-	0xdec: 0x88ad,
-	0xded: 0x88ae,
-	0xdee: 0x88af,
-	0xdef: 0x88bb,
-	0xdf0: 0x88bc,
-	0xdf1: 0x88bd,
-	0xdf2: 0x88be,
-	0xdf3: 0x88bf,
-	0xdf4: 0x88cc,
-	0xdf5: 0x88cd,
-	0xdf6: 0x88ce,
-	0xdf7: 0x88cf,
-	0xdf8: 0x88dd,
-	0xdf9: 0x88de,
-	0xdfa: 0x88df,
-	0xdfb: 0x88ee,
-	0xdfc: 0x88ef,
-	0xdfd: 0x88ff,
-	0xdfe: 0x8999,
-	0xdff: 0x899a,
-	0xe00: 0x899b,
-	0xe01: 0x899c,
-	0xe02: 0x899d,
-	0xe03: 0x899e,
-	0xe04: 0x899f,
-	0xe05: 0x89aa,
-	0xe06: 0x89ab,
-	0xe07: 0x89ac,
-	0xe08: 0x89ad,
-	0xe09: 0x89ae,
-	0xe0a: 0x89af,
-	0xe0b: 0x89bb,
-	0xe0c: 0x89bc,
-	0xe0d: 0x89bd,
-	0xe0e: 0x89be,
-	0xe0f: 0x89bf,
-	0xe10: 0x89cc,
-	0xe11: 0x89cd,
-	0xe12: 0x89ce,
-	0xe13: 0x89cf,
-	0xe14: 0x89dd,
-	0xe15: 0x89de,
-	0xe16: 0x89df,
-	0xe17: 0x89ee,
-	0xe18: 0x89ef,
-	0xe19: 0x89ff,
-	0xe1a: 0x8aaa,
-	0xe1b: 0x8aab,
-	0xe1c: 0x8aac,
-	0xe1d: 0x8aad,
-	0xe1e: 0x8aae,
-	0xe1f: 0x8aaf,
-	0xe20: 0x8abb,
-	0xe21: 0x8abc,
-	0xe22: 0x8abd,
-	0xe23: 0x8abe,
-	0xe24: 0x8abf,
-	0xe25: 0x8acc,
-	0xe26: 0x8acd,
-	0xe27: 0x8ace,
-	0xe28: 0x8acf,
-	0xe29: 0x8add,
-	0xe2a: 0x8ade,
-	0xe2b: 0x8adf,
-	0xe2c: 0x8aee,
-	0xe2d: 0x8aef,
-	0xe2e: 0x8aff,
-	0xe2f: 0x8bbb,
-	0xe30: 0x8bbc,
-	0xe31: 0x8bbd,
-	0xe32: 0x8bbe,
-	0xe33: 0x8bbf,
-	0xe34: 0x8bcc,
-	0xe35: 0x8bcd,
-	0xe36: 0x8bce,
-	0xe37: 0x8bcf,
-	0xe38: 0x8bdd,
-	0xe39: 0x8bde,
-	0xe3a: 0x8bdf,
-	0xe3b: 0x8bee,
-	0xe3c: 0x8bef,
-	0xe3d: 0x8bff,
-	0xe3e: 0x8ccc,
-	0xe3f: 0x8ccd,
-	0xe40: 0x8cce,
-	0xe41: 0x8ccf,
-	0xe42: 0x8cdd,
-	0xe43: 0x8cde,
-	0xe44: 0x8cdf,
-	0xe45: 0x8cee,
-	0xe46: 0x8cef,
-	0xe47: 0x8cff,
-	0xe48: 0x8ddd,
-	0xe49: 0x8dde,
-	0xe4a: 0x8ddf,
-	0xe4b: 0x8dee,
-	0xe4c: 0x8def,
-	0xe4d: 0x8dff,
-	0xe4e: 0x8eee,
-	0xe4f: 0x8eef,
-	0xe50: 0x8eff,
-	0xe51: 0x8fff,
-	0xe52: 0x9999,
-	0xe53: 0x999a,
-	0xe54: 0x999b,
-	0xe55: 0x999c,
-	0xe56: 0x999d,
-	0xe57: 0x999e,
-	0xe58: 0x999f,
-	0xe59: 0x99aa,
-	0xe5a: 0x99ab,
-	0xe5b: 0x99ac,
-	0xe5c: 0x99ad,
-	0xe5d: 0x99ae,
-	0xe5e: 0x99af,
-	0xe5f: 0x99bb,
-	0xe60: 0x99bc,
-	0xe61: 0x99bd,
-	0xe62: 0x99be,
-	0xe63: 0x99bf,
-	0xe64: 0x99cc,
-	0xe65: 0x99cd,
-	0xe66: 0x99ce,
-	0xe67: 0x99cf,
-	0xe68: 0x99dd,
-	0xe69: 0x99de,
-	0xe6a: 0x99df,
-	0xe6b: 0x99ee,
-	0xe6c: 0x99ef,
-	0xe6d: 0x99ff,
-	0xe6e: 0x9aaa,
-	0xe6f: 0x9aab,
-	0xe70: 0x9aac,
-	0xe71: 0x9aad,
-	0xe72: 0x9aae,
-	0xe73: 0x9aaf,
-	0xe74: 0x9abb,
-	0xe75: 0x9abc,
-	0xe76: 0x9abd,
-	0xe77: 0x9abe,
-	0xe78: 0x9abf,
-	0xe79: 0x9acc,
-	0xe7a: 0x9acd,
-	0xe7b: 0x9ace,
-	0xe7c: 0x9acf,
-	0xe7d: 0x9add,
-	0xe7e: 0x9ade,
-	0xe7f: 0x9adf,
-	0xe80: 0x9aee,
-	0xe81: 0x9aef,
-	0xe82: 0x9aff,
-	0xe83: 0x9bbb,
-	0xe84: 0x9bbc,
-	0xe85: 0x9bbd,
-	0xe86: 0x9bbe,
-	0xe87: 0x9bbf,
-	0xe88: 0x9bcc,
-	0xe89: 0x9bcd,
-	0xe8a: 0x9bce,
-	0xe8b: 0x9bcf,
-	0xe8c: 0x9bdd,
-	0xe8d: 0x9bde,
-	0xe8e: 0x9bdf,
-	0xe8f: 0x9bee,
-	0xe90: 0x9bef,
-	0xe91: 0x9bff,
-	0xe92: 0x9ccc,
-	0xe93: 0x9ccd,
-	0xe94: 0x9cce,
-	0xe95: 0x9ccf,
-	0xe96: 0x9cdd,
-	0xe97: 0x9cde,
-	0xe98: 0x9cdf,
-	0xe99: 0x9cee,
-	0xe9a: 0x9cef,
-	0xe9b: 0x9cff,
-	0xe9c: 0x9ddd,
-	0xe9d: 0x9dde,
-	0xe9e: 0x9ddf,
-	0xe9f: 0x9dee,
-	0xea0: 0x9def,
-	0xea1: 0x9dff,
-	0xea2: 0x9eee,
-	0xea3: 0x9eef,
-	0xea4: 0x9eff,
-	0xea5: 0x9fff,
-	0xea6: 0xaaaa,
-	0xea7: 0xaaab,
-	0xea8: 0xaaac,
-	0xea9: 0xaaad,
-	0xeaa: 0xaaae,
-	0xeab: 0xaaaf,
-	0xeac: 0xaabb,
-	0xead: 0xaabc,
-	0xeae: 0xaabd,
-	0xeaf: 0xaabe,
-	0xeb0: 0xaabf,
-	0xeb1: 0xaacc,
-	0xeb2: 0xaacd,
-	0xeb3: 0xaace,
-	0xeb4: 0xaacf,
-	0xeb5: 0xaadd,
-	0xeb6: 0xaade,
-	0xeb7: 0xaadf,
-	0xeb8: 0xaaee,
-	0xeb9: 0xaaef,
-	0xeba: 0xaaff,
-	0xebb: 0xabbb,
-	0xebc: 0xabbc,
-	0xebd: 0xabbd,
-	0xebe: 0xabbe,
-	0xebf: 0xabbf,
-	0xec0: 0xabcc,
-	0xec1: 0xabcd,
-	0xec2: 0xabce,
-	0xec3: 0xabcf,
-	0xec4: 0xabdd,
-	0xec5: 0xabde,
-	0xec6: 0xabdf,
-	0xec7: 0xabee,
-	0xec8: 0xabef,
-	0xec9: 0xabff,
-	0xeca: 0xaccc,
-	0xecb: 0xaccd,
-	0xecc: 0xacce,
-	0xecd: 0xaccf,
-	0xece: 0xacdd,
-	0xecf: 0xacde,
-	0xed0: 0xacdf,
-	0xed1: 0xacee,
-	0xed2: 0xacef,
-	0xed3: 0xacff,
-	0xed4: 0xaddd,
-	0xed5: 0xadde,
-	0xed6: 0xaddf,
-	0xed7: 0xadee,
-	0xed8: 0xadef,
-	0xed9: 0xadff,
-	0xeda: 0xaeee,
-	0xedb: 0xaeef,
-	0xedc: 0xaeff,
-	0xedd: 0xafff,
-	0xede: 0xbbbb,
-	0xedf: 0xbbbc,
-	0xee0: 0xbbbd,
-	0xee1: 0xbbbe,
-	0xee2: 0xbbbf,
-	0xee3: 0xbbcc,
-	0xee4: 0xbbcd,
-	0xee5: 0xbbce,
-	0xee6: 0xbbcf,
-	0xee7: 0xbbdd,
-	0xee8: 0xbbde,
-	0xee9: 0xbbdf,
-	0xeea: 0xbbee,
-	0xeeb: 0xbbef,
-	0xeec: 0xbbff,
-	0xeed: 0xbccc,
-	0xeee: 0xbccd,
-	0xeef: 0xbcce,
-	0xef0: 0xbccf,
-	0xef1: 0xbcdd,
-	0xef2: 0xbcde,
-	0xef3: 0xbcdf,
-	0xef4: 0xbcee,
-	0xef5: 0xbcef,
-	0xef6: 0xbcff,
-	0xef7: 0xbddd,
-	0xef8: 0xbdde,
-	0xef9: 0xbddf,
-	0xefa: 0xbdee,
-	0xefb: 0xbdef,
-	0xefc: 0xbdff,
-	0xefd: 0xbeee,
-	0xefe: 0xbeef,
-	0xeff: 0xbeff,
-	0xf00: 0xbfff,
-	0xf01: 0xcccc,
-	0xf02: 0xcccd,
-	0xf03: 0xccce,
-	0xf04: 0xcccf,
-	0xf05: 0xccdd,
-	0xf06: 0xccde,
-	0xf07: 0xccdf,
-	0xf08: 0xccee,
-	0xf09: 0xccef,
-	0xf0a: 0xccff,
-	0xf0b: 0xcddd,
-	0xf0c: 0xcdde,
-	0xf0d: 0xcddf,
-	0xf0e: 0xcdee,
-	0xf0f: 0xcdef,
-	0xf10: 0xcdff,
-	0xf11: 0xceee,
-	0xf12: 0xceef,
-	0xf13: 0xceff,
-	0xf14: 0xcfff,
-	0xf15: 0xdddd,
-	0xf16: 0xddde,
-	0xf17: 0xdddf,
-	0xf18: 0xddee,
-	0xf19: 0xddef,
-	0xf1a: 0xddff,
-	0xf1b: 0xdeee,
-	0xf1c: 0xdeef,
-	0xf1d: 0xdeff,
-	0xf1e: 0xdfff,
-	0xf1f: 0xeeee,
-	0xf20: 0xeeef,
-	0xf21: 0xeeff,
-	0xf22: 0xefff,
-	0xf23: 0xffff,
-}
-
-var lookupFingerprintsToBits = [...]uint16{
-	0x0000: 0x000,
-	0x0001: 0x001,
-	0x0002: 0x002,
-	0x0003: 0x003,
-	0x0004: 0x004,
-	0x0005: 0x005,
-	0x0006: 0x006,
-	0x0007: 0x007,
-	0x0008: 0x008,
-	0x0009: 0x009,
-	0x000a: 0x00a,
-	0x000b: 0x00b,
-	0x000c: 0x00c,
-	0x000d: 0x00d,
-	0x000e: 0x00e,
-	0x000f: 0x00f,
-	0x0011: 0x010,
-	0x0012: 0x011,
-	0x0013: 0x012,
-	0x0014: 0x013,
-	0x0015: 0x014,
-	0x0016: 0x015,
-	0x0017: 0x016,
-	0x0018: 0x017,
-	0x0019: 0x018,
-	0x001a: 0x019,
-	0x001b: 0x01a,
-	0x001c: 0x01b,
-	0x001d: 0x01c,
-	0x001e: 0x01d,
-	0x001f: 0x01e,
-	0x0022: 0x01f,
-	0x0023: 0x020,
-	0x0024: 0x021,
-	0x0025: 0x022,
-	0x0026: 0x023,
-	0x0027: 0x024,
-	0x0028: 0x025,
-	0x0029: 0x026,
-	0x002a: 0x027,
-	0x002b: 0x028,
-	0x002c: 0x029,
-	0x002d: 0x02a,
-	0x002e: 0x02b,
-	0x002f: 0x02c,
-	0x0033: 0x02d,
-	0x0034: 0x02e,
-	0x0035: 0x02f,
-	0x0036: 0x030,
-	0x0037: 0x031,
-	0x0038: 0x032,
-	0x0039: 0x033,
-	0x003a: 0x034,
-	0x003b: 0x035,
-	0x003c: 0x036,
-	0x003d: 0x037,
-	0x003e: 0x038,
-	0x003f: 0x039,
-	0x0044: 0x03a,
-	0x0045: 0x03b,
-	0x0046: 0x03c,
-	0x0047: 0x03d,
-	0x0048: 0x03e,
-	0x0049: 0x03f,
-	0x004a: 0x040,
-	0x004b: 0x041,
-	0x004c: 0x042,
-	0x004d: 0x043,
-	0x004e: 0x044,
-	0x004f: 0x045,
-	0x0055: 0x046,
-	0x0056: 0x047,
-	0x0057: 0x048,
-	0x0058: 0x049,
-	0x0059: 0x04a,
-	0x005a: 0x04b,
-	0x005b: 0x04c,
-	0x005c: 0x04d,
-	0x005d: 0x04e,
-	0x005e: 0x04f,
-	0x005f: 0x050,
-	0x0066: 0x051,
-	0x0067: 0x052,
-	0x0068: 0x053,
-	0x0069: 0x054,
-	0x006a: 0x055,
-	0x006b: 0x056,
-	0x006c: 0x057,
-	0x006d: 0x058,
-	0x006e: 0x059,
-	0x006f: 0x05a,
-	0x0077: 0x05b,
-	0x0078: 0x05c,
-	0x0079: 0x05d,
-	0x007a: 0x05e,
-	0x007b: 0x05f,
-	0x007c: 0x060,
-	0x007d: 0x061,
-	0x007e: 0x062,
-	0x007f: 0x063,
-	0x0088: 0x064,
-	0x0089: 0x065,
-	0x008a: 0x066,
-	0x008b: 0x067,
-	0x008c: 0x068,
-	0x008d: 0x069,
-	0x008e: 0x06a,
-	0x008f: 0x06b,
-	0x0099: 0x06c,
-	0x009a: 0x06d,
-	0x009b: 0x06e,
-	0x009c: 0x06f,
-	0x009d: 0x070,
-	0x009e: 0x071,
-	0x009f: 0x072,
-	0x00aa: 0x073,
-	0x00ab: 0x074,
-	0x00ac: 0x075,
-	0x00ad: 0x076,
-	0x00ae: 0x077,
-	0x00af: 0x078,
-	0x00bb: 0x079,
-	0x00bc: 0x07a,
-	0x00bd: 0x07b,
-	0x00be: 0x07c,
-	0x00bf: 0x07d,
-	0x00cc: 0x07e,
-	0x00cd: 0x07f,
-	0x00ce: 0x080,
-	0x00cf: 0x081,
-	0x00dd: 0x082,
-	0x00de: 0x083,
-	0x00df: 0x084,
-	0x00ee: 0x085,
-	0x00ef: 0x086,
-	0x00ff: 0x087,
-	0x0111: 0x088,
-	0x0112: 0x089,
-	0x0113: 0x08a,
-	0x0114: 0x08b,
-	0x0115: 0x08c,
-	0x0116: 0x08d,
-	0x0117: 0x08e,
-	0x0118: 0x08f,
-	0x0119: 0x090,
-	0x011a: 0x091,
-	0x011b: 0x092,
-	0x011c: 0x093,
-	0x011d: 0x094,
-	0x011e: 0x095,
-	0x011f: 0x096,
-	0x0122: 0x097,
-	0x0123: 0x098,
-	0x0124: 0x099,
-	0x0125: 0x09a,
-	0x0126: 0x09b,
-	0x0127: 0x09c,
-	0x0128: 0x09d,
-	0x0129: 0x09e,
-	0x012a: 0x09f,
-	0x012b: 0x0a0,
-	0x012c: 0x0a1,
-	0x012d: 0x0a2,
-	0x012e: 0x0a3,
-	0x012f: 0x0a4,
-	0x0133: 0x0a5,
-	0x0134: 0x0a6,
-	0x0135: 0x0a7,
-	0x0136: 0x0a8,
-	0x0137: 0x0a9,
-	0x0138: 0x0aa,
-	0x0139: 0x0ab,
-	0x013a: 0x0ac,
-	0x013b: 0x0ad,
-	0x013c: 0x0ae,
-	0x013d: 0x0af,
-	0x013e: 0x0b0,
-	0x013f: 0x0b1,
-	0x0144: 0x0b2,
-	0x0145: 0x0b3,
-	0x0146: 0x0b4,
-	0x0147: 0x0b5,
-	0x0148: 0x0b6,
-	0x0149: 0x0b7,
-	0x014a: 0x0b8,
-	0x014b: 0x0b9,
-	0x014c: 0x0ba,
-	0x014d: 0x0bb,
-	0x014e: 0x0bc,
-	0x014f: 0x0bd,
-	0x0155: 0x0be,
-	0x0156: 0x0bf,
-	0x0157: 0x0c0,
-	0x0158: 0x0c1,
-	0x0159: 0x0c2,
-	0x015a: 0x0c3,
-	0x015b: 0x0c4,
-	0x015c: 0x0c5,
-	0x015d: 0x0c6,
-	0x015e: 0x0c7,
-	0x015f: 0x0c8,
-	0x0166: 0x0c9,
-	0x0167: 0x0ca,
-	0x0168: 0x0cb,
-	0x0169: 0x0cc,
-	0x016a: 0x0cd,
-	0x016b: 0x0ce,
-	0x016c: 0x0cf,
-	0x016d: 0x0d0,
-	0x016e: 0x0d1,
-	0x016f: 0x0d2,
-	0x0177: 0x0d3,
-	0x0178: 0x0d4,
-	0x0179: 0x0d5,
-	0x017a: 0x0d6,
-	0x017b: 0x0d7,
-	0x017c: 0x0d8,
-	0x017d: 0x0d9,
-	0x017e: 0x0da,
-	0x017f: 0x0db,
-	0x0188: 0x0dc,
-	0x0189: 0x0dd,
-	0x018a: 0x0de,
-	0x018b: 0x0df,
-	0x018c: 0x0e0,
-	0x018d: 0x0e1,
-	0x018e: 0x0e2,
-	0x018f: 0x0e3,
-	0x0199: 0x0e4,
-	0x019a: 0x0e5,
-	0x019b: 0x0e6,
-	0x019c: 0x0e7,
-	0x019d: 0x0e8,
-	0x019e: 0x0e9,
-	0x019f: 0x0ea,
-	0x01aa: 0x0eb,
-	0x01ab: 0x0ec,
-	0x01ac: 0x0ed,
-	0x01ad: 0x0ee,
-	0x01ae: 0x0ef,
-	0x01af: 0x0f0,
-	0x01bb: 0x0f1,
-	0x01bc: 0x0f2,
-	0x01bd: 0x0f3,
-	0x01be: 0x0f4,
-	0x01bf: 0x0f5,
-	0x01cc: 0x0f6,
-	0x01cd: 0x0f7,
-	0x01ce: 0x0f8,
-	0x01cf: 0x0f9,
-	0x01dd: 0x0fa,
-	0x01de: 0x0fb,
-	0x01df: 0x0fc,
-	0x01ee: 0x0fd,
-	0x01ef: 0x0fe,
-	0x01ff: 0x0ff,
-	0x0222: 0x100,
-	0x0223: 0x101,
-	0x0224: 0x102,
-	0x0225: 0x103,
-	0x0226: 0x104,
-	0x0227: 0x105,
-	0x0228: 0x106,
-	0x0229: 0x107,
-	0x022a: 0x108,
-	0x022b: 0x109,
-	0x022c: 0x10a,
-	0x022d: 0x10b,
-	0x022e: 0x10c,
-	0x022f: 0x10d,
-	0x0233: 0x10e,
-	0x0234: 0x10f,
-	0x0235: 0x110,
-	0x0236: 0x111,
-	0x0237: 0x112,
-	0x0238: 0x113,
-	0x0239: 0x114,
-	0x023a: 0x115,
-	0x023b: 0x116,
-	0x023c: 0x117,
-	0x023d: 0x118,
-	0x023e: 0x119,
-	0x023f: 0x11a,
-	0x0244: 0x11b,
-	0x0245: 0x11c,
-	0x0246: 0x11d,
-	0x0247: 0x11e,
-	0x0248: 0x11f,
-	0x0249: 0x120,
-	0x024a: 0x121,
-	0x024b: 0x122,
-	0x024c: 0x123,
-	0x024d: 0x124,
-	0x024e: 0x125,
-	0x024f: 0x126,
-	0x0255: 0x127,
-	0x0256: 0x128,
-	0x0257: 0x129,
-	0x0258: 0x12a,
-	0x0259: 0x12b,
-	0x025a: 0x12c,
-	0x025b: 0x12d,
-	0x025c: 0x12e,
-	0x025d: 0x12f,
-	0x025e: 0x130,
-	0x025f: 0x131,
-	0x0266: 0x132,
-	0x0267: 0x133,
-	0x0268: 0x134,
-	0x0269: 0x135,
-	0x026a: 0x136,
-	0x026b: 0x137,
-	0x026c: 0x138,
-	0x026d: 0x139,
-	0x026e: 0x13a,
-	0x026f: 0x13b,
-	0x0277: 0x13c,
-	0x0278: 0x13d,
-	0x0279: 0x13e,
-	0x027a: 0x13f,
-	0x027b: 0x140,
-	0x027c: 0x141,
-	0x027d: 0x142,
-	0x027e: 0x143,
-	0x027f: 0x144,
-	0x0288: 0x145,
-	0x0289: 0x146,
-	0x028a: 0x147,
-	0x028b: 0x148,
-	0x028c: 0x149,
-	0x028d: 0x14a,
-	0x028e: 0x14b,
-	0x028f: 0x14c,
-	0x0299: 0x14d,
-	0x029a: 0x14e,
-	0x029b: 0x14f,
-	0x029c: 0x150,
-	0x029d: 0x151,
-	0x029e: 0x152,
-	0x029f: 0x153,
-	0x02aa: 0x154,
-	0x02ab: 0x155,
-	0x02ac: 0x156,
-	0x02ad: 0x157,
-	0x02ae: 0x158,
-	0x02af: 0x159,
-	0x02bb: 0x15a,
-	0x02bc: 0x15b,
-	0x02bd: 0x15c,
-	0x02be: 0x15d,
-	0x02bf: 0x15e,
-	0x02cc: 0x15f,
-	0x02cd: 0x160,
-	0x02ce: 0x161,
-	0x02cf: 0x162,
-	0x02dd: 0x163,
-	0x02de: 0x164,
-	0x02df: 0x165,
-	0x02ee: 0x166,
-	0x02ef: 0x167,
-	0x02ff: 0x168,
-	0x0333: 0x169,
-	0x0334: 0x16a,
-	0x0335: 0x16b,
-	0x0336: 0x16c,
-	0x0337: 0x16d,
-	0x0338: 0x16e,
-	0x0339: 0x16f,
-	0x033a: 0x170,
-	0x033b: 0x171,
-	0x033c: 0x172,
-	0x033d: 0x173,
-	0x033e: 0x174,
-	0x033f: 0x175,
-	0x0344: 0x176,
-	0x0345: 0x177,
-	0x0346: 0x178,
-	0x0347: 0x179,
-	0x0348: 0x17a,
-	0x0349: 0x17b,
-	0x034a: 0x17c,
-	0x034b: 0x17d,
-	0x034c: 0x17e,
-	0x034d: 0x17f,
-	0x034e: 0x180,
-	0x034f: 0x181,
-	0x0355: 0x182,
-	0x0356: 0x183,
-	0x0357: 0x184,
-	0x0358: 0x185,
-	0x0359: 0x186,
-	0x035a: 0x187,
-	0x035b: 0x188,
-	0x035c: 0x189,
-	0x035d: 0x18a,
-	0x035e: 0x18b,
-	0x035f: 0x18c,
-	0x0366: 0x18d,
-	0x0367: 0x18e,
-	0x0368: 0x18f,
-	0x0369: 0x190,
-	0x036a: 0x191,
-	0x036b: 0x192,
-	0x036c: 0x193,
-	0x036d: 0x194,
-	0x036e: 0x195,
-	0x036f: 0x196,
-	0x0377: 0x197,
-	0x0378: 0x198,
-	0x0379: 0x199,
-	0x037a: 0x19a,
-	0x037b: 0x19b,
-	0x037c: 0x19c,
-	0x037d: 0x19d,
-	0x037e: 0x19e,
-	0x037f: 0x19f,
-	0x0388: 0x1a0,
-	0x0389: 0x1a1,
-	0x038a: 0x1a2,
-	0x038b: 0x1a3,
-	0x038c: 0x1a4,
-	0x038d: 0x1a5,
-	0x038e: 0x1a6,
-	0x038f: 0x1a7,
-	0x0399: 0x1a8,
-	0x039a: 0x1a9,
-	0x039b: 0x1aa,
-	0x039c: 0x1ab,
-	0x039d: 0x1ac,
-	0x039e: 0x1ad,
-	0x039f: 0x1ae,
-	0x03aa: 0x1af,
-	0x03ab: 0x1b0,
-	0x03ac: 0x1b1,
-	0x03ad: 0x1b2,
-	0x03ae: 0x1b3,
-	0x03af: 0x1b4,
-	0x03bb: 0x1b5,
-	0x03bc: 0x1b6,
-	0x03bd: 0x1b7,
-	0x03be: 0x1b8,
-	0x03bf: 0x1b9,
-	0x03cc: 0x1ba,
-	0x03cd: 0x1bb,
-	0x03ce: 0x1bc,
-	0x03cf: 0x1bd,
-	0x03dd: 0x1be,
-	0x03de: 0x1bf,
-	0x03df: 0x1c0,
-	0x03ee: 0x1c1,
-	0x03ef: 0x1c2,
-	0x03ff: 0x1c3,
-	0x0444: 0x1c4,
-	0x0445: 0x1c5,
-	0x0446: 0x1c6,
-	0x0447: 0x1c7,
-	0x0448: 0x1c8,
-	0x0449: 0x1c9,
-	0x044a: 0x1ca,
-	0x044b: 0x1cb,
-	0x044c: 0x1cc,
-	0x044d: 0x1cd,
-	0x044e: 0x1ce,
-	0x044f: 0x1cf,
-	0x0455: 0x1d0,
-	0x0456: 0x1d1,
-	0x0457: 0x1d2,
-	0x0458: 0x1d3,
-	0x0459: 0x1d4,
-	0x045a: 0x1d5,
-	0x045b: 0x1d6,
-	0x045c: 0x1d7,
-	0x045d: 0x1d8,
-	0x045e: 0x1d9,
-	0x045f: 0x1da,
-	0x0466: 0x1db,
-	0x0467: 0x1dc,
-	0x0468: 0x1dd,
-	0x0469: 0x1de,
-	0x046a: 0x1df,
-	0x046b: 0x1e0,
-	0x046c: 0x1e1,
-	0x046d: 0x1e2,
-	0x046e: 0x1e3,
-	0x046f: 0x1e4,
-	0x0477: 0x1e5,
-	0x0478: 0x1e6,
-	0x0479: 0x1e7,
-	0x047a: 0x1e8,
-	0x047b: 0x1e9,
-	0x047c: 0x1ea,
-	0x047d: 0x1eb,
-	0x047e: 0x1ec,
-	0x047f: 0x1ed,
-	0x0488: 0x1ee,
-	0x0489: 0x1ef,
-	0x048a: 0x1f0,
-	0x048b: 0x1f1,
-	0x048c: 0x1f2,
-	0x048d: 0x1f3,
-	0x048e: 0x1f4,
-	0x048f: 0x1f5,
-	0x0499: 0x1f6,
-	0x049a: 0x1f7,
-	0x049b: 0x1f8,
-	0x049c: 0x1f9,
-	0x049d: 0x1fa,
-	0x049e: 0x1fb,
-	0x049f: 0x1fc,
-	0x04aa: 0x1fd,
-	0x04ab: 0x1fe,
-	0x04ac: 0x1ff,
-	0x04ad: 0x200,
-	0x04ae: 0x201,
-	0x04af: 0x202,
-	0x04bb: 0x203,
-	0x04bc: 0x204,
-	0x04bd: 0x205,
-	0x04be: 0x206,
-	0x04bf: 0x207,
-	0x04cc: 0x208,
-	0x04cd: 0x209,
-	0x04ce: 0x20a,
-	0x04cf: 0x20b,
-	0x04dd: 0x20c,
-	0x04de: 0x20d,
-	0x04df: 0x20e,
-	0x04ee: 0x20f,
-	0x04ef: 0x210,
-	0x04ff: 0x211,
-	0x0555: 0x212,
-	0x0556: 0x213,
-	0x0557: 0x214,
-	0x0558: 0x215,
-	0x0559: 0x216,
-	0x055a: 0x217,
-	0x055b: 0x218,
-	0x055c: 0x219,
-	0x055d: 0x21a,
-	0x055e: 0x21b,
-	0x055f: 0x21c,
-	0x0566: 0x21d,
-	0x0567: 0x21e,
-	0x0568: 0x21f,
-	0x0569: 0x220,
-	0x056a: 0x221,
-	0x056b: 0x222,
-	0x056c: 0x223,
-	0x056d: 0x224,
-	0x056e: 0x225,
-	0x056f: 0x226,
-	0x0577: 0x227,
-	0x0578: 0x228,
-	0x0579: 0x229,
-	0x057a: 0x22a,
-	0x057b: 0x22b,
-	0x057c: 0x22c,
-	0x057d: 0x22d,
-	0x057e: 0x22e,
-	0x057f: 0x22f,
-	0x0588: 0x230,
-	0x0589: 0x231,
-	0x058a: 0x232,
-	0x058b: 0x233,
-	0x058c: 0x234,
-	0x058d: 0x235,
-	0x058e: 0x236,
-	0x058f: 0x237,
-	0x0599: 0x238,
-	0x059a: 0x239,
-	0x059b: 0x23a,
-	0x059c: 0x23b,
-	0x059d: 0x23c,
-	0x059e: 0x23d,
-	0x059f: 0x23e,
-	0x05aa: 0x23f,
-	0x05ab: 0x240,
-	0x05ac: 0x241,
-	0x05ad: 0x242,
-	0x05ae: 0x243,
-	0x05af: 0x244,
-	0x05bb: 0x245,
-	0x05bc: 0x246,
-	0x05bd: 0x247,
-	0x05be: 0x248,
-	0x05bf: 0x249,
-	0x05cc: 0x24a,
-	0x05cd: 0x24b,
-	0x05ce: 0x24c,
-	0x05cf: 0x24d,
-	0x05dd: 0x24e,
-	0x05de: 0x24f,
-	0x05df: 0x250,
-	0x05ee: 0x251,
-	0x05ef: 0x252,
-	0x05ff: 0x253,
-	0x0666: 0x254,
-	0x0667: 0x255,
-	0x0668: 0x256,
-	0x0669: 0x257,
-	0x066a: 0x258,
-	0x066b: 0x259,
-	0x066c: 0x25a,
-	0x066d: 0x25b,
-	0x066e: 0x25c,
-	0x066f: 0x25d,
-	0x0677: 0x25e,
-	0x0678: 0x25f,
-	0x0679: 0x260,
-	0x067a: 0x261,
-	0x067b: 0x262,
-	0x067c: 0x263,
-	0x067d: 0x264,
-	0x067e: 0x265,
-	0x067f: 0x266,
-	0x0688: 0x267,
-	0x0689: 0x268,
-	0x068a: 0x269,
-	0x068b: 0x26a,
-	0x068c: 0x26b,
-	0x068d: 0x26c,
-	0x068e: 0x26d,
-	0x068f: 0x26e,
-	0x0699: 0x26f,
-	0x069a: 0x270,
-	0x069b: 0x271,
-	0x069c: 0x272,
-	0x069d: 0x273,
-	0x069e: 0x274,
-	0x069f: 0x275,
-	0x06aa: 0x276,
-	0x06ab: 0x277,
-	0x06ac: 0x278,
-	0x06ad: 0x279,
-	0x06ae: 0x27a,
-	0x06af: 0x27b,
-	0x06bb: 0x27c,
-	0x06bc: 0x27d,
-	0x06bd: 0x27e,
-	0x06be: 0x27f,
-	0x06bf: 0x280,
-	0x06cc: 0x281,
-	0x06cd: 0x282,
-	0x06ce: 0x283,
-	0x06cf: 0x284,
-	0x06dd: 0x285,
-	0x06de: 0x286,
-	0x06df: 0x287,
-	0x06ee: 0x288,
-	0x06ef: 0x289,
-	0x06ff: 0x28a,
-	0x0777: 0x28b,
-	0x0778: 0x28c,
-	0x0779: 0x28d,
-	0x077a: 0x28e,
-	0x077b: 0x28f,
-	0x077c: 0x290,
-	0x077d: 0x291,
-	0x077e: 0x292,
-	0x077f: 0x293,
-	0x0788: 0x294,
-	0x0789: 0x295,
-	0x078a: 0x296,
-	0x078b: 0x297,
-	0x078c: 0x298,
-	0x078d: 0x299,
-	0x078e: 0x29a,
-	0x078f: 0x29b,
-	0x0799: 0x29c,
-	0x079a: 0x29d,
-	0x079b: 0x29e,
-	0x079c: 0x29f,
-	0x079d: 0x2a0,
-	0x079e: 0x2a1,
-	0x079f: 0x2a2,
-	0x07aa: 0x2a3,
-	0x07ab: 0x2a4,
-	0x07ac: 0x2a5,
-	0x07ad: 0x2a6,
-	0x07ae: 0x2a7,
-	0x07af: 0x2a8,
-	0x07bb: 0x2a9,
-	0x07bc: 0x2aa,
-	0x07bd: 0x2ab,
-	0x07be: 0x2ac,
-	0x07bf: 0x2ad,
-	0x07cc: 0x2ae,
-	0x07cd: 0x2af,
-	0x07ce: 0x2b0,
-	0x07cf: 0x2b1,
-	0x07dd: 0x2b2,
-	0x07de: 0x2b3,
-	0x07df: 0x2b4,
-	0x07ee: 0x2b5,
-	0x07ef: 0x2b6,
-	0x07ff: 0x2b7,
-	0x0888: 0x2b8,
-	0x0889: 0x2b9,
-	0x088a: 0x2ba,
-	0x088b: 0x2bb,
-	0x088c: 0x2bc,
-	0x088d: 0x2bd,
-	0x088e: 0x2be,
-	0x088f: 0x2bf,
-	0x0899: 0x2c0,
-	0x089a: 0x2c1,
-	0x089b: 0x2c2,
-	0x089c: 0x2c3,
-	0x089d: 0x2c4,
-	0x089e: 0x2c5,
-	0x089f: 0x2c6,
-	0x08aa: 0x2c7,
-	0x08ab: 0x2c8,
-	0x08ac: 0x2c9,
-	0x08ad: 0x2ca,
-	0x08ae: 0x2cb,
-	0x08af: 0x2cc,
-	0x08bb: 0x2cd,
-	0x08bc: 0x2ce,
-	0x08bd: 0x2cf,
-	0x08be: 0x2d0,
-	0x08bf: 0x2d1,
-	0x08cc: 0x2d2,
-	0x08cd: 0x2d3,
-	0x08ce: 0x2d4,
-	0x08cf: 0x2d5,
-	0x08dd: 0x2d6,
-	0x08de: 0x2d7,
-	0x08df: 0x2d8,
-	0x08ee: 0x2d9,
-	0x08ef: 0x2da,
-	0x08ff: 0x2db,
-	0x0999: 0x2dc,
-	0x099a: 0x2dd,
-	0x099b: 0x2de,
-	0x099c: 0x2df,
-	0x099d: 0x2e0,
-	0x099e: 0x2e1,
-	0x099f: 0x2e2,
-	0x09aa: 0x2e3,
-	0x09ab: 0x2e4,
-	0x09ac: 0x2e5,
-	0x09ad: 0x2e6,
-	0x09ae: 0x2e7,
-	0x09af: 0x2e8,
-	0x09bb: 0x2e9,
-	0x09bc: 0x2ea,
-	0x09bd: 0x2eb,
-	0x09be: 0x2ec,
-	0x09bf: 0x2ed,
-	0x09cc: 0x2ee,
-	0x09cd: 0x2ef,
-	0x09ce: 0x2f0,
-	0x09cf: 0x2f1,
-	0x09dd: 0x2f2,
-	0x09de: 0x2f3,
-	0x09df: 0x2f4,
-	0x09ee: 0x2f5,
-	0x09ef: 0x2f6,
-	0x09ff: 0x2f7,
-	0x0aaa: 0x2f8,
-	0x0aab: 0x2f9,
-	0x0aac: 0x2fa,
-	0x0aad: 0x2fb,
-	0x0aae: 0x2fc,
-	0x0aaf: 0x2fd,
-	0x0abb: 0x2fe,
-	0x0abc: 0x2ff,
-	0x0abd: 0x300,
-	0x0abe: 0x301,
-	0x0abf: 0x302,
-	0x0acc: 0x303,
-	0x0acd: 0x304,
-	0x0ace: 0x305,
-	0x0acf: 0x306,
-	0x0add: 0x307,
-	0x0ade: 0x308,
-	0x0adf: 0x309,
-	0x0aee: 0x30a,
-	0x0aef: 0x30b,
-	0x0aff: 0x30c,
-	0x0bbb: 0x30d,
-	0x0bbc: 0x30e,
-	0x0bbd: 0x30f,
-	0x0bbe: 0x310,
-	0x0bbf: 0x311,
-	0x0bcc: 0x312,
-	0x0bcd: 0x313,
-	0x0bce: 0x314,
-	0x0bcf: 0x315,
-	0x0bdd: 0x316,
-	0x0bde: 0x317,
-	0x0bdf: 0x318,
-	0x0bee: 0x319,
-	0x0bef: 0x31a,
-	0x0bff: 0x31b,
-	0x0ccc: 0x31c,
-	0x0ccd: 0x31d,
-	0x0cce: 0x31e,
-	0x0ccf: 0x31f,
-	0x0cdd: 0x320,
-	0x0cde: 0x321,
-	0x0cdf: 0x322,
-	0x0cee: 0x323,
-	0x0cef: 0x324,
-	0x0cff: 0x325,
-	0x0ddd: 0x326,
-	0x0dde: 0x327,
-	0x0ddf: 0x328,
-	0x0dee: 0x329,
-	0x0def: 0x32a,
-	0x0dff: 0x32b,
-	0x0eee: 0x32c,
-	0x0eef: 0x32d,
-	0x0eff: 0x32e,
-	0x0fff: 0x32f,
-	0x1111: 0x330,
-	0x1112: 0x331,
-	0x1113: 0x332,
-	0x1114: 0x333,
-	0x1115: 0x334,
-	0x1116: 0x335,
-	0x1117: 0x336,
-	0x1118: 0x337,
-	0x1119: 0x338,
-	0x111a: 0x339,
-	0x111b: 0x33a,
-	0x111c: 0x33b,
-	0x111d: 0x33c,
-	0x111e: 0x33d,
-	0x111f: 0x33e,
-	0x1122: 0x33f,
-	0x1123: 0x340,
-	0x1124: 0x341,
-	0x1125: 0x342,
-	0x1126: 0x343,
-	0x1127: 0x344,
-	0x1128: 0x345,
-	0x1129: 0x346,
-	0x112a: 0x347,
-	0x112b: 0x348,
-	0x112c: 0x349,
-	0x112d: 0x34a,
-	0x112e: 0x34b,
-	0x112f: 0x34c,
-	0x1133: 0x34d,
-	0x1134: 0x34e,
-	0x1135: 0x34f,
-	0x1136: 0x350,
-	0x1137: 0x351,
-	0x1138: 0x352,
-	0x1139: 0x353,
-	0x113a: 0x354,
-	0x113b: 0x355,
-	0x113c: 0x356,
-	0x113d: 0x357,
-	0x113e: 0x358,
-	0x113f: 0x359,
-	0x1144: 0x35a,
-	0x1145: 0x35b,
-	0x1146: 0x35c,
-	0x1147: 0x35d,
-	0x1148: 0x35e,
-	0x1149: 0x35f,
-	0x114a: 0x360,
-	0x114b: 0x361,
-	0x114c: 0x362,
-	0x114d: 0x363,
-	0x114e: 0x364,
-	0x114f: 0x365,
-	0x1155: 0x366,
-	0x1156: 0x367,
-	0x1157: 0x368,
-	0x1158: 0x369,
-	0x1159: 0x36a,
-	0x115a: 0x36b,
-	0x115b: 0x36c,
-	0x115c: 0x36d,
-	0x115d: 0x36e,
-	0x115e: 0x36f,
-	0x115f: 0x370,
-	0x1166: 0x371,
-	0x1167: 0x372,
-	0x1168: 0x373,
-	0x1169: 0x374,
-	0x116a: 0x375,
-	0x116b: 0x376,
-	0x116c: 0x377,
-	0x116d: 0x378,
-	0x116e: 0x379,
-	0x116f: 0x37a,
-	0x1177: 0x37b,
-	0x1178: 0x37c,
-	0x1179: 0x37d,
-	0x117a: 0x37e,
-	0x117b: 0x37f,
-	0x117c: 0x380,
-	0x117d: 0x381,
-	0x117e: 0x382,
-	0x117f: 0x383,
-	0x1188: 0x384,
-	0x1189: 0x385,
-	0x118a: 0x386,
-	0x118b: 0x387,
-	0x118c: 0x388,
-	0x118d: 0x389,
-	0x118e: 0x38a,
-	0x118f: 0x38b,
-	0x1199: 0x38c,
-	0x119a: 0x38d,
-	0x119b: 0x38e,
-	0x119c: 0x38f,
-	0x119d: 0x390,
-	0x119e: 0x391,
-	0x119f: 0x392,
-	0x11aa: 0x393,
-	0x11ab: 0x394,
-	0x11ac: 0x395,
-	0x11ad: 0x396,
-	0x11ae: 0x397,
-	0x11af: 0x398,
-	0x11bb: 0x399,
-	0x11bc: 0x39a,
-	0x11bd: 0x39b,
-	0x11be: 0x39c,
-	0x11bf: 0x39d,
-	0x11cc: 0x39e,
-	0x11cd: 0x39f,
-	0x11ce: 0x3a0,
-	0x11cf: 0x3a1,
-	0x11dd: 0x3a2,
-	0x11de: 0x3a3,
-	0x11df: 0x3a4,
-	0x11ee: 0x3a5,
-	0x11ef: 0x3a6,
-	0x11ff: 0x3a7,
-	0x1222: 0x3a8,
-	0x1223: 0x3a9,
-	0x1224: 0x3aa,
-	0x1225: 0x3ab,
-	0x1226: 0x3ac,
-	0x1227: 0x3ad,
-	0x1228: 0x3ae,
-	0x1229: 0x3af,
-	0x122a: 0x3b0,
-	0x122b: 0x3b1,
-	0x122c: 0x3b2,
-	0x122d: 0x3b3,
-	0x122e: 0x3b4,
-	0x122f: 0x3b5,
-	0x1233: 0x3b6,
-	0x1234: 0x3b7,
-	0x1235: 0x3b8,
-	0x1236: 0x3b9,
-	0x1237: 0x3ba,
-	0x1238: 0x3bb,
-	0x1239: 0x3bc,
-	0x123a: 0x3bd,
-	0x123b: 0x3be,
-	0x123c: 0x3bf,
-	0x123d: 0x3c0,
-	0x123e: 0x3c1,
-	0x123f: 0x3c2,
-	0x1244: 0x3c3,
-	0x1245: 0x3c4,
-	0x1246: 0x3c5,
-	0x1247: 0x3c6,
-	0x1248: 0x3c7,
-	0x1249: 0x3c8,
-	0x124a: 0x3c9,
-	0x124b: 0x3ca,
-	0x124c: 0x3cb,
-	0x124d: 0x3cc,
-	0x124e: 0x3cd,
-	0x124f: 0x3ce,
-	0x1255: 0x3cf,
-	0x1256: 0x3d0,
-	0x1257: 0x3d1,
-	0x1258: 0x3d2,
-	0x1259: 0x3d3,
-	0x125a: 0x3d4,
-	0x125b: 0x3d5,
-	0x125c: 0x3d6,
-	0x125d: 0x3d7,
-	0x125e: 0x3d8,
-	0x125f: 0x3d9,
-	0x1266: 0x3da,
-	0x1267: 0x3db,
-	0x1268: 0x3dc,
-	0x1269: 0x3dd,
-	0x126a: 0x3de,
-	0x126b: 0x3df,
-	0x126c: 0x3e0,
-	0x126d: 0x3e1,
-	0x126e: 0x3e2,
-	0x126f: 0x3e3,
-	0x1277: 0x3e4,
-	0x1278: 0x3e5,
-	0x1279: 0x3e6,
-	0x127a: 0x3e7,
-	0x127b: 0x3e8,
-	0x127c: 0x3e9,
-	0x127d: 0x3ea,
-	0x127e: 0x3eb,
-	0x127f: 0x3ec,
-	0x1288: 0x3ed,
-	0x1289: 0x3ee,
-	0x128a: 0x3ef,
-	0x128b: 0x3f0,
-	0x128c: 0x3f1,
-	0x128d: 0x3f2,
-	0x128e: 0x3f3,
-	0x128f: 0x3f4,
-	0x1299: 0x3f5,
-	0x129a: 0x3f6,
-	0x129b: 0x3f7,
-	0x129c: 0x3f8,
-	0x129d: 0x3f9,
-	0x129e: 0x3fa,
-	0x129f: 0x3fb,
-	0x12aa: 0x3fc,
-	0x12ab: 0x3fd,
-	0x12ac: 0x3fe,
-	0x12ad: 0x3ff,
-	0x12ae: 0x400,
-	0x12af: 0x401,
-	0x12bb: 0x402,
-	0x12bc: 0x403,
-	0x12bd: 0x404,
-	0x12be: 0x405,
-	0x12bf: 0x406,
-	0x12cc: 0x407,
-	0x12cd: 0x408,
-	0x12ce: 0x409,
-	0x12cf: 0x40a,
-	0x12dd: 0x40b,
-	0x12de: 0x40c,
-	0x12df: 0x40d,
-	0x12ee: 0x40e,
-	0x12ef: 0x40f,
-	0x12ff: 0x410,
-	0x1333: 0x411,
-	0x1334: 0x412,
-	0x1335: 0x413,
-	0x1336: 0x414,
-	0x1337: 0x415,
-	0x1338: 0x416,
-	0x1339: 0x417,
-	0x133a: 0x418,
-	0x133b: 0x419,
-	0x133c: 0x41a,
-	0x133d: 0x41b,
-	0x133e: 0x41c,
-	0x133f: 0x41d,
-	0x1344: 0x41e,
-	0x1345: 0x41f,
-	0x1346: 0x420,
-	0x1347: 0x421,
-	0x1348: 0x422,
-	0x1349: 0x423,
-	0x134a: 0x424,
-	0x134b: 0x425,
-	0x134c: 0x426,
-	0x134d: 0x427,
-	0x134e: 0x428,
-	0x134f: 0x429,
-	0x1355: 0x42a,
-	0x1356: 0x42b,
-	0x1357: 0x42c,
-	0x1358: 0x42d,
-	0x1359: 0x42e,
-	0x135a: 0x42f,
-	0x135b: 0x430,
-	0x135c: 0x431,
-	0x135d: 0x432,
-	0x135e: 0x433,
-	0x135f: 0x434,
-	0x1366: 0x435,
-	0x1367: 0x436,
-	0x1368: 0x437,
-	0x1369: 0x438,
-	0x136a: 0x439,
-	0x136b: 0x43a,
-	0x136c: 0x43b,
-	0x136d: 0x43c,
-	0x136e: 0x43d,
-	0x136f: 0x43e,
-	0x1377: 0x43f,
-	0x1378: 0x440,
-	0x1379: 0x441,
-	0x137a: 0x442,
-	0x137b: 0x443,
-	0x137c: 0x444,
-	0x137d: 0x445,
-	0x137e: 0x446,
-	0x137f: 0x447,
-	0x1388: 0x448,
-	0x1389: 0x449,
-	0x138a: 0x44a,
-	0x138b: 0x44b,
-	0x138c: 0x44c,
-	0x138d: 0x44d,
-	0x138e: 0x44e,
-	0x138f: 0x44f,
-	0x1399: 0x450,
-	0x139a: 0x451,
-	0x139b: 0x452,
-	0x139c: 0x453,
-	0x139d: 0x454,
-	0x139e: 0x455,
-	0x139f: 0x456,
-	0x13aa: 0x457,
-	0x13ab: 0x458,
-	0x13ac: 0x459,
-	0x13ad: 0x45a,
-	0x13ae: 0x45b,
-	0x13af: 0x45c,
-	0x13bb: 0x45d,
-	0x13bc: 0x45e,
-	0x13bd: 0x45f,
-	0x13be: 0x460,
-	0x13bf: 0x461,
-	0x13cc: 0x462,
-	0x13cd: 0x463,
-	0x13ce: 0x464,
-	0x13cf: 0x465,
-	0x13dd: 0x466,
-	0x13de: 0x467,
-	0x13df: 0x468,
-	0x13ee: 0x469,
-	0x13ef: 0x46a,
-	0x13ff: 0x46b,
-	0x1444: 0x46c,
-	0x1445: 0x46d,
-	0x1446: 0x46e,
-	0x1447: 0x46f,
-	0x1448: 0x470,
-	0x1449: 0x471,
-	0x144a: 0x472,
-	0x144b: 0x473,
-	0x144c: 0x474,
-	0x144d: 0x475,
-	0x144e: 0x476,
-	0x144f: 0x477,
-	0x1455: 0x478,
-	0x1456: 0x479,
-	0x1457: 0x47a,
-	0x1458: 0x47b,
-	0x1459: 0x47c,
-	0x145a: 0x47d,
-	0x145b: 0x47e,
-	0x145c: 0x47f,
-	0x145d: 0x480,
-	0x145e: 0x481,
-	0x145f: 0x482,
-	0x1466: 0x483,
-	0x1467: 0x484,
-	0x1468: 0x485,
-	0x1469: 0x486,
-	0x146a: 0x487,
-	0x146b: 0x488,
-	0x146c: 0x489,
-	0x146d: 0x48a,
-	0x146e: 0x48b,
-	0x146f: 0x48c,
-	0x1477: 0x48d,
-	0x1478: 0x48e,
-	0x1479: 0x48f,
-	0x147a: 0x490,
-	0x147b: 0x491,
-	0x147c: 0x492,
-	0x147d: 0x493,
-	0x147e: 0x494,
-	0x147f: 0x495,
-	0x1488: 0x496,
-	0x1489: 0x497,
-	0x148a: 0x498,
-	0x148b: 0x499,
-	0x148c: 0x49a,
-	0x148d: 0x49b,
-	0x148e: 0x49c,
-	0x148f: 0x49d,
-	0x1499: 0x49e,
-	0x149a: 0x49f,
-	0x149b: 0x4a0,
-	0x149c: 0x4a1,
-	0x149d: 0x4a2,
-	0x149e: 0x4a3,
-	0x149f: 0x4a4,
-	0x14aa: 0x4a5,
-	0x14ab: 0x4a6,
-	0x14ac: 0x4a7,
-	0x14ad: 0x4a8,
-	0x14ae: 0x4a9,
-	0x14af: 0x4aa,
-	0x14bb: 0x4ab,
-	0x14bc: 0x4ac,
-	0x14bd: 0x4ad,
-	0x14be: 0x4ae,
-	0x14bf: 0x4af,
-	0x14cc: 0x4b0,
-	0x14cd: 0x4b1,
-	0x14ce: 0x4b2,
-	0x14cf: 0x4b3,
-	0x14dd: 0x4b4,
-	0x14de: 0x4b5,
-	0x14df: 0x4b6,
-	0x14ee: 0x4b7,
-	0x14ef: 0x4b8,
-	0x14ff: 0x4b9,
-	0x1555: 0x4ba,
-	0x1556: 0x4bb,
-	0x1557: 0x4bc,
-	0x1558: 0x4bd,
-	0x1559: 0x4be,
-	0x155a: 0x4bf,
-	0x155b: 0x4c0,
-	0x155c: 0x4c1,
-	0x155d: 0x4c2,
-	0x155e: 0x4c3,
-	0x155f: 0x4c4,
-	0x1566: 0x4c5,
-	0x1567: 0x4c6,
-	0x1568: 0x4c7,
-	0x1569: 0x4c8,
-	0x156a: 0x4c9,
-	0x156b: 0x4ca,
-	0x156c: 0x4cb,
-	0x156d: 0x4cc,
-	0x156e: 0x4cd,
-	0x156f: 0x4ce,
-	0x1577: 0x4cf,
-	0x1578: 0x4d0,
-	0x1579: 0x4d1,
-	0x157a: 0x4d2,
-	0x157b: 0x4d3,
-	0x157c: 0x4d4,
-	0x157d: 0x4d5,
-	0x157e: 0x4d6,
-	0x157f: 0x4d7,
-	0x1588: 0x4d8,
-	0x1589: 0x4d9,
-	0x158a: 0x4da,
-	0x158b: 0x4db,
-	0x158c: 0x4dc,
-	0x158d: 0x4dd,
-	0x158e: 0x4de,
-	0x158f: 0x4df,
-	0x1599: 0x4e0,
-	0x159a: 0x4e1,
-	0x159b: 0x4e2,
-	0x159c: 0x4e3,
-	0x159d: 0x4e4,
-	0x159e: 0x4e5,
-	0x159f: 0x4e6,
-	0x15aa: 0x4e7,
-	0x15ab: 0x4e8,
-	0x15ac: 0x4e9,
-	0x15ad: 0x4ea,
-	0x15ae: 0x4eb,
-	0x15af: 0x4ec,
-	0x15bb: 0x4ed,
-	0x15bc: 0x4ee,
-	0x15bd: 0x4ef,
-	0x15be: 0x4f0,
-	0x15bf: 0x4f1,
-	0x15cc: 0x4f2,
-	0x15cd: 0x4f3,
-	0x15ce: 0x4f4,
-	0x15cf: 0x4f5,
-	0x15dd: 0x4f6,
-	0x15de: 0x4f7,
-	0x15df: 0x4f8,
-	0x15ee: 0x4f9,
-	0x15ef: 0x4fa,
-	0x15ff: 0x4fb,
-	0x1666: 0x4fc,
-	0x1667: 0x4fd,
-	0x1668: 0x4fe,
-	0x1669: 0x4ff,
-	0x166a: 0x500,
-	0x166b: 0x501,
-	0x166c: 0x502,
-	0x166d: 0x503,
-	0x166e: 0x504,
-	0x166f: 0x505,
-	0x1677: 0x506,
-	0x1678: 0x507,
-	0x1679: 0x508,
-	0x167a: 0x509,
-	0x167b: 0x50a,
-	0x167c: 0x50b,
-	0x167d: 0x50c,
-	0x167e: 0x50d,
-	0x167f: 0x50e,
-	0x1688: 0x50f,
-	0x1689: 0x510,
-	0x168a: 0x511,
-	0x168b: 0x512,
-	0x168c: 0x513,
-	0x168d: 0x514,
-	0x168e: 0x515,
-	0x168f: 0x516,
-	0x1699: 0x517,
-	0x169a: 0x518,
-	0x169b: 0x519,
-	0x169c: 0x51a,
-	0x169d: 0x51b,
-	0x169e: 0x51c,
-	0x169f: 0x51d,
-	0x16aa: 0x51e,
-	0x16ab: 0x51f,
-	0x16ac: 0x520,
-	0x16ad: 0x521,
-	0x16ae: 0x522,
-	0x16af: 0x523,
-	0x16bb: 0x524,
-	0x16bc: 0x525,
-	0x16bd: 0x526,
-	0x16be: 0x527,
-	0x16bf: 0x528,
-	0x16cc: 0x529,
-	0x16cd: 0x52a,
-	0x16ce: 0x52b,
-	0x16cf: 0x52c,
-	0x16dd: 0x52d,
-	0x16de: 0x52e,
-	0x16df: 0x52f,
-	0x16ee: 0x530,
-	0x16ef: 0x531,
-	0x16ff: 0x532,
-	0x1777: 0x533,
-	0x1778: 0x534,
-	0x1779: 0x535,
-	0x177a: 0x536,
-	0x177b: 0x537,
-	0x177c: 0x538,
-	0x177d: 0x539,
-	0x177e: 0x53a,
-	0x177f: 0x53b,
-	0x1788: 0x53c,
-	0x1789: 0x53d,
-	0x178a: 0x53e,
-	0x178b: 0x53f,
-	0x178c: 0x540,
-	0x178d: 0x541,
-	0x178e: 0x542,
-	0x178f: 0x543,
-	0x1799: 0x544,
-	0x179a: 0x545,
-	0x179b: 0x546,
-	0x179c: 0x547,
-	0x179d: 0x548,
-	0x179e: 0x549,
-	0x179f: 0x54a,
-	0x17aa: 0x54b,
-	0x17ab: 0x54c,
-	0x17ac: 0x54d,
-	0x17ad: 0x54e,
-	0x17ae: 0x54f,
-	0x17af: 0x550,
-	0x17bb: 0x551,
-	0x17bc: 0x552,
-	0x17bd: 0x553,
-	0x17be: 0x554,
-	0x17bf: 0x555,
-	0x17cc: 0x556,
-	0x17cd: 0x557,
-	0x17ce: 0x558,
-	0x17cf: 0x559,
-	0x17dd: 0x55a,
-	0x17de: 0x55b,
-	0x17df: 0x55c,
-	0x17ee: 0x55d,
-	0x17ef: 0x55e,
-	0x17ff: 0x55f,
-	0x1888: 0x560,
-	0x1889: 0x561,
-	0x188a: 0x562,
-	0x188b: 0x563,
-	0x188c: 0x564,
-	0x188d: 0x565,
-	0x188e: 0x566,
-	0x188f: 0x567,
-	0x1899: 0x568,
-	0x189a: 0x569,
-	0x189b: 0x56a,
-	0x189c: 0x56b,
-	0x189d: 0x56c,
-	0x189e: 0x56d,
-	0x189f: 0x56e,
-	0x18aa: 0x56f,
-	0x18ab: 0x570,
-	0x18ac: 0x571,
-	0x18ad: 0x572,
-	0x18ae: 0x573,
-	0x18af: 0x574,
-	0x18bb: 0x575,
-	0x18bc: 0x576,
-	0x18bd: 0x577,
-	0x18be: 0x578,
-	0x18bf: 0x579,
-	0x18cc: 0x57a,
-	0x18cd: 0x57b,
-	0x18ce: 0x57c,
-	0x18cf: 0x57d,
-	0x18dd: 0x57e,
-	0x18de: 0x57f,
-	0x18df: 0x580,
-	0x18ee: 0x581,
-	0x18ef: 0x582,
-	0x18ff: 0x583,
-	0x1999: 0x584,
-	0x199a: 0x585,
-	0x199b: 0x586,
-	0x199c: 0x587,
-	0x199d: 0x588,
-	0x199e: 0x589,
-	0x199f: 0x58a,
-	0x19aa: 0x58b,
-	0x19ab: 0x58c,
-	0x19ac: 0x58d,
-	0x19ad: 0x58e,
-	0x19ae: 0x58f,
-	0x19af: 0x590,
-	0x19bb: 0x591,
-	0x19bc: 0x592,
-	0x19bd: 0x593,
-	0x19be: 0x594,
-	0x19bf: 0x595,
-	0x19cc: 0x596,
-	0x19cd: 0x597,
-	0x19ce: 0x598,
-	0x19cf: 0x599,
-	0x19dd: 0x59a,
-	0x19de: 0x59b,
-	0x19df: 0x59c,
-	0x19ee: 0x59d,
-	0x19ef: 0x59e,
-	0x19ff: 0x59f,
-	0x1aaa: 0x5a0,
-	0x1aab: 0x5a1,
-	0x1aac: 0x5a2,
-	0x1aad: 0x5a3,
-	0x1aae: 0x5a4,
-	0x1aaf: 0x5a5,
-	0x1abb: 0x5a6,
-	0x1abc: 0x5a7,
-	0x1abd: 0x5a8,
-	0x1abe: 0x5a9,
-	0x1abf: 0x5aa,
-	0x1acc: 0x5ab,
-	0x1acd: 0x5ac,
-	0x1ace: 0x5ad,
-	0x1acf: 0x5ae,
-	0x1add: 0x5af,
-	0x1ade: 0x5b0,
-	0x1adf: 0x5b1,
-	0x1aee: 0x5b2,
-	0x1aef: 0x5b3,
-	0x1aff: 0x5b4,
-	0x1bbb: 0x5b5,
-	0x1bbc: 0x5b6,
-	0x1bbd: 0x5b7,
-	0x1bbe: 0x5b8,
-	0x1bbf: 0x5b9,
-	0x1bcc: 0x5ba,
-	0x1bcd: 0x5bb,
-	0x1bce: 0x5bc,
-	0x1bcf: 0x5bd,
-	0x1bdd: 0x5be,
-	0x1bde: 0x5bf,
-	0x1bdf: 0x5c0,
-	0x1bee: 0x5c1,
-	0x1bef: 0x5c2,
-	0x1bff: 0x5c3,
-	0x1ccc: 0x5c4,
-	0x1ccd: 0x5c5,
-	0x1cce: 0x5c6,
-	0x1ccf: 0x5c7,
-	0x1cdd: 0x5c8,
-	0x1cde: 0x5c9,
-	0x1cdf: 0x5ca,
-	0x1cee: 0x5cb,
-	0x1cef: 0x5cc,
-	0x1cff: 0x5cd,
-	0x1ddd: 0x5ce,
-	0x1dde: 0x5cf,
-	0x1ddf: 0x5d0,
-	0x1dee: 0x5d1,
-	0x1def: 0x5d2,
-	0x1dff: 0x5d3,
-	0x1eee: 0x5d4,
-	0x1eef: 0x5d5,
-	0x1eff: 0x5d6,
-	0x1fff: 0x5d7,
-	0x2222: 0x5d8,
-	0x2223: 0x5d9,
-	0x2224: 0x5da,
-	0x2225: 0x5db,
-	0x2226: 0x5dc,
-	0x2227: 0x5dd,
-	0x2228: 0x5de,
-	0x2229: 0x5df,
-	0x222a: 0x5e0,
-	0x222b: 0x5e1,
-	0x222c: 0x5e2,
-	0x222d: 0x5e3,
-	0x222e: 0x5e4,
-	0x222f: 0x5e5,
-	0x2233: 0x5e6,
-	0x2234: 0x5e7,
-	0x2235: 0x5e8,
-	0x2236: 0x5e9,
-	0x2237: 0x5ea,
-	0x2238: 0x5eb,
-	0x2239: 0x5ec,
-	0x223a: 0x5ed,
-	0x223b: 0x5ee,
-	0x223c: 0x5ef,
-	0x223d: 0x5f0,
-	0x223e: 0x5f1,
-	0x223f: 0x5f2,
-	0x2244: 0x5f3,
-	0x2245: 0x5f4,
-	0x2246: 0x5f5,
-	0x2247: 0x5f6,
-	0x2248: 0x5f7,
-	0x2249: 0x5f8,
-	0x224a: 0x5f9,
-	0x224b: 0x5fa,
-	0x224c: 0x5fb,
-	0x224d: 0x5fc,
-	0x224e: 0x5fd,
-	0x224f: 0x5fe,
-	0x2255: 0x5ff,
-	0x2256: 0x600,
-	0x2257: 0x601,
-	0x2258: 0x602,
-	0x2259: 0x603,
-	0x225a: 0x604,
-	0x225b: 0x605,
-	0x225c: 0x606,
-	0x225d: 0x607,
-	0x225e: 0x608,
-	0x225f: 0x609,
-	0x2266: 0x60a,
-	0x2267: 0x60b,
-	0x2268: 0x60c,
-	0x2269: 0x60d,
-	0x226a: 0x60e,
-	0x226b: 0x60f,
-	0x226c: 0x610,
-	0x226d: 0x611,
-	0x226e: 0x612,
-	0x226f: 0x613,
-	0x2277: 0x614,
-	0x2278: 0x615,
-	0x2279: 0x616,
-	0x227a: 0x617,
-	0x227b: 0x618,
-	0x227c: 0x619,
-	0x227d: 0x61a,
-	0x227e: 0x61b,
-	0x227f: 0x61c,
-	0x2288: 0x61d,
-	0x2289: 0x61e,
-	0x228a: 0x61f,
-	0x228b: 0x620,
-	0x228c: 0x621,
-	0x228d: 0x622,
-	0x228e: 0x623,
-	0x228f: 0x624,
-	0x2299: 0x625,
-	0x229a: 0x626,
-	0x229b: 0x627,
-	0x229c: 0x628,
-	0x229d: 0x629,
-	0x229e: 0x62a,
-	0x229f: 0x62b,
-	0x22aa: 0x62c,
-	0x22ab: 0x62d,
-	0x22ac: 0x62e,
-	0x22ad: 0x62f,
-	0x22ae: 0x630,
-	0x22af: 0x631,
-	0x22bb: 0x632,
-	0x22bc: 0x633,
-	0x22bd: 0x634,
-	0x22be: 0x635,
-	0x22bf: 0x636,
-	0x22cc: 0x637,
-	0x22cd: 0x638,
-	0x22ce: 0x639,
-	0x22cf: 0x63a,
-	0x22dd: 0x63b,
-	0x22de: 0x63c,
-	0x22df: 0x63d,
-	0x22ee: 0x63e,
-	0x22ef: 0x63f,
-	0x22ff: 0x640,
-	0x2333: 0x641,
-	0x2334: 0x642,
-	0x2335: 0x643,
-	0x2336: 0x644,
-	0x2337: 0x645,
-	0x2338: 0x646,
-	0x2339: 0x647,
-	0x233a: 0x648,
-	0x233b: 0x649,
-	0x233c: 0x64a,
-	0x233d: 0x64b,
-	0x233e: 0x64c,
-	0x233f: 0x64d,
-	0x2344: 0x64e,
-	0x2345: 0x64f,
-	0x2346: 0x650,
-	0x2347: 0x651,
-	0x2348: 0x652,
-	0x2349: 0x653,
-	0x234a: 0x654,
-	0x234b: 0x655,
-	0x234c: 0x656,
-	0x234d: 0x657,
-	0x234e: 0x658,
-	0x234f: 0x659,
-	0x2355: 0x65a,
-	0x2356: 0x65b,
-	0x2357: 0x65c,
-	0x2358: 0x65d,
-	0x2359: 0x65e,
-	0x235a: 0x65f,
-	0x235b: 0x660,
-	0x235c: 0x661,
-	0x235d: 0x662,
-	0x235e: 0x663,
-	0x235f: 0x664,
-	0x2366: 0x665,
-	0x2367: 0x666,
-	0x2368: 0x667,
-	0x2369: 0x668,
-	0x236a: 0x669,
-	0x236b: 0x66a,
-	0x236c: 0x66b,
-	0x236d: 0x66c,
-	0x236e: 0x66d,
-	0x236f: 0x66e,
-	0x2377: 0x66f,
-	0x2378: 0x670,
-	0x2379: 0x671,
-	0x237a: 0x672,
-	0x237b: 0x673,
-	0x237c: 0x674,
-	0x237d: 0x675,
-	0x237e: 0x676,
-	0x237f: 0x677,
-	0x2388: 0x678,
-	0x2389: 0x679,
-	0x238a: 0x67a,
-	0x238b: 0x67b,
-	0x238c: 0x67c,
-	0x238d: 0x67d,
-	0x238e: 0x67e,
-	0x238f: 0x67f,
-	0x2399: 0x680,
-	0x239a: 0x681,
-	0x239b: 0x682,
-	0x239c: 0x683,
-	0x239d: 0x684,
-	0x239e: 0x685,
-	0x239f: 0x686,
-	0x23aa: 0x687,
-	0x23ab: 0x688,
-	0x23ac: 0x689,
-	0x23ad: 0x68a,
-	0x23ae: 0x68b,
-	0x23af: 0x68c,
-	0x23bb: 0x68d,
-	0x23bc: 0x68e,
-	0x23bd: 0x68f,
-	0x23be: 0x690,
-	0x23bf: 0x691,
-	0x23cc: 0x692,
-	0x23cd: 0x693,
-	0x23ce: 0x694,
-	0x23cf: 0x695,
-	0x23dd: 0x696,
-	0x23de: 0x697,
-	0x23df: 0x698,
-	0x23ee: 0x699,
-	0x23ef: 0x69a,
-	0x23ff: 0x69b,
-	0x2444: 0x69c,
-	0x2445: 0x69d,
-	0x2446: 0x69e,
-	0x2447: 0x69f,
-	0x2448: 0x6a0,
-	0x2449: 0x6a1,
-	0x244a: 0x6a2,
-	0x244b: 0x6a3,
-	0x244c: 0x6a4,
-	0x244d: 0x6a5,
-	0x244e: 0x6a6,
-	0x244f: 0x6a7,
-	0x2455: 0x6a8,
-	0x2456: 0x6a9,
-	0x2457: 0x6aa,
-	0x2458: 0x6ab,
-	0x2459: 0x6ac,
-	0x245a: 0x6ad,
-	0x245b: 0x6ae,
-	0x245c: 0x6af,
-	0x245d: 0x6b0,
-	0x245e: 0x6b1,
-	0x245f: 0x6b2,
-	0x2466: 0x6b3,
-	0x2467: 0x6b4,
-	0x2468: 0x6b5,
-	0x2469: 0x6b6,
-	0x246a: 0x6b7,
-	0x246b: 0x6b8,
-	0x246c: 0x6b9,
-	0x246d: 0x6ba,
-	0x246e: 0x6bb,
-	0x246f: 0x6bc,
-	0x2477: 0x6bd,
-	0x2478: 0x6be,
-	0x2479: 0x6bf,
-	0x247a: 0x6c0,
-	0x247b: 0x6c1,
-	0x247c: 0x6c2,
-	0x247d: 0x6c3,
-	0x247e: 0x6c4,
-	0x247f: 0x6c5,
-	0x2488: 0x6c6,
-	0x2489: 0x6c7,
-	0x248a: 0x6c8,
-	0x248b: 0x6c9,
-	0x248c: 0x6ca,
-	0x248d: 0x6cb,
-	0x248e: 0x6cc,
-	0x248f: 0x6cd,
-	0x2499: 0x6ce,
-	0x249a: 0x6cf,
-	0x249b: 0x6d0,
-	0x249c: 0x6d1,
-	0x249d: 0x6d2,
-	0x249e: 0x6d3,
-	0x249f: 0x6d4,
-	0x24aa: 0x6d5,
-	0x24ab: 0x6d6,
-	0x24ac: 0x6d7,
-	0x24ad: 0x6d8,
-	0x24ae: 0x6d9,
-	0x24af: 0x6da,
-	0x24bb: 0x6db,
-	0x24bc: 0x6dc,
-	0x24bd: 0x6dd,
-	0x24be: 0x6de,
-	0x24bf: 0x6df,
-	0x24cc: 0x6e0,
-	0x24cd: 0x6e1,
-	0x24ce: 0x6e2,
-	0x24cf: 0x6e3,
-	0x24dd: 0x6e4,
-	0x24de: 0x6e5,
-	0x24df: 0x6e6,
-	0x24ee: 0x6e7,
-	0x24ef: 0x6e8,
-	0x24ff: 0x6e9,
-	0x2555: 0x6ea,
-	0x2556: 0x6eb,
-	0x2557: 0x6ec,
-	0x2558: 0x6ed,
-	0x2559: 0x6ee,
-	0x255a: 0x6ef,
-	0x255b: 0x6f0,
-	0x255c: 0x6f1,
-	0x255d: 0x6f2,
-	0x255e: 0x6f3,
-	0x255f: 0x6f4,
-	0x2566: 0x6f5,
-	0x2567: 0x6f6,
-	0x2568: 0x6f7,
-	0x2569: 0x6f8,
-	0x256a: 0x6f9,
-	0x256b: 0x6fa,
-	0x256c: 0x6fb,
-	0x256d: 0x6fc,
-	0x256e: 0x6fd,
-	0x256f: 0x6fe,
-	0x2577: 0x6ff,
-	0x2578: 0x700,
-	0x2579: 0x701,
-	0x257a: 0x702,
-	0x257b: 0x703,
-	0x257c: 0x704,
-	0x257d: 0x705,
-	0x257e: 0x706,
-	0x257f: 0x707,
-	0x2588: 0x708,
-	0x2589: 0x709,
-	0x258a: 0x70a,
-	0x258b: 0x70b,
-	0x258c: 0x70c,
-	0x258d: 0x70d,
-	0x258e: 0x70e,
-	0x258f: 0x70f,
-	0x2599: 0x710,
-	0x259a: 0x711,
-	0x259b: 0x712,
-	0x259c: 0x713,
-	0x259d: 0x714,
-	0x259e: 0x715,
-	0x259f: 0x716,
-	0x25aa: 0x717,
-	0x25ab: 0x718,
-	0x25ac: 0x719,
-	0x25ad: 0x71a,
-	0x25ae: 0x71b,
-	0x25af: 0x71c,
-	0x25bb: 0x71d,
-	0x25bc: 0x71e,
-	0x25bd: 0x71f,
-	0x25be: 0x720,
-	0x25bf: 0x721,
-	0x25cc: 0x722,
-	0x25cd: 0x723,
-	0x25ce: 0x724,
-	0x25cf: 0x725,
-	0x25dd: 0x726,
-	0x25de: 0x727,
-	0x25df: 0x728,
-	0x25ee: 0x729,
-	0x25ef: 0x72a,
-	0x25ff: 0x72b,
-	0x2666: 0x72c,
-	0x2667: 0x72d,
-	0x2668: 0x72e,
-	0x2669: 0x72f,
-	0x266a: 0x730,
-	0x266b: 0x731,
-	0x266c: 0x732,
-	0x266d: 0x733,
-	0x266e: 0x734,
-	0x266f: 0x735,
-	0x2677: 0x736,
-	0x2678: 0x737,
-	0x2679: 0x738,
-	0x267a: 0x739,
-	0x267b: 0x73a,
-	0x267c: 0x73b,
-	0x267d: 0x73c,
-	0x267e: 0x73d,
-	0x267f: 0x73e,
-	0x2688: 0x73f,
-	0x2689: 0x740,
-	0x268a: 0x741,
-	0x268b: 0x742,
-	0x268c: 0x743,
-	0x268d: 0x744,
-	0x268e: 0x745,
-	0x268f: 0x746,
-	0x2699: 0x747,
-	0x269a: 0x748,
-	0x269b: 0x749,
-	0x269c: 0x74a,
-	0x269d: 0x74b,
-	0x269e: 0x74c,
-	0x269f: 0x74d,
-	0x26aa: 0x74e,
-	0x26ab: 0x74f,
-	0x26ac: 0x750,
-	0x26ad: 0x751,
-	0x26ae: 0x752,
-	0x26af: 0x753,
-	0x26bb: 0x754,
-	0x26bc: 0x755,
-	0x26bd: 0x756,
-	0x26be: 0x757,
-	0x26bf: 0x758,
-	0x26cc: 0x759,
-	0x26cd: 0x75a,
-	0x26ce: 0x75b,
-	0x26cf: 0x75c,
-	0x26dd: 0x75d,
-	0x26de: 0x75e,
-	0x26df: 0x75f,
-	0x26ee: 0x760,
-	0x26ef: 0x761,
-	0x26ff: 0x762,
-	0x2777: 0x763,
-	0x2778: 0x764,
-	0x2779: 0x765,
-	0x277a: 0x766,
-	0x277b: 0x767,
-	0x277c: 0x768,
-	0x277d: 0x769,
-	0x277e: 0x76a,
-	0x277f: 0x76b,
-	0x2788: 0x76c,
-	0x2789: 0x76d,
-	0x278a: 0x76e,
-	0x278b: 0x76f,
-	0x278c: 0x770,
-	0x278d: 0x771,
-	0x278e: 0x772,
-	0x278f: 0x773,
-	0x2799: 0x774,
-	0x279a: 0x775,
-	0x279b: 0x776,
-	0x279c: 0x777,
-	0x279d: 0x778,
-	0x279e: 0x779,
-	0x279f: 0x77a,
-	0x27aa: 0x77b,
-	0x27ab: 0x77c,
-	0x27ac: 0x77d,
-	0x27ad: 0x77e,
-	0x27ae: 0x77f,
-	0x27af: 0x780,
-	0x27bb: 0x781,
-	0x27bc: 0x782,
-	0x27bd: 0x783,
-	0x27be: 0x784,
-	0x27bf: 0x785,
-	0x27cc: 0x786,
-	0x27cd: 0x787,
-	0x27ce: 0x788,
-	0x27cf: 0x789,
-	0x27dd: 0x78a,
-	0x27de: 0x78b,
-	0x27df: 0x78c,
-	0x27ee: 0x78d,
-	0x27ef: 0x78e,
-	0x27ff: 0x78f,
-	0x2888: 0x790,
-	0x2889: 0x791,
-	0x288a: 0x792,
-	0x288b: 0x793,
-	0x288c: 0x794,
-	0x288d: 0x795,
-	0x288e: 0x796,
-	0x288f: 0x797,
-	0x2899: 0x798,
-	0x289a: 0x799,
-	0x289b: 0x79a,
-	0x289c: 0x79b,
-	0x289d: 0x79c,
-	0x289e: 0x79d,
-	0x289f: 0x79e,
-	0x28aa: 0x79f,
-	0x28ab: 0x7a0,
-	0x28ac: 0x7a1,
-	0x28ad: 0x7a2,
-	0x28ae: 0x7a3,
-	0x28af: 0x7a4,
-	0x28bb: 0x7a5,
-	0x28bc: 0x7a6,
-	0x28bd: 0x7a7,
-	0x28be: 0x7a8,
-	0x28bf: 0x7a9,
-	0x28cc: 0x7aa,
-	0x28cd: 0x7ab,
-	0x28ce: 0x7ac,
-	0x28cf: 0x7ad,
-	0x28dd: 0x7ae,
-	0x28de: 0x7af,
-	0x28df: 0x7b0,
-	0x28ee: 0x7b1,
-	0x28ef: 0x7b2,
-	0x28ff: 0x7b3,
-	0x2999: 0x7b4,
-	0x299a: 0x7b5,
-	0x299b: 0x7b6,
-	0x299c: 0x7b7,
-	0x299d: 0x7b8,
-	0x299e: 0x7b9,
-	0x299f: 0x7ba,
-	0x29aa: 0x7bb,
-	0x29ab: 0x7bc,
-	0x29ac: 0x7bd,
-	0x29ad: 0x7be,
-	0x29ae: 0x7bf,
-	0x29af: 0x7c0,
-	0x29bb: 0x7c1,
-	0x29bc: 0x7c2,
-	0x29bd: 0x7c3,
-	0x29be: 0x7c4,
-	0x29bf: 0x7c5,
-	0x29cc: 0x7c6,
-	0x29cd: 0x7c7,
-	0x29ce: 0x7c8,
-	0x29cf: 0x7c9,
-	0x29dd: 0x7ca,
-	0x29de: 0x7cb,
-	0x29df: 0x7cc,
-	0x29ee: 0x7cd,
-	0x29ef: 0x7ce,
-	0x29ff: 0x7cf,
-	0x2aaa: 0x7d0,
-	0x2aab: 0x7d1,
-	0x2aac: 0x7d2,
-	0x2aad: 0x7d3,
-	0x2aae: 0x7d4,
-	0x2aaf: 0x7d5,
-	0x2abb: 0x7d6,
-	0x2abc: 0x7d7,
-	0x2abd: 0x7d8,
-	0x2abe: 0x7d9,
-	0x2abf: 0x7da,
-	0x2acc: 0x7db,
-	0x2acd: 0x7dc,
-	0x2ace: 0x7dd,
-	0x2acf: 0x7de,
-	0x2add: 0x7df,
-	0x2ade: 0x7e0,
-	0x2adf: 0x7e1,
-	0x2aee: 0x7e2,
-	0x2aef: 0x7e3,
-	0x2aff: 0x7e4,
-	0x2bbb: 0x7e5,
-	0x2bbc: 0x7e6,
-	0x2bbd: 0x7e7,
-	0x2bbe: 0x7e8,
-	0x2bbf: 0x7e9,
-	0x2bcc: 0x7ea,
-	0x2bcd: 0x7eb,
-	0x2bce: 0x7ec,
-	0x2bcf: 0x7ed,
-	0x2bdd: 0x7ee,
-	0x2bde: 0x7ef,
-	0x2bdf: 0x7f0,
-	0x2bee: 0x7f1,
-	0x2bef: 0x7f2,
-	0x2bff: 0x7f3,
-	0x2ccc: 0x7f4,
-	0x2ccd: 0x7f5,
-	0x2cce: 0x7f6,
-	0x2ccf: 0x7f7,
-	0x2cdd: 0x7f8,
-	0x2cde: 0x7f9,
-	0x2cdf: 0x7fa,
-	0x2cee: 0x7fb,
-	0x2cef: 0x7fc,
-	0x2cff: 0x7fd,
-	0x2ddd: 0x7fe,
-	0x2dde: 0x7ff,
-	0x2ddf: 0x800,
-	0x2dee: 0x801,
-	0x2def: 0x802,
-	0x2dff: 0x803,
-	0x2eee: 0x804,
-	0x2eef: 0x805,
-	0x2eff: 0x806,
-	0x2fff: 0x807,
-	0x3333: 0x808,
-	0x3334: 0x809,
-	0x3335: 0x80a,
-	0x3336: 0x80b,
-	0x3337: 0x80c,
-	0x3338: 0x80d,
-	0x3339: 0x80e,
-	0x333a: 0x80f,
-	0x333b: 0x810,
-	0x333c: 0x811,
-	0x333d: 0x812,
-	0x333e: 0x813,
-	0x333f: 0x814,
-	0x3344: 0x815,
-	0x3345: 0x816,
-	0x3346: 0x817,
-	0x3347: 0x818,
-	0x3348: 0x819,
-	0x3349: 0x81a,
-	0x334a: 0x81b,
-	0x334b: 0x81c,
-	0x334c: 0x81d,
-	0x334d: 0x81e,
-	0x334e: 0x81f,
-	0x334f: 0x820,
-	0x3355: 0x821,
-	0x3356: 0x822,
-	0x3357: 0x823,
-	0x3358: 0x824,
-	0x3359: 0x825,
-	0x335a: 0x826,
-	0x335b: 0x827,
-	0x335c: 0x828,
-	0x335d: 0x829,
-	0x335e: 0x82a,
-	0x335f: 0x82b,
-	0x3366: 0x82c,
-	0x3367: 0x82d,
-	0x3368: 0x82e,
-	0x3369: 0x82f,
-	0x336a: 0x830,
-	0x336b: 0x831,
-	0x336c: 0x832,
-	0x336d: 0x833,
-	0x336e: 0x834,
-	0x336f: 0x835,
-	0x3377: 0x836,
-	0x3378: 0x837,
-	0x3379: 0x838,
-	0x337a: 0x839,
-	0x337b: 0x83a,
-	0x337c: 0x83b,
-	0x337d: 0x83c,
-	0x337e: 0x83d,
-	0x337f: 0x83e,
-	0x3388: 0x83f,
-	0x3389: 0x840,
-	0x338a: 0x841,
-	0x338b: 0x842,
-	0x338c: 0x843,
-	0x338d: 0x844,
-	0x338e: 0x845,
-	0x338f: 0x846,
-	0x3399: 0x847,
-	0x339a: 0x848,
-	0x339b: 0x849,
-	0x339c: 0x84a,
-	0x339d: 0x84b,
-	0x339e: 0x84c,
-	0x339f: 0x84d,
-	0x33aa: 0x84e,
-	0x33ab: 0x84f,
-	0x33ac: 0x850,
-	0x33ad: 0x851,
-	0x33ae: 0x852,
-	0x33af: 0x853,
-	0x33bb: 0x854,
-	0x33bc: 0x855,
-	0x33bd: 0x856,
-	0x33be: 0x857,
-	0x33bf: 0x858,
-	0x33cc: 0x859,
-	0x33cd: 0x85a,
-	0x33ce: 0x85b,
-	0x33cf: 0x85c,
-	0x33dd: 0x85d,
-	0x33de: 0x85e,
-	0x33df: 0x85f,
-	0x33ee: 0x860,
-	0x33ef: 0x861,
-	0x33ff: 0x862,
-	0x3444: 0x863,
-	0x3445: 0x864,
-	0x3446: 0x865,
-	0x3447: 0x866,
-	0x3448: 0x867,
-	0x3449: 0x868,
-	0x344a: 0x869,
-	0x344b: 0x86a,
-	0x344c: 0x86b,
-	0x344d: 0x86c,
-	0x344e: 0x86d,
-	0x344f: 0x86e,
-	0x3455: 0x86f,
-	0x3456: 0x870,
-	0x3457: 0x871,
-	0x3458: 0x872,
-	0x3459: 0x873,
-	0x345a: 0x874,
-	0x345b: 0x875,
-	0x345c: 0x876,
-	0x345d: 0x877,
-	0x345e: 0x878,
-	0x345f: 0x879,
-	0x3466: 0x87a,
-	0x3467: 0x87b,
-	0x3468: 0x87c,
-	0x3469: 0x87d,
-	0x346a: 0x87e,
-	0x346b: 0x87f,
-	0x346c: 0x880,
-	0x346d: 0x881,
-	0x346e: 0x882,
-	0x346f: 0x883,
-	0x3477: 0x884,
-	0x3478: 0x885,
-	0x3479: 0x886,
-	0x347a: 0x887,
-	0x347b: 0x888,
-	0x347c: 0x889,
-	0x347d: 0x88a,
-	0x347e: 0x88b,
-	0x347f: 0x88c,
-	0x3488: 0x88d,
-	0x3489: 0x88e,
-	0x348a: 0x88f,
-	0x348b: 0x890,
-	0x348c: 0x891,
-	0x348d: 0x892,
-	0x348e: 0x893,
-	0x348f: 0x894,
-	0x3499: 0x895,
-	0x349a: 0x896,
-	0x349b: 0x897,
-	0x349c: 0x898,
-	0x349d: 0x899,
-	0x349e: 0x89a,
-	0x349f: 0x89b,
-	0x34aa: 0x89c,
-	0x34ab: 0x89d,
-	0x34ac: 0x89e,
-	0x34ad: 0x89f,
-	0x34ae: 0x8a0,
-	0x34af: 0x8a1,
-	0x34bb: 0x8a2,
-	0x34bc: 0x8a3,
-	0x34bd: 0x8a4,
-	0x34be: 0x8a5,
-	0x34bf: 0x8a6,
-	0x34cc: 0x8a7,
-	0x34cd: 0x8a8,
-	0x34ce: 0x8a9,
-	0x34cf: 0x8aa,
-	0x34dd: 0x8ab,
-	0x34de: 0x8ac,
-	0x34df: 0x8ad,
-	0x34ee: 0x8ae,
-	0x34ef: 0x8af,
-	0x34ff: 0x8b0,
-	0x3555: 0x8b1,
-	0x3556: 0x8b2,
-	0x3557: 0x8b3,
-	0x3558: 0x8b4,
-	0x3559: 0x8b5,
-	0x355a: 0x8b6,
-	0x355b: 0x8b7,
-	0x355c: 0x8b8,
-	0x355d: 0x8b9,
-	0x355e: 0x8ba,
-	0x355f: 0x8bb,
-	0x3566: 0x8bc,
-	0x3567: 0x8bd,
-	0x3568: 0x8be,
-	0x3569: 0x8bf,
-	0x356a: 0x8c0,
-	0x356b: 0x8c1,
-	0x356c: 0x8c2,
-	0x356d: 0x8c3,
-	0x356e: 0x8c4,
-	0x356f: 0x8c5,
-	0x3577: 0x8c6,
-	0x3578: 0x8c7,
-	0x3579: 0x8c8,
-	0x357a: 0x8c9,
-	0x357b: 0x8ca,
-	0x357c: 0x8cb,
-	0x357d: 0x8cc,
-	0x357e: 0x8cd,
-	0x357f: 0x8ce,
-	0x3588: 0x8cf,
-	0x3589: 0x8d0,
-	0x358a: 0x8d1,
-	0x358b: 0x8d2,
-	0x358c: 0x8d3,
-	0x358d: 0x8d4,
-	0x358e: 0x8d5,
-	0x358f: 0x8d6,
-	0x3599: 0x8d7,
-	0x359a: 0x8d8,
-	0x359b: 0x8d9,
-	0x359c: 0x8da,
-	0x359d: 0x8db,
-	0x359e: 0x8dc,
-	0x359f: 0x8dd,
-	0x35aa: 0x8de,
-	0x35ab: 0x8df,
-	0x35ac: 0x8e0,
-	0x35ad: 0x8e1,
-	0x35ae: 0x8e2,
-	0x35af: 0x8e3,
-	0x35bb: 0x8e4,
-	0x35bc: 0x8e5,
-	0x35bd: 0x8e6,
-	0x35be: 0x8e7,
-	0x35bf: 0x8e8,
-	0x35cc: 0x8e9,
-	0x35cd: 0x8ea,
-	0x35ce: 0x8eb,
-	0x35cf: 0x8ec,
-	0x35dd: 0x8ed,
-	0x35de: 0x8ee,
-	0x35df: 0x8ef,
-	0x35ee: 0x8f0,
-	0x35ef: 0x8f1,
-	0x35ff: 0x8f2,
-	0x3666: 0x8f3,
-	0x3667: 0x8f4,
-	0x3668: 0x8f5,
-	0x3669: 0x8f6,
-	0x366a: 0x8f7,
-	0x366b: 0x8f8,
-	0x366c: 0x8f9,
-	0x366d: 0x8fa,
-	0x366e: 0x8fb,
-	0x366f: 0x8fc,
-	0x3677: 0x8fd,
-	0x3678: 0x8fe,
-	0x3679: 0x8ff,
-	0x367a: 0x900,
-	0x367b: 0x901,
-	0x367c: 0x902,
-	0x367d: 0x903,
-	0x367e: 0x904,
-	0x367f: 0x905,
-	0x3688: 0x906,
-	0x3689: 0x907,
-	0x368a: 0x908,
-	0x368b: 0x909,
-	0x368c: 0x90a,
-	0x368d: 0x90b,
-	0x368e: 0x90c,
-	0x368f: 0x90d,
-	0x3699: 0x90e,
-	0x369a: 0x90f,
-	0x369b: 0x910,
-	0x369c: 0x911,
-	0x369d: 0x912,
-	0x369e: 0x913,
-	0x369f: 0x914,
-	0x36aa: 0x915,
-	0x36ab: 0x916,
-	0x36ac: 0x917,
-	0x36ad: 0x918,
-	0x36ae: 0x919,
-	0x36af: 0x91a,
-	0x36bb: 0x91b,
-	0x36bc: 0x91c,
-	0x36bd: 0x91d,
-	0x36be: 0x91e,
-	0x36bf: 0x91f,
-	0x36cc: 0x920,
-	0x36cd: 0x921,
-	0x36ce: 0x922,
-	0x36cf: 0x923,
-	0x36dd: 0x924,
-	0x36de: 0x925,
-	0x36df: 0x926,
-	0x36ee: 0x927,
-	0x36ef: 0x928,
-	0x36ff: 0x929,
-	0x3777: 0x92a,
-	0x3778: 0x92b,
-	0x3779: 0x92c,
-	0x377a: 0x92d,
-	0x377b: 0x92e,
-	0x377c: 0x92f,
-	0x377d: 0x930,
-	0x377e: 0x931,
-	0x377f: 0x932,
-	0x3788: 0x933,
-	0x3789: 0x934,
-	0x378a: 0x935,
-	0x378b: 0x936,
-	0x378c: 0x937,
-	0x378d: 0x938,
-	0x378e: 0x939,
-	0x378f: 0x93a,
-	0x3799: 0x93b,
-	0x379a: 0x93c,
-	0x379b: 0x93d,
-	0x379c: 0x93e,
-	0x379d: 0x93f,
-	0x379e: 0x940,
-	0x379f: 0x941,
-	0x37aa: 0x942,
-	0x37ab: 0x943,
-	0x37ac: 0x944,
-	0x37ad: 0x945,
-	0x37ae: 0x946,
-	0x37af: 0x947,
-	0x37bb: 0x948,
-	0x37bc: 0x949,
-	0x37bd: 0x94a,
-	0x37be: 0x94b,
-	0x37bf: 0x94c,
-	0x37cc: 0x94d,
-	0x37cd: 0x94e,
-	0x37ce: 0x94f,
-	0x37cf: 0x950,
-	0x37dd: 0x951,
-	0x37de: 0x952,
-	0x37df: 0x953,
-	0x37ee: 0x954,
-	0x37ef: 0x955,
-	0x37ff: 0x956,
-	0x3888: 0x957,
-	0x3889: 0x958,
-	0x388a: 0x959,
-	0x388b: 0x95a,
-	0x388c: 0x95b,
-	0x388d: 0x95c,
-	0x388e: 0x95d,
-	0x388f: 0x95e,
-	0x3899: 0x95f,
-	0x389a: 0x960,
-	0x389b: 0x961,
-	0x389c: 0x962,
-	0x389d: 0x963,
-	0x389e: 0x964,
-	0x389f: 0x965,
-	0x38aa: 0x966,
-	0x38ab: 0x967,
-	0x38ac: 0x968,
-	0x38ad: 0x969,
-	0x38ae: 0x96a,
-	0x38af: 0x96b,
-	0x38bb: 0x96c,
-	0x38bc: 0x96d,
-	0x38bd: 0x96e,
-	0x38be: 0x96f,
-	0x38bf: 0x970,
-	0x38cc: 0x971,
-	0x38cd: 0x972,
-	0x38ce: 0x973,
-	0x38cf: 0x974,
-	0x38dd: 0x975,
-	0x38de: 0x976,
-	0x38df: 0x977,
-	0x38ee: 0x978,
-	0x38ef: 0x979,
-	0x38ff: 0x97a,
-	0x3999: 0x97b,
-	0x399a: 0x97c,
-	0x399b: 0x97d,
-	0x399c: 0x97e,
-	0x399d: 0x97f,
-	0x399e: 0x980,
-	0x399f: 0x981,
-	0x39aa: 0x982,
-	0x39ab: 0x983,
-	0x39ac: 0x984,
-	0x39ad: 0x985,
-	0x39ae: 0x986,
-	0x39af: 0x987,
-	0x39bb: 0x988,
-	0x39bc: 0x989,
-	0x39bd: 0x98a,
-	0x39be: 0x98b,
-	0x39bf: 0x98c,
-	0x39cc: 0x98d,
-	0x39cd: 0x98e,
-	0x39ce: 0x98f,
-	0x39cf: 0x990,
-	0x39dd: 0x991,
-	0x39de: 0x992,
-	0x39df: 0x993,
-	0x39ee: 0x994,
-	0x39ef: 0x995,
-	0x39ff: 0x996,
-	0x3aaa: 0x997,
-	0x3aab: 0x998,
-	0x3aac: 0x999,
-	0x3aad: 0x99a,
-	0x3aae: 0x99b,
-	0x3aaf: 0x99c,
-	0x3abb: 0x99d,
-	0x3abc: 0x99e,
-	0x3abd: 0x99f,
-	0x3abe: 0x9a0,
-	0x3abf: 0x9a1,
-	0x3acc: 0x9a2,
-	0x3acd: 0x9a3,
-	0x3ace: 0x9a4,
-	0x3acf: 0x9a5,
-	0x3add: 0x9a6,
-	0x3ade: 0x9a7,
-	0x3adf: 0x9a8,
-	0x3aee: 0x9a9,
-	0x3aef: 0x9aa,
-	0x3aff: 0x9ab,
-	0x3bbb: 0x9ac,
-	0x3bbc: 0x9ad,
-	0x3bbd: 0x9ae,
-	0x3bbe: 0x9af,
-	0x3bbf: 0x9b0,
-	0x3bcc: 0x9b1,
-	0x3bcd: 0x9b2,
-	0x3bce: 0x9b3,
-	0x3bcf: 0x9b4,
-	0x3bdd: 0x9b5,
-	0x3bde: 0x9b6,
-	0x3bdf: 0x9b7,
-	0x3bee: 0x9b8,
-	0x3bef: 0x9b9,
-	0x3bff: 0x9ba,
-	0x3ccc: 0x9bb,
-	0x3ccd: 0x9bc,
-	0x3cce: 0x9bd,
-	0x3ccf: 0x9be,
-	0x3cdd: 0x9bf,
-	0x3cde: 0x9c0,
-	0x3cdf: 0x9c1,
-	0x3cee: 0x9c2,
-	0x3cef: 0x9c3,
-	0x3cff: 0x9c4,
-	0x3ddd: 0x9c5,
-	0x3dde: 0x9c6,
-	0x3ddf: 0x9c7,
-	0x3dee: 0x9c8,
-	0x3def: 0x9c9,
-	0x3dff: 0x9ca,
-	0x3eee: 0x9cb,
-	0x3eef: 0x9cc,
-	0x3eff: 0x9cd,
-	0x3fff: 0x9ce,
-	0x4444: 0x9cf,
-	0x4445: 0x9d0,
-	0x4446: 0x9d1,
-	0x4447: 0x9d2,
-	0x4448: 0x9d3,
-	0x4449: 0x9d4,
-	0x444a: 0x9d5,
-	0x444b: 0x9d6,
-	0x444c: 0x9d7,
-	0x444d: 0x9d8,
-	0x444e: 0x9d9,
-	0x444f: 0x9da,
-	0x4455: 0x9db,
-	0x4456: 0x9dc,
-	0x4457: 0x9dd,
-	0x4458: 0x9de,
-	0x4459: 0x9df,
-	0x445a: 0x9e0,
-	0x445b: 0x9e1,
-	0x445c: 0x9e2,
-	0x445d: 0x9e3,
-	0x445e: 0x9e4,
-	0x445f: 0x9e5,
-	0x4466: 0x9e6,
-	0x4467: 0x9e7,
-	0x4468: 0x9e8,
-	0x4469: 0x9e9,
-	0x446a: 0x9ea,
-	0x446b: 0x9eb,
-	0x446c: 0x9ec,
-	0x446d: 0x9ed,
-	0x446e: 0x9ee,
-	0x446f: 0x9ef,
-	0x4477: 0x9f0,
-	0x4478: 0x9f1,
-	0x4479: 0x9f2,
-	0x447a: 0x9f3,
-	0x447b: 0x9f4,
-	0x447c: 0x9f5,
-	0x447d: 0x9f6,
-	0x447e: 0x9f7,
-	0x447f: 0x9f8,
-	0x4488: 0x9f9,
-	0x4489: 0x9fa,
-	0x448a: 0x9fb,
-	0x448b: 0x9fc,
-	0x448c: 0x9fd,
-	0x448d: 0x9fe,
-	0x448e: 0x9ff,
-	0x448f: 0xa00,
-	0x4499: 0xa01,
-	0x449a: 0xa02,
-	0x449b: 0xa03,
-	0x449c: 0xa04,
-	0x449d: 0xa05,
-	0x449e: 0xa06,
-	0x449f: 0xa07,
-	0x44aa: 0xa08,
-	0x44ab: 0xa09,
-	0x44ac: 0xa0a,
-	0x44ad: 0xa0b,
-	0x44ae: 0xa0c,
-	0x44af: 0xa0d,
-	0x44bb: 0xa0e,
-	0x44bc: 0xa0f,
-	0x44bd: 0xa10,
-	0x44be: 0xa11,
-	0x44bf: 0xa12,
-	0x44cc: 0xa13,
-	0x44cd: 0xa14,
-	0x44ce: 0xa15,
-	0x44cf: 0xa16,
-	0x44dd: 0xa17,
-	0x44de: 0xa18,
-	0x44df: 0xa19,
-	0x44ee: 0xa1a,
-	0x44ef: 0xa1b,
-	0x44ff: 0xa1c,
-	0x4555: 0xa1d,
-	0x4556: 0xa1e,
-	0x4557: 0xa1f,
-	0x4558: 0xa20,
-	0x4559: 0xa21,
-	0x455a: 0xa22,
-	0x455b: 0xa23,
-	0x455c: 0xa24,
-	0x455d: 0xa25,
-	0x455e: 0xa26,
-	0x455f: 0xa27,
-	0x4566: 0xa28,
-	0x4567: 0xa29,
-	0x4568: 0xa2a,
-	0x4569: 0xa2b,
-	0x456a: 0xa2c,
-	0x456b: 0xa2d,
-	0x456c: 0xa2e,
-	0x456d: 0xa2f,
-	0x456e: 0xa30,
-	0x456f: 0xa31,
-	0x4577: 0xa32,
-	0x4578: 0xa33,
-	0x4579: 0xa34,
-	0x457a: 0xa35,
-	0x457b: 0xa36,
-	0x457c: 0xa37,
-	0x457d: 0xa38,
-	0x457e: 0xa39,
-	0x457f: 0xa3a,
-	0x4588: 0xa3b,
-	0x4589: 0xa3c,
-	0x458a: 0xa3d,
-	0x458b: 0xa3e,
-	0x458c: 0xa3f,
-	0x458d: 0xa40,
-	0x458e: 0xa41,
-	0x458f: 0xa42,
-	0x4599: 0xa43,
-	0x459a: 0xa44,
-	0x459b: 0xa45,
-	0x459c: 0xa46,
-	0x459d: 0xa47,
-	0x459e: 0xa48,
-	0x459f: 0xa49,
-	0x45aa: 0xa4a,
-	0x45ab: 0xa4b,
-	0x45ac: 0xa4c,
-	0x45ad: 0xa4d,
-	0x45ae: 0xa4e,
-	0x45af: 0xa4f,
-	0x45bb: 0xa50,
-	0x45bc: 0xa51,
-	0x45bd: 0xa52,
-	0x45be: 0xa53,
-	0x45bf: 0xa54,
-	0x45cc: 0xa55,
-	0x45cd: 0xa56,
-	0x45ce: 0xa57,
-	0x45cf: 0xa58,
-	0x45dd: 0xa59,
-	0x45de: 0xa5a,
-	0x45df: 0xa5b,
-	0x45ee: 0xa5c,
-	0x45ef: 0xa5d,
-	0x45ff: 0xa5e,
-	0x4666: 0xa5f,
-	0x4667: 0xa60,
-	0x4668: 0xa61,
-	0x4669: 0xa62,
-	0x466a: 0xa63,
-	0x466b: 0xa64,
-	0x466c: 0xa65,
-	0x466d: 0xa66,
-	0x466e: 0xa67,
-	0x466f: 0xa68,
-	0x4677: 0xa69,
-	0x4678: 0xa6a,
-	0x4679: 0xa6b,
-	0x467a: 0xa6c,
-	0x467b: 0xa6d,
-	0x467c: 0xa6e,
-	0x467d: 0xa6f,
-	0x467e: 0xa70,
-	0x467f: 0xa71,
-	0x4688: 0xa72,
-	0x4689: 0xa73,
-	0x468a: 0xa74,
-	0x468b: 0xa75,
-	0x468c: 0xa76,
-	0x468d: 0xa77,
-	0x468e: 0xa78,
-	0x468f: 0xa79,
-	0x4699: 0xa7a,
-	0x469a: 0xa7b,
-	0x469b: 0xa7c,
-	0x469c: 0xa7d,
-	0x469d: 0xa7e,
-	0x469e: 0xa7f,
-	0x469f: 0xa80,
-	0x46aa: 0xa81,
-	0x46ab: 0xa82,
-	0x46ac: 0xa83,
-	0x46ad: 0xa84,
-	0x46ae: 0xa85,
-	0x46af: 0xa86,
-	0x46bb: 0xa87,
-	0x46bc: 0xa88,
-	0x46bd: 0xa89,
-	0x46be: 0xa8a,
-	0x46bf: 0xa8b,
-	0x46cc: 0xa8c,
-	0x46cd: 0xa8d,
-	0x46ce: 0xa8e,
-	0x46cf: 0xa8f,
-	0x46dd: 0xa90,
-	0x46de: 0xa91,
-	0x46df: 0xa92,
-	0x46ee: 0xa93,
-	0x46ef: 0xa94,
-	0x46ff: 0xa95,
-	0x4777: 0xa96,
-	0x4778: 0xa97,
-	0x4779: 0xa98,
-	0x477a: 0xa99,
-	0x477b: 0xa9a,
-	0x477c: 0xa9b,
-	0x477d: 0xa9c,
-	0x477e: 0xa9d,
-	0x477f: 0xa9e,
-	0x4788: 0xa9f,
-	0x4789: 0xaa0,
-	0x478a: 0xaa1,
-	0x478b: 0xaa2,
-	0x478c: 0xaa3,
-	0x478d: 0xaa4,
-	0x478e: 0xaa5,
-	0x478f: 0xaa6,
-	0x4799: 0xaa7,
-	0x479a: 0xaa8,
-	0x479b: 0xaa9,
-	0x479c: 0xaaa,
-	0x479d: 0xaab,
-	0x479e: 0xaac,
-	0x479f: 0xaad,
-	0x47aa: 0xaae,
-	0x47ab: 0xaaf,
-	0x47ac: 0xab0,
-	0x47ad: 0xab1,
-	0x47ae: 0xab2,
-	0x47af: 0xab3,
-	0x47bb: 0xab4,
-	0x47bc: 0xab5,
-	0x47bd: 0xab6,
-	0x47be: 0xab7,
-	0x47bf: 0xab8,
-	0x47cc: 0xab9,
-	0x47cd: 0xaba,
-	0x47ce: 0xabb,
-	0x47cf: 0xabc,
-	0x47dd: 0xabd,
-	0x47de: 0xabe,
-	0x47df: 0xabf,
-	0x47ee: 0xac0,
-	0x47ef: 0xac1,
-	0x47ff: 0xac2,
-	0x4888: 0xac3,
-	0x4889: 0xac4,
-	0x488a: 0xac5,
-	0x488b: 0xac6,
-	0x488c: 0xac7,
-	0x488d: 0xac8,
-	0x488e: 0xac9,
-	0x488f: 0xaca,
-	0x4899: 0xacb,
-	0x489a: 0xacc,
-	0x489b: 0xacd,
-	0x489c: 0xace,
-	0x489d: 0xacf,
-	0x489e: 0xad0,
-	0x489f: 0xad1,
-	0x48aa: 0xad2,
-	0x48ab: 0xad3,
-	0x48ac: 0xad4,
-	0x48ad: 0xad5,
-	0x48ae: 0xad6,
-	0x48af: 0xad7,
-	0x48bb: 0xad8,
-	0x48bc: 0xad9,
-	0x48bd: 0xada,
-	0x48be: 0xadb,
-	0x48bf: 0xadc,
-	0x48cc: 0xadd,
-	0x48cd: 0xade,
-	0x48ce: 0xadf,
-	0x48cf: 0xae0,
-	0x48dd: 0xae1,
-	0x48de: 0xae2,
-	0x48df: 0xae3,
-	0x48ee: 0xae4,
-	0x48ef: 0xae5,
-	0x48ff: 0xae6,
-	0x4999: 0xae7,
-	0x499a: 0xae8,
-	0x499b: 0xae9,
-	0x499c: 0xaea,
-	0x499d: 0xaeb,
-	0x499e: 0xaec,
-	0x499f: 0xaed,
-	0x49aa: 0xaee,
-	0x49ab: 0xaef,
-	0x49ac: 0xaf0,
-	0x49ad: 0xaf1,
-	0x49ae: 0xaf2,
-	0x49af: 0xaf3,
-	0x49bb: 0xaf4,
-	0x49bc: 0xaf5,
-	0x49bd: 0xaf6,
-	0x49be: 0xaf7,
-	0x49bf: 0xaf8,
-	0x49cc: 0xaf9,
-	0x49cd: 0xafa,
-	0x49ce: 0xafb,
-	0x49cf: 0xafc,
-	0x49dd: 0xafd,
-	0x49de: 0xafe,
-	0x49df: 0xaff,
-	0x49ee: 0xb00,
-	0x49ef: 0xb01,
-	0x49ff: 0xb02,
-	0x4aaa: 0xb03,
-	0x4aab: 0xb04,
-	0x4aac: 0xb05,
-	0x4aad: 0xb06,
-	0x4aae: 0xb07,
-	0x4aaf: 0xb08,
-	0x4abb: 0xb09,
-	0x4abc: 0xb0a,
-	0x4abd: 0xb0b,
-	0x4abe: 0xb0c,
-	0x4abf: 0xb0d,
-	0x4acc: 0xb0e,
-	0x4acd: 0xb0f,
-	0x4ace: 0xb10,
-	0x4acf: 0xb11,
-	0x4add: 0xb12,
-	0x4ade: 0xb13,
-	0x4adf: 0xb14,
-	0x4aee: 0xb15,
-	0x4aef: 0xb16,
-	0x4aff: 0xb17,
-	0x4bbb: 0xb18,
-	0x4bbc: 0xb19,
-	0x4bbd: 0xb1a,
-	0x4bbe: 0xb1b,
-	0x4bbf: 0xb1c,
-	0x4bcc: 0xb1d,
-	0x4bcd: 0xb1e,
-	0x4bce: 0xb1f,
-	0x4bcf: 0xb20,
-	0x4bdd: 0xb21,
-	0x4bde: 0xb22,
-	0x4bdf: 0xb23,
-	0x4bee: 0xb24,
-	0x4bef: 0xb25,
-	0x4bff: 0xb26,
-	0x4ccc: 0xb27,
-	0x4ccd: 0xb28,
-	0x4cce: 0xb29,
-	0x4ccf: 0xb2a,
-	0x4cdd: 0xb2b,
-	0x4cde: 0xb2c,
-	0x4cdf: 0xb2d,
-	0x4cee: 0xb2e,
-	0x4cef: 0xb2f,
-	0x4cff: 0xb30,
-	0x4ddd: 0xb31,
-	0x4dde: 0xb32,
-	0x4ddf: 0xb33,
-	0x4dee: 0xb34,
-	0x4def: 0xb35,
-	0x4dff: 0xb36,
-	0x4eee: 0xb37,
-	0x4eef: 0xb38,
-	0x4eff: 0xb39,
-	0x4fff: 0xb3a,
-	0x5555: 0xb3b,
-	0x5556: 0xb3c,
-	0x5557: 0xb3d,
-	0x5558: 0xb3e,
-	0x5559: 0xb3f,
-	0x555a: 0xb40,
-	0x555b: 0xb41,
-	0x555c: 0xb42,
-	0x555d: 0xb43,
-	0x555e: 0xb44,
-	0x555f: 0xb45,
-	0x5566: 0xb46,
-	0x5567: 0xb47,
-	0x5568: 0xb48,
-	0x5569: 0xb49,
-	0x556a: 0xb4a,
-	0x556b: 0xb4b,
-	0x556c: 0xb4c,
-	0x556d: 0xb4d,
-	0x556e: 0xb4e,
-	0x556f: 0xb4f,
-	0x5577: 0xb50,
-	0x5578: 0xb51,
-	0x5579: 0xb52,
-	0x557a: 0xb53,
-	0x557b: 0xb54,
-	0x557c: 0xb55,
-	0x557d: 0xb56,
-	0x557e: 0xb57,
-	0x557f: 0xb58,
-	0x5588: 0xb59,
-	0x5589: 0xb5a,
-	0x558a: 0xb5b,
-	0x558b: 0xb5c,
-	0x558c: 0xb5d,
-	0x558d: 0xb5e,
-	0x558e: 0xb5f,
-	0x558f: 0xb60,
-	0x5599: 0xb61,
-	0x559a: 0xb62,
-	0x559b: 0xb63,
-	0x559c: 0xb64,
-	0x559d: 0xb65,
-	0x559e: 0xb66,
-	0x559f: 0xb67,
-	0x55aa: 0xb68,
-	0x55ab: 0xb69,
-	0x55ac: 0xb6a,
-	0x55ad: 0xb6b,
-	0x55ae: 0xb6c,
-	0x55af: 0xb6d,
-	0x55bb: 0xb6e,
-	0x55bc: 0xb6f,
-	0x55bd: 0xb70,
-	0x55be: 0xb71,
-	0x55bf: 0xb72,
-	0x55cc: 0xb73,
-	0x55cd: 0xb74,
-	0x55ce: 0xb75,
-	0x55cf: 0xb76,
-	0x55dd: 0xb77,
-	0x55de: 0xb78,
-	0x55df: 0xb79,
-	0x55ee: 0xb7a,
-	0x55ef: 0xb7b,
-	0x55ff: 0xb7c,
-	0x5666: 0xb7d,
-	0x5667: 0xb7e,
-	0x5668: 0xb7f,
-	0x5669: 0xb80,
-	0x566a: 0xb81,
-	0x566b: 0xb82,
-	0x566c: 0xb83,
-	0x566d: 0xb84,
-	0x566e: 0xb85,
-	0x566f: 0xb86,
-	0x5677: 0xb87,
-	0x5678: 0xb88,
-	0x5679: 0xb89,
-	0x567a: 0xb8a,
-	0x567b: 0xb8b,
-	0x567c: 0xb8c,
-	0x567d: 0xb8d,
-	0x567e: 0xb8e,
-	0x567f: 0xb8f,
-	0x5688: 0xb90,
-	0x5689: 0xb91,
-	0x568a: 0xb92,
-	0x568b: 0xb93,
-	0x568c: 0xb94,
-	0x568d: 0xb95,
-	0x568e: 0xb96,
-	0x568f: 0xb97,
-	0x5699: 0xb98,
-	0x569a: 0xb99,
-	0x569b: 0xb9a,
-	0x569c: 0xb9b,
-	0x569d: 0xb9c,
-	0x569e: 0xb9d,
-	0x569f: 0xb9e,
-	0x56aa: 0xb9f,
-	0x56ab: 0xba0,
-	0x56ac: 0xba1,
-	0x56ad: 0xba2,
-	0x56ae: 0xba3,
-	0x56af: 0xba4,
-	0x56bb: 0xba5,
-	0x56bc: 0xba6,
-	0x56bd: 0xba7,
-	0x56be: 0xba8,
-	0x56bf: 0xba9,
-	0x56cc: 0xbaa,
-	0x56cd: 0xbab,
-	0x56ce: 0xbac,
-	0x56cf: 0xbad,
-	0x56dd: 0xbae,
-	0x56de: 0xbaf,
-	0x56df: 0xbb0,
-	0x56ee: 0xbb1,
-	0x56ef: 0xbb2,
-	0x56ff: 0xbb3,
-	0x5777: 0xbb4,
-	0x5778: 0xbb5,
-	0x5779: 0xbb6,
-	0x577a: 0xbb7,
-	0x577b: 0xbb8,
-	0x577c: 0xbb9,
-	0x577d: 0xbba,
-	0x577e: 0xbbb,
-	0x577f: 0xbbc,
-	0x5788: 0xbbd,
-	0x5789: 0xbbe,
-	0x578a: 0xbbf,
-	0x578b: 0xbc0,
-	0x578c: 0xbc1,
-	0x578d: 0xbc2,
-	0x578e: 0xbc3,
-	0x578f: 0xbc4,
-	0x5799: 0xbc5,
-	0x579a: 0xbc6,
-	0x579b: 0xbc7,
-	0x579c: 0xbc8,
-	0x579d: 0xbc9,
-	0x579e: 0xbca,
-	0x579f: 0xbcb,
-	0x57aa: 0xbcc,
-	0x57ab: 0xbcd,
-	0x57ac: 0xbce,
-	0x57ad: 0xbcf,
-	0x57ae: 0xbd0,
-	0x57af: 0xbd1,
-	0x57bb: 0xbd2,
-	0x57bc: 0xbd3,
-	0x57bd: 0xbd4,
-	0x57be: 0xbd5,
-	0x57bf: 0xbd6,
-	0x57cc: 0xbd7,
-	0x57cd: 0xbd8,
-	0x57ce: 0xbd9,
-	0x57cf: 0xbda,
-	0x57dd: 0xbdb,
-	0x57de: 0xbdc,
-	0x57df: 0xbdd,
-	0x57ee: 0xbde,
-	0x57ef: 0xbdf,
-	0x57ff: 0xbe0,
-	0x5888: 0xbe1,
-	0x5889: 0xbe2,
-	0x588a: 0xbe3,
-	0x588b: 0xbe4,
-	0x588c: 0xbe5,
-	0x588d: 0xbe6,
-	0x588e: 0xbe7,
-	0x588f: 0xbe8,
-	0x5899: 0xbe9,
-	0x589a: 0xbea,
-	0x589b: 0xbeb,
-	0x589c: 0xbec,
-	0x589d: 0xbed,
-	0x589e: 0xbee,
-	0x589f: 0xbef,
-	0x58aa: 0xbf0,
-	0x58ab: 0xbf1,
-	0x58ac: 0xbf2,
-	0x58ad: 0xbf3,
-	0x58ae: 0xbf4,
-	0x58af: 0xbf5,
-	0x58bb: 0xbf6,
-	0x58bc: 0xbf7,
-	0x58bd: 0xbf8,
-	0x58be: 0xbf9,
-	0x58bf: 0xbfa,
-	0x58cc: 0xbfb,
-	0x58cd: 0xbfc,
-	0x58ce: 0xbfd,
-	0x58cf: 0xbfe,
-	0x58dd: 0xbff,
-	0x58de: 0xc00,
-	0x58df: 0xc01,
-	0x58ee: 0xc02,
-	0x58ef: 0xc03,
-	0x58ff: 0xc04,
-	0x5999: 0xc05,
-	0x599a: 0xc06,
-	0x599b: 0xc07,
-	0x599c: 0xc08,
-	0x599d: 0xc09,
-	0x599e: 0xc0a,
-	0x599f: 0xc0b,
-	0x59aa: 0xc0c,
-	0x59ab: 0xc0d,
-	0x59ac: 0xc0e,
-	0x59ad: 0xc0f,
-	0x59ae: 0xc10,
-	0x59af: 0xc11,
-	0x59bb: 0xc12,
-	0x59bc: 0xc13,
-	0x59bd: 0xc14,
-	0x59be: 0xc15,
-	0x59bf: 0xc16,
-	0x59cc: 0xc17,
-	0x59cd: 0xc18,
-	0x59ce: 0xc19,
-	0x59cf: 0xc1a,
-	0x59dd: 0xc1b,
-	0x59de: 0xc1c,
-	0x59df: 0xc1d,
-	0x59ee: 0xc1e,
-	0x59ef: 0xc1f,
-	0x59ff: 0xc20,
-	0x5aaa: 0xc21,
-	0x5aab: 0xc22,
-	0x5aac: 0xc23,
-	0x5aad: 0xc24,
-	0x5aae: 0xc25,
-	0x5aaf: 0xc26,
-	0x5abb: 0xc27,
-	0x5abc: 0xc28,
-	0x5abd: 0xc29,
-	0x5abe: 0xc2a,
-	0x5abf: 0xc2b,
-	0x5acc: 0xc2c,
-	0x5acd: 0xc2d,
-	0x5ace: 0xc2e,
-	0x5acf: 0xc2f,
-	0x5add: 0xc30,
-	0x5ade: 0xc31,
-	0x5adf: 0xc32,
-	0x5aee: 0xc33,
-	0x5aef: 0xc34,
-	0x5aff: 0xc35,
-	0x5bbb: 0xc36,
-	0x5bbc: 0xc37,
-	0x5bbd: 0xc38,
-	0x5bbe: 0xc39,
-	0x5bbf: 0xc3a,
-	0x5bcc: 0xc3b,
-	0x5bcd: 0xc3c,
-	0x5bce: 0xc3d,
-	0x5bcf: 0xc3e,
-	0x5bdd: 0xc3f,
-	0x5bde: 0xc40,
-	0x5bdf: 0xc41,
-	0x5bee: 0xc42,
-	0x5bef: 0xc43,
-	0x5bff: 0xc44,
-	0x5ccc: 0xc45,
-	0x5ccd: 0xc46,
-	0x5cce: 0xc47,
-	0x5ccf: 0xc48,
-	0x5cdd: 0xc49,
-	0x5cde: 0xc4a,
-	0x5cdf: 0xc4b,
-	0x5cee: 0xc4c,
-	0x5cef: 0xc4d,
-	0x5cff: 0xc4e,
-	0x5ddd: 0xc4f,
-	0x5dde: 0xc50,
-	0x5ddf: 0xc51,
-	0x5dee: 0xc52,
-	0x5def: 0xc53,
-	0x5dff: 0xc54,
-	0x5eee: 0xc55,
-	0x5eef: 0xc56,
-	0x5eff: 0xc57,
-	0x5fff: 0xc58,
-	0x6666: 0xc59,
-	0x6667: 0xc5a,
-	0x6668: 0xc5b,
-	0x6669: 0xc5c,
-	0x666a: 0xc5d,
-	0x666b: 0xc5e,
-	0x666c: 0xc5f,
-	0x666d: 0xc60,
-	0x666e: 0xc61,
-	0x666f: 0xc62,
-	0x6677: 0xc63,
-	0x6678: 0xc64,
-	0x6679: 0xc65,
-	0x667a: 0xc66,
-	0x667b: 0xc67,
-	0x667c: 0xc68,
-	0x667d: 0xc69,
-	0x667e: 0xc6a,
-	0x667f: 0xc6b,
-	0x6688: 0xc6c,
-	0x6689: 0xc6d,
-	0x668a: 0xc6e,
-	0x668b: 0xc6f,
-	0x668c: 0xc70,
-	0x668d: 0xc71,
-	0x668e: 0xc72,
-	0x668f: 0xc73,
-	0x6699: 0xc74,
-	0x669a: 0xc75,
-	0x669b: 0xc76,
-	0x669c: 0xc77,
-	0x669d: 0xc78,
-	0x669e: 0xc79,
-	0x669f: 0xc7a,
-	0x66aa: 0xc7b,
-	0x66ab: 0xc7c,
-	0x66ac: 0xc7d,
-	0x66ad: 0xc7e,
-	0x66ae: 0xc7f,
-	0x66af: 0xc80,
-	0x66bb: 0xc81,
-	0x66bc: 0xc82,
-	0x66bd: 0xc83,
-	0x66be: 0xc84,
-	0x66bf: 0xc85,
-	0x66cc: 0xc86,
-	0x66cd: 0xc87,
-	0x66ce: 0xc88,
-	0x66cf: 0xc89,
-	0x66dd: 0xc8a,
-	0x66de: 0xc8b,
-	0x66df: 0xc8c,
-	0x66ee: 0xc8d,
-	0x66ef: 0xc8e,
-	0x66ff: 0xc8f,
-	0x6777: 0xc90,
-	0x6778: 0xc91,
-	0x6779: 0xc92,
-	0x677a: 0xc93,
-	0x677b: 0xc94,
-	0x677c: 0xc95,
-	0x677d: 0xc96,
-	0x677e: 0xc97,
-	0x677f: 0xc98,
-	0x6788: 0xc99,
-	0x6789: 0xc9a,
-	0x678a: 0xc9b,
-	0x678b: 0xc9c,
-	0x678c: 0xc9d,
-	0x678d: 0xc9e,
-	0x678e: 0xc9f,
-	0x678f: 0xca0,
-	0x6799: 0xca1,
-	0x679a: 0xca2,
-	0x679b: 0xca3,
-	0x679c: 0xca4,
-	0x679d: 0xca5,
-	0x679e: 0xca6,
-	0x679f: 0xca7,
-	0x67aa: 0xca8,
-	0x67ab: 0xca9,
-	0x67ac: 0xcaa,
-	0x67ad: 0xcab,
-	0x67ae: 0xcac,
-	0x67af: 0xcad,
-	0x67bb: 0xcae,
-	0x67bc: 0xcaf,
-	0x67bd: 0xcb0,
-	0x67be: 0xcb1,
-	0x67bf: 0xcb2,
-	0x67cc: 0xcb3,
-	0x67cd: 0xcb4,
-	0x67ce: 0xcb5,
-	0x67cf: 0xcb6,
-	0x67dd: 0xcb7,
-	0x67de: 0xcb8,
-	0x67df: 0xcb9,
-	0x67ee: 0xcba,
-	0x67ef: 0xcbb,
-	0x67ff: 0xcbc,
-	0x6888: 0xcbd,
-	0x6889: 0xcbe,
-	0x688a: 0xcbf,
-	0x688b: 0xcc0,
-	0x688c: 0xcc1,
-	0x688d: 0xcc2,
-	0x688e: 0xcc3,
-	0x688f: 0xcc4,
-	0x6899: 0xcc5,
-	0x689a: 0xcc6,
-	0x689b: 0xcc7,
-	0x689c: 0xcc8,
-	0x689d: 0xcc9,
-	0x689e: 0xcca,
-	0x689f: 0xccb,
-	0x68aa: 0xccc,
-	0x68ab: 0xccd,
-	0x68ac: 0xcce,
-	0x68ad: 0xccf,
-	0x68ae: 0xcd0,
-	0x68af: 0xcd1,
-	0x68bb: 0xcd2,
-	0x68bc: 0xcd3,
-	0x68bd: 0xcd4,
-	0x68be: 0xcd5,
-	0x68bf: 0xcd6,
-	0x68cc: 0xcd7,
-	0x68cd: 0xcd8,
-	0x68ce: 0xcd9,
-	0x68cf: 0xcda,
-	0x68dd: 0xcdb,
-	0x68de: 0xcdc,
-	0x68df: 0xcdd,
-	0x68ee: 0xcde,
-	0x68ef: 0xcdf,
-	0x68ff: 0xce0,
-	0x6999: 0xce1,
-	0x699a: 0xce2,
-	0x699b: 0xce3,
-	0x699c: 0xce4,
-	0x699d: 0xce5,
-	0x699e: 0xce6,
-	0x699f: 0xce7,
-	0x69aa: 0xce8,
-	0x69ab: 0xce9,
-	0x69ac: 0xcea,
-	0x69ad: 0xceb,
-	0x69ae: 0xcec,
-	0x69af: 0xced,
-	0x69bb: 0xcee,
-	0x69bc: 0xcef,
-	0x69bd: 0xcf0,
-	0x69be: 0xcf1,
-	0x69bf: 0xcf2,
-	0x69cc: 0xcf3,
-	0x69cd: 0xcf4,
-	0x69ce: 0xcf5,
-	0x69cf: 0xcf6,
-	0x69dd: 0xcf7,
-	0x69de: 0xcf8,
-	0x69df: 0xcf9,
-	0x69ee: 0xcfa,
-	0x69ef: 0xcfb,
-	0x69ff: 0xcfc,
-	0x6aaa: 0xcfd,
-	0x6aab: 0xcfe,
-	0x6aac: 0xcff,
-	0x6aad: 0xd00,
-	0x6aae: 0xd01,
-	0x6aaf: 0xd02,
-	0x6abb: 0xd03,
-	0x6abc: 0xd04,
-	0x6abd: 0xd05,
-	0x6abe: 0xd06,
-	0x6abf: 0xd07,
-	0x6acc: 0xd08,
-	0x6acd: 0xd09,
-	0x6ace: 0xd0a,
-	0x6acf: 0xd0b,
-	0x6add: 0xd0c,
-	0x6ade: 0xd0d,
-	0x6adf: 0xd0e,
-	0x6aee: 0xd0f,
-	0x6aef: 0xd10,
-	0x6aff: 0xd11,
-	0x6bbb: 0xd12,
-	0x6bbc: 0xd13,
-	0x6bbd: 0xd14,
-	0x6bbe: 0xd15,
-	0x6bbf: 0xd16,
-	0x6bcc: 0xd17,
-	0x6bcd: 0xd18,
-	0x6bce: 0xd19,
-	0x6bcf: 0xd1a,
-	0x6bdd: 0xd1b,
-	0x6bde: 0xd1c,
-	0x6bdf: 0xd1d,
-	0x6bee: 0xd1e,
-	0x6bef: 0xd1f,
-	0x6bff: 0xd20,
-	0x6ccc: 0xd21,
-	0x6ccd: 0xd22,
-	0x6cce: 0xd23,
-	0x6ccf: 0xd24,
-	0x6cdd: 0xd25,
-	0x6cde: 0xd26,
-	0x6cdf: 0xd27,
-	0x6cee: 0xd28,
-	0x6cef: 0xd29,
-	0x6cff: 0xd2a,
-	0x6ddd: 0xd2b,
-	0x6dde: 0xd2c,
-	0x6ddf: 0xd2d,
-	0x6dee: 0xd2e,
-	0x6def: 0xd2f,
-	0x6dff: 0xd30,
-	0x6eee: 0xd31,
-	0x6eef: 0xd32,
-	0x6eff: 0xd33,
-	0x6fff: 0xd34,
-	0x7777: 0xd35,
-	0x7778: 0xd36,
-	0x7779: 0xd37,
-	0x777a: 0xd38,
-	0x777b: 0xd39,
-	0x777c: 0xd3a,
-	0x777d: 0xd3b,
-	0x777e: 0xd3c,
-	0x777f: 0xd3d,
-	0x7788: 0xd3e,
-	0x7789: 0xd3f,
-	0x778a: 0xd40,
-	0x778b: 0xd41,
-	0x778c: 0xd42,
-	0x778d: 0xd43,
-	0x778e: 0xd44,
-	0x778f: 0xd45,
-	0x7799: 0xd46,
-	0x779a: 0xd47,
-	0x779b: 0xd48,
-	0x779c: 0xd49,
-	0x779d: 0xd4a,
-	0x779e: 0xd4b,
-	0x779f: 0xd4c,
-	0x77aa: 0xd4d,
-	0x77ab: 0xd4e,
-	0x77ac: 0xd4f,
-	0x77ad: 0xd50,
-	0x77ae: 0xd51,
-	0x77af: 0xd52,
-	0x77bb: 0xd53,
-	0x77bc: 0xd54,
-	0x77bd: 0xd55,
-	0x77be: 0xd56,
-	0x77bf: 0xd57,
-	0x77cc: 0xd58,
-	0x77cd: 0xd59,
-	0x77ce: 0xd5a,
-	0x77cf: 0xd5b,
-	0x77dd: 0xd5c,
-	0x77de: 0xd5d,
-	0x77df: 0xd5e,
-	0x77ee: 0xd5f,
-	0x77ef: 0xd60,
-	0x77ff: 0xd61,
-	0x7888: 0xd62,
-	0x7889: 0xd63,
-	0x788a: 0xd64,
-	0x788b: 0xd65,
-	0x788c: 0xd66,
-	0x788d: 0xd67,
-	0x788e: 0xd68,
-	0x788f: 0xd69,
-	0x7899: 0xd6a,
-	0x789a: 0xd6b,
-	0x789b: 0xd6c,
-	0x789c: 0xd6d,
-	0x789d: 0xd6e,
-	0x789e: 0xd6f,
-	0x789f: 0xd70,
-	0x78aa: 0xd71,
-	0x78ab: 0xd72,
-	0x78ac: 0xd73,
-	0x78ad: 0xd74,
-	0x78ae: 0xd75,
-	0x78af: 0xd76,
-	0x78bb: 0xd77,
-	0x78bc: 0xd78,
-	0x78bd: 0xd79,
-	0x78be: 0xd7a,
-	0x78bf: 0xd7b,
-	0x78cc: 0xd7c,
-	0x78cd: 0xd7d,
-	0x78ce: 0xd7e,
-	0x78cf: 0xd7f,
-	0x78dd: 0xd80,
-	0x78de: 0xd81,
-	0x78df: 0xd82,
-	0x78ee: 0xd83,
-	0x78ef: 0xd84,
-	0x78ff: 0xd85,
-	0x7999: 0xd86,
-	0x799a: 0xd87,
-	0x799b: 0xd88,
-	0x799c: 0xd89,
-	0x799d: 0xd8a,
-	0x799e: 0xd8b,
-	0x799f: 0xd8c,
-	0x79aa: 0xd8d,
-	0x79ab: 0xd8e,
-	0x79ac: 0xd8f,
-	0x79ad: 0xd90,
-	0x79ae: 0xd91,
-	0x79af: 0xd92,
-	0x79bb: 0xd93,
-	0x79bc: 0xd94,
-	0x79bd: 0xd95,
-	0x79be: 0xd96,
-	0x79bf: 0xd97,
-	0x79cc: 0xd98,
-	0x79cd: 0xd99,
-	0x79ce: 0xd9a,
-	0x79cf: 0xd9b,
-	0x79dd: 0xd9c,
-	0x79de: 0xd9d,
-	0x79df: 0xd9e,
-	0x79ee: 0xd9f,
-	0x79ef: 0xda0,
-	0x79ff: 0xda1,
-	0x7aaa: 0xda2,
-	0x7aab: 0xda3,
-	0x7aac: 0xda4,
-	0x7aad: 0xda5,
-	0x7aae: 0xda6,
-	0x7aaf: 0xda7,
-	0x7abb: 0xda8,
-	0x7abc: 0xda9,
-	0x7abd: 0xdaa,
-	0x7abe: 0xdab,
-	0x7abf: 0xdac,
-	0x7acc: 0xdad,
-	0x7acd: 0xdae,
-	0x7ace: 0xdaf,
-	0x7acf: 0xdb0,
-	0x7add: 0xdb1,
-	0x7ade: 0xdb2,
-	0x7adf: 0xdb3,
-	0x7aee: 0xdb4,
-	0x7aef: 0xdb5,
-	0x7aff: 0xdb6,
-	0x7bbb: 0xdb7,
-	0x7bbc: 0xdb8,
-	0x7bbd: 0xdb9,
-	0x7bbe: 0xdba,
-	0x7bbf: 0xdbb,
-	0x7bcc: 0xdbc,
-	0x7bcd: 0xdbd,
-	0x7bce: 0xdbe,
-	0x7bcf: 0xdbf,
-	0x7bdd: 0xdc0,
-	0x7bde: 0xdc1,
-	0x7bdf: 0xdc2,
-	0x7bee: 0xdc3,
-	0x7bef: 0xdc4,
-	0x7bff: 0xdc5,
-	0x7ccc: 0xdc6,
-	0x7ccd: 0xdc7,
-	0x7cce: 0xdc8,
-	0x7ccf: 0xdc9,
-	0x7cdd: 0xdca,
-	0x7cde: 0xdcb,
-	0x7cdf: 0xdcc,
-	0x7cee: 0xdcd,
-	0x7cef: 0xdce,
-	0x7cff: 0xdcf,
-	0x7ddd: 0xdd0,
-	0x7dde: 0xdd1,
-	0x7ddf: 0xdd2,
-	0x7dee: 0xdd3,
-	0x7def: 0xdd4,
-	0x7dff: 0xdd5,
-	0x7eee: 0xdd6,
-	0x7eef: 0xdd7,
-	0x7eff: 0xdd8,
-	0x7fff: 0xdd9,
-	0x8888: 0xdda,
-	0x8889: 0xddb,
-	0x888a: 0xddc,
-	0x888b: 0xddd,
-	0x888c: 0xdde,
-	0x888d: 0xddf,
-	0x888e: 0xde0,
-	0x888f: 0xde1,
-	0x8899: 0xde2,
-	0x889a: 0xde3,
-	0x889b: 0xde4,
-	0x889c: 0xde5,
-	0x889d: 0xde6,
-	0x889e: 0xde7,
-	0x889f: 0xde8,
-	0x88aa: 0xde9,
-	0x88ab: 0xdea,
-	0x88ac: 0xdeb,
-	0x88ad: 0xdec,
-	0x88ae: 0xded,
-	0x88af: 0xdee,
-	0x88bb: 0xdef,
-	0x88bc: 0xdf0,
-	0x88bd: 0xdf1,
-	0x88be: 0xdf2,
-	0x88bf: 0xdf3,
-	0x88cc: 0xdf4,
-	0x88cd: 0xdf5,
-	0x88ce: 0xdf6,
-	0x88cf: 0xdf7,
-	0x88dd: 0xdf8,
-	0x88de: 0xdf9,
-	0x88df: 0xdfa,
-	0x88ee: 0xdfb,
-	0x88ef: 0xdfc,
-	0x88ff: 0xdfd,
-	0x8999: 0xdfe,
-	0x899a: 0xdff,
-	0x899b: 0xe00,
-	0x899c: 0xe01,
-	0x899d: 0xe02,
-	0x899e: 0xe03,
-	0x899f: 0xe04,
-	0x89aa: 0xe05,
-	0x89ab: 0xe06,
-	0x89ac: 0xe07,
-	0x89ad: 0xe08,
-	0x89ae: 0xe09,
-	0x89af: 0xe0a,
-	0x89bb: 0xe0b,
-	0x89bc: 0xe0c,
-	0x89bd: 0xe0d,
-	0x89be: 0xe0e,
-	0x89bf: 0xe0f,
-	0x89cc: 0xe10,
-	0x89cd: 0xe11,
-	0x89ce: 0xe12,
-	0x89cf: 0xe13,
-	0x89dd: 0xe14,
-	0x89de: 0xe15,
-	0x89df: 0xe16,
-	0x89ee: 0xe17,
-	0x89ef: 0xe18,
-	0x89ff: 0xe19,
-	0x8aaa: 0xe1a,
-	0x8aab: 0xe1b,
-	0x8aac: 0xe1c,
-	0x8aad: 0xe1d,
-	0x8aae: 0xe1e,
-	0x8aaf: 0xe1f,
-	0x8abb: 0xe20,
-	0x8abc: 0xe21,
-	0x8abd: 0xe22,
-	0x8abe: 0xe23,
-	0x8abf: 0xe24,
-	0x8acc: 0xe25,
-	0x8acd: 0xe26,
-	0x8ace: 0xe27,
-	0x8acf: 0xe28,
-	0x8add: 0xe29,
-	0x8ade: 0xe2a,
-	0x8adf: 0xe2b,
-	0x8aee: 0xe2c,
-	0x8aef: 0xe2d,
-	0x8aff: 0xe2e,
-	0x8bbb: 0xe2f,
-	0x8bbc: 0xe30,
-	0x8bbd: 0xe31,
-	0x8bbe: 0xe32,
-	0x8bbf: 0xe33,
-	0x8bcc: 0xe34,
-	0x8bcd: 0xe35,
-	0x8bce: 0xe36,
-	0x8bcf: 0xe37,
-	0x8bdd: 0xe38,
-	0x8bde: 0xe39,
-	0x8bdf: 0xe3a,
-	0x8bee: 0xe3b,
-	0x8bef: 0xe3c,
-	0x8bff: 0xe3d,
-	0x8ccc: 0xe3e,
-	0x8ccd: 0xe3f,
-	0x8cce: 0xe40,
-	0x8ccf: 0xe41,
-	0x8cdd: 0xe42,
-	0x8cde: 0xe43,
-	0x8cdf: 0xe44,
-	0x8cee: 0xe45,
-	0x8cef: 0xe46,
-	0x8cff: 0xe47,
-	0x8ddd: 0xe48,
-	0x8dde: 0xe49,
-	0x8ddf: 0xe4a,
-	0x8dee: 0xe4b,
-	0x8def: 0xe4c,
-	0x8dff: 0xe4d,
-	0x8eee: 0xe4e,
-	0x8eef: 0xe4f,
-	0x8eff: 0xe50,
-	0x8fff: 0xe51,
-	0x9999: 0xe52,
-	0x999a: 0xe53,
-	0x999b: 0xe54,
-	0x999c: 0xe55,
-	0x999d: 0xe56,
-	0x999e: 0xe57,
-	0x999f: 0xe58,
-	0x99aa: 0xe59,
-	0x99ab: 0xe5a,
-	0x99ac: 0xe5b,
-	0x99ad: 0xe5c,
-	0x99ae: 0xe5d,
-	0x99af: 0xe5e,
-	0x99bb: 0xe5f,
-	0x99bc: 0xe60,
-	0x99bd: 0xe61,
-	0x99be: 0xe62,
-	0x99bf: 0xe63,
-	0x99cc: 0xe64,
-	0x99cd: 0xe65,
-	0x99ce: 0xe66,
-	0x99cf: 0xe67,
-	0x99dd: 0xe68,
-	0x99de: 0xe69,
-	0x99df: 0xe6a,
-	0x99ee: 0xe6b,
-	0x99ef: 0xe6c,
-	0x99ff: 0xe6d,
-	0x9aaa: 0xe6e,
-	0x9aab: 0xe6f,
-	0x9aac: 0xe70,
-	0x9aad: 0xe71,
-	0x9aae: 0xe72,
-	0x9aaf: 0xe73,
-	0x9abb: 0xe74,
-	0x9abc: 0xe75,
-	0x9abd: 0xe76,
-	0x9abe: 0xe77,
-	0x9abf: 0xe78,
-	0x9acc: 0xe79,
-	0x9acd: 0xe7a,
-	0x9ace: 0xe7b,
-	0x9acf: 0xe7c,
-	0x9add: 0xe7d,
-	0x9ade: 0xe7e,
-	0x9adf: 0xe7f,
-	0x9aee: 0xe80,
-	0x9aef: 0xe81,
-	0x9aff: 0xe82,
-	0x9bbb: 0xe83,
-	0x9bbc: 0xe84,
-	0x9bbd: 0xe85,
-	0x9bbe: 0xe86,
-	0x9bbf: 0xe87,
-	0x9bcc: 0xe88,
-	0x9bcd: 0xe89,
-	0x9bce: 0xe8a,
-	0x9bcf: 0xe8b,
-	0x9bdd: 0xe8c,
-	0x9bde: 0xe8d,
-	0x9bdf: 0xe8e,
-	0x9bee: 0xe8f,
-	0x9bef: 0xe90,
-	0x9bff: 0xe91,
-	0x9ccc: 0xe92,
-	0x9ccd: 0xe93,
-	0x9cce: 0xe94,
-	0x9ccf: 0xe95,
-	0x9cdd: 0xe96,
-	0x9cde: 0xe97,
-	0x9cdf: 0xe98,
-	0x9cee: 0xe99,
-	0x9cef: 0xe9a,
-	0x9cff: 0xe9b,
-	0x9ddd: 0xe9c,
-	0x9dde: 0xe9d,
-	0x9ddf: 0xe9e,
-	0x9dee: 0xe9f,
-	0x9def: 0xea0,
-	0x9dff: 0xea1,
-	0x9eee: 0xea2,
-	0x9eef: 0xea3,
-	0x9eff: 0xea4,
-	0x9fff: 0xea5,
-	0xaaaa: 0xea6,
-	0xaaab: 0xea7,
-	0xaaac: 0xea8,
-	0xaaad: 0xea9,
-	0xaaae: 0xeaa,
-	0xaaaf: 0xeab,
-	0xaabb: 0xeac,
-	0xaabc: 0xead,
-	0xaabd: 0xeae,
-	0xaabe: 0xeaf,
-	0xaabf: 0xeb0,
-	0xaacc: 0xeb1,
-	0xaacd: 0xeb2,
-	0xaace: 0xeb3,
-	0xaacf: 0xeb4,
-	0xaadd: 0xeb5,
-	0xaade: 0xeb6,
-	0xaadf: 0xeb7,
-	0xaaee: 0xeb8,
-	0xaaef: 0xeb9,
-	0xaaff: 0xeba,
-	0xabbb: 0xebb,
-	0xabbc: 0xebc,
-	0xabbd: 0xebd,
-	0xabbe: 0xebe,
-	0xabbf: 0xebf,
-	0xabcc: 0xec0,
-	0xabcd: 0xec1,
-	0xabce: 0xec2,
-	0xabcf: 0xec3,
-	0xabdd: 0xec4,
-	0xabde: 0xec5,
-	0xabdf: 0xec6,
-	0xabee: 0xec7,
-	0xabef: 0xec8,
-	0xabff: 0xec9,
-	0xaccc: 0xeca,
-	0xaccd: 0xecb,
-	0xacce: 0xecc,
-	0xaccf: 0xecd,
-	0xacdd: 0xece,
-	0xacde: 0xecf,
-	0xacdf: 0xed0,
-	0xacee: 0xed1,
-	0xacef: 0xed2,
-	0xacff: 0xed3,
-	0xaddd: 0xed4,
-	0xadde: 0xed5,
-	0xaddf: 0xed6,
-	0xadee: 0xed7,
-	0xadef: 0xed8,
-	0xadff: 0xed9,
-	0xaeee: 0xeda,
-	0xaeef: 0xedb,
-	0xaeff: 0xedc,
-	0xafff: 0xedd,
-	0xbbbb: 0xede,
-	0xbbbc: 0xedf,
-	0xbbbd: 0xee0,
-	0xbbbe: 0xee1,
-	0xbbbf: 0xee2,
-	0xbbcc: 0xee3,
-	0xbbcd: 0xee4,
-	0xbbce: 0xee5,
-	0xbbcf: 0xee6,
-	0xbbdd: 0xee7,
-	0xbbde: 0xee8,
-	0xbbdf: 0xee9,
-	0xbbee: 0xeea,
-	0xbbef: 0xeeb,
-	0xbbff: 0xeec,
-	0xbccc: 0xeed,
-	0xbccd: 0xeee,
-	0xbcce: 0xeef,
-	0xbccf: 0xef0,
-	0xbcdd: 0xef1,
-	0xbcde: 0xef2,
-	0xbcdf: 0xef3,
-	0xbcee: 0xef4,
-	0xbcef: 0xef5,
-	0xbcff: 0xef6,
-	0xbddd: 0xef7,
-	0xbdde: 0xef8,
-	0xbddf: 0xef9,
-	0xbdee: 0xefa,
-	0xbdef: 0xefb,
-	0xbdff: 0xefc,
-	0xbeee: 0xefd,
-	0xbeef: 0xefe,
-	0xbeff: 0xeff,
-	0xbfff: 0xf00,
-	0xcccc: 0xf01,
-	0xcccd: 0xf02,
-	0xccce: 0xf03,
-	0xcccf: 0xf04,
-	0xccdd: 0xf05,
-	0xccde: 0xf06,
-	0xccdf: 0xf07,
-	0xccee: 0xf08,
-	0xccef: 0xf09,
-	0xccff: 0xf0a,
-	0xcddd: 0xf0b,
-	0xcdde: 0xf0c,
-	0xcddf: 0xf0d,
-	0xcdee: 0xf0e,
-	0xcdef: 0xf0f,
-	0xcdff: 0xf10,
-	0xceee: 0xf11,
-	0xceef: 0xf12,
-	0xceff: 0xf13,
-	0xcfff: 0xf14,
-	0xdddd: 0xf15,
-	0xddde: 0xf16,
-	0xdddf: 0xf17,
-	0xddee: 0xf18,
-	0xddef: 0xf19,
-	0xddff: 0xf1a,
-	0xdeee: 0xf1b,
-	0xdeef: 0xf1c,
-	0xdeff: 0xf1d,
-	0xdfff: 0xf1e,
-	0xeeee: 0xf1f,
-	0xeeef: 0xf20,
-	0xeeff: 0xf21,
-	0xefff: 0xf22,
-	0xffff: 0xf23,
-}
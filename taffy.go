@@ -0,0 +1,392 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// A cuckoo filter that grows in place as items are added, keeping its false-positive rate about
+// the same however many times it grows, for long-lived sets whose size isn't known up front. It
+// never overflows. See https://arxiv.org/abs/2109.01947.
+//
+// The number of buckets is a power of two, and an item's first bucket is the low bits of its hash.
+// An entry keeps the hash bits just above those as its fingerprint, so when the table doubles, each
+// entry takes the lowest bit of its fingerprint as the new high bit of its bucket and moves there,
+// without needing the item itself. That costs each entry a bit of fingerprint, so items added
+// after the table has doubled g times get fingerprints g bits longer than the first ones did, and
+// the false positives of each generation of items shrink about as fast as the generations grow.
+//
+// Entries whose fingerprints run out are copied to both buckets they might belong in. Deleting such
+// an item removes only one copy, so the other goes on matching the items that share its bucket and
+// 8-bit tag, but the first items' fingerprints only run out once the table has doubled as many
+// times as they have bits.
+type TaffyFilter struct {
+	// Holds the bucket size, number of buckets, count, and hash settings. Its own buckets are unused.
+	h *Filter
+	// log2 of the number of buckets.
+	k uint
+	// The number of fingerprint bits that items added now get.
+	l int
+	// The number of items to hold before growing.
+	capacity int
+	// b entries per bucket, each packed into l+taffyTagBits+2 bits. From the top: 1 bit for which of
+	// the item's buckets the entry is in, the item's tag, and then its fingerprint below a 1 bit
+	// marking where it starts. 0 is an empty slot.
+	entries []uint64
+}
+
+const (
+	// Every entry keeps these top bits of the item's hash, which choose its second bucket and are
+	// never given up to the bucket index.
+	taffyTagBits = 8
+	// The fewest fingerprint bits the first items get, so that they don't run out too soon.
+	taffyMinFingerprintBits = 4
+)
+
+// Returns a new TaffyFilter that starts out with room for n items, with an estimated false-positive
+// rate of fp however many items are added.
+func NewTaffy(n int, fp float64) *TaffyFilter {
+	if n < 1 {
+		n = 1
+	}
+	// The generations of items each add about the same to the false-positive rate, and their sum
+	// converges to twice the rate of the newest, so aim for half of fp.
+	f, b, nBuckets := params(n, fp/2)
+	k := uint(bits.Len64(uint64(nBuckets - 1)))
+	l := f - taffyTagBits
+	if l < taffyMinFingerprintBits {
+		l = taffyMinFingerprintBits
+	}
+	return newTaffyFilter(b, k, l, int(float64(n)*float64(uint64(1)<<k)/float64(nBuckets)+0.5))
+}
+
+func newTaffyFilter(b int, k uint, l int, capacity int) *TaffyFilter {
+	h := newFilterWords(taffyTagBits, b, 1<<k, bucketEncodingFor(taffyTagBits, b), nil)
+	return &TaffyFilter{
+		h:        h,
+		k:        k,
+		l:        l,
+		capacity: capacity,
+		entries:  make([]uint64, packedWords(uint64(l+taffyTagBits+2), uint64(b)<<k)),
+	}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (tf *TaffyFilter) SetSeed(seed uint64) {
+	tf.h.SetSeed(seed)
+}
+
+// Returns the seed set with SetSeed.
+func (tf *TaffyFilter) Seed() uint64 {
+	return tf.h.Seed()
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (tf *TaffyFilter) Add(x []byte) {
+	if tf.h.count >= tf.capacity {
+		tf.grow()
+	}
+	hash := tf.h.hashItem(x)
+	tag := hash >> (64 - taffyTagBits)
+	// Growing never fails, since each bucket's entries split between the two it becomes, so when
+	// there's no room for x, make some.
+	for !tf.kick(tf.makeEntry(0, tag, tf.l, (hash>>tf.k)&(uint64(1)<<uint(tf.l)-1)), tf.idx(hash)) {
+		tf.grow()
+	}
+	tf.h.count++
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (tf *TaffyFilter) Contains(x []byte) Result {
+	if _, ok := tf.find(tf.h.hashItem(x)); ok {
+		return Maybe
+	}
+	return No
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (tf *TaffyFilter) Delete(x []byte) {
+	if !tf.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (tf *TaffyFilter) TryDelete(x []byte) bool {
+	s, ok := tf.find(tf.h.hashItem(x))
+	if !ok {
+		return false
+	}
+	tf.setEntry(s, 0)
+	tf.h.count--
+	return true
+}
+
+// Returns the number of items in the filter.
+func (tf *TaffyFilter) Count() int {
+	return tf.h.count
+}
+
+// Returns the number of buckets in the table, which doubles each time the filter grows.
+func (tf *TaffyFilter) NumBuckets() int {
+	return 1 << tf.k
+}
+
+// Returns the number of bytes used by the filter's entries.
+func (tf *TaffyFilter) SizeBytes() uint64 {
+	return uint64(len(tf.entries)) * 8
+}
+
+// Returns an estimate of the filter's current false-positive rate, from the fingerprint length of
+// every entry. Takes time proportional to the size of the filter.
+func (tf *TaffyFilter) EstimatedFalsePositiveRate() float64 {
+	// A query looks at 2 of the buckets, and matches an entry there with a fingerprint of n bits
+	// with probability 2^-(n+taffyTagBits).
+	sum := 0.0
+	for s := uint64(0); s < uint64(tf.h.b)<<tf.k; s++ {
+		if e := tf.entry(s); e != 0 {
+			_, _, n, _ := tf.splitEntry(e)
+			sum += math.Ldexp(1, -(n + taffyTagBits))
+		}
+	}
+	return math.Min(sum*2/float64(uint64(1)<<tf.k), 1)
+}
+
+// Removes every item from the filter, reusing its memory. The filter stays the size it has grown
+// to.
+func (tf *TaffyFilter) Reset() {
+	for i := range tf.entries {
+		tf.entries[i] = 0
+	}
+	tf.h.count = 0
+}
+
+// Returns an independent copy of the filter.
+func (tf *TaffyFilter) Clone() *TaffyFilter {
+	c := newTaffyFilter(tf.h.b, tf.k, tf.l, tf.capacity)
+	copy(c.entries, tf.entries)
+	c.h.count = tf.h.count
+	c.h.hashingFrom(tf.h)
+	c.h.rngState = tf.h.rngState
+	return c
+}
+
+// Returns the first bucket of an item whose hash is hash.
+func (tf *TaffyFilter) idx(hash uint64) uint64 {
+	return hash & (uint64(1)<<tf.k - 1)
+}
+
+// Returns the bucket other than i that an entry with the given tag can be in.
+func (tf *TaffyFilter) otherIdx(tag, i uint64) uint64 {
+	return (i ^ mixFingerprint(fingerprint(tag))) & (uint64(1)<<tf.k - 1)
+}
+
+// Returns the index of the entry in slot j of bucket i.
+func (tf *TaffyFilter) slot(i, j uint64) uint64 {
+	return i*uint64(tf.h.b) + j
+}
+
+func (tf *TaffyFilter) width() uint64 {
+	return uint64(tf.l + taffyTagBits + 2)
+}
+
+func (tf *TaffyFilter) entry(s uint64) uint64 {
+	return getPacked(tf.entries, tf.width(), s)
+}
+
+func (tf *TaffyFilter) setEntry(s, e uint64) {
+	setPacked(tf.entries, tf.width(), s, e)
+}
+
+// Returns the entry for side 0 or 1 of its item, with the given tag and n-bit fingerprint v.
+func (tf *TaffyFilter) makeEntry(side, tag uint64, n int, v uint64) uint64 {
+	return side<<uint(tf.l+taffyTagBits+1) | tag<<uint(tf.l+1) | uint64(1)<<uint(n) | v
+}
+
+// The inverse of makeEntry.
+func (tf *TaffyFilter) splitEntry(e uint64) (side, tag uint64, n int, v uint64) {
+	side = e >> uint(tf.l+taffyTagBits+1)
+	tag = (e >> uint(tf.l+1)) & (1<<taffyTagBits - 1)
+	fp := e & (uint64(1)<<uint(tf.l+1) - 1)
+	n = bits.Len64(fp) - 1
+	return side, tag, n, fp &^ (uint64(1) << uint(n))
+}
+
+// Returns the index of an entry matching the item whose hash is hash.
+func (tf *TaffyFilter) find(hash uint64) (uint64, bool) {
+	i1, tag := tf.idx(hash), hash>>(64-taffyTagBits)
+	rest := hash >> tf.k
+	for _, i := range [2]uint64{i1, tf.otherIdx(tag, i1)} {
+		for s := tf.slot(i, 0); s < tf.slot(i+1, 0); s++ {
+			e := tf.entry(s)
+			if e == 0 {
+				continue
+			}
+			_, eTag, n, v := tf.splitEntry(e)
+			if eTag == tag && v == rest&(uint64(1)<<uint(n)-1) {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Returns the index of an empty entry in bucket i.
+func (tf *TaffyFilter) empty(i uint64) (uint64, bool) {
+	for s := tf.slot(i, 0); s < tf.slot(i+1, 0); s++ {
+		if tf.entry(s) == 0 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// Places entry e, which is for side 0 of its item and so belongs in bucket i1 or the other bucket
+// for its tag, kicking other entries to their other buckets to make room if necessary. Returns
+// false if no room could be made, after undoing the kicks.
+func (tf *TaffyFilter) kick(e uint64, i1 uint64) bool {
+	_, tag, _, _ := tf.splitEntry(e)
+	i2 := tf.otherIdx(tag, i1)
+	if s, ok := tf.empty(i1); ok {
+		tf.setEntry(s, e)
+		return true
+	}
+	if s, ok := tf.empty(i2); ok {
+		tf.setEntry(s, tf.flipSide(e))
+		return true
+	}
+
+	var pathBuf [16]entryKick
+	path := pathBuf[:0]
+	i := i1
+	if tf.h.randInt()%2 == 1 {
+		i, e = i2, tf.flipSide(e)
+	}
+	for n := 0; n < maxNumKicks; n++ {
+		s := tf.slot(i, uint64(tf.h.randInt()%tf.h.b))
+		victim := tf.entry(s)
+		path = append(path, entryKick{s: s, e: victim})
+		tf.setEntry(s, e)
+		_, tag, _, _ := tf.splitEntry(victim)
+		e, i = tf.flipSide(victim), tf.otherIdx(tag, i)
+		if s, ok := tf.empty(i); ok {
+			tf.setEntry(s, e)
+			return true
+		}
+	}
+
+	for j := len(path) - 1; j >= 0; j-- {
+		tf.setEntry(path[j].s, path[j].e)
+	}
+	return false
+}
+
+// Returns e moved to the other side of its item.
+func (tf *TaffyFilter) flipSide(e uint64) uint64 {
+	return e ^ uint64(1)<<uint(tf.l+taffyTagBits+1)
+}
+
+// Doubles the number of buckets, moving each entry to whichever of the two buckets its old one
+// became that it belongs in.
+func (tf *TaffyFilter) grow() {
+	old := *tf
+	tf.k++
+	if tf.l < taffyMaxFingerprintBits(tf.k) {
+		tf.l++
+	} else {
+		tf.l = taffyMaxFingerprintBits(tf.k)
+	}
+	tf.capacity *= 2
+	tf.h.n = uint64(1) << tf.k
+	tf.entries = make([]uint64, packedWords(tf.width(), uint64(tf.h.b)<<tf.k))
+
+	high := uint64(1) << old.k
+	for i := uint64(0); i < high; i++ {
+		for s := old.slot(i, 0); s < old.slot(i+1, 0); s++ {
+			e := old.entry(s)
+			if e == 0 {
+				continue
+			}
+			side, tag, n, v := old.splitEntry(e)
+			if n == 0 {
+				tf.put(i, tf.makeEntry(side, tag, 0, 0))
+				tf.put(i|high, tf.makeEntry(side, tag, 0, 0))
+				continue
+			}
+			// The fingerprint's lowest bit is the new high bit of the item's first bucket. Its second
+			// bucket differs from the first by the tag's mix, in the new bit too.
+			bit := v & 1
+			if side == 1 {
+				bit ^= (mixFingerprint(fingerprint(tag)) >> old.k) & 1
+			}
+			tf.put(i|bit*high, tf.makeEntry(side, tag, n-1, v>>1))
+		}
+	}
+}
+
+// Returns the most fingerprint bits an item can get in a table of 2^k buckets: its hash has to hold
+// the bucket index, the fingerprint, and the tag, and its entry has to fit in a word.
+func taffyMaxFingerprintBits(k uint) int {
+	if k < 2 {
+		return 64 - taffyTagBits - 2
+	}
+	return 64 - taffyTagBits - int(k)
+}
+
+// Puts e in an empty slot of bucket i, which must have one.
+func (tf *TaffyFilter) put(i, e uint64) {
+	s, ok := tf.empty(i)
+	if !ok {
+		panic("cuckoo: no room in TaffyFilter bucket while growing")
+	}
+	tf.setEntry(s, e)
+}
+
+// The magic that starts a TaffyFilter's encoding.
+var taffyMagic = [4]byte{'C', 'K', 'T', 'F'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform: a Filter header
+// with its own magic, then the fingerprint length of new items as a byte, the number of items to
+// hold before growing as a uint64, and the packed entries as little-endian words.
+func (tf *TaffyFilter) MarshalBinary() ([]byte, error) {
+	h := encodeVariantHeader(taffyMagic, tf.h)
+	out := make([]byte, 0, len(h)+9+len(tf.entries)*8)
+	out = append(out, h...)
+	out = append(out, byte(tf.l))
+	out = binary.LittleEndian.AppendUint64(out, uint64(tf.capacity))
+	for _, w := range tf.entries {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of tf with the filter encoded in
+// data.
+func (tf *TaffyFilter) UnmarshalBinary(data []byte) error {
+	hdr, data, err := decodeVariantHeader(taffyMagic, data)
+	if err != nil {
+		return err
+	}
+	if hdr.hashing != hashXXH || hdr.nBuckets&(hdr.nBuckets-1) != 0 || len(data) < 9 {
+		return errCorrupt
+	}
+	k := uint(bits.TrailingZeros64(hdr.nBuckets))
+	l := int(data[0])
+	capacity := binary.LittleEndian.Uint64(data[1:])
+	data = data[9:]
+	if l < 1 || l > taffyMaxFingerprintBits(k) || capacity > uint64(maxInt) ||
+		uint64(len(data)) != packedWords(uint64(l+taffyTagBits+2), uint64(hdr.b)<<k)*8 {
+		return errCorrupt
+	}
+	result := newTaffyFilter(hdr.b, k, l, int(capacity))
+	for i := range result.entries {
+		result.entries[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	hdr.restore(result.h)
+	*tf = *result
+	return nil
+}
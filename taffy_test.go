@@ -0,0 +1,87 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaffy(t *testing.T) {
+	const fp = 0.01
+	tf := NewTaffy(1000, fp)
+	startBuckets := tf.NumBuckets()
+	keys := make([][]byte, 200000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		tf.Add(keys[i])
+	}
+	require.Equal(t, len(keys), tf.Count())
+	require.GreaterOrEqual(t, tf.NumBuckets(), startBuckets*128)
+	for _, x := range keys {
+		require.Equal(t, Maybe, tf.Contains(x))
+	}
+
+	require.Less(t, tf.EstimatedFalsePositiveRate(), fp)
+	fps := 0
+	const tries = 200000
+	for i := len(keys); i < len(keys)+tries; i++ {
+		if tf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/tries, fp)
+
+	for _, x := range keys[:len(keys)/2] {
+		tf.Delete(x)
+	}
+	require.Equal(t, len(keys)/2, tf.Count())
+	require.False(t, tf.TryDelete([]byte("never added")))
+
+	c := tf.Clone()
+	tf.Reset()
+	require.Zero(t, tf.Count())
+	require.Equal(t, No, tf.Contains(keys[len(keys)-1]))
+	require.Equal(t, Maybe, c.Contains(keys[len(keys)-1]))
+}
+
+func TestTaffyGrowKeepsEntries(t *testing.T) {
+	tf := NewTaffy(100, 0.01)
+	tf.SetSeed(7)
+	var keys [][]byte
+	for i := 0; i < 90; i++ {
+		keys = append(keys, binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		tf.Add(keys[i])
+	}
+	// Grow far past the point where the first items' fingerprints run out, which copies them to
+	// both of their possible buckets.
+	for j := 0; j < 12; j++ {
+		tf.grow()
+		for _, x := range keys {
+			require.Equal(t, Maybe, tf.Contains(x), "after growing %d times", j+1)
+		}
+	}
+}
+
+func TestTaffySerialize(t *testing.T) {
+	tf := NewTaffy(100, 0.001)
+	tf.SetSeed(42)
+	for i := 0; i < 1000; i++ {
+		tf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := tf.MarshalBinary()
+	require.NoError(t, err)
+
+	var tf2 TaffyFilter
+	require.NoError(t, tf2.UnmarshalBinary(data))
+	require.Equal(t, tf.Count(), tf2.Count())
+	require.Equal(t, tf.NumBuckets(), tf2.NumBuckets())
+	require.Equal(t, tf.entries, tf2.entries)
+	require.Equal(t, uint64(42), tf2.Seed())
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, Maybe, tf2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, tf2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, tf2.UnmarshalBinary(append(data, 0)))
+}
@@ -0,0 +1,199 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// A cuckoo filter in which each item is added with one or more of k labels, for asking which of k
+// sets an item might be in with one lookup, such as which tenant tiers have seen a key, instead of
+// keeping k separate filters.
+//
+// Each item is stored once, with a bitmask of its labels, so an item with several labels costs no
+// more than one with a single label. Lookup returns the labels an item was added with, and for an
+// item that wasn't added at all, returns no labels except with about the false-positive rate of a
+// Filter with the same parameters. An item that collides with another one, sharing its fingerprint
+// and buckets, shares its labels too. Adding a label that an item, or one it collides with, already
+// has takes a second entry, the way adding an item to a Filter twice does, so that deleting one of
+// them leaves the other.
+type TaggedFilter struct {
+	// The extra bits of each entry are the item's labels, label l in bit l.
+	entryTable
+}
+
+// Returns a new TaggedFilter capable of holding n items with an estimated false-positive rate of fp,
+// with labels in [0, k) for k in [1, 16].
+func NewTagged(n int, fp float64, k int) *TaggedFilter {
+	f, b, nBuckets := params(n, fp)
+	return NewTaggedRaw(f, b, k, nBuckets)
+}
+
+// Returns a new TaggedFilter constructed using raw parameters. f, b, and n are as for NewRaw, and
+// labels are in [0, k) for k in [1, 16].
+func NewTaggedRaw(f, b, k, n int) *TaggedFilter {
+	nBuckets := rawBuckets(f, b, n)
+	if k < 1 || k > 16 {
+		panic(fmt.Errorf("%w: number of labels k=%d must be in [1, 16]", ErrInvalidParams, k))
+	}
+	return &TaggedFilter{newEntryTable(f, b, k, nBuckets)}
+}
+
+// Returns the number of labels, k.
+func (tf *TaggedFilter) Labels() int {
+	return tf.extra
+}
+
+// Adds x to the filter with the given label. After Add(x, label) returns, Lookup(x) includes label.
+func (tf *TaggedFilter) Add(x []byte, label int) {
+	f, i1, i2 := tf.h.itemToIdxs(x)
+	mask := tf.labelBit(label)
+	if s, ok := tf.findLabel(f, i1, i2, mask, false); ok {
+		tf.setEntry(s, tf.entry(s)|mask<<uint(tf.h.f))
+		return
+	}
+	tf.h.count++
+	if !tf.h.overflowed && !tf.kick(tf.makeEntry(f, mask), i1, i2, false) {
+		tf.h.overflowed = true
+	}
+}
+
+// Adds x to the filter with the given label like Add, unless there's no room for it. In that case,
+// returns ErrOverflowed and leaves the filter as it was, rather than overflowing it.
+func (tf *TaggedFilter) Insert(x []byte, label int) error {
+	f, i1, i2 := tf.h.itemToIdxs(x)
+	mask := tf.labelBit(label)
+	if tf.h.overflowed {
+		return ErrOverflowed
+	}
+	if s, ok := tf.findLabel(f, i1, i2, mask, false); ok {
+		tf.setEntry(s, tf.entry(s)|mask<<uint(tf.h.f))
+		return nil
+	}
+	if !tf.kick(tf.makeEntry(f, mask), i1, i2, true) {
+		return ErrOverflowed
+	}
+	tf.h.count++
+	return nil
+}
+
+// Returns the index of an entry holding fingerprint f in bucket i1 or i2 whose labels include mask
+// if has is true, or don't if has is false.
+func (tf *TaggedFilter) findLabel(f fingerprint, i1, i2, mask uint64, has bool) (uint64, bool) {
+	for _, i := range [2]uint64{i1, i2} {
+		for s := tf.slot(i, 0); s < tf.slot(i+1, 0); s++ {
+			e := tf.entry(s)
+			if tf.fingerprint(e) == f && (tf.extraBits(e)&mask != 0) == has {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (tf *TaggedFilter) TryAdd(x []byte, label int) bool {
+	return tf.Insert(x, label) == nil
+}
+
+// Returns the labels x might have been added with, label l in bit l. Returns 0 if x definitely
+// wasn't added. Once the filter has overflowed, returns every label for any item that isn't found.
+func (tf *TaggedFilter) Lookup(x []byte) uint64 {
+	f, i1, i2 := tf.h.itemToIdxs(x)
+	var labels uint64
+	found := false
+	for _, i := range [2]uint64{i1, i2} {
+		for s := tf.slot(i, 0); s < tf.slot(i+1, 0); s++ {
+			if e := tf.entry(s); tf.fingerprint(e) == f {
+				labels |= tf.extraBits(e)
+				found = true
+			}
+		}
+	}
+	if !found && tf.h.overflowed {
+		return uint64(1)<<uint(tf.extra) - 1
+	}
+	return labels
+}
+
+// Returns No if x was definitely not added with label, and Maybe if it might have been.
+func (tf *TaggedFilter) Has(x []byte, label int) Result {
+	if tf.Lookup(x)&tf.labelBit(label) != 0 {
+		return Maybe
+	}
+	return No
+}
+
+// Returns No if x was definitely not added with any label, and Maybe if x might be in the filter.
+func (tf *TaggedFilter) Contains(x []byte) Result {
+	if tf.Lookup(x) != 0 {
+		return Maybe
+	}
+	return No
+}
+
+// Removes label from x. x must have been previously added with label. Once x has no labels left,
+// it's no longer in the filter.
+func (tf *TaggedFilter) Delete(x []byte, label int) {
+	if !tf.TryDelete(x, label) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Removes label from x like Delete, but if x definitely wasn't added with label, returns false
+// instead of panicking.
+func (tf *TaggedFilter) TryDelete(x []byte, label int) bool {
+	f, i1, i2 := tf.h.itemToIdxs(x)
+	mask := tf.labelBit(label)
+	if tf.h.overflowed {
+		return true
+	}
+	s, ok := tf.findLabel(f, i1, i2, mask, true)
+	if !ok {
+		return false
+	}
+	e := tf.entry(s) &^ (mask << uint(tf.h.f))
+	if tf.extraBits(e) == 0 {
+		e = 0
+		tf.h.count--
+	}
+	tf.setEntry(s, e)
+	return true
+}
+
+// Returns the number of items in the filter, counting each once however many labels it has, but
+// twice if it was added twice with the same label.
+func (tf *TaggedFilter) Count() int {
+	return tf.h.count
+}
+
+// Returns an independent copy of the filter.
+func (tf *TaggedFilter) Clone() *TaggedFilter {
+	return &TaggedFilter{tf.clone()}
+}
+
+// Returns the bit for label, panicking if it's out of range.
+func (tf *TaggedFilter) labelBit(label int) uint64 {
+	if label < 0 || label >= tf.extra {
+		panic(fmt.Sprintf("cuckoo: label %d must be in [0, %d)", label, tf.extra))
+	}
+	return uint64(1) << uint(label)
+}
+
+// The magic that starts a TaggedFilter's encoding. See entryTable.marshal.
+var taggedMagic = [4]byte{'C', 'K', 'T', 'G'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (tf *TaggedFilter) MarshalBinary() ([]byte, error) {
+	return tf.marshal(taggedMagic), nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of tf with the filter encoded in
+// data.
+func (tf *TaggedFilter) UnmarshalBinary(data []byte) error {
+	t, err := unmarshalEntryTable(taggedMagic, data)
+	if err != nil {
+		return err
+	}
+	tf.entryTable = t
+	return nil
+}
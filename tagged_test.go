@@ -0,0 +1,142 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagged(t *testing.T) {
+	const n = 1000
+	tf := NewTagged(n, 0.001, 4)
+	require.Equal(t, 4, tf.Labels())
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+
+	// Item i has label l for each bit l of i%16.
+	for i := 0; i < n; i++ {
+		for l := 0; l < 4; l++ {
+			if (i%16)&(1<<l) != 0 {
+				tf.Add(key(i), l)
+			}
+		}
+	}
+	// Those without labels aren't added, and a few collide with others and share their entries.
+	count := tf.Count()
+	require.InDelta(t, n-n/16, count, 10)
+	mismatched := 0
+	for i := 0; i < n; i++ {
+		labels := tf.Lookup(key(i))
+		// Collisions can only add labels.
+		require.Equal(t, uint64(i%16), labels&uint64(i%16))
+		if labels != uint64(i%16) {
+			mismatched++
+		}
+	}
+	require.Less(t, mismatched, 10)
+	fps := 0
+	for i := n; i < 11*n; i++ {
+		if tf.Contains(key(i)) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/(10*n), 0.002)
+
+	require.Equal(t, Maybe, tf.Has(key(3), 1))
+	tf.Delete(key(3), 1)
+	require.Equal(t, No, tf.Has(key(3), 1))
+	require.Equal(t, Maybe, tf.Has(key(3), 0))
+	tf.Delete(key(3), 0)
+	require.Equal(t, No, tf.Contains(key(3)))
+	require.Equal(t, count-1, tf.Count())
+	require.False(t, tf.TryDelete(key(3), 0))
+	require.Panics(t, func() { tf.Delete(key(3), 0) })
+	require.Panics(t, func() { tf.Add(key(3), 4) })
+}
+
+func TestTaggedCollision(t *testing.T) {
+	tf := NewTaggedRaw(4, 4, 2, 64)
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	// Find two items with the same fingerprint and buckets.
+	type idxs struct {
+		f      fingerprint
+		i1, i2 uint64
+	}
+	seen := make(map[idxs]int)
+	a, b := 0, 0
+	for i := 0; ; i++ {
+		f, i1, i2 := tf.h.itemToIdxs(key(i))
+		if j, ok := seen[idxs{f, i1, i2}]; ok {
+			a, b = j, i
+			break
+		}
+		seen[idxs{f, i1, i2}] = i
+	}
+
+	tf.Add(key(a), 0)
+	tf.Add(key(b), 0)
+	require.Equal(t, 2, tf.Count())
+	tf.Delete(key(a), 0)
+	require.Equal(t, Maybe, tf.Has(key(b), 0))
+	require.Equal(t, 1, tf.Count())
+	tf.Delete(key(b), 0)
+	require.Equal(t, No, tf.Contains(key(b)))
+
+	// Different labels share an entry, and each delete clears only its own.
+	tf.Add(key(a), 0)
+	require.NoError(t, tf.Insert(key(b), 1))
+	require.Equal(t, 1, tf.Count())
+	require.Equal(t, uint64(0b11), tf.Lookup(key(a)))
+	tf.Delete(key(b), 1)
+	require.Equal(t, uint64(0b01), tf.Lookup(key(a)))
+
+	// The same item added twice with the same label has to be deleted twice.
+	tf.Add(key(a), 0)
+	tf.Add(key(a), 1)
+	require.Equal(t, 2, tf.Count())
+	require.Equal(t, uint64(0b11), tf.Lookup(key(a)))
+	tf.Delete(key(a), 0)
+	require.Equal(t, Maybe, tf.Has(key(a), 0))
+	tf.Delete(key(a), 0)
+	require.Equal(t, No, tf.Has(key(a), 0))
+	require.Equal(t, Maybe, tf.Has(key(a), 1))
+}
+
+func TestTaggedOverflow(t *testing.T) {
+	tf := NewTaggedRaw(8, 2, 3, 4)
+	i := 0
+	for ; tf.TryAdd(binary.LittleEndian.AppendUint64(nil, uint64(i)), i%3); i++ {
+	}
+	require.False(t, tf.Overflowed())
+	count := tf.Count()
+	tf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)), 0)
+	require.True(t, tf.Overflowed())
+	require.Equal(t, count+1, tf.Count())
+	require.Equal(t, uint64(0b111), tf.Lookup([]byte("never added")))
+	require.Error(t, tf.Insert([]byte("y"), 0))
+}
+
+func TestTaggedSerialize(t *testing.T) {
+	tf := NewTaggedRaw(10, 4, 5, 256)
+	tf.SetSeed(99)
+	for i := 0; i < 500; i++ {
+		tf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)), i%5)
+	}
+	data, err := tf.MarshalBinary()
+	require.NoError(t, err)
+
+	var tf2 TaggedFilter
+	require.NoError(t, tf2.UnmarshalBinary(data))
+	require.Equal(t, tf.entries, tf2.entries)
+	require.Equal(t, tf.Count(), tf2.Count())
+	require.Equal(t, 5, tf2.Labels())
+	require.Equal(t, uint64(99), tf2.Seed())
+	for i := 0; i < 500; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		require.Equal(t, tf.Lookup(x), tf2.Lookup(x))
+	}
+
+	require.Error(t, tf2.UnmarshalBinary(data[:len(data)-1]))
+	var vf ValueFilter
+	require.Error(t, vf.UnmarshalBinary(data))
+}
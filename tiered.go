@@ -0,0 +1,237 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// A filter for workloads where most lookups are for recently added items, which keeps those items
+// in a small front Filter that stays in cache, in front of a large back Filter that holds the rest.
+//
+// Items are added to the front, and Contains checks the front before the back, so a lookup for a
+// recent item only touches the front. Once the front fills up, or when Flush is called, its items
+// are promoted to the back all at once, in the order of their buckets there, which is much cheaper
+// than adding them to the back one at a time as they arrive. To do that, the front keeps the hash of
+// each of its items alongside it, 8 bytes apiece.
+type TieredFilter struct {
+	front *Filter
+	back  *Filter
+	// The hashes of the items in front, in the order they were added.
+	pending []uint64
+	// The number of items front takes before they're promoted.
+	recent int
+}
+
+// Returns a new TieredFilter capable of holding n items with an estimated false-positive rate of
+// fp, with a front that holds the last recent items added, at most, before they're promoted.
+func NewTiered(n int, fp float64, recent int) *TieredFilter {
+	if recent < 1 {
+		recent = 1
+	}
+	// Lookups for items that weren't added check both, so each gets half of fp.
+	return &TieredFilter{
+		front:   New(recent, fp/2),
+		back:    New(n, fp/2),
+		pending: make([]uint64, 0, recent),
+		recent:  recent,
+	}
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (tf *TieredFilter) SetSeed(seed uint64) {
+	tf.front.SetSeed(seed)
+	tf.back.SetSeed(seed)
+}
+
+// Returns the seed set with SetSeed.
+func (tf *TieredFilter) Seed() uint64 {
+	return tf.back.Seed()
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe.
+func (tf *TieredFilter) Add(x []byte) {
+	// Both Filters use the same hash, so x only needs hashing once.
+	h := tf.back.hashItem(x)
+	if len(tf.pending) >= tf.recent || tf.front.insert(tf.front.hashToIdxs(h)) != nil {
+		tf.Flush()
+		tf.front.add(tf.front.hashToIdxs(h))
+	}
+	tf.pending = append(tf.pending, h)
+}
+
+// Promotes every item in the front to the back, leaving the front empty. Called by Add whenever the
+// front fills up, and can also be called periodically to keep the front holding only the most
+// recently added items.
+func (tf *TieredFilter) Flush() {
+	type promoted struct {
+		f      fingerprint
+		i1, i2 uint64
+	}
+	ps := make([]promoted, len(tf.pending))
+	for j, h := range tf.pending {
+		ps[j].f, ps[j].i1, ps[j].i2 = tf.back.hashToIdxs(h)
+	}
+	// In bucket order, so that consecutive writes to the back are close together.
+	sort.Slice(ps, func(a, b int) bool { return ps[a].i1 < ps[b].i1 })
+	for _, p := range ps {
+		tf.back.add(p.f, p.i1, p.i2)
+	}
+	tf.front.Reset()
+	tf.pending = tf.pending[:0]
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (tf *TieredFilter) Contains(x []byte) Result {
+	h := tf.back.hashItem(x)
+	if tf.front.contains(tf.front.hashToIdxs(h)) == Maybe {
+		return Maybe
+	}
+	return tf.back.contains(tf.back.hashToIdxs(h))
+}
+
+// Deletes x from the filter. x must have been previously added.
+func (tf *TieredFilter) Delete(x []byte) {
+	if !tf.TryDelete(x) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x from the filter like Delete, but if x definitely isn't in the filter, returns false
+// instead of panicking.
+func (tf *TieredFilter) TryDelete(x []byte) bool {
+	h := tf.back.hashItem(x)
+	// The front knows exactly which hashes it holds, so look there first to avoid deleting another
+	// item's fingerprint from the back.
+	for j, p := range tf.pending {
+		if p == h {
+			tf.front.delete(tf.front.hashToIdxs(h))
+			tf.pending = append(tf.pending[:j], tf.pending[j+1:]...)
+			return true
+		}
+	}
+	f, i1, i2 := tf.back.hashToIdxs(h)
+	if !tf.back.lookup(f, i1, i2) && !tf.back.overflowed {
+		return false
+	}
+	tf.back.delete(f, i1, i2)
+	return true
+}
+
+// Returns the number of items in the filter.
+func (tf *TieredFilter) Count() int {
+	return tf.front.count + tf.back.count
+}
+
+// Returns the number of items in the front, which haven't yet been promoted.
+func (tf *TieredFilter) Recent() int {
+	return len(tf.pending)
+}
+
+// True if the back has overflowed, and now blindly returns Maybe for every query.
+func (tf *TieredFilter) Overflowed() bool {
+	return tf.back.Overflowed()
+}
+
+// Returns the number of bytes used by the buckets of both Filters and the front's hashes.
+func (tf *TieredFilter) SizeBytes() uint64 {
+	return tf.front.SizeBytes() + tf.back.SizeBytes() + uint64(tf.recent)*8
+}
+
+// Returns an estimate of the filter's current false-positive rate: the chance that either Filter
+// returns Maybe for an item that was never added.
+func (tf *TieredFilter) EstimatedFalsePositiveRate() float64 {
+	return 1 - (1-tf.front.EstimatedFalsePositiveRate())*(1-tf.back.EstimatedFalsePositiveRate())
+}
+
+// Removes every item from the filter, reusing its memory.
+func (tf *TieredFilter) Reset() {
+	tf.front.Reset()
+	tf.back.Reset()
+	tf.pending = tf.pending[:0]
+}
+
+// Returns an independent copy of the filter.
+func (tf *TieredFilter) Clone() *TieredFilter {
+	return &TieredFilter{
+		front:   tf.front.Clone(),
+		back:    tf.back.Clone(),
+		pending: append([]uint64(nil), tf.pending...),
+		recent:  tf.recent,
+	}
+}
+
+// Serialized format, little-endian:
+//
+//	magic    [4]byte  "CKTR"
+//	recent   uint64   the number of items the front takes before they're promoted
+//	n        uint64   the number of items in the front
+//	pending  []uint64 their hashes
+//
+// followed by, for the front and then the back, the length of its encoding as a uint64 and its
+// encoding as written by MarshalBinary.
+var tieredMagic = [4]byte{'C', 'K', 'T', 'R'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (tf *TieredFilter) MarshalBinary() ([]byte, error) {
+	out := append([]byte(nil), tieredMagic[:]...)
+	out = binary.LittleEndian.AppendUint64(out, uint64(tf.recent))
+	out = binary.LittleEndian.AppendUint64(out, uint64(len(tf.pending)))
+	for _, h := range tf.pending {
+		out = binary.LittleEndian.AppendUint64(out, h)
+	}
+	for _, fl := range [2]*Filter{tf.front, tf.back} {
+		data, err := fl.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(data)))
+		out = append(out, data...)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of tf with the filter encoded in
+// data.
+func (tf *TieredFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 20 || !bytes.Equal(data[:4], tieredMagic[:]) {
+		return errCorrupt
+	}
+	recent := binary.LittleEndian.Uint64(data[4:])
+	n := binary.LittleEndian.Uint64(data[12:])
+	data = data[20:]
+	if recent == 0 || recent > uint64(maxInt) || n > recent || n > uint64(len(data))/8 {
+		return errCorrupt
+	}
+	result := &TieredFilter{pending: make([]uint64, n), recent: int(recent)}
+	for j := range result.pending {
+		result.pending[j] = binary.LittleEndian.Uint64(data[j*8:])
+	}
+	data = data[n*8:]
+	var fls [2]*Filter
+	for j := range fls {
+		if len(data) < 8 {
+			return errCorrupt
+		}
+		size := binary.LittleEndian.Uint64(data)
+		data = data[8:]
+		if size > uint64(len(data)) {
+			return errCorrupt
+		}
+		fls[j] = &Filter{}
+		if err := fls[j].UnmarshalBinary(data[:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	// Add and Contains hash items once for both Filters, the way the default hash does.
+	if len(data) != 0 || fls[0].hashing != hashXXH || fls[1].hashing != hashXXH ||
+		fls[0].seed != fls[1].seed || fls[0].count != int(n) {
+		return errCorrupt
+	}
+	result.front, result.back = fls[0], fls[1]
+	*tf = *result
+	return nil
+}
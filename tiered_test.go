@@ -0,0 +1,98 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTiered(t *testing.T) {
+	const n, recent = 10000, 500
+	tf := NewTiered(n, 0.01, recent)
+	key := func(i int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(i)) }
+	for i := 0; i < n; i++ {
+		tf.Add(key(i))
+		require.LessOrEqual(t, tf.Recent(), recent)
+	}
+	require.Equal(t, n, tf.Count())
+	require.False(t, tf.Overflowed())
+	require.Equal(t, recent, tf.Recent())
+
+	// The most recent keys are answered by the front alone.
+	for i := n - tf.Recent(); i < n; i++ {
+		require.Equal(t, Maybe, tf.front.Contains(key(i)))
+	}
+	for i := 0; i < n; i++ {
+		require.Equal(t, Maybe, tf.Contains(key(i)))
+	}
+	fps := 0
+	for i := n; i < 11*n; i++ {
+		if tf.Contains(key(i)) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/(10*n), 0.02)
+
+	// Deleting works whichever tier an item is in.
+	tf.Delete(key(0))
+	tf.Delete(key(n - 1))
+	require.Equal(t, n-2, tf.Count())
+	require.Equal(t, No, tf.front.Contains(key(n-1)))
+
+	tf.Flush()
+	require.Zero(t, tf.Recent())
+	require.Zero(t, tf.front.Count())
+	for i := 1; i < n-1; i++ {
+		require.Equal(t, Maybe, tf.back.Contains(key(i)))
+	}
+
+	tf.Reset()
+	require.Zero(t, tf.Count())
+	require.Equal(t, No, tf.Contains(key(1)))
+}
+
+func TestTieredRepeats(t *testing.T) {
+	// Adding the same item more times than its buckets in the front have room for promotes early.
+	tf := NewTiered(1000, 0.01, 100)
+	for i := 0; i < 12; i++ {
+		tf.Add([]byte("x"))
+	}
+	require.Equal(t, 12, tf.Count())
+	require.Equal(t, 4, tf.Recent())
+	for i := 0; i < 12; i++ {
+		tf.Delete([]byte("x"))
+	}
+	require.Zero(t, tf.Count())
+	require.False(t, tf.TryDelete([]byte("x")))
+}
+
+func TestTieredSerialize(t *testing.T) {
+	tf := NewTiered(1000, 0.01, 64)
+	tf.SetSeed(99)
+	for i := 0; i < 900; i++ {
+		tf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	data, err := tf.MarshalBinary()
+	require.NoError(t, err)
+
+	var tf2 TieredFilter
+	require.NoError(t, tf2.UnmarshalBinary(data))
+	require.Equal(t, tf.pending, tf2.pending)
+	require.True(t, tf.front.Equal(tf2.front))
+	require.True(t, tf.back.Equal(tf2.back))
+	require.Equal(t, uint64(99), tf2.Seed())
+	require.Equal(t, tf.SizeBytes(), tf2.SizeBytes())
+
+	// It carries on promoting where the original left off.
+	for i := 900; i < 1000; i++ {
+		tf2.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, Maybe, tf2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, tf2.UnmarshalBinary(data[:len(data)-1]))
+	var d DynamicFilter
+	require.Error(t, d.UnmarshalBinary(data))
+}
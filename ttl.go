@@ -0,0 +1,329 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// A cuckoo filter whose items expire a fixed time after they're added, for questions like "have I
+// seen this URL in the last hour?" without having to rotate filters by hand.
+//
+// Each entry keeps a coarse timestamp of k bits alongside its fingerprint, counting in ticks of
+// ttl/2^(k-1). An item is in the filter for at least ttl after it was last added and at most one
+// tick longer. Once expired, an entry is ignored by Contains and its slot is reused by Add, so a
+// filter sized for the number of items added in any one ttl never fills up.
+//
+// Adding an item that's already in the filter restarts its ttl rather than storing it again. Like a
+// false positive, this can happen to another item that collides with it, which then lives longer
+// than it should.
+type TTLFilter struct {
+	// The extra bits of each entry are the tick it was added, modulo 2^k.
+	entryTable
+	ttl time.Duration
+	// The length of a tick in nanoseconds.
+	resolution int64
+	// The number of ticks in ttl, 2^(k-1). An entry expires once it's more than this many ticks old.
+	ticksPerTTL int64
+	// The tick of the last sweep, which cleared every expired entry. Every entry's tick is in
+	// [sweepTick-ticksPerTTL, sweepTick+ticksPerTTL), which is what lets it be recovered from its
+	// low k bits.
+	sweepTick int64
+	// The latest tick seen by Add, so that a clock that steps backwards doesn't.
+	lastTick int64
+	// The tick an item was last lost to overflow. The filter returns Maybe for everything until
+	// that item would have expired.
+	overflowTick int64
+	now          func() time.Time
+}
+
+// Returns a new TTLFilter capable of holding n items added within any ttl with an estimated
+// false-positive rate of fp, with 4-bit timestamps, so that items expire within ttl/8 of ttl.
+func NewTTL(n int, fp float64, ttl time.Duration) *TTLFilter {
+	f, b, nBuckets := params(n, fp)
+	return NewTTLRaw(f, b, 4, nBuckets, ttl)
+}
+
+// Returns a new TTLFilter constructed using raw parameters. f, b, and n are as for NewRaw, and k is
+// the number of bits in each timestamp, in [2, 16], so that items expire within ttl/2^(k-1) of
+// ttl.
+func NewTTLRaw(f, b, k, n int, ttl time.Duration) *TTLFilter {
+	nBuckets := rawBuckets(f, b, n)
+	if k < 2 || k > 16 {
+		panic(fmt.Errorf("%w: timestamp bits k=%d must be in [2, 16]", ErrInvalidParams, k))
+	}
+	if ttl <= 0 {
+		panic(fmt.Errorf("%w: ttl=%s must be positive", ErrInvalidParams, ttl))
+	}
+	return newTTLFilter(f, b, k, nBuckets, ttl)
+}
+
+func newTTLFilter(f, b, k, nBuckets int, ttl time.Duration) *TTLFilter {
+	ticksPerTTL := int64(1) << uint(k-1)
+	return &TTLFilter{
+		entryTable:  newEntryTable(f, b, k, nBuckets),
+		ttl:         ttl,
+		resolution:  (int64(ttl) + ticksPerTTL - 1) / ticksPerTTL,
+		ticksPerTTL: ticksPerTTL,
+		now:         time.Now,
+	}
+}
+
+// Sets the clock the filter uses to tell when items were added and whether they've expired, which
+// is time.Now by default.
+func (tf *TTLFilter) SetClock(now func() time.Time) {
+	tf.now = now
+}
+
+// Returns the time items stay in the filter after they're added.
+func (tf *TTLFilter) TTL() time.Duration {
+	return tf.ttl
+}
+
+// Adds an item to the filter, or restarts its ttl if it's already there. After Add(x) returns,
+// Contains(x) returns Maybe for at least ttl.
+func (tf *TTLFilter) Add(x []byte) {
+	t := tf.advance()
+	if !tf.add(x, t, false) {
+		tf.h.overflowed = true
+		tf.overflowTick = t
+	}
+}
+
+// Adds x to the filter like Add, unless there's no room for it. In that case, returns ErrOverflowed
+// and leaves the filter's unexpired items as they were, rather than overflowing it.
+func (tf *TTLFilter) Insert(x []byte) error {
+	if !tf.add(x, tf.advance(), true) {
+		return ErrOverflowed
+	}
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (tf *TTLFilter) TryAdd(x []byte) bool {
+	return tf.Insert(x) == nil
+}
+
+// Returns No if x is definitely not in the filter or has expired, and Maybe if x might have been
+// added within the last ttl.
+func (tf *TTLFilter) Contains(x []byte) Result {
+	t := tf.tick()
+	f, i1, i2 := tf.h.itemToIdxs(x)
+	if _, ok := tf.find(f, i1, i2, t); ok || tf.lost(t) {
+		return Maybe
+	}
+	return No
+}
+
+// Returns the number of items in the filter, including any that have expired since the filter last
+// cleared them. Expired items are cleared at least once every ttl, while items are being added.
+func (tf *TTLFilter) Count() int {
+	return tf.h.count
+}
+
+// True if an item was lost to overflow within the last ttl, and so the filter blindly returns Maybe
+// for every query until it would have expired.
+func (tf *TTLFilter) Overflowed() bool {
+	return tf.lost(tf.tick())
+}
+
+// Removes every item from the filter, reusing its memory.
+func (tf *TTLFilter) Reset() {
+	tf.entryTable.Reset()
+	tf.sweepTick = 0
+	tf.lastTick = 0
+	tf.overflowTick = 0
+}
+
+// Returns an independent copy of the filter, which uses the same clock.
+func (tf *TTLFilter) Clone() *TTLFilter {
+	c := *tf
+	c.entryTable = tf.clone()
+	return &c
+}
+
+// Returns the current tick.
+func (tf *TTLFilter) tick() int64 {
+	return tf.now().UnixNano() / tf.resolution
+}
+
+// Returns the current tick for an Add, first clearing expired entries if the tick has moved far
+// enough from the last sweep that the entry added now couldn't be told apart from old ones.
+func (tf *TTLFilter) advance() int64 {
+	t := tf.tick()
+	if t < tf.lastTick {
+		t = tf.lastTick
+	}
+	tf.lastTick = t
+	if t-tf.sweepTick >= tf.ticksPerTTL {
+		tf.sweep(t)
+	}
+	return t
+}
+
+// Clears every entry that has expired by tick t.
+func (tf *TTLFilter) sweep(t int64) {
+	count := 0
+	for s := uint64(0); s < tf.h.nBuckets()*uint64(tf.h.b); s++ {
+		if e := tf.entry(s); e != 0 {
+			if tf.live(e, t) {
+				count++
+			} else {
+				tf.setEntry(s, 0)
+			}
+		}
+	}
+	// Recounted rather than decremented, so that items lost to overflow stop being counted.
+	tf.h.count = count
+	tf.sweepTick = t
+	if !tf.lost(t) {
+		tf.h.overflowed = false
+	}
+}
+
+// Clears the entries of bucket i that have expired by tick t.
+func (tf *TTLFilter) clearExpired(i uint64, t int64) {
+	for s := tf.slot(i, 0); s < tf.slot(i+1, 0); s++ {
+		if e := tf.entry(s); e != 0 && !tf.live(e, t) {
+			tf.setEntry(s, 0)
+			tf.h.count--
+		}
+	}
+}
+
+// True if entry e hasn't expired by tick t.
+func (tf *TTLFilter) live(e uint64, t int64) bool {
+	return t-tf.addedTick(e) <= tf.ticksPerTTL
+}
+
+// Returns the tick entry e was added, from the low k bits kept in the entry.
+func (tf *TTLFilter) addedTick(e uint64) int64 {
+	base := tf.sweepTick - tf.ticksPerTTL
+	mask := uint64(1)<<uint(tf.extra) - 1
+	return base + int64((tf.extraBits(e)-uint64(base))&mask)
+}
+
+// True if an item lost to overflow might not have expired by tick t.
+func (tf *TTLFilter) lost(t int64) bool {
+	return tf.h.overflowed && t-tf.overflowTick <= tf.ticksPerTTL
+}
+
+// Returns the index of an unexpired entry holding fingerprint f in bucket i1 or i2.
+func (tf *TTLFilter) find(f fingerprint, i1, i2 uint64, t int64) (uint64, bool) {
+	for _, i := range [2]uint64{i1, i2} {
+		for s := tf.slot(i, 0); s < tf.slot(i+1, 0); s++ {
+			if e := tf.entry(s); e != 0 && tf.fingerprint(e) == f && tf.live(e, t) {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Adds x at tick t, or restarts its ttl. Returns false if there was no room, in which case an item
+// was lost unless undo is true.
+func (tf *TTLFilter) add(x []byte, t int64, undo bool) bool {
+	f, i1, i2 := tf.h.itemToIdxs(x)
+	e := tf.makeEntry(f, uint64(t)&(uint64(1)<<uint(tf.extra)-1))
+	if s, ok := tf.find(f, i1, i2, t); ok {
+		tf.setEntry(s, e)
+		return true
+	}
+	ok := tf.kick(e, i1, i2, t, undo)
+	if ok || !undo {
+		// Without undo, e took the place of the entry that was lost, which stays counted until the
+		// next sweep.
+		tf.h.count++
+	}
+	return ok
+}
+
+// Like entryTable.kick, but clears the expired entries of each bucket it looks in, so that their
+// slots can be used.
+func (tf *TTLFilter) kick(e uint64, i1, i2 uint64, t int64, undo bool) bool {
+	for _, i := range [2]uint64{i1, i2} {
+		tf.clearExpired(i, t)
+		if s, ok := tf.empty(i); ok {
+			tf.setEntry(s, e)
+			return true
+		}
+	}
+
+	var pathBuf [16]entryKick
+	path := pathBuf[:0]
+	is := [2]uint64{i1, i2}
+	i := is[tf.h.randInt()%len(is)]
+	for n := 0; n < maxNumKicks; n++ {
+		s := tf.slot(i, uint64(tf.h.randInt()%tf.h.b))
+		victim := tf.entry(s)
+		if undo {
+			path = append(path, entryKick{s: s, e: victim})
+		}
+		tf.setEntry(s, e)
+		e = victim
+		i = tf.h.otherIdx(tf.fingerprint(e), i)
+		tf.clearExpired(i, t)
+		if s, ok := tf.empty(i); ok {
+			tf.setEntry(s, e)
+			return true
+		}
+	}
+
+	for j := len(path) - 1; j >= 0; j-- {
+		tf.setEntry(path[j].s, path[j].e)
+	}
+	return false
+}
+
+// The magic that starts a TTLFilter's encoding.
+var ttlMagic = [4]byte{'C', 'K', 'T', 'L'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform: a Filter header
+// with its own magic, then the number of timestamp bits as a byte, then the ttl in nanoseconds and
+// the sweep, last, and overflow ticks as little-endian int64s, and then the packed entries as
+// little-endian words.
+func (tf *TTLFilter) MarshalBinary() ([]byte, error) {
+	h := encodeVariantHeader(ttlMagic, tf.h)
+	out := make([]byte, 0, len(h)+1+4*8+len(tf.entries)*8)
+	out = append(out, h...)
+	out = append(out, byte(tf.extra))
+	out = binary.LittleEndian.AppendUint64(out, uint64(tf.ttl))
+	out = binary.LittleEndian.AppendUint64(out, uint64(tf.sweepTick))
+	out = binary.LittleEndian.AppendUint64(out, uint64(tf.lastTick))
+	out = binary.LittleEndian.AppendUint64(out, uint64(tf.overflowTick))
+	for _, w := range tf.entries {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of tf with the filter encoded in
+// data. The clock is reset to time.Now.
+func (tf *TTLFilter) UnmarshalBinary(data []byte) error {
+	hdr, data, err := decodeVariantHeader(ttlMagic, data)
+	if err != nil {
+		return err
+	}
+	if len(data) < 1+4*8 || data[0] < 2 || data[0] > 16 {
+		return errCorrupt
+	}
+	k := int(data[0])
+	ttl := time.Duration(binary.LittleEndian.Uint64(data[1:]))
+	var ticks [3]int64
+	for j := range ticks {
+		ticks[j] = int64(binary.LittleEndian.Uint64(data[9+j*8:]))
+	}
+	data = data[1+4*8:]
+	if ttl <= 0 ||
+		uint64(len(data)) != packedWords(uint64(hdr.f+k), hdr.nBuckets*uint64(hdr.b))*8 {
+		return errCorrupt
+	}
+	result := newTTLFilter(hdr.f, hdr.b, k, int(hdr.nBuckets), ttl)
+	result.sweepTick, result.lastTick, result.overflowTick = ticks[0], ticks[1], ticks[2]
+	for i := range result.entries {
+		result.entries[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	hdr.restore(result.h)
+	*tf = *result
+	return nil
+}
@@ -0,0 +1,151 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// A clock for TTLFilter.SetClock that only moves when told to.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestTTL(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	tf := NewTTL(1000, 0.01, time.Hour)
+	tf.SetClock(clock.now)
+	require.Equal(t, time.Hour, tf.TTL())
+
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		tf.Add(keys[i])
+	}
+	// A few keys collide with earlier ones and only restart their ttl.
+	count := tf.Count()
+	require.InDelta(t, len(keys), count, 20)
+
+	// Everything is still there right up to the ttl.
+	clock.t = clock.t.Add(time.Hour)
+	for _, x := range keys {
+		require.Equal(t, Maybe, tf.Contains(x))
+	}
+
+	// Adding an item again restarts its ttl.
+	for _, x := range keys[:500] {
+		tf.Add(x)
+	}
+	require.Equal(t, count, tf.Count())
+
+	// At most a tick later, the rest are gone but for false positives.
+	clock.t = clock.t.Add(time.Hour / 8)
+	fps := 0
+	for _, x := range keys[500:] {
+		if tf.Contains(x) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, fps, 20)
+	for _, x := range keys[:500] {
+		require.Equal(t, Maybe, tf.Contains(x))
+	}
+	clock.t = clock.t.Add(time.Hour)
+	for _, x := range keys {
+		require.Equal(t, No, tf.Contains(x))
+	}
+
+	tf.Add(keys[0])
+	require.Equal(t, 1, tf.Count())
+	tf.Reset()
+	require.Zero(t, tf.Count())
+	require.Equal(t, No, tf.Contains(keys[0]))
+}
+
+func TestTTLReusesExpired(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	tf := NewTTLRaw(8, 4, 2, 64, time.Minute)
+	tf.SetClock(clock.now)
+
+	// Far more items than fit at once, but never more than fit within one ttl.
+	for round := 0; round < 50; round++ {
+		for i := 0; i < 100; i++ {
+			x := binary.LittleEndian.AppendUint64(nil, uint64(round*100+i))
+			require.NoError(t, tf.Insert(x))
+			require.Equal(t, Maybe, tf.Contains(x))
+		}
+		clock.t = clock.t.Add(time.Minute)
+	}
+	require.False(t, tf.Overflowed())
+	require.LessOrEqual(t, tf.Count(), 64*4)
+
+	// A clock that steps backwards doesn't bring anything back.
+	clock.t = clock.t.Add(-time.Hour)
+	tf.Add([]byte("x"))
+	require.Equal(t, No, tf.Contains(binary.LittleEndian.AppendUint64(nil, 0)))
+}
+
+func TestTTLOverflow(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	tf := NewTTLRaw(8, 2, 4, 16, time.Minute)
+	tf.SetClock(clock.now)
+	i := 0
+	for ; tf.TryAdd(binary.LittleEndian.AppendUint64(nil, uint64(i))); i++ {
+	}
+	require.False(t, tf.Overflowed())
+	require.Equal(t, No, tf.Contains([]byte("never added")))
+
+	// Add makes different choices of what to kick than the TryAdd that failed, so it may still find
+	// room for an item or two.
+	for ; !tf.Overflowed(); i++ {
+		tf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+	}
+	require.Equal(t, Maybe, tf.Contains([]byte("never added")))
+
+	// Once everything that could have been lost has expired, the filter recovers.
+	clock.t = clock.t.Add(2 * time.Minute)
+	require.False(t, tf.Overflowed())
+	require.Equal(t, No, tf.Contains([]byte("never added")))
+	tf.Add([]byte("x"))
+	require.Equal(t, 1, tf.Count())
+	require.Equal(t, Maybe, tf.Contains([]byte("x")))
+}
+
+func TestTTLSerialize(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	tf := NewTTLRaw(10, 4, 6, 256, time.Second)
+	tf.SetSeed(99)
+	tf.SetClock(clock.now)
+	for i := 0; i < 500; i++ {
+		tf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		clock.t = clock.t.Add(time.Millisecond)
+	}
+	data, err := tf.MarshalBinary()
+	require.NoError(t, err)
+
+	var tf2 TTLFilter
+	require.NoError(t, tf2.UnmarshalBinary(data))
+	tf2.SetClock(clock.now)
+	require.Equal(t, tf.entries, tf2.entries)
+	require.Equal(t, 500, tf2.Count())
+	require.Equal(t, uint64(99), tf2.Seed())
+	require.Equal(t, time.Second, tf2.TTL())
+	clock.t = clock.t.Add(time.Second - 250*time.Millisecond)
+	for i := 0; i < 500; i++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+		require.Equal(t, tf.Contains(x), tf2.Contains(x))
+		if i >= 250 {
+			require.Equal(t, Maybe, tf2.Contains(x))
+		}
+	}
+
+	require.Error(t, tf2.UnmarshalBinary(data[:len(data)-1]))
+	var cf CountingFilter
+	require.Error(t, cf.UnmarshalBinary(data))
+}
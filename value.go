@@ -0,0 +1,149 @@
+package cuckoo
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// A cuckoo filter that stores a small value with each item, making it an approximate map from
+// items to values of up to 16 bits, such as a hint for which shard holds an item.
+//
+// Get returns No for an item that definitely wasn't added. Otherwise, it returns Maybe along with a
+// value, which is the item's value unless the item collides with another one, sharing its
+// fingerprint and buckets, in which case it may be the other item's value. Collisions are what make
+// false positives, so they happen about as often as a Filter with the same parameters gives one, and
+// a longer fingerprint makes them rarer between added items too.
+//
+// An item added more than once is stored once for each Add, so the same item can be added with
+// several values, and Get returns one of them.
+type ValueFilter struct {
+	// The extra bits of each entry are the item's value.
+	entryTable
+}
+
+// Returns a new ValueFilter capable of holding n items with an estimated false-positive rate of fp,
+// each with a value of k bits, in [1, 16].
+func NewValue(n int, fp float64, k int) *ValueFilter {
+	f, b, nBuckets := params(n, fp)
+	return NewValueRaw(f, b, k, nBuckets)
+}
+
+// Returns a new ValueFilter constructed using raw parameters. f, b, and n are as for NewRaw, and k
+// is the number of bits in each value, in [1, 16].
+func NewValueRaw(f, b, k, n int) *ValueFilter {
+	nBuckets := rawBuckets(f, b, n)
+	if k < 1 || k > 16 {
+		panic(fmt.Errorf("%w: value bits k=%d must be in [1, 16]", ErrInvalidParams, k))
+	}
+	return &ValueFilter{newEntryTable(f, b, k, nBuckets)}
+}
+
+// Adds x to the filter with value v, of which only the low k bits are kept. After Add(x, v)
+// returns, Get(x) returns Maybe.
+func (vf *ValueFilter) Add(x []byte, v uint64) {
+	f, i1, i2 := vf.h.itemToIdxs(x)
+	vf.h.count++
+	if !vf.h.overflowed && !vf.kick(vf.entryFor(f, v), i1, i2, false) {
+		vf.h.overflowed = true
+	}
+}
+
+// Adds x to the filter with value v like Add, unless there's no room for it. In that case, returns
+// ErrOverflowed and leaves the filter as it was, rather than overflowing it.
+func (vf *ValueFilter) Insert(x []byte, v uint64) error {
+	f, i1, i2 := vf.h.itemToIdxs(x)
+	if vf.h.overflowed || !vf.kick(vf.entryFor(f, v), i1, i2, true) {
+		return ErrOverflowed
+	}
+	vf.h.count++
+	return nil
+}
+
+// Like Insert, but reports whether x was added instead of returning an error.
+func (vf *ValueFilter) TryAdd(x []byte, v uint64) bool {
+	return vf.Insert(x, v) == nil
+}
+
+// Returns No if x is definitely not in the filter. Otherwise, returns Maybe and the value x was
+// added with, or if x collides with another item, possibly that item's value. Once the filter has
+// overflowed, returns 0 and Maybe for any item that isn't found.
+func (vf *ValueFilter) Get(x []byte) (uint64, Result) {
+	f, i1, i2 := vf.h.itemToIdxs(x)
+	if s, ok := vf.find(f, i1, i2); ok {
+		return vf.extraBits(vf.entry(s)), Maybe
+	}
+	if vf.h.overflowed {
+		return 0, Maybe
+	}
+	return 0, No
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (vf *ValueFilter) Contains(x []byte) Result {
+	_, r := vf.Get(x)
+	return r
+}
+
+// Deletes x, added with value v, from the filter. x must have been previously added with v.
+func (vf *ValueFilter) Delete(x []byte, v uint64) {
+	if !vf.TryDelete(x, v) {
+		panic(fmt.Errorf("%w: %s", ErrNotInserted, hex.EncodeToString(x)))
+	}
+}
+
+// Deletes x, added with value v, from the filter like Delete, but if x definitely wasn't added with
+// v, returns false instead of panicking.
+func (vf *ValueFilter) TryDelete(x []byte, v uint64) bool {
+	f, i1, i2 := vf.h.itemToIdxs(x)
+	if !vf.h.overflowed {
+		e := vf.entryFor(f, v)
+		found := false
+		for _, i := range [2]uint64{i1, i2} {
+			for s := vf.slot(i, 0); s < vf.slot(i+1, 0) && !found; s++ {
+				if vf.entry(s) == e {
+					vf.setEntry(s, 0)
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	vf.h.count--
+	return true
+}
+
+// Returns the number of items in the filter.
+func (vf *ValueFilter) Count() int {
+	return vf.h.count
+}
+
+// Returns an independent copy of the filter.
+func (vf *ValueFilter) Clone() *ValueFilter {
+	return &ValueFilter{vf.clone()}
+}
+
+// Returns the entry for fingerprint f with value v.
+func (vf *ValueFilter) entryFor(f fingerprint, v uint64) uint64 {
+	return vf.makeEntry(f, v&(uint64(1)<<uint(vf.extra)-1))
+}
+
+// The magic that starts a ValueFilter's encoding. See entryTable.marshal.
+var valueMagic = [4]byte{'C', 'K', 'V', 'L'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (vf *ValueFilter) MarshalBinary() ([]byte, error) {
+	return vf.marshal(valueMagic), nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of vf with the filter encoded in
+// data.
+func (vf *ValueFilter) UnmarshalBinary(data []byte) error {
+	t, err := unmarshalEntryTable(valueMagic, data)
+	if err != nil {
+		return err
+	}
+	vf.entryTable = t
+	return nil
+}
@@ -0,0 +1,110 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue(t *testing.T) {
+	// A low enough false-positive rate that no two keys collide, so that a wrong value never matches.
+	vf := NewValue(1000, 0.0001, 2)
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = binary.LittleEndian.AppendUint64(nil, uint64(i))
+		// Only the low 2 bits are kept.
+		vf.Add(keys[i], uint64(i)|0x100)
+	}
+	require.Equal(t, len(keys), vf.Count())
+	for i, x := range keys {
+		v, r := vf.Get(x)
+		require.Equal(t, Maybe, r)
+		require.Equal(t, uint64(i%4), v)
+	}
+
+	fps := 0
+	for i := len(keys); i < len(keys)+10000; i++ {
+		if vf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, fps, 30)
+
+	for i, x := range keys[:500] {
+		require.False(t, vf.TryDelete(x, uint64(i+1)))
+		vf.Delete(x, uint64(i))
+	}
+	require.Equal(t, 500, vf.Count())
+	for i, x := range keys[500:] {
+		v, r := vf.Get(x)
+		require.Equal(t, Maybe, r)
+		require.Equal(t, uint64((i+500)%4), v)
+	}
+	require.Panics(t, func() { vf.Delete([]byte("never added"), 0) })
+}
+
+func TestValueSameItem(t *testing.T) {
+	vf := NewValueRaw(12, 4, 4, 64)
+	x := []byte("x")
+	vf.Add(x, 3)
+	vf.Add(x, 7)
+	v, r := vf.Get(x)
+	require.Equal(t, Maybe, r)
+	require.Contains(t, []uint64{3, 7}, v)
+	vf.Delete(x, 3)
+	v, r = vf.Get(x)
+	require.Equal(t, Maybe, r)
+	require.Equal(t, uint64(7), v)
+	vf.Delete(x, 7)
+	_, r = vf.Get(x)
+	require.Equal(t, No, r)
+}
+
+func TestValueFull(t *testing.T) {
+	vf := NewValueRaw(12, 4, 3, 64)
+	var added int
+	for ; ; added++ {
+		x := binary.LittleEndian.AppendUint64(nil, uint64(added))
+		before := append([]uint64(nil), vf.entries...)
+		if !vf.TryAdd(x, uint64(added)) {
+			require.Equal(t, before, vf.entries)
+			break
+		}
+	}
+	require.False(t, vf.Overflowed())
+	for i := 0; i < added; i++ {
+		v, r := vf.Get(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		require.Equal(t, Maybe, r)
+		require.Equal(t, uint64(i%8), v)
+	}
+	for i := 0; i < 100; i++ {
+		vf.Add(binary.LittleEndian.AppendUint64(nil, uint64(added+i)), 0)
+	}
+	require.True(t, vf.Overflowed())
+	_, r := vf.Get([]byte("never added"))
+	require.Equal(t, Maybe, r)
+}
+
+func TestValueSerialize(t *testing.T) {
+	vf := NewValue(1000, 0.0001, 5)
+	for i := 0; i < 1000; i++ {
+		vf.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)), uint64(i))
+	}
+	data, err := vf.MarshalBinary()
+	require.NoError(t, err)
+
+	var vf2 ValueFilter
+	require.NoError(t, vf2.UnmarshalBinary(data))
+	require.Equal(t, vf.entries, vf2.entries)
+	require.Equal(t, 1000, vf2.Count())
+	for i := 0; i < 1000; i++ {
+		v, r := vf2.Get(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		require.Equal(t, Maybe, r)
+		require.Equal(t, uint64(i%32), v)
+	}
+
+	var cf CountingFilter
+	require.Error(t, cf.UnmarshalBinary(data))
+	require.Error(t, vf2.UnmarshalBinary(data[:len(data)-1]))
+}
@@ -0,0 +1,154 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Write-ahead log format. All multi-byte fields are little-endian.
+//
+//	magic      [4]byte  "CKOL"
+//	version    uint8
+//	f          uint8
+//	b          uint8
+//	hashing    uint8    hash scheme
+//	nBuckets   uint64
+//	rngState   uint64   the state of the filter's generator for choosing what to kick
+//	records    each (op uint8, fingerprint uint16, i1 uint64)
+//
+// Reset is recorded with a zero fingerprint and i1. Replaying the log from rngState makes the same
+// choices the filter made, so the recovered filter's layout matches the original's exactly.
+// Records hold the fingerprint and primary bucket rather than the item itself, so they're a fixed
+// size regardless of the size of the items and replaying them doesn't need to rehash.
+const (
+	walHeaderSize = 4 + 1 + 1 + 1 + 1 + 8 + 8
+	walRecordSize = 1 + 2 + 8
+
+	walOpAdd    = 'A'
+	walOpDelete = 'D'
+	walOpReset  = 'R'
+)
+
+var walMagic = [4]byte{'C', 'K', 'O', 'L'}
+
+type walWriter struct {
+	w   io.Writer
+	buf [walRecordSize]byte
+	// The first error returned by w, after which nothing more is written.
+	err error
+}
+
+func (l *walWriter) write(b []byte) {
+	if l.err != nil {
+		return
+	}
+	_, l.err = l.w.Write(b)
+}
+
+func (l *walWriter) record(op byte, f fingerprint, i1 uint64) {
+	l.buf[0] = op
+	binary.LittleEndian.PutUint16(l.buf[1:3], uint16(f))
+	binary.LittleEndian.PutUint64(l.buf[3:11], i1)
+	l.write(l.buf[:])
+}
+
+// Starts appending a record of every subsequent Add, Delete, Age, and Reset to w, so that after a
+// crash the filter can be rebuilt with Recover from the last snapshot and the log written since.
+// The usual pattern is to call SetLog with a fresh log immediately after writing each snapshot.
+//
+// w is written to once per operation, so it's usually wise to buffer it; records that were
+// buffered but not yet written when the process crashed are lost. Passing nil stops logging.
+func (fl *Filter) SetLog(w io.Writer) {
+	if w == nil {
+		fl.log = nil
+		return
+	}
+	fl.log = &walWriter{w: w}
+	var h [walHeaderSize]byte
+	copy(h[0:4], walMagic[:])
+	h[4] = serializeVersion
+	h[5] = byte(fl.f)
+	h[6] = byte(fl.b)
+	h[7] = byte(fl.hashing)
+	binary.LittleEndian.PutUint64(h[8:16], fl.nBuckets())
+	binary.LittleEndian.PutUint64(h[16:24], fl.rngState)
+	fl.log.write(h[:])
+}
+
+// Returns the first error encountered while writing to the log set with SetLog, if any. Once an
+// error has occurred, no more records are written.
+func (fl *Filter) LogErr() error {
+	if fl.log == nil {
+		return nil
+	}
+	return fl.log.err
+}
+
+// Rebuilds a filter by reading a snapshot written by WriteTo or MarshalBinary from snapshot, then
+// replaying the log written since then (see SetLog) from log on top of it.
+//
+// A partial record at the end of the log, which happens when the process crashed mid-write, is
+// ignored.
+func Recover(snapshot io.Reader, log io.Reader) (*Filter, error) {
+	fl := &Filter{}
+	_, err := fl.ReadFrom(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var h [walHeaderSize]byte
+	_, err = io.ReadFull(log, h[:])
+	if err == io.EOF {
+		// Crashed before even the header was written, so there's nothing to replay.
+		return fl, nil
+	} else if err == io.ErrUnexpectedEOF {
+		return fl, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(h[0:4], walMagic[:]) {
+		return nil, errCorrupt
+	}
+	if h[4] != serializeVersion {
+		return nil, fmt.Errorf("cuckoo: unsupported log version %d", h[4])
+	}
+	if int(h[5]) != fl.f || int(h[6]) != fl.b || hashScheme(h[7]) != fl.hashing ||
+		binary.LittleEndian.Uint64(h[8:16]) != fl.nBuckets() {
+		return nil, fmt.Errorf("cuckoo: log was written by a filter with different parameters")
+	}
+	fl.rngState = binary.LittleEndian.Uint64(h[16:24])
+
+	var rec [walRecordSize]byte
+	for {
+		_, err := io.ReadFull(log, rec[:])
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return fl, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if rec[0] == walOpReset {
+			fl.reset()
+			continue
+		}
+		f := fingerprint(binary.LittleEndian.Uint16(rec[1:3]))
+		i1 := binary.LittleEndian.Uint64(rec[3:11])
+		if f == 0 || uint64(f) >= uint64(1)<<uint(fl.f) || i1 >= fl.nBuckets() {
+			return nil, errCorrupt
+		}
+		i2 := fl.otherIdx(f, i1)
+		switch rec[0] {
+		case walOpAdd:
+			fl.add(f, i1, i2)
+		case walOpDelete:
+			if !fl.delete(f, i1, i2) {
+				return nil, fmt.Errorf(
+					"%w: log deletes fingerprint %x that isn't in the filter", ErrNotInserted, f,
+				)
+			}
+		default:
+			return nil, errCorrupt
+		}
+	}
+}
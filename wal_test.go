@@ -0,0 +1,87 @@
+package cuckoo
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	trand.RandomN(t, 20, func(t *testing.T, r *rand.Rand) {
+		n := r.Int()%2000 + 100
+		fl := New(n, 0.01)
+
+		randomKey := func() []byte {
+			var key [8]byte
+			_, _ = r.Read(key[:])
+			return key[:]
+		}
+
+		var items [][]byte
+		for i := 0; i < n/2; i++ {
+			items = append(items, randomKey())
+			fl.Add(items[len(items)-1])
+		}
+
+		var snapshot, log bytes.Buffer
+		_, err := fl.WriteTo(&snapshot)
+		require.NoError(t, err)
+		fl.SetLog(&log)
+
+		for i := 0; i < n/2; i++ {
+			items = append(items, randomKey())
+			fl.Add(items[len(items)-1])
+		}
+		for i := 0; i < n/10; i++ {
+			j := r.Int() % len(items)
+			fl.Delete(items[j])
+			items = append(items[:j], items[j+1:]...)
+		}
+		require.NoError(t, fl.LogErr())
+
+		// Simulate a crash partway through writing the last record.
+		logBytes := log.Bytes()
+		torn := append(logBytes[:len(logBytes):len(logBytes)], walOpAdd, 0x01)
+
+		recovered, err := Recover(&snapshot, bytes.NewReader(torn))
+		require.NoError(t, err)
+		require.Equal(t, fl.Count(), recovered.Count())
+		for _, item := range items {
+			require.Equal(t, Maybe, recovered.Contains(item))
+		}
+		require.NoError(t, recovered.CheckInvariants())
+	})
+}
+
+func TestRecoverMismatch(t *testing.T) {
+	var snapshot, log bytes.Buffer
+	_, err := NewRaw(8, 4, 1024).WriteTo(&snapshot)
+	require.NoError(t, err)
+	NewRaw(8, 4, 2048).SetLog(&log)
+
+	_, err = Recover(&snapshot, &log)
+	require.Error(t, err)
+}
+
+func TestRecoverReset(t *testing.T) {
+	fl := New(100, 0.001)
+	fl.Add([]byte("a"))
+	var snapshot, log bytes.Buffer
+	_, err := fl.WriteTo(&snapshot)
+	require.NoError(t, err)
+	fl.SetLog(&log)
+
+	fl.Add([]byte("b"))
+	fl.Reset()
+	fl.Add([]byte("c"))
+
+	recovered, err := Recover(&snapshot, &log)
+	require.NoError(t, err)
+	require.Equal(t, 1, recovered.Count())
+	require.Equal(t, No, recovered.Contains([]byte("a")))
+	require.Equal(t, No, recovered.Contains([]byte("b")))
+	require.Equal(t, Maybe, recovered.Contains([]byte("c")))
+}
@@ -0,0 +1,321 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// A filter that only remembers recent items, for questions like "have I seen this in the last
+// million requests?" or "in the last ten minutes?", in bounded memory and without deleting
+// anything.
+//
+// A WindowedFilter is a fixed number of generations, each a Filter. Items are added to the newest
+// generation. Once it has taken its share of items, or its share of the window has passed, the
+// oldest generation is dropped and an empty one takes new items in its place. Lookups check every
+// generation, so each has a false-positive rate of fp/generations.
+type WindowedFilter struct {
+	// Oldest first. Items are added to the last.
+	fls []*Filter
+	// The number of items each generation takes before the next starts, or 0 if generations
+	// rotate on a timer.
+	capacity int
+	// How long each generation takes items before the next starts, or 0 if generations rotate by
+	// count.
+	period time.Duration
+	// When the newest generation started taking items, if generations rotate on a timer.
+	started time.Time
+	now     func() time.Time
+}
+
+// Returns a new WindowedFilter that remembers at least the last n items added, with an estimated
+// false-positive rate of fp, split into the given number of generations, at least 2. Memory use is
+// about generations/(generations-1) times that of a Filter for n items, so more generations waste
+// less space, at the cost of a longer fingerprint in each.
+//
+// If a generation fills up with copies of the same few items before it has taken its share, the
+// next one starts early, and for a while the filter remembers fewer than the last n items.
+func NewWindowed(n int, fp float64, generations int) *WindowedFilter {
+	checkGenerations(generations)
+	if n < 1 {
+		n = 1
+	}
+	capacity := (n + generations - 2) / (generations - 1)
+	w := newWindowedFilter(capacity, fp, generations)
+	w.capacity = capacity
+	return w
+}
+
+// Returns a new WindowedFilter that remembers every item added within the last window, with an
+// estimated false-positive rate of fp as long as no more than n items are added in any window,
+// split into the given number of generations, at least 2. A generation starts every
+// window/(generations-1), and items are remembered for at most one generation longer than window.
+//
+// Each generation has room for an even share of n. If more items than that arrive while a
+// generation is taking them, it overflows, and the filter returns Maybe for everything until that
+// generation is dropped.
+func NewTimedWindowed(n int, fp float64, window time.Duration, generations int) *WindowedFilter {
+	checkGenerations(generations)
+	if window <= 0 {
+		panic(fmt.Errorf("%w: window=%s must be positive", ErrInvalidParams, window))
+	}
+	if n < 1 {
+		n = 1
+	}
+	period := window / time.Duration(generations-1)
+	if period == 0 {
+		period = 1
+	}
+	w := newWindowedFilter((n+generations-2)/(generations-1), fp, generations)
+	w.period = period
+	w.started = w.now()
+	return w
+}
+
+func checkGenerations(generations int) {
+	if generations < 2 || generations > 255 {
+		panic(fmt.Errorf("%w: generations=%d must be in [2, 255]", ErrInvalidParams, generations))
+	}
+}
+
+// Returns a WindowedFilter with the given number of generations, each sized for n items, that
+// rotates neither by count nor on a timer.
+func newWindowedFilter(n int, fp float64, generations int) *WindowedFilter {
+	w := &WindowedFilter{now: time.Now}
+	for j := 0; j < generations; j++ {
+		w.fls = append(w.fls, New(n, fp/float64(generations)))
+	}
+	return w
+}
+
+// Sets the seed mixed into the hash of every item. See Filter.SetSeed.
+func (w *WindowedFilter) SetSeed(seed uint64) {
+	for _, fl := range w.fls {
+		fl.SetSeed(seed)
+	}
+}
+
+// Returns the seed set with SetSeed.
+func (w *WindowedFilter) Seed() uint64 {
+	return w.fls[0].Seed()
+}
+
+// Sets the clock used to tell when to start a new generation, which is time.Now by default, and
+// restarts the newest generation's share of the window from the new clock's current time. Only
+// used by a WindowedFilter made with NewTimedWindowed.
+func (w *WindowedFilter) SetClock(now func() time.Time) {
+	w.now = now
+	if w.period != 0 {
+		w.started = now()
+	}
+}
+
+// Adds an item to the filter. After Add(x) returns, Contains(x) returns Maybe until x's generation
+// is dropped.
+func (w *WindowedFilter) Add(x []byte) {
+	h := w.fls[0].hashItem(x)
+	if w.period != 0 {
+		if due := w.due(); due == len(w.fls) {
+			// Nothing is left to keep time with, so start over from now.
+			w.Reset()
+		} else if due > 0 {
+			w.rotate(due)
+			w.started = w.started.Add(time.Duration(due) * w.period)
+		}
+		last := w.fls[len(w.fls)-1]
+		last.add(last.hashToIdxs(h))
+		return
+	}
+	last := w.fls[len(w.fls)-1]
+	if last.count < w.capacity && last.insert(last.hashToIdxs(h)) == nil {
+		return
+	}
+	// Either the newest generation has taken its share, or it's too full of one item's duplicates
+	// to take more. Starting the next early shortens the window a little rather than overflowing.
+	w.rotate(1)
+	last = w.fls[len(w.fls)-1]
+	last.add(last.hashToIdxs(h))
+}
+
+// Returns the number of generations that should have started since the newest one did, or 0 if
+// generations rotate by count.
+func (w *WindowedFilter) due() int {
+	if w.period == 0 {
+		return 0
+	}
+	elapsed := w.now().Sub(w.started)
+	if elapsed < w.period {
+		return 0
+	}
+	if elapsed/w.period >= time.Duration(len(w.fls)) {
+		return len(w.fls)
+	}
+	return int(elapsed / w.period)
+}
+
+// Drops the oldest k generations and starts k empty ones, reusing their memory.
+func (w *WindowedFilter) rotate(k int) {
+	for ; k > 0; k-- {
+		oldest := w.fls[0]
+		oldest.Reset()
+		copy(w.fls, w.fls[1:])
+		w.fls[len(w.fls)-1] = oldest
+	}
+}
+
+// Returns the generations still in the window, oldest first.
+func (w *WindowedFilter) live() []*Filter {
+	return w.fls[w.due():]
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might have been added within the
+// window.
+func (w *WindowedFilter) Contains(x []byte) Result {
+	h := w.fls[0].hashItem(x)
+	// Newer generations are checked first, since recent items are usually the ones asked about.
+	fls := w.live()
+	for j := len(fls) - 1; j >= 0; j-- {
+		if fls[j].contains(fls[j].hashToIdxs(h)) == Maybe {
+			return Maybe
+		}
+	}
+	return No
+}
+
+// Returns the number of items added within the window.
+func (w *WindowedFilter) Count() int {
+	n := 0
+	for _, fl := range w.live() {
+		n += fl.count
+	}
+	return n
+}
+
+// Returns the number of generations.
+func (w *WindowedFilter) Generations() int {
+	return len(w.fls)
+}
+
+// Returns the number of bytes used by the buckets of every generation.
+func (w *WindowedFilter) SizeBytes() uint64 {
+	var n uint64
+	for _, fl := range w.fls {
+		n += fl.SizeBytes()
+	}
+	return n
+}
+
+// Returns an estimate of the filter's current false-positive rate: the chance that at least one
+// generation in the window returns Maybe for an item that was never added.
+func (w *WindowedFilter) EstimatedFalsePositiveRate() float64 {
+	none := 1.0
+	for _, fl := range w.live() {
+		none *= 1 - fl.EstimatedFalsePositiveRate()
+	}
+	return 1 - none
+}
+
+// Removes every item from the filter, reusing its memory.
+func (w *WindowedFilter) Reset() {
+	for _, fl := range w.fls {
+		fl.Reset()
+	}
+	if w.period != 0 {
+		w.started = w.now()
+	}
+}
+
+// Returns an independent copy of the filter, which uses the same clock.
+func (w *WindowedFilter) Clone() *WindowedFilter {
+	c := *w
+	c.fls = nil
+	for _, fl := range w.fls {
+		c.fls = append(c.fls, fl.Clone())
+	}
+	return &c
+}
+
+// Serialized format, little-endian:
+//
+//	magic     [4]byte  "CKWN"
+//	capacity  uint64   the number of items each generation takes, or 0
+//	period    int64    the nanoseconds each generation takes items for, or 0
+//	started   int64    when the newest generation started, in nanoseconds since the Unix epoch
+//	n         uint32   the number of generations
+//
+// followed by, for each generation, oldest first, the length of its encoding as a uint64 and its
+// encoding as written by MarshalBinary.
+var windowedMagic = [4]byte{'C', 'K', 'W', 'N'}
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (w *WindowedFilter) MarshalBinary() ([]byte, error) {
+	out := append([]byte(nil), windowedMagic[:]...)
+	out = binary.LittleEndian.AppendUint64(out, uint64(w.capacity))
+	out = binary.LittleEndian.AppendUint64(out, uint64(w.period))
+	var started int64
+	if w.period != 0 {
+		started = w.started.UnixNano()
+	}
+	out = binary.LittleEndian.AppendUint64(out, uint64(started))
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(w.fls)))
+	for _, fl := range w.fls {
+		data, err := fl.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(data)))
+		out = append(out, data...)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of w with the filter encoded in
+// data. The clock is reset to time.Now.
+func (w *WindowedFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 32 || !bytes.Equal(data[:4], windowedMagic[:]) {
+		return errCorrupt
+	}
+	capacity := binary.LittleEndian.Uint64(data[4:])
+	period := int64(binary.LittleEndian.Uint64(data[12:]))
+	started := int64(binary.LittleEndian.Uint64(data[20:]))
+	n := binary.LittleEndian.Uint32(data[28:])
+	if n < 2 || n > 255 || capacity > uint64(maxInt) || period < 0 ||
+		(capacity == 0) == (period == 0) {
+		return errCorrupt
+	}
+	result := &WindowedFilter{
+		capacity: int(capacity),
+		period:   time.Duration(period),
+		now:      time.Now,
+	}
+	if period != 0 {
+		result.started = time.Unix(0, started)
+	}
+	data = data[32:]
+	for j := uint32(0); j < n; j++ {
+		if len(data) < 8 {
+			return errCorrupt
+		}
+		size := binary.LittleEndian.Uint64(data)
+		data = data[8:]
+		if size > uint64(len(data)) {
+			return errCorrupt
+		}
+		fl := &Filter{}
+		if err := fl.UnmarshalBinary(data[:size]); err != nil {
+			return err
+		}
+		// Add and Contains hash items once for every generation, the way the default hash does.
+		if fl.hashing != hashXXH || (j > 0 && fl.seed != result.fls[0].seed) {
+			return errCorrupt
+		}
+		result.fls = append(result.fls, fl)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return errCorrupt
+	}
+	*w = *result
+	return nil
+}
@@ -0,0 +1,116 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowed(t *testing.T) {
+	const n = 1000
+	w := NewWindowed(n, 0.01, 4)
+	require.Equal(t, 4, w.Generations())
+	empty := w.SizeBytes()
+
+	for i := 0; i < 10*n; i++ {
+		w.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		require.LessOrEqual(t, w.Count(), 4*((n+2)/3))
+	}
+	require.Equal(t, empty, w.SizeBytes())
+
+	// The last n items are all there, and most older ones are gone.
+	for i := 9 * n; i < 10*n; i++ {
+		require.Equal(t, Maybe, w.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+	fps := 0
+	for i := 0; i < 8*n; i++ {
+		if w.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/(8*n), 0.02)
+
+	w.Reset()
+	require.Zero(t, w.Count())
+	require.Equal(t, No, w.Contains(binary.LittleEndian.AppendUint64(nil, uint64(10*n-1))))
+}
+
+func TestWindowedRepeats(t *testing.T) {
+	w := NewWindowed(100, 0.01, 2)
+	for i := 0; i < 1000; i++ {
+		w.Add([]byte("x"))
+		require.Equal(t, Maybe, w.Contains([]byte("x")))
+	}
+	require.Less(t, w.EstimatedFalsePositiveRate(), 0.01)
+}
+
+func TestTimedWindowed(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	w := NewTimedWindowed(1000, 0.01, time.Minute, 4)
+	w.SetClock(clock.now)
+
+	// One key every 100ms, so that the last minute is the last 600 keys.
+	for i := 0; i < 3000; i++ {
+		w.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		clock.t = clock.t.Add(100 * time.Millisecond)
+	}
+	require.False(t, w.fls[len(w.fls)-1].Overflowed())
+	for i := 2400; i < 3000; i++ {
+		require.Equal(t, Maybe, w.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+	fps := 0
+	for i := 0; i < 2200; i++ {
+		if w.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/2200, 0.02)
+
+	// Generations drop out of the window as time passes, even with nothing being added.
+	clock.t = clock.t.Add(time.Minute - time.Second)
+	require.Equal(t, Maybe, w.Contains(binary.LittleEndian.AppendUint64(nil, 2999)))
+	require.NotZero(t, w.Count())
+	clock.t = clock.t.Add(20 * time.Second)
+	require.Zero(t, w.Count())
+	require.Equal(t, No, w.Contains(binary.LittleEndian.AppendUint64(nil, 2999)))
+
+	clock.t = clock.t.Add(time.Hour)
+	w.Add([]byte("x"))
+	require.Equal(t, 1, w.Count())
+	require.Equal(t, Maybe, w.Contains([]byte("x")))
+}
+
+func TestWindowedSerialize(t *testing.T) {
+	for _, w := range []*WindowedFilter{
+		NewWindowed(500, 0.01, 3),
+		NewTimedWindowed(500, 0.01, time.Hour, 3),
+	} {
+		w.SetSeed(99)
+		for i := 0; i < 800; i++ {
+			w.Add(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		}
+		data, err := w.MarshalBinary()
+		require.NoError(t, err)
+
+		var w2 WindowedFilter
+		require.NoError(t, w2.UnmarshalBinary(data))
+		require.Equal(t, w.Count(), w2.Count())
+		require.Equal(t, uint64(99), w2.Seed())
+		require.Equal(t, w.capacity, w2.capacity)
+		require.Equal(t, w.period, w2.period)
+		require.True(t, w.started.Equal(w2.started))
+		for j := range w.fls {
+			require.True(t, w.fls[j].Equal(w2.fls[j]))
+		}
+		for i := 0; i < 800; i++ {
+			x := binary.LittleEndian.AppendUint64(nil, uint64(i))
+			require.Equal(t, w.Contains(x), w2.Contains(x))
+		}
+
+		require.Error(t, w2.UnmarshalBinary(data[:len(data)-1]))
+		var d DynamicFilter
+		require.Error(t, d.UnmarshalBinary(data))
+	}
+}
@@ -0,0 +1,301 @@
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// An immutable filter built once from a complete set of keys, for sets that don't change, like a
+// blocklist shipped with a release. It uses about 30% less space than a Filter with the same
+// false-positive rate, and looks in three places for every query rather than two buckets.
+//
+// This is a binary fuse filter, see https://arxiv.org/abs/2201.01174. Each key hashes to three
+// slots in consecutive segments of a table of f-bit values, and the table is solved so that the
+// three values XOR to the key's fingerprint. A key that wasn't in the set matches with probability
+// 2^-f. The table has about 1.125 slots per key for large sets, and more for small ones.
+type XorFilter struct {
+	// f-bit values, packed.
+	table []uint64
+	f     int
+	// The number of slots in each segment, a power of two.
+	segmentLength uint64
+	// The number of segments a key's first slot can be in. The table has two more.
+	segmentCount uint64
+	// The number of distinct keys the filter was built from.
+	count int
+	// The seed given to the item hash, as with Filter.SetSeed.
+	seed uint64
+	// Mixed into each item's hash, and chosen while building so that the table can be solved.
+	buildSeed uint64
+}
+
+// The most times BuildXor tries a new buildSeed before giving up. Each try succeeds with
+// probability near 1 for all but tiny sets.
+const maxXorTries = 100
+
+// Returns a new XorFilter containing every key produced by keys, with a false-positive rate of
+// about fp.
+//
+// keys is called once, and should call yield for each key until it runs out or yield returns
+// false. The key passed to yield may be reused once yield returns. While building, about 50 bytes
+// per key are held in memory.
+func BuildXor(keys func(yield func(key []byte) bool), fp float64) *XorFilter {
+	return BuildXorSeeded(keys, fp, 0)
+}
+
+// Like BuildXor, but mixes seed into the hash of every item. See Filter.SetSeed.
+func BuildXorSeeded(keys func(yield func(key []byte) bool), fp float64, seed uint64) *XorFilter {
+	f := int(math.Min(math.Max(math.Ceil(math.Log2(1/fp)), 2), 16))
+	var hashes []uint64
+	keys(func(key []byte) bool {
+		hashes = append(hashes, xxhash64(key, seed))
+		return true
+	})
+	// Repeated keys would never peel, since their slots are the same.
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+	n := 0
+	for i, h := range hashes {
+		if i == 0 || h != hashes[n-1] {
+			hashes[n] = h
+			n++
+		}
+	}
+	hashes = hashes[:n]
+
+	xf := newXorFilter(f, len(hashes))
+	xf.seed = seed
+	rng := seed
+	for try := 0; ; try++ {
+		xf.buildSeed = splitMix64(&rng)
+		if xf.solve(hashes) {
+			break
+		}
+		if try == maxXorTries {
+			panic("cuckoo: could not build XorFilter")
+		}
+	}
+	xf.count = len(hashes)
+	return xf
+}
+
+// Returns an empty XorFilter with f-bit values sized for n keys.
+func newXorFilter(f int, n int) *XorFilter {
+	segmentLength := uint64(4)
+	sizeFactor := 1.125
+	if n > 1 {
+		segmentLength = uint64(1) << uint(math.Floor(math.Log(float64(n))/math.Log(3.33)+2.25))
+		sizeFactor = math.Max(1.125, 0.875+0.25*math.Log(1e6)/math.Log(float64(n)))
+	}
+	if segmentLength > 1<<18 {
+		segmentLength = 1 << 18
+	}
+	capacity := uint64(math.Round(float64(n) * sizeFactor))
+	segmentCount := uint64(1)
+	if c := (capacity + segmentLength - 1) / segmentLength; c > 3 {
+		segmentCount = c - 2
+	}
+	return &XorFilter{
+		table:         make([]uint64, packedWords(uint64(f), (segmentCount+2)*segmentLength)),
+		f:             f,
+		segmentLength: segmentLength,
+		segmentCount:  segmentCount,
+	}
+}
+
+// Returns the next output of the SplitMix64 generator with state s.
+func splitMix64(s *uint64) uint64 {
+	*s += 0x9E3779B97F4A7C15
+	z := *s
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// Returns No if x is definitely not in the filter, and Maybe if x might be in the filter.
+func (xf *XorFilter) Contains(x []byte) Result {
+	h := xf.mix(xxhash64(x, xf.seed))
+	s := xf.slots(h)
+	v := getPacked(xf.table, uint64(xf.f), s[0]) ^
+		getPacked(xf.table, uint64(xf.f), s[1]) ^
+		getPacked(xf.table, uint64(xf.f), s[2])
+	if v == xf.fingerprint(h) {
+		return Maybe
+	}
+	return No
+}
+
+// Returns the number of distinct keys the filter was built from.
+func (xf *XorFilter) Count() int {
+	return xf.count
+}
+
+// Returns the seed given to BuildXorSeeded.
+func (xf *XorFilter) Seed() uint64 {
+	return xf.seed
+}
+
+// Returns the number of bytes used by the filter's table.
+func (xf *XorFilter) SizeBytes() uint64 {
+	return uint64(len(xf.table)) * 8
+}
+
+// Returns the filter's false-positive rate.
+func (xf *XorFilter) EstimatedFalsePositiveRate() float64 {
+	return math.Exp2(-float64(xf.f))
+}
+
+// Returns an item's hash with buildSeed mixed in, using the finalizer of MurmurHash3.
+func (xf *XorFilter) mix(h uint64) uint64 {
+	h += xf.buildSeed
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	return h ^ (h >> 33)
+}
+
+// Returns the three slots of the item whose mixed hash is h, one in each of three consecutive
+// segments.
+func (xf *XorFilter) slots(h uint64) [3]uint64 {
+	s0, _ := bits.Mul64(h, xf.segmentCount*xf.segmentLength)
+	s1 := s0 + xf.segmentLength
+	s2 := s1 + xf.segmentLength
+	mask := xf.segmentLength - 1
+	return [3]uint64{s0, s1 ^ ((h >> 18) & mask), s2 ^ (h & mask)}
+}
+
+// Returns the fingerprint of the item whose mixed hash is h.
+func (xf *XorFilter) fingerprint(h uint64) uint64 {
+	return (h ^ (h >> 32)) & (uint64(1)<<uint(xf.f) - 1)
+}
+
+// Fills the table so that every one of hashes, which must be distinct, is found. Returns false if
+// the current buildSeed doesn't allow it.
+//
+// Each slot counts the keys that use it and XORs together their hashes. A slot used by only one key
+// can be set last, to whatever that key needs, so it's peeled off along with the key, and that may
+// leave other slots with only one key. If every key is peeled, the table is set in the reverse
+// order.
+func (xf *XorFilter) solve(hashes []uint64) bool {
+	nSlots := (xf.segmentCount + 2) * xf.segmentLength
+	counts := make([]uint32, nSlots)
+	xors := make([]uint64, nSlots)
+	for _, h := range hashes {
+		h = xf.mix(h)
+		for _, s := range xf.slots(h) {
+			counts[s]++
+			xors[s] ^= h
+		}
+	}
+
+	queue := make([]uint64, 0, len(hashes))
+	for s, c := range counts {
+		if c == 1 {
+			queue = append(queue, uint64(s))
+		}
+	}
+	type peeled struct {
+		h uint64
+		s uint64
+	}
+	stack := make([]peeled, 0, len(hashes))
+	for len(queue) > 0 {
+		s := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if counts[s] != 1 {
+			continue
+		}
+		h := xors[s]
+		stack = append(stack, peeled{h: h, s: s})
+		for _, t := range xf.slots(h) {
+			counts[t]--
+			xors[t] ^= h
+			if counts[t] == 1 {
+				queue = append(queue, t)
+			}
+		}
+	}
+	if len(stack) != len(hashes) {
+		return false
+	}
+
+	for i := range xf.table {
+		xf.table[i] = 0
+	}
+	f := uint64(xf.f)
+	for j := len(stack) - 1; j >= 0; j-- {
+		h, s := stack[j].h, stack[j].s
+		v := xf.fingerprint(h)
+		for _, t := range xf.slots(h) {
+			if t != s {
+				v ^= getPacked(xf.table, f, t)
+			}
+		}
+		setPacked(xf.table, f, s, v)
+	}
+	return true
+}
+
+// Serialized format, little-endian:
+//
+//	magic          [4]byte  "CKXR"
+//	f              uint8    the number of bits in each value
+//	segmentLength  uint64
+//	segmentCount   uint64
+//	count          uint64   the number of keys
+//	seed           uint64
+//	buildSeed      uint64
+//	table          []uint64 the packed values
+var xorMagic = [4]byte{'C', 'K', 'X', 'R'}
+
+const xorHeaderSize = 4 + 1 + 5*8
+
+// Implements encoding.BinaryMarshaler. The encoding is the same on every platform.
+func (xf *XorFilter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, xorHeaderSize+len(xf.table)*8)
+	out = append(out, xorMagic[:]...)
+	out = append(out, byte(xf.f))
+	out = binary.LittleEndian.AppendUint64(out, xf.segmentLength)
+	out = binary.LittleEndian.AppendUint64(out, xf.segmentCount)
+	out = binary.LittleEndian.AppendUint64(out, uint64(xf.count))
+	out = binary.LittleEndian.AppendUint64(out, xf.seed)
+	out = binary.LittleEndian.AppendUint64(out, xf.buildSeed)
+	for _, w := range xf.table {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, replacing the contents of xf with the filter encoded in
+// data.
+func (xf *XorFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < xorHeaderSize || !bytes.Equal(data[:4], xorMagic[:]) {
+		return errCorrupt
+	}
+	result := &XorFilter{
+		f:             int(data[4]),
+		segmentLength: binary.LittleEndian.Uint64(data[5:]),
+		segmentCount:  binary.LittleEndian.Uint64(data[13:]),
+		seed:          binary.LittleEndian.Uint64(data[29:]),
+		buildSeed:     binary.LittleEndian.Uint64(data[37:]),
+	}
+	count := binary.LittleEndian.Uint64(data[21:])
+	data = data[xorHeaderSize:]
+	if result.f < 2 || result.f > 16 || count > uint64(maxInt) ||
+		result.segmentLength == 0 || result.segmentLength > 1<<18 ||
+		result.segmentLength&(result.segmentLength-1) != 0 ||
+		result.segmentCount == 0 || result.segmentCount > uint64(maxInt)/result.segmentLength ||
+		uint64(len(data)) != packedWords(uint64(result.f), (result.segmentCount+2)*result.segmentLength)*8 {
+		return errCorrupt
+	}
+	result.count = int(count)
+	result.table = make([]uint64, len(data)/8)
+	for i := range result.table {
+		result.table[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	*xf = *result
+	return nil
+}
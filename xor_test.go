@@ -0,0 +1,74 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func xorKeys(n int) func(yield func(key []byte) bool) {
+	return func(yield func(key []byte) bool) {
+		var buf []byte
+		for i := 0; i < n; i++ {
+			buf = binary.LittleEndian.AppendUint64(buf[:0], uint64(i))
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+func TestXor(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 1000, 100000} {
+		xf := BuildXor(xorKeys(n), 0.01)
+		require.Equal(t, n, xf.Count())
+		for i := 0; i < n; i++ {
+			require.Equal(t, Maybe, xf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+		}
+	}
+
+	const n, fp = 100000, 0.01
+	xf := BuildXor(xorKeys(n), fp)
+	fps := 0
+	const tries = 100000
+	for i := n; i < n+tries; i++ {
+		if xf.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))) == Maybe {
+			fps++
+		}
+	}
+	require.Less(t, float64(fps)/tries, fp)
+	require.InDelta(t, xf.EstimatedFalsePositiveRate(), float64(fps)/tries, 0.002)
+
+	// At least 25% smaller than a Filter for the same false-positive rate.
+	require.Less(t, float64(xf.SizeBytes()), 0.75*float64(EstimateSizeBytes(n, fp)))
+}
+
+func TestXorDuplicates(t *testing.T) {
+	xf := BuildXor(func(yield func(key []byte) bool) {
+		for i := 0; i < 100; i++ {
+			yield([]byte("a"))
+			yield([]byte("b"))
+		}
+	}, 0.01)
+	require.Equal(t, 2, xf.Count())
+	require.Equal(t, Maybe, xf.Contains([]byte("a")))
+	require.Equal(t, Maybe, xf.Contains([]byte("b")))
+}
+
+func TestXorSerialize(t *testing.T) {
+	xf := BuildXorSeeded(xorKeys(1000), 0.001, 42)
+	data, err := xf.MarshalBinary()
+	require.NoError(t, err)
+
+	var xf2 XorFilter
+	require.NoError(t, xf2.UnmarshalBinary(data))
+	require.Equal(t, xf, &xf2)
+	require.Equal(t, uint64(42), xf2.Seed())
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, Maybe, xf2.Contains(binary.LittleEndian.AppendUint64(nil, uint64(i))))
+	}
+
+	require.Error(t, xf2.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, xf2.UnmarshalBinary(append(data, 0)))
+}
@@ -0,0 +1,147 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// Returns the 64-bit xxHash (XXH64) of b with the given seed, following the reference
+// specification at https://github.com/Cyan4973/xxHash/blob/dev/doc/xxhash_spec.md. Several times
+// faster than FNV-1a for all but the shortest items.
+func xxhash64(b []byte, seed uint64) uint64 {
+	n := len(b)
+	var h uint64
+	if n >= 32 {
+		v1 := seed + xxPrime1 + xxPrime2
+		v2 := seed + xxPrime2
+		v3 := seed
+		v4 := seed - xxPrime1
+		for len(b) >= 32 {
+			v1 = xxRound(v1, binary.LittleEndian.Uint64(b[0:8]))
+			v2 = xxRound(v2, binary.LittleEndian.Uint64(b[8:16]))
+			v3 = xxRound(v3, binary.LittleEndian.Uint64(b[16:24]))
+			v4 = xxRound(v4, binary.LittleEndian.Uint64(b[24:32]))
+			b = b[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) +
+			bits.RotateLeft64(v4, 18)
+		h = xxMergeRound(h, v1)
+		h = xxMergeRound(h, v2)
+		h = xxMergeRound(h, v3)
+		h = xxMergeRound(h, v4)
+	} else {
+		h = seed + xxPrime5
+	}
+	return xxFinish(h+uint64(n), b)
+}
+
+// Mixes the last fewer than 32 bytes of the input into h and finalizes it.
+func xxFinish(h uint64, b []byte) uint64 {
+	for len(b) >= 8 {
+		h ^= xxRound(0, binary.LittleEndian.Uint64(b[:8]))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+		b = b[8:]
+	}
+	if len(b) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(b[:4])) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		b = b[4:]
+	}
+	for _, c := range b {
+		h ^= uint64(c) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+// The streaming form of xxhash64, for items that don't fit in memory at once. Writing an item to it
+// in any number of pieces gives the same hash as xxhash64 of the whole item.
+type xxh64Digest struct {
+	seed           uint64
+	v1, v2, v3, v4 uint64
+	// The number of bytes written so far.
+	total uint64
+	// Holds the start of a stripe until all 32 bytes have been written.
+	mem [32]byte
+	n   int
+}
+
+func newXXH64Digest(seed uint64) *xxh64Digest {
+	return &xxh64Digest{
+		seed: seed,
+		v1:   seed + xxPrime1 + xxPrime2,
+		v2:   seed + xxPrime2,
+		v3:   seed,
+		v4:   seed - xxPrime1,
+	}
+}
+
+// Implements io.Writer. Never returns an error.
+func (d *xxh64Digest) Write(b []byte) (int, error) {
+	written := len(b)
+	d.total += uint64(len(b))
+	if d.n+len(b) < 32 {
+		d.n += copy(d.mem[d.n:], b)
+		return written, nil
+	}
+	if d.n > 0 {
+		c := copy(d.mem[d.n:], b)
+		d.stripe(d.mem[:])
+		b = b[c:]
+		d.n = 0
+	}
+	for len(b) >= 32 {
+		d.stripe(b[:32])
+		b = b[32:]
+	}
+	d.n = copy(d.mem[:], b)
+	return written, nil
+}
+
+func (d *xxh64Digest) stripe(b []byte) {
+	d.v1 = xxRound(d.v1, binary.LittleEndian.Uint64(b[0:8]))
+	d.v2 = xxRound(d.v2, binary.LittleEndian.Uint64(b[8:16]))
+	d.v3 = xxRound(d.v3, binary.LittleEndian.Uint64(b[16:24]))
+	d.v4 = xxRound(d.v4, binary.LittleEndian.Uint64(b[24:32]))
+}
+
+// Returns the hash of everything written so far.
+func (d *xxh64Digest) Sum64() uint64 {
+	var h uint64
+	if d.total >= 32 {
+		h = bits.RotateLeft64(d.v1, 1) + bits.RotateLeft64(d.v2, 7) +
+			bits.RotateLeft64(d.v3, 12) + bits.RotateLeft64(d.v4, 18)
+		h = xxMergeRound(h, d.v1)
+		h = xxMergeRound(h, d.v2)
+		h = xxMergeRound(h, d.v3)
+		h = xxMergeRound(h, d.v4)
+	} else {
+		h = d.seed + xxPrime5
+	}
+	return xxFinish(h+d.total, d.mem[:d.n])
+}
@@ -0,0 +1,44 @@
+package cuckoo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bradenaw/trand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXXHash64(t *testing.T) {
+	// Reference values from the xxHash command line tool.
+	for _, c := range []struct {
+		in   string
+		want uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"as", 0x1c330fb2d66be179},
+		{"asd", 0x631c37ce72a97393},
+		{"asdf", 0x415872f599cea71e},
+		{"abc", 0x44bc2cf5ad770999},
+		{"Call me Ishmael. Some years ago--never mind how long precisely-", 0x02a2e85470d6fd96},
+	} {
+		require.Equal(t, c.want, xxhash64([]byte(c.in), 0), "%q", c.in)
+	}
+}
+
+func TestXXH64Digest(t *testing.T) {
+	trand.RandomN(t, 200, func(t *testing.T, r *rand.Rand) {
+		b := make([]byte, r.Intn(300))
+		_, _ = r.Read(b)
+		seed := r.Uint64()
+
+		d := newXXH64Digest(seed)
+		rest := b
+		for len(rest) > 0 {
+			k := r.Intn(len(rest)) + 1
+			_, _ = d.Write(rest[:k])
+			rest = rest[k:]
+		}
+		require.Equal(t, xxhash64(b, seed), d.Sum64())
+	})
+}